@@ -19,6 +19,7 @@ const (
 	ContractParamSenderPk     = "__sender_pk__"
 	ContractParamBlockHeight  = "__block_height__"
 	ContractParamTxId         = "__tx_id__"
+	ContractParamTxTimeStamp  = "__tx_time_stamp__"
 	ContractParamContextPtr   = "__context_ptr__"
 
 	// method name used by smart contract sdk
@@ -495,6 +496,9 @@ func GetBlockHeight() (string, ResultCode) {
 func GetTxId() (string, ResultCode) {
 	return stringArg(ContractParamTxId)
 }
+func GetTxTimeStamp() (string, ResultCode) {
+	return stringArg(ContractParamTxTimeStamp)
+}
 func getCtxPtr() int32 {
 	if str, resultCode := stringArg(ContractParamContextPtr); resultCode != SUCCESS {
 		LogMessage("failed to get ctx ptr")
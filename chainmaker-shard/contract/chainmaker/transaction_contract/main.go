@@ -46,9 +46,19 @@ func Execute() {
 			ErrorResult("failed to parse rollback params")
 			return
 		}
+		// check deadline, expired cross can not be executed
+		// NOTE: the prebuilt transaction.wasm is compiled before this check, rebuild it with tinygo to enforce the deadline
+		deadline := UnpackDeadline(Args())
+		if isExpired(deadline) {
+			ErrorResult("cross expired: " + crossID)
+			return
+		}
 		// put data
 		putExecute(crossID, eMap)
 		putRollback(crossID, rMap)
+		if deadline != NoDeadline {
+			putDeadline(crossID, deadline)
+		}
 	}
 
 	// call execute method
@@ -85,6 +95,11 @@ func Commit() {
 	} else {
 		// check state
 		crossState := getState(string(crossID))
+		// late commit of expired cross will be rejected, it should be rolled back
+		if (crossState == ExecuteSuccess || crossState == CommitFail) && isExpired(getDeadline(string(crossID))) {
+			ErrorResult("failed to Commit, cross expired: " + string(crossID))
+			return
+		}
 		if crossState == ExecuteSuccess {
 			// change state
 			putState(string(crossID), CommitSuccess)
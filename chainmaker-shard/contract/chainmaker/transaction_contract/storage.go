@@ -29,6 +29,7 @@ const (
 	FieldRollback = "Rollback"
 	FieldState    = "State"
 	FieldProof    = "Proof"
+	FieldDeadline = "Deadline"
 )
 
 // necessary params to call a contract
@@ -153,6 +154,43 @@ func getState(crossID string) State {
 	}
 }
 
+// put cross deadline
+func putDeadline(crossID string, deadline int64) ResultCode {
+	return PutStateByte(crossID, FieldDeadline, []byte(strconv.FormatInt(deadline, 10)))
+}
+
+// get cross deadline, NoDeadline will be returned if not set
+func getDeadline(crossID string) int64 {
+	if result, resultCode := GetStateByte(crossID, FieldDeadline); resultCode != SUCCESS || len(result) == 0 {
+		return NoDeadline
+	} else {
+		deadline, err := strconv.ParseInt(string(result), 10, 64)
+		if err != nil {
+			return NoDeadline
+		}
+		return deadline
+	}
+}
+
+// check whether the deadline has passed by the timestamp of current tx,
+// the cross is treated as expired if the chain does not provide a valid timestamp of tx
+func isExpired(deadline int64) bool {
+	if deadline == NoDeadline {
+		return false
+	}
+	timestamp, resultCode := GetTxTimeStamp()
+	if resultCode != SUCCESS {
+		LogMessage("failed to get tx timestamp, cross with deadline is treated as expired")
+		return true
+	}
+	now, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		LogMessage("invalid tx timestamp: " + timestamp + ", cross with deadline is treated as expired")
+		return true
+	}
+	return now >= deadline
+}
+
 // put cross proof
 func putProof(proofKey, txProof string) ResultCode {
 	return PutStateByte(proofKey, FieldProof, []byte(txProof))
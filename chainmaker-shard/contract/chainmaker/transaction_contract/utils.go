@@ -5,16 +5,20 @@
 
 package main
 
+import "strconv"
+
 const (
 	KeyCrossID      = "crossID"
 	KeyExecuteData  = "executeData"
 	KeyRollbackData = "rollbackData"
+	KeyDeadline     = "deadline"
 
 	KeyContractName = "contractName"
 	KeyMethod       = "method"
 	KeyParams       = "params"
 
 	EmptyCrossID = ""
+	NoDeadline   = int64(0)
 )
 
 // UnpackUploadParams - check and parse transaction called params
@@ -50,6 +54,19 @@ func UnpackUploadParams(args []*EasyCodecItem) (string, *CallContractParams, *Ca
 	return crossID, eParams, rParams
 }
 
+// UnpackDeadline - parse the deadline of cross from called params, which is unix timestamp in seconds
+// map[deadline] 		= "deadline" 		// optional, NoDeadline if not set
+func UnpackDeadline(args []*EasyCodecItem) int64 {
+	if v, ok := GetValueFromItems(args, KeyDeadline, EasyKeyType_USER); ok {
+		if deadlineBytes, convertOK := v.([]byte); convertOK {
+			if deadline, err := strconv.ParseInt(string(deadlineBytes), 10, 64); err == nil && deadline > 0 {
+				return deadline
+			}
+		}
+	}
+	return NoDeadline
+}
+
 func ParamsMapToBytes(params map[string]string) []byte {
 	items := make([]*EasyCodecItem, 0)
 	for key, value := range params {
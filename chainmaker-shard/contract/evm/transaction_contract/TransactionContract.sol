@@ -0,0 +1,148 @@
+// Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+pragma solidity ^0.8.0;
+
+/// @title TransactionContract 跨链事务合约，逻辑与chainmaker、fabric的transaction_contract一致
+/// @notice 业务合约由本合约调用，业务合约中的msg.sender为本合约地址；
+/// 每次调用通过CrossResult事件返回业务合约的执行结果，跨链代理的evm转接器根据该事件判断执行是否成功
+contract TransactionContract {
+    // unknown state
+    string private constant StateUnknown = "Unknown";
+    // execute state
+    string private constant ExecuteSuccess = "ExecuteSuccess";
+    string private constant ExecuteFail = "ExecuteFail";
+    // commit state
+    string private constant CommitSuccess = "CommitSuccess";
+    string private constant CommitFail = "CommitFail";
+    // rollback state
+    string private constant RollbackSuccess = "RollbackSuccess";
+    string private constant RollbackFail = "RollbackFail";
+    string private constant RollbackIgnore = "RollbackIgnore"; // 由于没有 crossID 的回滚，忽略该步骤
+
+    string private constant ProofPutSuccess = "ProofPutSuccess";
+
+    // necessary params to call a contract
+    struct CallParams {
+        address target; // 业务合约地址
+        bytes data;     // abi编码的业务合约调用数据
+    }
+
+    mapping(string => string) private states;          // crossID => state
+    mapping(string => CallParams) private rollbacks;   // crossID => 回滚调用
+    mapping(string => uint256) private deadlines;      // crossID => 截止时间，unix时间戳，单位：秒
+    mapping(string => string) private proofs;          // crossID.proofKey => 证明
+
+    /// @notice 事务合约每次调用的结果
+    /// @param crossID 跨链ID
+    /// @param method 事务合约方法，execute、commit、rollback、saveProof
+    /// @param success 是否成功，业务合约执行失败时为false
+    /// @param result 业务合约的返回值或事务状态
+    event CrossResult(string crossID, string method, bool success, bytes result);
+
+    /// @notice 执行业务合约，记录回滚调用，重复的crossID和已过期的跨链事务将被拒绝
+    /// @param deadline 截止时间，unix时间戳，单位：秒，0表示不限制
+    function execute(
+        string calldata crossID,
+        address executeContract,
+        bytes calldata executeData,
+        address rollbackContract,
+        bytes calldata rollbackData,
+        uint256 deadline
+    ) external {
+        require(bytes(crossID).length > 0, "failed to get crossID");
+        require(bytes(states[crossID]).length == 0, string(abi.encodePacked("duplicated crossID: ", crossID)));
+        require(executeContract != address(0), "executeParams is nil");
+        require(rollbackContract != address(0), "rollbackParams is nil");
+        require(!isExpired(deadline), string(abi.encodePacked("cross expired: ", crossID)));
+        rollbacks[crossID] = CallParams(rollbackContract, rollbackData);
+        if (deadline != 0) {
+            deadlines[crossID] = deadline;
+        }
+        (bool success, bytes memory result) = executeContract.call(executeData);
+        states[crossID] = success ? ExecuteSuccess : ExecuteFail;
+        emit CrossResult(crossID, "execute", success, result);
+    }
+
+    /// @notice 确认跨链事务，已过期的跨链事务只能回滚
+    function commit(string calldata crossID) external {
+        string memory state = getState(crossID);
+        bool committable = equals(state, ExecuteSuccess) || equals(state, CommitFail);
+        // late commit of expired cross will be rejected, it should be rolled back
+        require(!(committable && isExpired(deadlines[crossID])),
+            string(abi.encodePacked("failed to Commit, cross expired: ", crossID)));
+        if (committable) {
+            states[crossID] = CommitSuccess;
+        } else if (!equals(state, CommitSuccess)) {
+            revert(string(abi.encodePacked("failed to Commit, unexpected pre-state: ", state)));
+        }
+        emit CrossResult(crossID, "commit", true, bytes(CommitSuccess));
+    }
+
+    /// @notice 回滚跨链事务，未执行或执行失败的跨链事务忽略回滚
+    function rollback(string calldata crossID) external {
+        string memory state = getState(crossID);
+        if (equals(state, ExecuteSuccess) || equals(state, RollbackFail)) {
+            CallParams storage cp = rollbacks[crossID];
+            (bool success, bytes memory result) = cp.target.call(cp.data);
+            states[crossID] = success ? RollbackSuccess : RollbackFail;
+            emit CrossResult(crossID, "rollback", success, result);
+            return;
+        }
+        if (equals(state, StateUnknown) || equals(state, ExecuteFail) || equals(state, RollbackIgnore)) {
+            states[crossID] = RollbackIgnore;
+            emit CrossResult(crossID, "rollback", true, bytes(RollbackIgnore));
+            return;
+        }
+        if (equals(state, CommitSuccess) || equals(state, CommitFail)) {
+            emit CrossResult(crossID, "rollback", true, bytes(RollbackIgnore));
+            return;
+        }
+        if (equals(state, RollbackSuccess)) {
+            emit CrossResult(crossID, "rollback", true, bytes(RollbackSuccess));
+            return;
+        }
+        revert(string(abi.encodePacked("failed to Rollback, unexpected state: ", state)));
+    }
+
+    /// @notice 读取跨链事务的状态
+    function readState(string calldata crossID) external view returns (string memory) {
+        return getState(crossID);
+    }
+
+    /// @notice 保存证明，已保存的证明不会被覆盖，返回历史数据
+    function saveProof(string calldata crossID, string calldata proofKey, string calldata txProof) external {
+        string memory key = string(abi.encodePacked(crossID, ".", proofKey));
+        if (bytes(proofs[key]).length > 0) {
+            // Proof 已存在，返回历史数据
+            emit CrossResult(crossID, "saveProof", true, bytes(proofs[key]));
+            return;
+        }
+        proofs[key] = txProof;
+        emit CrossResult(crossID, "saveProof", true, bytes(ProofPutSuccess));
+    }
+
+    /// @notice 读取证明
+    function readProof(string calldata crossID, string calldata proofKey) external view returns (string memory) {
+        string memory proof = proofs[string(abi.encodePacked(crossID, ".", proofKey))];
+        require(bytes(proof).length > 0,
+            string(abi.encodePacked("failed to call ReadProof, crossID: ", crossID, "proofKey: ", proofKey)));
+        return proof;
+    }
+
+    function getState(string memory crossID) private view returns (string memory) {
+        string memory state = states[crossID];
+        if (bytes(state).length == 0) {
+            return StateUnknown;
+        }
+        return state;
+    }
+
+    /// @dev 到达截止时间即视为过期，与代理及其他链的事务合约保持一致
+    function isExpired(uint256 deadline) private view returns (bool) {
+        return deadline != 0 && block.timestamp >= deadline;
+    }
+
+    function equals(string memory a, string memory b) private pure returns (bool) {
+        return keccak256(bytes(a)) == keccak256(bytes(b));
+    }
+}
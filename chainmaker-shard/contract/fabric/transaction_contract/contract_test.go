@@ -9,7 +9,9 @@ import (
 	"chainmaker.org/chainmaker-cross/utils"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric-chaincode-go/shim"
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
@@ -207,10 +209,62 @@ func TestSmartContract_Rollback(t *testing.T) {
 
 }
 
+func TestSmartContract_Deadline(t *testing.T) {
+	sc, txCtx, fn := setUp(t)
+	defer fn()
+	execute := CallContractParams{
+		ContractName: fabcarContract,
+		Method:       methodQuery,
+		Params:       nil,
+	}
+	executeBz, err := json.Marshal(execute)
+	require.NoError(t, err)
+	params := string(executeBz)
+
+	// test execute before deadline
+	crossID := utils.GetUUID()
+	respStr, err := sc.ExecuteWithDeadline(txCtx, crossID, params, params, strconv.FormatInt(txTimestamp+10, 10))
+	require.NoError(t, err)
+	require.Contains(t, respStr, string(ExecuteSuccess))
+	deadline, err := getDeadline(txCtx, crossID)
+	require.NoError(t, err)
+	require.Equal(t, txTimestamp+10, deadline)
+	_, err = sc.Commit(txCtx, crossID)
+	require.NoError(t, err)
+
+	// test execute expired cross
+	expiredCrossID := utils.GetUUID()
+	respStr, err = sc.ExecuteWithDeadline(txCtx, expiredCrossID, params, params, strconv.FormatInt(txTimestamp, 10))
+	require.Equal(t, err, fmt.Errorf("cross expired: "+expiredCrossID))
+	require.Equal(t, respStr, "")
+	require.False(t, isCrossIDExist(txCtx, expiredCrossID))
+
+	// test invalid deadline
+	_, err = sc.ExecuteWithDeadline(txCtx, utils.GetUUID(), params, params, "test")
+	require.Error(t, err)
+
+	// test late commit of expired cross
+	lateCrossID := utils.GetUUID()
+	require.NoError(t, putState(txCtx, lateCrossID, ExecuteSuccess))
+	require.NoError(t, putRollback(txCtx, lateCrossID, executeBz))
+	require.NoError(t, putDeadline(txCtx, lateCrossID, txTimestamp-1))
+	respStr, err = sc.Commit(txCtx, lateCrossID)
+	require.Equal(t, err, fmt.Errorf("failed to Commit cross: [%s], cross expired", lateCrossID))
+	require.Equal(t, respStr, "")
+	// expired cross can still be rolled back
+	_, err = sc.Rollback(txCtx, lateCrossID)
+	require.NoError(t, err)
+	state, err := getState(txCtx, lateCrossID)
+	require.NoError(t, err)
+	require.Equal(t, RollbackSuccess, state)
+}
+
 func TestSmartContract_ReadState(t *testing.T) {
 
 }
 
+const txTimestamp = int64(1600000000)
+
 func setUp(t *testing.T) (*SmartContract, contractapi.TransactionContextInterface, func()) {
 	dPoSStakeRuntime := NewSmartContract()
 	ctrl := gomock.NewController(t)
@@ -238,6 +292,12 @@ func setUp(t *testing.T) (*SmartContract, contractapi.TransactionContextInterfac
 		},
 	).AnyTimes()
 
+	shimContext.EXPECT().GetTxTimestamp().DoAndReturn(
+		func() (*timestamp.Timestamp, error) {
+			return &timestamp.Timestamp{Seconds: txTimestamp}, nil
+		},
+	).AnyTimes()
+
 	shimContext.EXPECT().GetChannelID().DoAndReturn(
 		func() string {
 			return channelID
@@ -22,7 +22,14 @@ func NewSmartContract() *SmartContract {
 	}
 }
 
+// Execute execute the cross tx without deadline
 func (s *SmartContract) Execute(ctx contractapi.TransactionContextInterface, crossID, executeParams, rollbackParams string) (string, error) {
+	return s.ExecuteWithDeadline(ctx, crossID, executeParams, rollbackParams, EmptyParam)
+}
+
+// ExecuteWithDeadline execute the cross tx, deadline is unix timestamp in seconds, empty or "0" means no deadline,
+// the commit of cross will be rejected once the deadline passes
+func (s *SmartContract) ExecuteWithDeadline(ctx contractapi.TransactionContextInterface, crossID, executeParams, rollbackParams, deadline string) (string, error) {
 	var err error
 	// check and parse params
 	if crossID == EmptyCrossID {
@@ -52,6 +59,18 @@ func (s *SmartContract) Execute(ctx contractapi.TransactionContextInterface, cro
 	if err != nil {
 		return "", err
 	}
+	// check deadline, expired cross can not be executed
+	crossDeadline, err := ParseDeadline(deadline)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse deadline: %v", err)
+	}
+	expired, err := isExpired(ctx, crossDeadline)
+	if err != nil {
+		return "", err
+	}
+	if expired {
+		return "", fmt.Errorf("cross expired: " + crossID)
+	}
 
 	// put data
 	err = putExecute(ctx, crossID, []byte(executeParams))
@@ -62,6 +81,12 @@ func (s *SmartContract) Execute(ctx contractapi.TransactionContextInterface, cro
 	if err != nil {
 		return "", err
 	}
+	if crossDeadline != NoDeadline {
+		err = putDeadline(ctx, crossID, crossDeadline)
+		if err != nil {
+			return "", err
+		}
+	}
 	// call execute method
 	resp := ctx.GetStub().InvokeChaincode(execute.ContractName, ToArgs(execute.Method, execute.Params), ctx.GetStub().GetChannelID())
 	if resp.Status != SUCCESS200 {
@@ -108,6 +133,18 @@ func (s *SmartContract) Commit(ctx contractapi.TransactionContextInterface, cros
 		if err != nil {
 			return "", err
 		}
+		// late commit of expired cross will be rejected, it should be rolled back
+		if crossState == ExecuteSuccess || crossState == CommitFail {
+			crossDeadline, err := getDeadline(ctx, crossID)
+			if err != nil {
+				return "", err
+			}
+			if expired, err := isExpired(ctx, crossDeadline); err != nil {
+				return "", err
+			} else if expired {
+				return "", fmt.Errorf("failed to Commit cross: [%s], cross expired", crossID)
+			}
+		}
 		if crossState == ExecuteSuccess {
 			// change state
 			err = putState(ctx, crossID, CommitSuccess)
@@ -8,6 +8,8 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
+
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
 )
 
@@ -33,6 +35,7 @@ const (
 	FieldRollback = "Rollback"
 	FieldState    = "State"
 	FieldProof    = "Proof"
+	FieldDeadline = "Deadline"
 )
 
 // necessary params to call a contract
@@ -105,6 +108,34 @@ func getState(ctx contractapi.TransactionContextInterface, crossID string) (Stat
 	}
 }
 
+// put cross deadline
+func putDeadline(ctx contractapi.TransactionContextInterface, crossID string, deadline int64) error {
+	return putStateByte(ctx, crossID, FieldDeadline, []byte(strconv.FormatInt(deadline, 10)))
+}
+
+// get cross deadline, NoDeadline will be returned if not set
+func getDeadline(ctx contractapi.TransactionContextInterface, crossID string) (int64, error) {
+	if result, err := getStateByte(ctx, crossID, FieldDeadline); err != nil {
+		return NoDeadline, err
+	} else if len(result) == 0 {
+		return NoDeadline, nil
+	} else {
+		return strconv.ParseInt(string(result), 10, 64)
+	}
+}
+
+// check whether the deadline has passed by the timestamp of current tx
+func isExpired(ctx contractapi.TransactionContextInterface, deadline int64) (bool, error) {
+	if deadline == NoDeadline {
+		return false, nil
+	}
+	timestamp, err := ctx.GetStub().GetTxTimestamp()
+	if err != nil {
+		return false, err
+	}
+	return timestamp.GetSeconds() >= deadline, nil
+}
+
 // put cross proof
 func putProof(ctx contractapi.TransactionContextInterface, proofKey, txProof string) error {
 	return putStateByte(ctx, proofKey, FieldProof, []byte(txProof))
@@ -5,6 +5,8 @@
 
 package main
 
+import "strconv"
+
 const (
 	KeyContractName = "contractName"
 	KeyMethod       = "method"
@@ -12,6 +14,7 @@ const (
 
 	EmptyCrossID = ""
 	EmptyParam	 = ""
+	NoDeadline   = int64(0)
 )
 
 const (
@@ -27,4 +30,12 @@ func ToArgs(method string, params []string) [][]byte {
 		bytes = append(bytes, []byte(param))
 	}
 	return bytes
-}
\ No newline at end of file
+}
+
+// ParseDeadline parse the deadline of cross which is unix timestamp in seconds, empty means no deadline
+func ParseDeadline(deadline string) (int64, error) {
+	if deadline == EmptyParam {
+		return NoDeadline, nil
+	}
+	return strconv.ParseInt(deadline, 10, 64)
+}
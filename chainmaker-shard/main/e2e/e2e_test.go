@@ -0,0 +1,333 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package e2e
+
+import (
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"chainmaker.org/chainmaker-cross/adapter/simulator"
+	"chainmaker.org/chainmaker-cross/conf/chaos"
+	"chainmaker.org/chainmaker-cross/event"
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+	"github.com/stretchr/testify/require"
+)
+
+const resultTimeout = 60 * time.Second
+
+func TestMain(m *testing.M) {
+	// 被harness启动的子进程运行代理后直接退出
+	RunProxyIfRequested()
+	os.Exit(m.Run())
+}
+
+// startHarness start proxy1 with chain1 and proxy2 with chain2, proxy1 is used to receive the cross events
+func startHarness(t *testing.T, router string, chain1, chain2 *ChainSpec) *Harness {
+	return startChaosHarness(t, router, nil, chain1, chain2)
+}
+
+// startChaosHarness start proxy{i} with the i-th chain, proxy1 injects the faults of scenario
+func startChaosHarness(t *testing.T, router string, scenario *chaos.Scenario, chains ...*ChainSpec) *Harness {
+	if testing.Short() {
+		t.Skip("skip end-to-end test in short mode")
+	}
+	specs := make([]*ProxySpec, 0, len(chains))
+	for i, chain := range chains {
+		specs = append(specs, &ProxySpec{Name: "proxy" + strconv.Itoa(i+1), Router: router, Chains: []*ChainSpec{chain}})
+	}
+	specs[0].Chaos = scenario
+	h, err := NewHarness(t.TempDir(), specs...)
+	require.Nil(t, err)
+	require.Nil(t, h.Start())
+	t.Cleanup(h.Stop)
+	return h
+}
+
+func newCrossEvent(t *testing.T, crossID string) *eventproto.CrossEvent {
+	tx1, err := NewCrossTx("chain1", 0, "alice", "90")
+	require.Nil(t, err)
+	tx2, err := NewCrossTx("chain2", 1, "bob", "110")
+	require.Nil(t, err)
+	return newCrossEventOf(crossID, tx1, tx2)
+}
+
+// newMultiChainCrossEvent create the cross event whose i-th tx writes user{i}=i on chain{i}
+func newMultiChainCrossEvent(t *testing.T, crossID string, chainCount int) *eventproto.CrossEvent {
+	crossTxs := make([]*eventproto.CrossTx, 0, chainCount)
+	for i := 1; i <= chainCount; i++ {
+		crossTx, err := NewCrossTx("chain"+strconv.Itoa(i), int32(i-1), "user"+strconv.Itoa(i), strconv.Itoa(i))
+		require.Nil(t, err)
+		crossTxs = append(crossTxs, crossTx)
+	}
+	return newCrossEventOf(crossID, crossTxs...)
+}
+
+func newCrossEventOf(crossID string, crossTxs ...*eventproto.CrossTx) *eventproto.CrossEvent {
+	crossEvent := event.NewCrossEvent(crossTxs)
+	if crossID != "" {
+		crossEvent.CrossId = crossID
+	}
+	return crossEvent
+}
+
+func requireState(t *testing.T, h *Harness, chainID, crossID, state string) *simulator.Ledger {
+	ledger, err := h.Ledger(chainID)
+	require.Nil(t, err)
+	require.Equal(t, state, ledger.GetState(crossID))
+	return ledger
+}
+
+// executeTx return the execute transaction of cross on the simulated chain
+func executeTx(t *testing.T, ledger *simulator.Ledger, crossID string) *simulator.SimTx {
+	for _, tx := range ledger.Txs {
+		if tx.CrossID == crossID && tx.Method == simulator.ExecuteMethod {
+			return tx
+		}
+	}
+	require.FailNow(t, "execute tx of cross is not found", crossID)
+	return nil
+}
+
+func testCrossSuccess(t *testing.T, router string) {
+	h := startHarness(t, router, &ChainSpec{ChainID: "chain1"}, &ChainSpec{ChainID: "chain2", BlockLatency: 100})
+	crossEvent := newCrossEvent(t, "")
+	require.Nil(t, h.Submit("proxy1", crossEvent))
+
+	resp, err := h.WaitResult("proxy1", crossEvent.GetCrossId(), resultTimeout)
+	require.Nil(t, err)
+	require.Equal(t, int32(event.SuccessResp), resp.GetCode(), resp.GetMsg())
+	ledger := requireState(t, h, "chain1", crossEvent.GetCrossId(), simulator.StateCommitSuccess)
+	require.Equal(t, "90", ledger.Data["alice"])
+	ledger = requireState(t, h, "chain2", crossEvent.GetCrossId(), simulator.StateCommitSuccess)
+	require.Equal(t, "110", ledger.Data["bob"])
+}
+
+func TestCrossSuccessByLibP2P(t *testing.T) {
+	testCrossSuccess(t, RouterLibP2P)
+}
+
+func TestCrossSuccessByHttp(t *testing.T) {
+	testCrossSuccess(t, RouterHttp)
+}
+
+func TestCrossRollback(t *testing.T) {
+	crossID := "rollback-cross"
+	h := startHarness(t, RouterLibP2P, &ChainSpec{ChainID: "chain1"}, &ChainSpec{ChainID: "chain2",
+		Faults: []*simulator.Fault{{Method: simulator.ExecuteMethod, CrossID: crossID, Error: "insufficient balance"}}})
+	require.Nil(t, h.Submit("proxy1", newCrossEvent(t, crossID)))
+
+	resp, err := h.WaitResult("proxy1", crossID, resultTimeout)
+	require.Nil(t, err)
+	require.Equal(t, int32(event.FailureResp), resp.GetCode())
+	ledger := requireState(t, h, "chain1", crossID, simulator.StateRollbackSuccess)
+	_, exist := ledger.Data["alice"]
+	require.False(t, exist)
+	// 执行失败的链也会收到回滚，由事务合约忽略
+	ledger = requireState(t, h, "chain2", crossID, simulator.StateRollbackIgnore)
+	_, exist = ledger.Data["bob"]
+	require.False(t, exist)
+}
+
+func TestCrossRecoverAfterCrash(t *testing.T) {
+	crossID := "crash-cross"
+	h := startHarness(t, RouterHttp, &ChainSpec{ChainID: "chain1",
+		Faults: []*simulator.Fault{{Method: simulator.CommitMethod, CrossID: crossID, Crash: true}}}, &ChainSpec{ChainID: "chain2"})
+	require.Nil(t, h.Submit("proxy1", newCrossEvent(t, crossID)))
+
+	// 提交交易上链后代理崩溃，重启后恢复未完成的跨链事务
+	exitCode, err := h.Proxy("proxy1").WaitExit(resultTimeout)
+	require.Nil(t, err)
+	require.Equal(t, simulator.CrashExitCode, exitCode)
+	requireState(t, h, "chain1", crossID, simulator.StateCommitSuccess)
+	require.Nil(t, h.Proxy("proxy1").Restart())
+
+	resp, err := h.WaitResult("proxy1", crossID, resultTimeout)
+	require.Nil(t, err)
+	require.Equal(t, int32(event.SuccessResp), resp.GetCode(), resp.GetMsg())
+	requireState(t, h, "chain1", crossID, simulator.StateCommitSuccess)
+	requireState(t, h, "chain2", crossID, simulator.StateCommitSuccess)
+}
+
+// testChaosCrash crash proxy1 once at the point of rule, the cross transaction should be committed on both chains after restart
+func testChaosCrash(t *testing.T, rule *chaos.Rule) {
+	crossID := "chaos-cross"
+	rule.Action, rule.CrossID, rule.Times = chaos.ActionCrash, crossID, 1
+	h := startChaosHarness(t, RouterHttp, &chaos.Scenario{Name: t.Name(), Rules: []*chaos.Rule{rule}},
+		&ChainSpec{ChainID: "chain1"}, &ChainSpec{ChainID: "chain2"})
+	require.Nil(t, h.Submit("proxy1", newCrossEvent(t, crossID)))
+
+	exitCode, err := h.Proxy("proxy1").WaitExit(resultTimeout)
+	require.Nil(t, err)
+	require.Equal(t, chaos.CrashExitCode, exitCode)
+	// 崩溃规则的命中次数已持久化，重启后不再生效
+	require.Nil(t, h.Proxy("proxy1").Restart())
+
+	resp, err := h.WaitResult("proxy1", crossID, resultTimeout)
+	require.Nil(t, err)
+	require.Equal(t, int32(event.SuccessResp), resp.GetCode(), resp.GetMsg())
+	require.Len(t, resp.GetTxResponses(), 2)
+	ledger := requireState(t, h, "chain1", crossID, simulator.StateCommitSuccess)
+	require.Equal(t, "90", ledger.Data["alice"])
+	ledger = requireState(t, h, "chain2", crossID, simulator.StateCommitSuccess)
+	require.Equal(t, "110", ledger.Data["bob"])
+}
+
+// chain2执行后结果记录前崩溃，重启后重新执行chain2，事务合约保证重复执行幂等
+func TestChaosCrashAfterExecute(t *testing.T) {
+	testChaosCrash(t, &chaos.Rule{Point: chaos.AfterExecute, ChainID: "chain2"})
+}
+
+// chain2的证明保存到chain1前崩溃，重启后重新执行chain2并保存证明
+func TestChaosCrashBeforeSaveProof(t *testing.T) {
+	testChaosCrash(t, &chaos.Rule{Point: chaos.BeforeSaveProof, ChainID: "chain1"})
+}
+
+// chain1提交后链状态记录前崩溃，重启后提交未记录成功的链
+func TestChaosCrashMidCommit(t *testing.T) {
+	testChaosCrash(t, &chaos.Rule{Point: chaos.MidCommit, ChainID: "chain1"})
+}
+
+// 所有链已提交，整体成功状态写入存储前崩溃
+func TestChaosCrashBeforeFinishWrite(t *testing.T) {
+	testChaosCrash(t, &chaos.Rule{Point: chaos.BeforeStateWrite, Op: "StateSuccess"})
+}
+
+func TestCrossMultiChain(t *testing.T) {
+	crossID := "multi-chain-cross"
+	h := startChaosHarness(t, RouterHttp, nil, &ChainSpec{ChainID: "chain1"}, &ChainSpec{ChainID: "chain2"}, &ChainSpec{ChainID: "chain3"})
+	require.Nil(t, h.Submit("proxy1", newMultiChainCrossEvent(t, crossID, 3)))
+
+	resp, err := h.WaitResult("proxy1", crossID, resultTimeout)
+	require.Nil(t, err)
+	require.Equal(t, int32(event.SuccessResp), resp.GetCode(), resp.GetMsg())
+	require.Len(t, resp.GetTxResponses(), 3)
+	ledgers := make([]*simulator.Ledger, 0, 3)
+	for i := 1; i <= 3; i++ {
+		ledger := requireState(t, h, "chain"+strconv.Itoa(i), crossID, simulator.StateCommitSuccess)
+		require.Equal(t, strconv.Itoa(i), ledger.Data["user"+strconv.Itoa(i)])
+		ledgers = append(ledgers, ledger)
+	}
+	require.Empty(t, executeTx(t, ledgers[0], crossID).ProofTxKey)
+	for i := 1; i < 3; i++ {
+		prevTx, tx := executeTx(t, ledgers[i-1], crossID), executeTx(t, ledgers[i], crossID)
+		// 第i笔交易执行时传入第i-1笔交易的证明
+		require.Equal(t, prevTx.TxID, tx.ProofTxKey)
+		// 相邻两条链以后一笔交易的proofKey互存证明：第i笔交易的证明保存到第i-1条链，第i-1笔交易的证明保存到第i条链
+		proofKey := crossID + ".proof.chain" + strconv.Itoa(i+1)
+		require.Contains(t, ledgers[i-1].Proofs[proofKey], tx.TxID)
+		require.Contains(t, ledgers[i].Proofs[proofKey], prevTx.TxID)
+	}
+	require.Len(t, ledgers[0].Proofs, 1)
+	require.Len(t, ledgers[1].Proofs, 2)
+	require.Len(t, ledgers[2].Proofs, 1)
+}
+
+// requireMiddleChainRolledBack check the cross whose middle chain failed to execute, the executed chains are rolled back
+// and the last chain is never executed
+func requireMiddleChainRolledBack(t *testing.T, h *Harness, crossID string) {
+	resp, err := h.WaitResult("proxy1", crossID, resultTimeout)
+	require.Nil(t, err)
+	require.Equal(t, int32(event.FailureResp), resp.GetCode())
+	ledger1 := requireState(t, h, "chain1", crossID, simulator.StateRollbackSuccess)
+	_, exist := ledger1.Data["user1"]
+	require.False(t, exist)
+	ledger2 := requireState(t, h, "chain2", crossID, simulator.StateRollbackIgnore)
+	_, exist = ledger2.Data["user2"]
+	require.False(t, exist)
+	require.Equal(t, executeTx(t, ledger1, crossID).TxID, executeTx(t, ledger2, crossID).ProofTxKey)
+	ledger3 := requireState(t, h, "chain3", crossID, simulator.StateUnknown)
+	require.Empty(t, ledger3.Txs)
+}
+
+func TestCrossMultiChainMiddleFailed(t *testing.T) {
+	crossID := "multi-chain-failed-cross"
+	h := startChaosHarness(t, RouterHttp, nil, &ChainSpec{ChainID: "chain1"}, &ChainSpec{ChainID: "chain2",
+		Faults: []*simulator.Fault{{Method: simulator.ExecuteMethod, CrossID: crossID, Error: "insufficient balance"}}},
+		&ChainSpec{ChainID: "chain3"})
+	require.Nil(t, h.Submit("proxy1", newMultiChainCrossEvent(t, crossID, 3)))
+	requireMiddleChainRolledBack(t, h, crossID)
+}
+
+func TestCrossMultiChainMiddleFailedRecover(t *testing.T) {
+	crossID := "multi-chain-recover-cross"
+	// 中间链执行失败后，回滚状态写入前代理崩溃，重启后继续回滚
+	scenario := &chaos.Scenario{Name: t.Name(), Rules: []*chaos.Rule{{Point: chaos.BeforeStateWrite,
+		Action: chaos.ActionCrash, CrossID: crossID, Op: "StateRollbackSuccess", Times: 1}}}
+	h := startChaosHarness(t, RouterHttp, scenario, &ChainSpec{ChainID: "chain1"}, &ChainSpec{ChainID: "chain2",
+		Faults: []*simulator.Fault{{Method: simulator.ExecuteMethod, CrossID: crossID, Error: "insufficient balance"}}},
+		&ChainSpec{ChainID: "chain3"})
+	require.Nil(t, h.Submit("proxy1", newMultiChainCrossEvent(t, crossID, 3)))
+
+	exitCode, err := h.Proxy("proxy1").WaitExit(resultTimeout)
+	require.Nil(t, err)
+	require.Equal(t, chaos.CrashExitCode, exitCode)
+	require.Nil(t, h.Proxy("proxy1").Restart())
+	requireMiddleChainRolledBack(t, h, crossID)
+}
+
+// newParallelCrossEvent create the cross event whose txs are executed concurrently
+func newParallelCrossEvent(t *testing.T, crossID string, chainCount int) *eventproto.CrossEvent {
+	crossEvent := newMultiChainCrossEvent(t, crossID, chainCount)
+	crossEvent.SetExecuteMode(event.ParallelExecuteMode)
+	return crossEvent
+}
+
+// requireOwnProof check the proof of the chain is saved to itself in parallel mode, no proof is passed into execute
+func requireOwnProof(t *testing.T, ledger *simulator.Ledger, crossID, chainID string) {
+	tx := executeTx(t, ledger, crossID)
+	require.Empty(t, tx.ProofTxKey)
+	require.Len(t, ledger.Proofs, 1)
+	require.Contains(t, ledger.Proofs[crossID+".proof."+chainID], tx.TxID)
+}
+
+func TestCrossParallelFailed(t *testing.T) {
+	crossID := "parallel-failed-cross"
+	h := startChaosHarness(t, RouterHttp, nil, &ChainSpec{ChainID: "chain1"}, &ChainSpec{ChainID: "chain2",
+		Faults: []*simulator.Fault{{Method: simulator.ExecuteMethod, CrossID: crossID, Error: "insufficient balance"}}},
+		&ChainSpec{ChainID: "chain3"})
+	require.Nil(t, h.Submit("proxy1", newParallelCrossEvent(t, crossID, 3)))
+
+	resp, err := h.WaitResult("proxy1", crossID, resultTimeout)
+	require.Nil(t, err)
+	require.Equal(t, int32(event.FailureResp), resp.GetCode())
+	// 其他链均已执行，全部回滚；失败链的回滚被合约忽略
+	for _, chainID := range []string{"chain1", "chain3"} {
+		ledger := requireState(t, h, chainID, crossID, simulator.StateRollbackSuccess)
+		require.Empty(t, ledger.Data)
+		requireOwnProof(t, ledger, crossID, chainID)
+	}
+	ledger2 := requireState(t, h, "chain2", crossID, simulator.StateRollbackIgnore)
+	require.Empty(t, ledger2.Data)
+	require.Empty(t, ledger2.Proofs)
+}
+
+func TestCrossParallelRecover(t *testing.T) {
+	crossID := "parallel-recover-cross"
+	// 全部执行并证明成功后，提交过程中代理崩溃，重启后继续提交
+	scenario := &chaos.Scenario{Name: t.Name(), Rules: []*chaos.Rule{{Point: chaos.MidCommit,
+		Action: chaos.ActionCrash, CrossID: crossID, ChainID: "chain1", Times: 1}}}
+	h := startChaosHarness(t, RouterHttp, scenario,
+		&ChainSpec{ChainID: "chain1"}, &ChainSpec{ChainID: "chain2"}, &ChainSpec{ChainID: "chain3"})
+	require.Nil(t, h.Submit("proxy1", newParallelCrossEvent(t, crossID, 3)))
+
+	exitCode, err := h.Proxy("proxy1").WaitExit(resultTimeout)
+	require.Nil(t, err)
+	require.Equal(t, chaos.CrashExitCode, exitCode)
+	require.Nil(t, h.Proxy("proxy1").Restart())
+	resp, err := h.WaitResult("proxy1", crossID, resultTimeout)
+	require.Nil(t, err)
+	require.Equal(t, int32(event.SuccessResp), resp.GetCode(), resp.GetMsg())
+	require.Len(t, resp.GetTxResponses(), 3)
+	for i := 1; i <= 3; i++ {
+		chainID := "chain" + strconv.Itoa(i)
+		ledger := requireState(t, h, chainID, crossID, simulator.StateCommitSuccess)
+		require.Equal(t, strconv.Itoa(i), ledger.Data["user"+strconv.Itoa(i)])
+		// 每条链的证明保存在自己的链上，恢复后不会写到其他链
+		requireOwnProof(t, ledger, crossID, chainID)
+	}
+}
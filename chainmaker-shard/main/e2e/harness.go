@@ -0,0 +1,579 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package e2e run several cross proxies with simulated chains on the local machine, every proxy is a child
+// process of the test binary because the proxy server uses global singletons
+package e2e
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"chainmaker.org/chainmaker-cross/adapter/simulator"
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/conf/chaos"
+	"chainmaker.org/chainmaker-cross/event"
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+	"chainmaker.org/chainmaker-cross/server"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/spf13/cobra"
+)
+
+const (
+	EnvProxyConfig = "CROSS_E2E_PROXY_CONFIG" // 子进程通过该环境变量获取代理的配置文件
+
+	RouterLibP2P = "libp2p" // 其他代理通过libp2p访问该代理
+	RouterHttp   = "http"   // 其他代理通过http访问该代理
+
+	crossPathFormat    = "http://127.0.0.1:%d/cross?method=%s" // web监听的接口路径
+	proxyReadyTimeout  = 30 * time.Second                      // 等待代理启动的时间
+	proxyStopTimeout   = 10 * time.Second                      // 等待代理退出的时间
+	pollInterval       = 200 * time.Millisecond                // 轮询间隔
+	httpRequestTimeout = 5 * time.Second                       // 请求代理的超时时间
+)
+
+// ChainSpec the simulated chain which is connected by the proxy
+type ChainSpec struct {
+	ChainID      string             // 链ID
+	BlockLatency int64              // 出块延迟(ms)
+	Faults       []*simulator.Fault // 启动时注入的故障，代理重启后重新生效，已上链的交易不会再次触发
+}
+
+// ProxySpec the proxy which is started by harness
+type ProxySpec struct {
+	Name   string          // 代理名称
+	Router string          // 其他代理访问该代理的方式，libp2p或http
+	Chains []*ChainSpec    // 代理直连的模拟链
+	Chaos  *chaos.Scenario // 代理开启的故障场景，规则命中次数持久化在工作目录中，重启后已生效的崩溃规则不再重复生效
+}
+
+// Proxy the proxy process
+type Proxy struct {
+	sync.Mutex
+	spec       *ProxySpec
+	dir        string    // 代理的工作目录，包括配置、存储、日志和模拟链账本
+	configPath string    // 代理配置文件
+	webPort    int       // web监听端口
+	p2pPort    int       // libp2p监听端口
+	peerID     string    // libp2p网络身份
+	cmd        *exec.Cmd // 代理进程，未启动时为nil
+	exited     chan struct{}
+	exitCode   int
+}
+
+// Harness start the proxies and submit cross events to them
+type Harness struct {
+	dir     string
+	proxies []*Proxy
+	byName  map[string]*Proxy
+	byChain map[string]*Proxy
+}
+
+// RunProxyIfRequested run the proxy server and exit if the process is started by harness,
+// it should be called in TestMain before running the tests
+func RunProxyIfRequested() {
+	configPath := os.Getenv(EnvProxyConfig)
+	if configPath == "" {
+		return
+	}
+	conf.BinaryAbsDirPath = filepath.Dir(configPath)
+	conf.ConfigFilepath = configPath
+	if err := conf.InitLocalConfig(&cobra.Command{}); err != nil {
+		fmt.Fprintf(os.Stderr, "init config [%s] failed, %v\n", configPath, err)
+		os.Exit(1)
+	}
+	proxyServer := server.NewServer()
+	if err := proxyServer.Start(); err != nil {
+		fmt.Fprintf(os.Stderr, "start proxy failed, %v\n", err)
+		os.Exit(1)
+	}
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	<-signals
+	_ = proxyServer.Stop()
+	os.Exit(0)
+}
+
+// NewHarness create the harness, the config of every proxy is generated in dir,
+// every proxy routes the chains of other proxies by the router type of them
+func NewHarness(dir string, specs ...*ProxySpec) (*Harness, error) {
+	h := &Harness{
+		dir:     dir,
+		byName:  make(map[string]*Proxy),
+		byChain: make(map[string]*Proxy),
+	}
+	for _, spec := range specs {
+		if _, exist := h.byName[spec.Name]; exist {
+			return nil, fmt.Errorf("duplicated proxy name [%s]", spec.Name)
+		}
+		proxy, err := newProxy(filepath.Join(dir, spec.Name), spec)
+		if err != nil {
+			return nil, err
+		}
+		h.proxies = append(h.proxies, proxy)
+		h.byName[spec.Name] = proxy
+		for _, chain := range spec.Chains {
+			if _, exist := h.byChain[chain.ChainID]; exist {
+				return nil, fmt.Errorf("duplicated chain id [%s]", chain.ChainID)
+			}
+			h.byChain[chain.ChainID] = proxy
+		}
+	}
+	for _, proxy := range h.proxies {
+		if err := h.writeConfig(proxy); err != nil {
+			return nil, err
+		}
+	}
+	return h, nil
+}
+
+// Start start all the proxies and wait until they are ready
+func (h *Harness) Start() error {
+	for _, proxy := range h.proxies {
+		if err := proxy.Start(); err != nil {
+			h.Stop()
+			return err
+		}
+	}
+	return nil
+}
+
+// Stop stop all the proxies
+func (h *Harness) Stop() {
+	for _, proxy := range h.proxies {
+		_ = proxy.Stop()
+	}
+}
+
+// Proxy return the proxy by name
+func (h *Harness) Proxy(name string) *Proxy {
+	return h.byName[name]
+}
+
+// Ledger return the ledger of simulated chain, which is read from the state file
+func (h *Harness) Ledger(chainID string) (*simulator.Ledger, error) {
+	proxy, exist := h.byChain[chainID]
+	if !exist {
+		return nil, fmt.Errorf("can not find proxy of chain [%s]", chainID)
+	}
+	ledger, err := simulator.LoadLedger(proxy.stateFile(chainID))
+	if os.IsNotExist(err) {
+		// 链上还没有交易
+		return simulator.NewLedger(), nil
+	}
+	return ledger, err
+}
+
+// NewCrossTx create the cross tx of simulated chain, the business contract writes key=value when executing
+func NewCrossTx(chainID string, index int32, key, value string) (*eventproto.CrossTx, error) {
+	nonce := make([]byte, 8)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	txID := func(method string) string {
+		return fmt.Sprintf("%s.%s.%s", chainID, hex.EncodeToString(nonce), method)
+	}
+	executePayload, err := json.Marshal(simulator.NewTxRequest(txID(simulator.ExecuteMethod), key, value))
+	if err != nil {
+		return nil, err
+	}
+	commitPayload, err := json.Marshal(simulator.NewTxRequest(txID(simulator.CommitMethod), "", ""))
+	if err != nil {
+		return nil, err
+	}
+	rollbackPayload, err := json.Marshal(simulator.NewTxRequest(txID(simulator.RollbackMethod), "", ""))
+	if err != nil {
+		return nil, err
+	}
+	crossTx := event.NewCrossTx(chainID, index, executePayload, commitPayload, rollbackPayload)
+	crossTx.ProofKey = "proof." + chainID
+	return crossTx, nil
+}
+
+// Submit send the cross event to the proxy, the cross event is handled asynchronously
+func (h *Harness) Submit(proxyName string, crossEvent *eventproto.CrossEvent) error {
+	proxy, exist := h.byName[proxyName]
+	if !exist {
+		return fmt.Errorf("can not find proxy [%s]", proxyName)
+	}
+	resp := make(map[string]interface{})
+	return proxy.post("InvokeCrossEvent", crossEvent, &resp)
+}
+
+// WaitResult wait until the cross transaction is finished, the result is success or failure
+func (h *Harness) WaitResult(proxyName, crossID string, timeout time.Duration) (*eventproto.CrossResponse, error) {
+	proxy, exist := h.byName[proxyName]
+	if !exist {
+		return nil, fmt.Errorf("can not find proxy [%s]", proxyName)
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		resp := &eventproto.CrossResponse{}
+		err := proxy.post("GetCrossEvent", &eventproto.CrossSearchEvent{CrossId: crossID}, resp)
+		if err == nil && resp.GetCrossId() == crossID &&
+			(resp.GetCode() == event.SuccessResp || resp.GetCode() == event.FailureResp) {
+			return resp, nil
+		}
+		if time.Now().After(deadline) {
+			if err == nil {
+				err = fmt.Errorf("code = %d, %s", resp.GetCode(), resp.GetMsg())
+			}
+			return nil, fmt.Errorf("wait result of cross[%s] timeout, %v", crossID, err)
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// writeConfig write the config of proxy and its simulated chains
+func (h *Harness) writeConfig(proxy *Proxy) error {
+	chainIDs := make([]string, 0)
+	for _, other := range h.proxies {
+		for _, chain := range other.spec.Chains {
+			chainIDs = append(chainIDs, chain.ChainID)
+		}
+	}
+	adapters := make([]map[string]interface{}, 0, len(proxy.spec.Chains))
+	for _, chain := range proxy.spec.Chains {
+		simConfig := map[string]interface{}{
+			"state_file":    proxy.stateFile(chain.ChainID),
+			"block_latency": chain.BlockLatency,
+			"faults":        chain.Faults,
+		}
+		simConfigPath := filepath.Join(proxy.dir, chain.ChainID+".json")
+		if err := writeJSON(simConfigPath, simConfig); err != nil {
+			return err
+		}
+		adapters = append(adapters, map[string]interface{}{
+			"provider":    "simulator",
+			"chain_id":    chain.ChainID,
+			"config_path": simConfigPath,
+			"retry_policy": map[string]interface{}{
+				"max_attempts":    25,
+				"initial_backoff": 200,
+			},
+		})
+	}
+	routers := make([]map[string]interface{}, 0, len(h.proxies))
+	for _, other := range h.proxies {
+		if other == proxy {
+			continue
+		}
+		otherChainIDs := make([]string, 0, len(other.spec.Chains))
+		for _, chain := range other.spec.Chains {
+			otherChainIDs = append(otherChainIDs, chain.ChainID)
+		}
+		router := map[string]interface{}{
+			"name":      other.spec.Name,
+			"provider":  other.spec.Router,
+			"chain_ids": otherChainIDs,
+		}
+		switch other.spec.Router {
+		case RouterLibP2P:
+			router["libp2p"] = map[string]interface{}{
+				"address":            fmt.Sprintf("/ip4/127.0.0.1/tcp/%d/p2p/%s", other.p2pPort, other.peerID),
+				"protocol_id":        "/listener",
+				"delimit":            "\n",
+				"reconnect_limit":    1000,
+				"reconnect_interval": 500,
+			}
+		case RouterHttp:
+			router["http"] = map[string]interface{}{
+				"address":                fmt.Sprintf("http://127.0.0.1:%d", other.webPort),
+				"request_timeout":        10000,
+				"request_max_retries":    50,
+				"request_retry_interval": 200,
+				"max_connection":         100,
+				"idle_conn_timeout":      120,
+			}
+		default:
+			return fmt.Errorf("unsupported router [%s] of proxy [%s]", other.spec.Router, other.spec.Name)
+		}
+		routers = append(routers, router)
+	}
+	logs := make([]map[string]interface{}, 0)
+	for _, module := range []string{"default", "server"} {
+		logs = append(logs, map[string]interface{}{
+			"module":        module,
+			"log_level":     "INFO",
+			"file_path":     filepath.Join(proxy.dir, "logs", module+".log"),
+			"max_age":       1,
+			"rotation_time": 1,
+		})
+	}
+	config := map[string]interface{}{
+		"listener": map[string]interface{}{
+			"web": map[string]interface{}{
+				"address":        "127.0.0.1",
+				"port":           proxy.webPort,
+				"open_tx_router": true,
+			},
+			"channel": map[string]interface{}{
+				"provider": "libp2p",
+				"libp2p": map[string]interface{}{
+					"address":       fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", proxy.p2pPort),
+					"priv_key_file": filepath.Join(proxy.dir, "p2p.key"),
+					"protocol_id":   "/listener",
+					"delimit":       "\n",
+				},
+			},
+		},
+		"adapters": adapters,
+		"routers":  routers,
+		"provers": []map[string]interface{}{{
+			"provider":  "trust",
+			"policy":    "trust",
+			"chain_ids": chainIDs,
+		}},
+		"storage": map[string]interface{}{
+			"provider": "leveldb",
+			"leveldb": map[string]interface{}{
+				"store_path":        filepath.Join(proxy.dir, "storage"),
+				"write_buffer_size": 4,
+				"bloom_filter_bits": 10,
+			},
+		},
+		"retry_policy": map[string]interface{}{
+			"max_attempts":    50,
+			"initial_backoff": 200,
+			"execute_timeout": 20000,
+		},
+		"log": logs,
+	}
+	if proxy.spec.Chaos != nil {
+		scenarioPath := filepath.Join(proxy.dir, "chaos.json")
+		if err := writeJSON(scenarioPath, proxy.spec.Chaos); err != nil {
+			return err
+		}
+		config["chaos"] = map[string]interface{}{
+			"enable":        true,
+			"scenario_file": scenarioPath,
+			"state_file":    filepath.Join(proxy.dir, "chaos", "state.json"),
+		}
+	}
+	return writeJSON(proxy.configPath, config)
+}
+
+// newProxy allocate the ports and the libp2p key of proxy
+func newProxy(dir string, spec *ProxySpec) (*Proxy, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	webPort, err := freePort()
+	if err != nil {
+		return nil, err
+	}
+	p2pPort, err := freePort()
+	if err != nil {
+		return nil, err
+	}
+	peerID, err := generateP2PKey(filepath.Join(dir, "p2p.key"))
+	if err != nil {
+		return nil, err
+	}
+	return &Proxy{
+		spec:       spec,
+		dir:        dir,
+		configPath: filepath.Join(dir, "cross_chain.json"),
+		webPort:    webPort,
+		p2pPort:    p2pPort,
+		peerID:     peerID,
+	}, nil
+}
+
+// Start start the proxy process and wait until the web listener is ready
+func (p *Proxy) Start() error {
+	p.Lock()
+	if p.cmd != nil {
+		p.Unlock()
+		return fmt.Errorf("proxy [%s] has been started", p.spec.Name)
+	}
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	cmd.Env = append(os.Environ(), EnvProxyConfig+"="+p.configPath)
+	output, err := os.OpenFile(filepath.Join(p.dir, "output.log"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		p.Unlock()
+		return err
+	}
+	cmd.Stdout = output
+	cmd.Stderr = output
+	if err = cmd.Start(); err != nil {
+		p.Unlock()
+		_ = output.Close()
+		return fmt.Errorf("start proxy [%s] failed, %v", p.spec.Name, err)
+	}
+	exited := make(chan struct{})
+	p.cmd = cmd
+	p.exited = exited
+	p.Unlock()
+	go func() {
+		_ = cmd.Wait()
+		_ = output.Close()
+		p.Lock()
+		p.exitCode = cmd.ProcessState.ExitCode()
+		p.cmd = nil
+		p.Unlock()
+		close(exited)
+	}()
+	return p.waitReady(exited)
+}
+
+// Stop stop the proxy process gracefully
+func (p *Proxy) Stop() error {
+	p.Lock()
+	cmd, exited := p.cmd, p.exited
+	p.Unlock()
+	if cmd == nil {
+		return nil
+	}
+	_ = cmd.Process.Signal(syscall.SIGTERM)
+	select {
+	case <-exited:
+		return nil
+	case <-time.After(proxyStopTimeout):
+		_ = cmd.Process.Kill()
+		<-exited
+		return fmt.Errorf("proxy [%s] does not exit in %s, killed", p.spec.Name, proxyStopTimeout)
+	}
+}
+
+// Restart stop the proxy if it is running and start it again, the unfinished cross transactions are recovered
+func (p *Proxy) Restart() error {
+	if err := p.Stop(); err != nil {
+		return err
+	}
+	return p.Start()
+}
+
+// WaitExit wait until the proxy process exits and return the exit code, which is used to wait the injected crash
+func (p *Proxy) WaitExit(timeout time.Duration) (int, error) {
+	p.Lock()
+	exited := p.exited
+	p.Unlock()
+	if exited == nil {
+		return 0, fmt.Errorf("proxy [%s] has not been started", p.spec.Name)
+	}
+	select {
+	case <-exited:
+		p.Lock()
+		defer p.Unlock()
+		return p.exitCode, nil
+	case <-time.After(timeout):
+		return 0, fmt.Errorf("proxy [%s] does not exit in %s", p.spec.Name, timeout)
+	}
+}
+
+// Dir return the work dir of proxy, the logs of proxy are in it
+func (p *Proxy) Dir() string {
+	return p.dir
+}
+
+// waitReady wait until the web listener responses
+func (p *Proxy) waitReady(exited chan struct{}) error {
+	deadline := time.Now().Add(proxyReadyTimeout)
+	for {
+		select {
+		case <-exited:
+			return fmt.Errorf("proxy [%s] exited while starting, see %s", p.spec.Name, filepath.Join(p.dir, "output.log"))
+		default:
+		}
+		resp := &eventproto.CrossResponse{}
+		if err := p.post("GetCrossEvent", &eventproto.CrossSearchEvent{CrossId: "ready"}, resp); err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("proxy [%s] is not ready in %s", p.spec.Name, proxyReadyTimeout)
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// post send the request to the web listener of proxy
+func (p *Proxy) post(method string, body, out interface{}) error {
+	content, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: httpRequestTimeout}
+	resp, err := client.Post(fmt.Sprintf(crossPathFormat, p.webPort, method), "application/json", bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// stateFile return the ledger file of simulated chain
+func (p *Proxy) stateFile(chainID string) string {
+	return filepath.Join(p.dir, "chains", chainID+".state.json")
+}
+
+// freePort return a free tcp port of localhost
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	addr, ok := listener.Addr().(*net.TCPAddr)
+	if !ok {
+		return 0, errors.New("unexpected address type")
+	}
+	return addr.Port, nil
+}
+
+// generateP2PKey write the ecdsa key of libp2p channel listener and return the peer id
+func generateP2PKey(keyFile string) (string, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", err
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return "", err
+	}
+	if err = ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0600); err != nil {
+		return "", err
+	}
+	privateKey, _, err := crypto.KeyPairFromStdKey(key)
+	if err != nil {
+		return "", err
+	}
+	peerID, err := peer.IDFromPrivateKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+	return peerID.Pretty(), nil
+}
+
+// writeJSON write the config as json, which can be read by viper
+func writeJSON(file string, config interface{}) error {
+	content, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, content, 0644)
+}
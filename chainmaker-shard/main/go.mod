@@ -3,12 +3,17 @@ module chainmaker.org/chainmaker-cross/main
 go 1.15
 
 require (
+	chainmaker.org/chainmaker-cross/adapter v0.0.0
 	chainmaker.org/chainmaker-cross/conf v0.0.0
+	chainmaker.org/chainmaker-cross/event v0.0.0
 	chainmaker.org/chainmaker-cross/logger v0.0.0
+	chainmaker.org/chainmaker-cross/pb/protogo v0.0.0
 	chainmaker.org/chainmaker-cross/server v0.0.0
 	github.com/google/martian v2.1.0+incompatible
+	github.com/libp2p/go-libp2p-core v0.8.5
 	github.com/spf13/cobra v1.1.3
 	github.com/spf13/pflag v1.0.5
+	github.com/stretchr/testify v1.7.0
 )
 
 replace (
@@ -20,6 +25,7 @@ replace (
 	chainmaker.org/chainmaker-cross/handler => ../module/handler
 	chainmaker.org/chainmaker-cross/listener => ../module/listener
 	chainmaker.org/chainmaker-cross/logger => ../module/logger
+	chainmaker.org/chainmaker-cross/monitor => ../module/monitor
 	chainmaker.org/chainmaker-cross/net => ./../module/net
 	chainmaker.org/chainmaker-cross/pb/protogo => ../module/pb/protogo
 	chainmaker.org/chainmaker-cross/prover => ../module/prover
@@ -22,8 +22,8 @@ type ChainAdapter interface {
 	// SaveProof save the proof and verify in the chain
 	SaveProof(crossID, proofKey string, txProof *eventproto.Proof, verifyResult bool) (*eventproto.TxResponse, error)
 
-	// Prove prove the proof
-	Prove(txProof *eventproto.Proof) bool
+	// Prove prove the proof by the proof policy, the decision is audited for the cross transaction
+	Prove(crossID string, txProof *eventproto.Proof) bool
 
 	// QueryByTxKey query tx response by tx-key
 	QueryByTxKey(txKey string) (*event.CommonTxResponse, error)
@@ -6,12 +6,18 @@
 package adapter
 
 import (
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 	"sync"
 
 	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
 
 	"chainmaker.org/chainmaker-cross/event"
+	"chainmaker.org/chainmaker-cross/monitor"
+	"chainmaker.org/chainmaker-cross/prover/impl"
 	"go.uber.org/zap"
 )
 
@@ -32,9 +38,15 @@ func GetChainAdapterDispatcher() *ChainAdapterDispatcher {
 type ChainAdapterDispatcher struct {
 	sync.RWMutex                         // 读写锁
 	adapters     map[string]ChainAdapter // 转接器的Map，按链ID区分
+	attestor     ed25519.PrivateKey      // 背书私钥，为空时不响应背书请求
 	log          *zap.SugaredLogger      // 日志模块
 }
 
+// SetAttestor set the private key which signs the attestations for other proxies
+func (d *ChainAdapterDispatcher) SetAttestor(privateKey ed25519.PrivateKey) {
+	d.attestor = privateKey
+}
+
 // SetLog set module of logger
 func (d *ChainAdapterDispatcher) SetLog(log *zap.SugaredLogger) {
 	d.log = log
@@ -54,10 +66,20 @@ func (d *ChainAdapterDispatcher) Invoke(chainID string, tx *eventproto.Transacti
 	defer d.RUnlock()
 	if adapter, exist := d.adapters[chainID]; exist {
 		d.log.Infof("find chain[%s]'s adapter", chainID)
+		// 其他代理请求背书，只查询交易不执行
+		if tx.GetOpFunc() == event.AttestOpFunc {
+			return d.attest(adapter, tx)
+		}
+		// 将其他链的证明保存到本链上，不执行交易
+		if tx.GetOpFunc() == event.SaveProofOpFunc {
+			verifyResult, _ := strconv.ParseBool(string(tx.GetPayload()))
+			return d.saveProof(adapter, tx.GetCrossID(), tx.ProofKey, tx.TxProof, verifyResult)
+		}
 		// 判断是否需要进行证明
 		if tx.NeedProve() {
 			var verifyResult = false
-			if verifyResult = adapter.Prove(tx.TxProof); !verifyResult {
+			verifyResult = adapter.Prove(tx.GetCrossID(), tx.TxProof)
+			if !verifyResult {
 				// 证明失败，打印信息，然后返回error
 				d.log.Errorf("cross[%s]->chain[%s]'s tx-proof prove failed", tx.GetCrossID(), tx.GetChainID())
 				return nil, fmt.Errorf("cross[%s]->chain[%s]'s tx-proof prove failed", tx.GetCrossID(), tx.GetChainID())
@@ -67,6 +89,7 @@ func (d *ChainAdapterDispatcher) Invoke(chainID string, tx *eventproto.Transacti
 			proofResponse, err := adapter.SaveProof(tx.GetCrossID(), tx.ProofKey, tx.TxProof, verifyResult)
 			if err != nil {
 				d.log.Errorf("cross[%s]->chain[%s] save proof error, ", tx.GetCrossID(), tx.GetChainID(), err)
+				monitor.ObserveAdapterError(chainID, "SaveProof")
 				// 保存数据失败，不影响交易主流程，错误不返回
 				//return nil, err
 			} else {
@@ -75,39 +98,86 @@ func (d *ChainAdapterDispatcher) Invoke(chainID string, tx *eventproto.Transacti
 					tx.GetCrossID(), tx.GetChainID(), proofResponse.TxKey, proofResponse.BlockHeight, proofResponse.Index)
 			}
 		}
-		return adapter.Invoke(tx)
+		txResponse, err := adapter.Invoke(tx)
+		if err != nil {
+			monitor.ObserveAdapterError(chainID, "Invoke")
+		}
+		return txResponse, err
 	}
 	d.log.Errorf("can not find adapter for chain[%s]", chainID)
 	return nil, fmt.Errorf("can not find adapter for chain[%v]", chainID)
 }
 
+// attest query the tx of proof from the chain directly and sign the result, the signed attestation is returned
+// by the extra of tx response
+func (d *ChainAdapterDispatcher) attest(adapter ChainAdapter, tx *eventproto.TransactionEvent) (*eventproto.TxResponse, error) {
+	if d.attestor == nil {
+		return nil, errors.New("attestor is not configured")
+	}
+	proof := tx.TxProof
+	if proof == nil {
+		return nil, errors.New("proof to attest is nil")
+	}
+	txResp, err := adapter.QueryByTxKey(proof.GetTxKey())
+	if err != nil {
+		// 无法确定交易状态时不背书，由请求方计为未通过
+		monitor.ObserveAdapterError(adapter.GetChainID(), "QueryByTxKey")
+		return nil, err
+	}
+	// 交易需执行成功，且高度与位置一致，index小于0表示转接器无法获取交易位置
+	result := txResp.IsSuccess() && txResp.TxKey == proof.GetTxKey() && txResp.BlockHeight == proof.GetBlockHeight() &&
+		(txResp.Index < 0 || txResp.Index == proof.GetIndex())
+	attestation, err := impl.SignAttestation(d.attestor, proof, result)
+	if err != nil {
+		return nil, err
+	}
+	extra, err := json.Marshal(attestation)
+	if err != nil {
+		return nil, err
+	}
+	d.log.Infof("attest tx[%s] of chain[%s] with result %v", proof.GetTxKey(), proof.GetChainID(), result)
+	return event.NewTxResponse(proof.GetChainID(), proof.GetTxKey(), proof.GetBlockHeight(), proof.GetIndex(),
+		proof.GetContract(), extra), nil
+}
+
 func (d *ChainAdapterDispatcher) SaveProof(chainID, crossID, proofTxKey string, txProof *eventproto.Proof, verifyResult bool) (*eventproto.TxResponse, error) {
 	d.RLock()
 	defer d.RUnlock()
 	if adapter, exist := d.adapters[chainID]; exist {
 		d.log.Infof("find chain[%s]'s adapter", chainID)
-		proofResponse, err := adapter.SaveProof(crossID, proofTxKey, txProof, verifyResult)
-		if err != nil {
-			d.log.Errorf("cross[%s]->chain[%s] save proof error, ", crossID, chainID, err)
-			// 保存数据失败，此时直接返回错误
-			return nil, err
-		}
-		// 打印内容，后续写入数据库
-		d.log.Infof("save proof success, cross[%s]->chain[%s] txKey[%s] block[%v] index[%v]",
-			crossID, chainID, proofResponse.TxKey, proofResponse.BlockHeight, proofResponse.Index)
-		return proofResponse, err
+		return d.saveProof(adapter, crossID, proofTxKey, txProof, verifyResult)
 	}
 	d.log.Errorf("can not find adapter for chain[%s]", chainID)
 	return nil, fmt.Errorf("can not find adapter for chain[%v]", chainID)
 }
 
+// saveProof save the proof to the chain of adapter, the error is returned directly
+func (d *ChainAdapterDispatcher) saveProof(adapter ChainAdapter, crossID, proofTxKey string, txProof *eventproto.Proof, verifyResult bool) (*eventproto.TxResponse, error) {
+	chainID := adapter.GetChainID()
+	proofResponse, err := adapter.SaveProof(crossID, proofTxKey, txProof, verifyResult)
+	if err != nil {
+		d.log.Errorf("cross[%s]->chain[%s] save proof error, ", crossID, chainID, err)
+		monitor.ObserveAdapterError(chainID, "SaveProof")
+		// 保存数据失败，此时直接返回错误
+		return nil, err
+	}
+	// 打印内容，后续写入数据库
+	d.log.Infof("save proof success, cross[%s]->chain[%s] txKey[%s] block[%v] index[%v]",
+		crossID, chainID, proofResponse.TxKey, proofResponse.BlockHeight, proofResponse.Index)
+	return proofResponse, err
+}
+
 // QueryByTxKey query transaction by chain-id and tx-key
 func (d *ChainAdapterDispatcher) QueryByTxKey(chainID string, txKey string) (*event.CommonTxResponse, error) {
 	d.RLock()
 	defer d.RUnlock()
 	if adapter, exist := d.adapters[chainID]; exist {
 		d.log.Infof("find chain[%s]'s adapter", chainID)
-		return adapter.QueryByTxKey(txKey)
+		txResponse, err := adapter.QueryByTxKey(txKey)
+		if err != nil {
+			monitor.ObserveAdapterError(chainID, "QueryByTxKey")
+		}
+		return txResponse, err
 	}
 	d.log.Errorf("can not find adapter for chain[%s]", chainID)
 	return nil, fmt.Errorf("can not find adapter for chain[%v]", chainID)
@@ -119,7 +189,11 @@ func (d *ChainAdapterDispatcher) Query(chainID string, payload []byte) (*event.C
 	defer d.RUnlock()
 	if adapter, exist := d.adapters[chainID]; exist {
 		d.log.Infof("find chain[%s]'s adapter", chainID)
-		return adapter.QueryTx(payload)
+		txResponse, err := adapter.QueryTx(payload)
+		if err != nil {
+			monitor.ObserveAdapterError(chainID, "QueryTx")
+		}
+		return txResponse, err
 	}
 	d.log.Errorf("can not find adapter for chain[%s]", chainID)
 	return nil, fmt.Errorf("can not find adapter for chain[%v]", chainID)
@@ -9,9 +9,13 @@ import (
 	"fmt"
 
 	"chainmaker.org/chainmaker-cross/adapter/chainmaker"
+	"chainmaker.org/chainmaker-cross/adapter/evm"
 	"chainmaker.org/chainmaker-cross/adapter/fabric"
+	"chainmaker.org/chainmaker-cross/adapter/rest"
+	"chainmaker.org/chainmaker-cross/adapter/simulator"
 	"chainmaker.org/chainmaker-cross/conf"
 	"chainmaker.org/chainmaker-cross/logger"
+	"chainmaker.org/chainmaker-cross/utils"
 	"go.uber.org/zap"
 )
 
@@ -21,6 +25,9 @@ const (
 	ChainMakerProvider 	Provider = "chainmaker"
 	FabricProvider 		Provider = "fabric"
 	EthProvider        	Provider = "ETH"
+	EvmProvider        	Provider = "evm"
+	HttpProvider       	Provider = "http"
+	SimulatorProvider  	Provider = "simulator"
 )
 
 
@@ -38,6 +45,13 @@ func InitAdapters() *ChainAdapterDispatcher {
 			log.Errorf("adapter config [%s] create adapter error:[%v]", adapterCfgPath, err)
 		}
 	}
+	if attestorCfg := conf.Config.AttestorConfig; attestorCfg != nil && attestorCfg.KeyFile != "" {
+		if privateKey, err := utils.LoadPrivateKey(conf.FinalCfgPath(attestorCfg.KeyFile)); err == nil {
+			dispatcher.SetAttestor(privateKey)
+		} else {
+			log.Errorf("load attestor key [%s] error:[%v]", attestorCfg.KeyFile, err)
+		}
+	}
 	return dispatcher
 }
 
@@ -52,6 +66,18 @@ func createAdapter(adapterCfg *conf.AdapterConfig, adapterCfgPath string, log *z
 		log.Infof("create fabric adapter, chain id: [%s]", adapterCfg.ChainID)
 		return fabric.NewFabricAdapter(adapterCfg.ChainID, adapterCfgPath, adapterCfg.ProofContract, log)
 	}
+	if adapterProvider == EvmProvider || adapterProvider == EthProvider {
+		log.Infof("create evm adapter, chain id: [%s]", adapterCfg.ChainID)
+		return evm.NewEvmAdapter(adapterCfg.ChainID, adapterCfgPath, adapterCfg.ProofContract, log)
+	}
+	if adapterProvider == HttpProvider {
+		log.Infof("create http adapter, chain id: [%s]", adapterCfg.ChainID)
+		return rest.NewRestAdapter(adapterCfg.ChainID, adapterCfgPath, log)
+	}
+	if adapterProvider == SimulatorProvider {
+		log.Infof("create simulated adapter, chain id: [%s]", adapterCfg.ChainID)
+		return simulator.NewSimulatedAdapter(adapterCfg.ChainID, adapterCfgPath, log)
+	}
 	panic(fmt.Sprintf("can not find adapters for %v", adapterProvider))
 }
 
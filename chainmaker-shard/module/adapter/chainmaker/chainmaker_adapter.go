@@ -34,12 +34,20 @@ const (
 	ContractResultCode_OK         = 0
 )
 
+// defaultRetryPolicy default retry policy for loading transaction result, which can be overridden by adapter config
+var defaultRetryPolicy = &conf.RetryPolicy{
+	MaxAttempts:    RetryCount,
+	InitialBackoff: int64(RetryTimePeriod / time.Millisecond),
+	Multiplier:     1,
+}
+
 // ChainMakerAdapter adapter of chainmaker
 type ChainMakerAdapter struct {
 	chainID       string                   // chainID
 	proofContract *conf.ProofContract      // 证据保存的合约信息
 	dispatcher    *prover.ProverDispatcher // chainmaker 交易证明的证明模块分发入口
 	sdk           sdk.SDKInterface         // chainmaker sdk 实例
+	retryPolicy   *conf.RetryPolicy        // 查询交易结果的重试策略
 	logger        *zap.SugaredLogger       // 日志模块
 }
 
@@ -62,6 +70,7 @@ func NewChainMakerAdapter(chainID, configPath string, proofContract *conf.ProofC
 		proofContract: proofContract,
 		dispatcher:    prover.GetProverDispatcher(),
 		sdk:           chainMakerSdk,
+		retryPolicy:   defaultRetryPolicy.Merge(conf.Config.AdapterConfigs.GetRetryPolicy(chainID)),
 		logger:        logger,
 	}, nil
 }
@@ -71,21 +80,13 @@ func (c *ChainMakerAdapter) GetChainID() string {
 	return c.chainID
 }
 
-// Prove prove the proof
-func (c *ChainMakerAdapter) Prove(txProof *eventproto.Proof) bool {
-	if txProof == nil {
-		c.logger.Info("the proof is nil, not need to prove, will return true")
-		return true
-	}
-	proveResult, err := c.dispatcher.Prove(txProof)
-	if err != nil {
-		c.logger.Errorf("prove the proof error, ", err)
-		return false
+// Prove prove the proof by the proof policy, the missing proof is decided by the policy of this chain
+func (c *ChainMakerAdapter) Prove(crossID string, txProof *eventproto.Proof) bool {
+	decision := c.dispatcher.Decide(crossID, c.chainID, txProof)
+	if !decision.Result {
+		c.logger.Errorf("cross[%s] prove the proof of chain[%s] failed, %s", crossID, decision.ChainID, decision.Reason)
 	}
-	if !proveResult {
-		c.logger.Error("prove the proof failed, will return false")
-	}
-	return proveResult
+	return decision.Result
 }
 
 // SaveProof save the proof and verify in the chain
@@ -101,7 +102,7 @@ func (c *ChainMakerAdapter) SaveProof(crossID, proofKey string, txProof *eventpr
 	if pr, exist = c.dispatcher.GetProver(txProof.ChainId); !exist {
 		return nil, fmt.Errorf("can not find prover for chain[%s]", txProof.ChainId)
 	}
-	verifiedProof := eventproto.NewVerifiedProof(txProof, verifyResult, fmt.Sprintf("%v", pr.GetType()), "")
+	verifiedProof := prover.NewVerifiedProof(pr, txProof, verifyResult)
 	// 允许重新保存
 	return c.saveProof(crossID, proofKey, verifiedProof)
 }
@@ -136,6 +137,14 @@ func (c *ChainMakerAdapter) QueryTx(payload []byte) (*event.CommonTxResponse, er
 	return c.QueryByTxKey(txKey)
 }
 
+// getRetryPolicy return the retry policy of adapter
+func (c *ChainMakerAdapter) getRetryPolicy() *conf.RetryPolicy {
+	if c.retryPolicy == nil {
+		return defaultRetryPolicy
+	}
+	return c.retryPolicy
+}
+
 // saveProof
 func (c *ChainMakerAdapter) saveProof(crossID, proofKey string, verifiedProof *eventproto.VerifiedProof) (*eventproto.TxResponse, error) {
 	// 表示该交易未上链，可重新上链操作
@@ -219,8 +228,9 @@ func (c *ChainMakerAdapter) sendTxRequest(crossID, chainID string, txRequest *co
 func (c ChainMakerAdapter) loadTransactionInfo(crossID, chainID, txId string) (*common.TransactionInfo, error) {
 	c.logger.Infof("start get cross[%s]->chain[%s]'s tx-request[%s]'s result", crossID, chainID, txId)
 	var (
-		txInfo *common.TransactionInfo
-		err    error
+		txInfo      *common.TransactionInfo
+		err         error
+		retryPolicy = c.getRetryPolicy()
 	)
 	err = retry.Retry(func(uint) error {
 		c.logger.Infof("cross[%s]->chain[%s]'s tx[%s] get......", crossID, chainID, txId)
@@ -230,8 +240,8 @@ func (c ChainMakerAdapter) loadTransactionInfo(crossID, chainID, txId string) (*
 		}
 		return nil
 	},
-		strategy.Limit(RetryCount),
-		strategy.Wait(RetryTimePeriod), // 指定超时等待
+		strategy.Limit(uint(retryPolicy.MaxAttempts)),
+		strategy.Backoff(retryPolicy.Backoff), // 按重试策略等待
 	)
 	if err != nil {
 		c.logger.Errorf("cross[%s]->chain[%s]'s tx[%s] load failed, ", crossID, chainID, txId, err)
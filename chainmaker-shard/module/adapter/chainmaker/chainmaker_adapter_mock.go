@@ -26,9 +26,10 @@ func MockNilChainMakerAdapter() *ChainMakerAdapter {
 	log := logger.GetLogger(logger.ModuleAdapter)
 	pd := prover.GetProverDispatcher()
 	return &ChainMakerAdapter{
-		chainID:    "chainID",
-		dispatcher: pd,
-		sdk:        nil,
-		logger:     log,
+		chainID:     "chainID",
+		dispatcher:  pd,
+		sdk:         nil,
+		retryPolicy: defaultRetryPolicy,
+		logger:      log,
 	}
 }
@@ -0,0 +1,247 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package evm
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// PackCall encode the call of contract method by the solidity signature and the string values of arguments,
+// e.g. PackCall("transfer(address,uint256)", "0x5B38Da6a701c568545dCfcB03FcB875f56beddC4", "100"),
+// the supported types are address, bool, string, bytes, bytesN, intN and uintN
+func PackCall(signature string, values ...string) ([]byte, error) {
+	signature = strings.ReplaceAll(signature, " ", "")
+	start, end := strings.Index(signature, "("), strings.LastIndex(signature, ")")
+	if start <= 0 || end != len(signature)-1 {
+		return nil, fmt.Errorf("invalid method signature [%s], it should be like transfer(address,uint256)", signature)
+	}
+	var typeNames []string
+	if start+1 < end {
+		typeNames = strings.Split(signature[start+1:end], ",")
+	}
+	if len(typeNames) != len(values) {
+		return nil, fmt.Errorf("method [%s] needs %d arguments, but %d are given", signature, len(typeNames), len(values))
+	}
+	arguments := make(abi.Arguments, len(typeNames))
+	for i, typeName := range typeNames {
+		typ, err := abi.NewType(typeName, "", nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid type [%s] of method [%s], %v", typeName, signature, err)
+		}
+		arguments[i], typeNames[i] = abi.Argument{Type: typ}, typ.String()
+	}
+	data, err := packArguments(arguments, values)
+	if err != nil {
+		return nil, fmt.Errorf("pack arguments of method [%s] failed, %v", signature, err)
+	}
+	// 方法选择器使用规范的签名计算，如uint转换为uint256
+	canonical := signature[:start] + "(" + strings.Join(typeNames, ",") + ")"
+	return append(crypto.Keccak256([]byte(canonical))[:4], data...), nil
+}
+
+// PackTransactionCall encode the call of transaction contract method by the string values of arguments
+func PackTransactionCall(method string, values ...string) ([]byte, error) {
+	m, exist := transactionABI.Methods[method]
+	if !exist {
+		return nil, fmt.Errorf("method [%s] is not found in transaction contract", method)
+	}
+	if len(m.Inputs) != len(values) {
+		return nil, fmt.Errorf("method [%s] needs %d arguments, but %d are given", method, len(m.Inputs), len(values))
+	}
+	data, err := packArguments(m.Inputs, values)
+	if err != nil {
+		return nil, fmt.Errorf("pack arguments of method [%s] failed, %v", method, err)
+	}
+	return append(append([]byte{}, m.ID...), data...), nil
+}
+
+// packArguments convert the string values to the types of arguments and pack them
+func packArguments(arguments abi.Arguments, values []string) ([]byte, error) {
+	args := make([]interface{}, len(values))
+	for i, value := range values {
+		arg, err := convertArgument(arguments[i].Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("argument %d: %v", i, err)
+		}
+		args[i] = arg
+	}
+	return arguments.Pack(args...)
+}
+
+// convertArgument convert the string value to the go type which is required by abi packing
+func convertArgument(typ abi.Type, value string) (interface{}, error) {
+	value = strings.TrimSpace(value)
+	switch typ.T {
+	case abi.AddressTy:
+		if !common.IsHexAddress(value) {
+			return nil, fmt.Errorf("invalid address [%s]", value)
+		}
+		return common.HexToAddress(value), nil
+	case abi.BoolTy:
+		return strconv.ParseBool(value)
+	case abi.StringTy:
+		return value, nil
+	case abi.BytesTy:
+		return decodeHex(value)
+	case abi.FixedBytesTy:
+		b, err := decodeHex(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) > typ.Size {
+			return nil, fmt.Errorf("value [%s] is longer than bytes%d", value, typ.Size)
+		}
+		array := reflect.New(reflect.ArrayOf(typ.Size, reflect.TypeOf(byte(0)))).Elem()
+		reflect.Copy(array, reflect.ValueOf(b))
+		return array.Interface(), nil
+	case abi.IntTy, abi.UintTy:
+		number, ok := new(big.Int).SetString(value, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer [%s]", value)
+		}
+		return convertInteger(typ, number)
+	default:
+		return nil, fmt.Errorf("type [%s] is not supported", typ.String())
+	}
+}
+
+// convertInteger convert the big integer to the go type of intN or uintN
+func convertInteger(typ abi.Type, number *big.Int) (interface{}, error) {
+	if typ.T == abi.UintTy {
+		if number.Sign() < 0 || number.BitLen() > typ.Size {
+			return nil, fmt.Errorf("value %s overflows %s", number, typ.String())
+		}
+	} else {
+		limit := new(big.Int).Lsh(big.NewInt(1), uint(typ.Size-1))
+		if number.Cmp(new(big.Int).Neg(limit)) < 0 || number.Cmp(limit) >= 0 {
+			return nil, fmt.Errorf("value %s overflows %s", number, typ.String())
+		}
+	}
+	if typ.Size > 64 {
+		return number, nil
+	}
+	if typ.T == abi.UintTy {
+		value := number.Uint64()
+		switch typ.Size {
+		case 8:
+			return uint8(value), nil
+		case 16:
+			return uint16(value), nil
+		case 32:
+			return uint32(value), nil
+		case 64:
+			return value, nil
+		}
+	} else {
+		value := number.Int64()
+		switch typ.Size {
+		case 8:
+			return int8(value), nil
+		case 16:
+			return int16(value), nil
+		case 32:
+			return int32(value), nil
+		case 64:
+			return value, nil
+		}
+	}
+	return nil, fmt.Errorf("type [%s] is not supported", typ.String())
+}
+
+// formatArgument format the unpacked argument to string, which is the reverse of convertArgument
+func formatArgument(value interface{}) string {
+	switch v := value.(type) {
+	case []byte:
+		return hexutil.Encode(v)
+	case common.Address:
+		return v.Hex()
+	default:
+		rv := reflect.ValueOf(value)
+		if rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return hexutil.Encode(b)
+		}
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+// decodeTransactionCall decode the call data of transaction contract, return the method and the named arguments
+func decodeTransactionCall(data []byte) (string, []string, []string, error) {
+	if len(data) < 4 {
+		return "", nil, nil, errors.New("call data is too short")
+	}
+	method, err := transactionABI.MethodById(data[:4])
+	if err != nil {
+		return "", nil, nil, err
+	}
+	values, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return "", nil, nil, err
+	}
+	names, args := make([]string, len(values)), make([]string, len(values))
+	for i, value := range values {
+		names[i], args[i] = method.Inputs[i].Name, formatArgument(value)
+	}
+	return method.Name, names, args, nil
+}
+
+// parseCrossResults parse the CrossResult events which are emitted by the contract in receipt
+func parseCrossResults(receipt *types.Receipt, contract common.Address) ([]*CrossResult, error) {
+	event := transactionABI.Events[CrossResultEvent]
+	results := make([]*CrossResult, 0)
+	for _, log := range receipt.Logs {
+		if log.Address != contract || len(log.Topics) == 0 || log.Topics[0] != event.ID {
+			continue
+		}
+		values, err := transactionABI.Unpack(CrossResultEvent, log.Data)
+		if err != nil {
+			return nil, fmt.Errorf("unpack %s event failed, %v", CrossResultEvent, err)
+		}
+		if len(values) != 4 {
+			return nil, fmt.Errorf("%s event has %d fields, but 4 are expected", CrossResultEvent, len(values))
+		}
+		result := &CrossResult{}
+		result.CrossID, _ = values[0].(string)
+		result.Method, _ = values[1].(string)
+		result.Success, _ = values[2].(bool)
+		result.Result, _ = values[3].([]byte)
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// resultMessage return the readable message of the result of contract call, which may be the revert reason
+func resultMessage(result []byte) string {
+	if reason, err := abi.UnpackRevert(result); err == nil {
+		return reason
+	}
+	if utf8.Valid(result) && strings.IndexFunc(string(result), func(r rune) bool { return r < 0x20 && r != '\n' }) < 0 {
+		return string(result)
+	}
+	return hexutil.Encode(result)
+}
+
+// decodeHex decode hex string with or without 0x prefix
+func decodeHex(value string) ([]byte, error) {
+	if strings.HasPrefix(value, "0x") || strings.HasPrefix(value, "0X") {
+		value = value[2:]
+	}
+	return hex.DecodeString(value)
+}
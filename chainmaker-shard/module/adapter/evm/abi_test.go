@@ -0,0 +1,67 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package evm
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackCall(t *testing.T) {
+	data, err := PackCall("transfer(address, uint)", "0x5B38Da6a701c568545dCfcB03FcB875f56beddC4", "100")
+	require.Nil(t, err)
+	require.Equal(t, "0xa9059cbb", hexutil.Encode(data[:4]))
+	require.Len(t, data, 4+32*2)
+	require.Equal(t, byte(100), data[len(data)-1])
+
+	data, err = PackCall("reset()")
+	require.Nil(t, err)
+	require.Len(t, data, 4)
+
+	_, err = PackCall("transfer", "100")
+	require.NotNil(t, err)
+	_, err = PackCall("transfer(address,uint256)", "100")
+	require.NotNil(t, err)
+	_, err = PackCall("transfer(address,uint256)", "0x01", "100")
+	require.NotNil(t, err)
+	_, err = PackCall("set(uint8)", "256")
+	require.NotNil(t, err)
+	_, err = PackCall("set(int8)", "-129")
+	require.NotNil(t, err)
+	_, err = PackCall("set(bytes2)", "0x010203")
+	require.NotNil(t, err)
+
+	_, err = PackCall("set(int8,bool,bytes32,string,bytes)", "-128", "true", "0x01", "value", "0x0102")
+	require.Nil(t, err)
+}
+
+func TestDecodeTransactionCall(t *testing.T) {
+	data, err := PackTransactionCall(ExecuteMethod, "crossID", "0x5B38Da6a701c568545dCfcB03FcB875f56beddC4", "0x0102",
+		"0xAb8483F64d9C6d1EcF9b849Ae677dD3315835cb2", "0x", "1024")
+	require.Nil(t, err)
+	method, names, args, err := decodeTransactionCall(data)
+	require.Nil(t, err)
+	require.Equal(t, ExecuteMethod, method)
+	require.Equal(t, []string{"crossID", "executeContract", "executeData", "rollbackContract", "rollbackData", "deadline"}, names)
+	require.Equal(t, []string{"crossID", "0x5B38Da6a701c568545dCfcB03FcB875f56beddC4", "0x0102",
+		"0xAb8483F64d9C6d1EcF9b849Ae677dD3315835cb2", "0x", "1024"}, args)
+
+	_, err = PackTransactionCall("notExist", "crossID")
+	require.NotNil(t, err)
+	_, _, _, err = decodeTransactionCall([]byte{1, 2})
+	require.NotNil(t, err)
+}
+
+func TestResultMessage(t *testing.T) {
+	require.Equal(t, "ok", resultMessage([]byte("ok")))
+	require.Equal(t, "0x0001", resultMessage([]byte{0, 1}))
+	// Error(string) encoded revert reason
+	revert, err := PackCall("Error(string)", "duplicated crossID")
+	require.Nil(t, err)
+	require.Equal(t, "duplicated crossID", resultMessage(revert))
+}
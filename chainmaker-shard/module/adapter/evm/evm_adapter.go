@@ -0,0 +1,383 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package evm
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/event"
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+	"chainmaker.org/chainmaker-cross/prover"
+	"github.com/Rican7/retry"
+	"github.com/Rican7/retry/strategy"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+const (
+	WaitTimeOut     = 30 * time.Second // 单次RPC调用的超时时间
+	RetryCount      = 150              // 150 * 0.2 = 30s
+	RetryTimePeriod = 200 * time.Millisecond
+	MaxSentTxs      = 10000 // 记录的已发送交易的最大数量，超过后清空
+)
+
+// defaultRetryPolicy default retry policy for loading transaction receipt, which can be overridden by adapter config
+var defaultRetryPolicy = &conf.RetryPolicy{
+	MaxAttempts:    RetryCount,
+	InitialBackoff: int64(RetryTimePeriod / time.Millisecond),
+	Multiplier:     1,
+}
+
+// Backend the json-rpc methods which are used by evm adapter,
+// both ethclient.Client and backends.SimulatedBackend implement it
+type Backend interface {
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// chainIDReader the backend which can query the chain id, such as ethclient.Client
+type chainIDReader interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+}
+
+// EvmAdapter adapter of evm compatible chain, which calls the transaction contract through json-rpc
+type EvmAdapter struct {
+	sync.Mutex                             // 发送交易的锁，保证nonce有序
+	chainID       string                   // chainID
+	proofContract *conf.ProofContract      // 证据保存的合约信息，合约名为合约地址
+	dispatcher    *prover.ProverDispatcher // evm 交易证明的证明模块分发入口
+	backend       Backend                  // json-rpc 客户端
+	privateKey    *ecdsa.PrivateKey        // 发送交易的账户私钥
+	from          common.Address           // 发送交易的账户地址
+	signer        types.Signer             // 交易签名器
+	gasLimit      uint64                   // 交易的gas上限，为0时由节点估算
+	sentTxs       map[common.Hash]string   // payload的hash => 交易hash，用于QueryTx
+	retryPolicy   *conf.RetryPolicy        // 查询交易回执的重试策略
+	logger        *zap.SugaredLogger       // 日志模块
+}
+
+// NewEvmAdapter create new instance of evm adapter by the config file
+func NewEvmAdapter(chainID, configPath string, proofContract *conf.ProofContract, logger *zap.SugaredLogger) (*EvmAdapter, error) {
+	evmViper := viper.New()
+	evmViper.SetConfigFile(configPath)
+	if err := evmViper.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("read evm adapter config failed, %v", err)
+	}
+	config := &EvmConfig{}
+	if err := evmViper.Unmarshal(config); err != nil {
+		return nil, fmt.Errorf("unmarshal evm adapter config failed, %v", err)
+	}
+	privateKey, err := crypto.LoadECDSA(conf.FinalCfgPath(config.PrivateKeyFile))
+	if err != nil {
+		return nil, fmt.Errorf("load private key [%s] failed, %v", config.PrivateKeyFile, err)
+	}
+	client, err := ethclient.Dial(config.RpcURL)
+	if err != nil {
+		return nil, fmt.Errorf("dial evm node [%s] failed, %v", config.RpcURL, err)
+	}
+	return NewEvmAdapterWithBackend(chainID, config, client, privateKey, proofContract, logger)
+}
+
+// NewEvmAdapterWithBackend create new instance of evm adapter with the backend, such as simulated backend for test
+func NewEvmAdapterWithBackend(chainID string, config *EvmConfig, backend Backend, privateKey *ecdsa.PrivateKey,
+	proofContract *conf.ProofContract, logger *zap.SugaredLogger) (*EvmAdapter, error) {
+	evmChainID := big.NewInt(config.ChainID)
+	if config.ChainID == 0 {
+		reader, ok := backend.(chainIDReader)
+		if !ok {
+			return nil, errors.New("chain_id of evm adapter is not configured")
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), WaitTimeOut)
+		defer cancel()
+		var err error
+		if evmChainID, err = reader.ChainID(ctx); err != nil {
+			return nil, fmt.Errorf("query chain id of evm failed, %v", err)
+		}
+	}
+	return &EvmAdapter{
+		chainID:       chainID,
+		proofContract: proofContract,
+		dispatcher:    prover.GetProverDispatcher(),
+		backend:       backend,
+		privateKey:    privateKey,
+		from:          crypto.PubkeyToAddress(privateKey.PublicKey),
+		signer:        types.NewEIP155Signer(evmChainID),
+		gasLimit:      config.GasLimit,
+		sentTxs:       make(map[common.Hash]string),
+		retryPolicy:   defaultRetryPolicy.Merge(conf.Config.AdapterConfigs.GetRetryPolicy(chainID)),
+		logger:        logger,
+	}, nil
+}
+
+// GetChainID return chain id
+func (e *EvmAdapter) GetChainID() string {
+	return e.chainID
+}
+
+// Prove prove the proof by the proof policy, the missing proof is decided by the policy of this chain
+func (e *EvmAdapter) Prove(crossID string, txProof *eventproto.Proof) bool {
+	decision := e.dispatcher.Decide(crossID, e.chainID, txProof)
+	if !decision.Result {
+		e.logger.Errorf("cross[%s] prove the proof of chain[%s] failed, %s", crossID, decision.ChainID, decision.Reason)
+	}
+	return decision.Result
+}
+
+// SaveProof save the proof and verify in the chain
+func (e *EvmAdapter) SaveProof(crossID, proofKey string, txProof *eventproto.Proof, verifyResult bool) (*eventproto.TxResponse, error) {
+	e.logger.Infof("start save proof for cross[%s]->chain[%s]", crossID, txProof.GetChainID())
+	if e.proofContract == nil {
+		return nil, fmt.Errorf("proofContract is not configured for chain[%s]", e.chainID)
+	}
+	if !common.IsHexAddress(e.proofContract.Name) {
+		return nil, fmt.Errorf("proofContract [%s] of chain[%s] is not an address", e.proofContract.Name, e.chainID)
+	}
+	pr, exist := e.dispatcher.GetProver(txProof.GetChainID())
+	if !exist {
+		return nil, fmt.Errorf("can not find prover for chain[%s]", txProof.GetChainID())
+	}
+	jsonText, err := json.Marshal(prover.NewVerifiedProof(pr, txProof, verifyResult))
+	if err != nil {
+		e.logger.Errorf("marshal verified proof error crossID = [%s], %v", crossID, err)
+		return nil, err
+	}
+	// 允许重新保存，合约中已存在的证明不会被覆盖
+	data, err := transactionABI.Pack(e.proofContract.Method, crossID, proofKey, string(jsonText))
+	if err != nil {
+		return nil, fmt.Errorf("pack call of proof contract failed, %v", err)
+	}
+	tx, receipt, err := e.call(crossID, common.HexToAddress(e.proofContract.Name), data)
+	if err != nil {
+		return nil, err
+	}
+	return e.newTxResponse(tx, receipt), nil
+}
+
+// Invoke transfer transaction-event which calls the transaction contract
+func (e *EvmAdapter) Invoke(txEvent *eventproto.TransactionEvent) (*eventproto.TxResponse, error) {
+	payload := txEvent.GetPayload()
+	request := &TxRequest{}
+	if err := json.Unmarshal(payload, request); err != nil {
+		return nil, fmt.Errorf("unmarshal transaction payload failed, %s", err.Error())
+	}
+	if !common.IsHexAddress(request.To) {
+		return nil, fmt.Errorf("invalid contract address [%s] in transaction payload", request.To)
+	}
+	data, err := decodeHex(request.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid call data in transaction payload, %v", err)
+	}
+	e.logger.Infof("cross[%s]->chain[%s]'s tx-request unmarshalled", txEvent.GetCrossID(), txEvent.GetChainID())
+	tx, receipt, err := e.call(txEvent.GetCrossID(), common.HexToAddress(request.To), data)
+	if tx != nil {
+		e.recordSentTx(payload, tx.Hash().Hex())
+	}
+	if err != nil {
+		return nil, err
+	}
+	e.logger.Infof("cross[%s]->chain[%s]'s tx[%s] invoke success", txEvent.GetCrossID(), e.chainID, tx.Hash().Hex())
+	return e.newTxResponse(tx, receipt), nil
+}
+
+// QueryByTxKey query transaction response by txkey, which is the hash of transaction
+func (e *EvmAdapter) QueryByTxKey(txKey string) (*event.CommonTxResponse, error) {
+	if len(txKey) == 0 {
+		return nil, fmt.Errorf("TxKey is <nil>")
+	}
+	hash := common.HexToHash(txKey)
+	ctx, cancel := context.WithTimeout(context.Background(), WaitTimeOut)
+	defer cancel()
+	tx, _, err := e.backend.TransactionByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := e.backend.TransactionReceipt(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	txResponse := e.newTxResponse(tx, receipt)
+	if err = checkReceipt(tx, receipt); err != nil {
+		return event.NewCommonTxResponse(txResponse, event.FailureResp, err.Error()), nil
+	}
+	return event.NewCommonTxResponse(txResponse, event.SuccessResp, ""), nil
+}
+
+// QueryTx query transaction by the payload which has been invoked,
+// the transaction is signed by adapter so that only the payloads sent by this adapter can be found
+func (e *EvmAdapter) QueryTx(payload []byte) (*event.CommonTxResponse, error) {
+	e.Lock()
+	txKey, exist := e.sentTxs[crypto.Keccak256Hash(payload)]
+	e.Unlock()
+	if !exist {
+		return nil, errors.New("transaction of the payload is not sent by this adapter")
+	}
+	e.logger.Infof("find txKey = [%s] of payload", txKey)
+	return e.QueryByTxKey(txKey)
+}
+
+// getRetryPolicy return the retry policy of adapter
+func (e *EvmAdapter) getRetryPolicy() *conf.RetryPolicy {
+	if e.retryPolicy == nil {
+		return defaultRetryPolicy
+	}
+	return e.retryPolicy
+}
+
+// call send the transaction to the contract and wait for the receipt, the transaction is returned once it is sent
+func (e *EvmAdapter) call(crossID string, to common.Address, data []byte) (*types.Transaction, *types.Receipt, error) {
+	tx, err := e.sendTransaction(to, data)
+	if err != nil {
+		e.logger.Errorf("cross[%s]->chain[%s]'s tx-request send failed, %v", crossID, e.chainID, err)
+		return nil, nil, err
+	}
+	receipt, err := e.loadReceipt(crossID, tx.Hash())
+	if err != nil {
+		return tx, nil, err
+	}
+	if err = checkReceipt(tx, receipt); err != nil {
+		e.logger.Errorf("cross[%s]->chain[%s]'s tx[%s] invoke failed, %v", crossID, e.chainID, tx.Hash().Hex(), err)
+		return tx, receipt, err
+	}
+	return tx, receipt, nil
+}
+
+// sendTransaction sign and send the transaction by the account of adapter
+func (e *EvmAdapter) sendTransaction(to common.Address, data []byte) (*types.Transaction, error) {
+	// 同一账户的交易需要串行发送，保证nonce有序
+	e.Lock()
+	defer e.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), WaitTimeOut)
+	defer cancel()
+	nonce, err := e.backend.PendingNonceAt(ctx, e.from)
+	if err != nil {
+		return nil, fmt.Errorf("query nonce of [%s] failed, %v", e.from.Hex(), err)
+	}
+	gasPrice, err := e.backend.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("suggest gas price failed, %v", err)
+	}
+	gasLimit := e.gasLimit
+	if gasLimit == 0 {
+		// 估算失败通常是合约执行被拒绝，如重复的crossID
+		if gasLimit, err = e.backend.EstimateGas(ctx, ethereum.CallMsg{From: e.from, To: &to, Data: data}); err != nil {
+			return nil, fmt.Errorf("estimate gas failed, %v", err)
+		}
+	}
+	tx, err := types.SignTx(types.NewTransaction(nonce, to, big.NewInt(0), gasLimit, gasPrice, data), e.signer, e.privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("sign transaction failed, %v", err)
+	}
+	if err = e.backend.SendTransaction(ctx, tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// loadReceipt wait for the receipt of transaction by the retry policy
+func (e *EvmAdapter) loadReceipt(crossID string, hash common.Hash) (*types.Receipt, error) {
+	e.logger.Infof("start get cross[%s]->chain[%s]'s tx-request[%s]'s receipt", crossID, e.chainID, hash.Hex())
+	var (
+		receipt     *types.Receipt
+		retryPolicy = e.getRetryPolicy()
+	)
+	err := retry.Retry(func(uint) error {
+		ctx, cancel := context.WithTimeout(context.Background(), WaitTimeOut)
+		defer cancel()
+		var err error
+		if receipt, err = e.backend.TransactionReceipt(ctx, hash); err != nil {
+			return err
+		}
+		if receipt == nil {
+			return ethereum.NotFound
+		}
+		return nil
+	},
+		strategy.Limit(uint(retryPolicy.MaxAttempts)),
+		strategy.Backoff(retryPolicy.Backoff), // 按重试策略等待
+	)
+	if err != nil {
+		e.logger.Errorf("cross[%s]->chain[%s]'s tx[%s] load failed, %v", crossID, e.chainID, hash.Hex(), err)
+		return nil, fmt.Errorf("cross[%s]->chain[%s]'s tx[%s] load failed, %s", crossID, e.chainID, hash.Hex(), err.Error())
+	}
+	return receipt, nil
+}
+
+// recordSentTx record the transaction of payload for QueryTx
+func (e *EvmAdapter) recordSentTx(payload []byte, txKey string) {
+	e.Lock()
+	defer e.Unlock()
+	if len(e.sentTxs) >= MaxSentTxs {
+		e.sentTxs = make(map[common.Hash]string)
+	}
+	e.sentTxs[crypto.Keccak256Hash(payload)] = txKey
+}
+
+// newTxResponse convert the transaction and receipt to TxResponse
+func (e *EvmAdapter) newTxResponse(tx *types.Transaction, receipt *types.Receipt) *eventproto.TxResponse {
+	var (
+		blockHeight int64
+		index       int32
+	)
+	if receipt.BlockNumber != nil {
+		blockHeight = receipt.BlockNumber.Int64()
+	}
+	index = int32(receipt.TransactionIndex)
+	return event.NewTxResponse(e.chainID, tx.Hash().Hex(), blockHeight, index, convertToContract(tx), nil)
+}
+
+// checkReceipt check that the transaction is executed and all the calls of business contract succeed
+func checkReceipt(tx *types.Transaction, receipt *types.Receipt) error {
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("transaction[%s] is reverted", tx.Hash().Hex())
+	}
+	if tx.To() == nil {
+		return nil
+	}
+	results, err := parseCrossResults(receipt, *tx.To())
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		if !result.Success {
+			return fmt.Errorf("%s of cross[%s] failed, %s", result.Method, result.CrossID, resultMessage(result.Result))
+		}
+	}
+	return nil
+}
+
+// convertToContract convert the call of transaction contract to ContractInfo
+func convertToContract(tx *types.Transaction) *eventproto.ContractInfo {
+	if tx.To() == nil {
+		return event.NewContract("", "", "", nil)
+	}
+	method, names, args, err := decodeTransactionCall(tx.Data())
+	contract := event.NewContract(tx.To().Hex(), "", method, nil)
+	if err != nil {
+		// 非事务合约的调用，不解析参数
+		return contract
+	}
+	for i := range names {
+		contract.AddParameter(event.NewContractParameter(names[i], args[i]))
+	}
+	return contract
+}
@@ -0,0 +1,239 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package evm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/event"
+	"chainmaker.org/chainmaker-cross/logger"
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+	"chainmaker.org/chainmaker-cross/prover"
+	"chainmaker.org/chainmaker-cross/prover/impl"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testChainID    = "evm1"
+	testEvmChainID = 1337
+)
+
+var (
+	contractAddress = common.HexToAddress("0x5B38Da6a701c568545dCfcB03FcB875f56beddC4")
+	businessAddress = common.HexToAddress("0xAb8483F64d9C6d1EcF9b849Ae677dD3315835cb2")
+)
+
+// mockBackend mock json-rpc backend which executes the transaction contract in memory
+type mockBackend struct {
+	sync.Mutex
+	signer   types.Signer
+	nonces   map[common.Address]uint64
+	txs      map[common.Hash]*types.Transaction
+	receipts map[common.Hash]*types.Receipt
+	failed   bool // 业务合约执行是否失败
+	reverted bool // 交易是否被回滚
+}
+
+func newMockBackend() *mockBackend {
+	return &mockBackend{
+		signer:   types.NewEIP155Signer(big.NewInt(testEvmChainID)),
+		nonces:   make(map[common.Address]uint64),
+		txs:      make(map[common.Hash]*types.Transaction),
+		receipts: make(map[common.Hash]*types.Receipt),
+	}
+}
+
+func (m *mockBackend) ChainID(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(testEvmChainID), nil
+}
+
+func (m *mockBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.nonces[account], nil
+}
+
+func (m *mockBackend) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(1), nil
+}
+
+func (m *mockBackend) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	return 100000, nil
+}
+
+func (m *mockBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	m.Lock()
+	defer m.Unlock()
+	from, err := types.Sender(m.signer, tx)
+	if err != nil {
+		return err
+	}
+	if tx.Nonce() != m.nonces[from] {
+		return fmt.Errorf("invalid nonce %d, expected %d", tx.Nonce(), m.nonces[from])
+	}
+	method, _, args, err := decodeTransactionCall(tx.Data())
+	if err != nil {
+		return err
+	}
+	success, result := !m.failed, []byte("ok")
+	if m.failed {
+		result = []byte("insufficient balance")
+	}
+	data, err := transactionABI.Events[CrossResultEvent].Inputs.Pack(args[0], method, success, result)
+	if err != nil {
+		return err
+	}
+	status := types.ReceiptStatusSuccessful
+	if m.reverted {
+		status = types.ReceiptStatusFailed
+	}
+	m.nonces[from]++
+	m.txs[tx.Hash()] = tx
+	m.receipts[tx.Hash()] = &types.Receipt{
+		Status:      status,
+		TxHash:      tx.Hash(),
+		BlockNumber: big.NewInt(int64(len(m.txs))),
+		Logs: []*types.Log{{
+			Address: *tx.To(),
+			Topics:  []common.Hash{transactionABI.Events[CrossResultEvent].ID},
+			Data:    data,
+		}},
+	}
+	return nil
+}
+
+func (m *mockBackend) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	m.Lock()
+	defer m.Unlock()
+	tx, exist := m.txs[hash]
+	if !exist {
+		return nil, false, ethereum.NotFound
+	}
+	return tx, false, nil
+}
+
+func (m *mockBackend) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	m.Lock()
+	defer m.Unlock()
+	receipt, exist := m.receipts[txHash]
+	if !exist {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+func newTestAdapter(t *testing.T, backend *mockBackend) *EvmAdapter {
+	privateKey, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	proofContract := &conf.ProofContract{Name: contractAddress.Hex(), Method: SaveProofMethod}
+	evmAdapter, err := NewEvmAdapterWithBackend(testChainID, &EvmConfig{}, backend, privateKey, proofContract,
+		logger.GetLogger(logger.ModuleAdapter))
+	require.Nil(t, err)
+	evmAdapter.retryPolicy = &conf.RetryPolicy{MaxAttempts: 3, InitialBackoff: 1, Multiplier: 1}
+	return evmAdapter
+}
+
+func newExecutePayload(t *testing.T, crossID string) []byte {
+	executeData, err := PackCall("transfer(address,uint256)", businessAddress.Hex(), "100")
+	require.Nil(t, err)
+	data, err := PackTransactionCall(ExecuteMethod, crossID, businessAddress.Hex(), hexutil.Encode(executeData),
+		businessAddress.Hex(), hexutil.Encode(executeData), "0")
+	require.Nil(t, err)
+	payload, err := json.Marshal(NewTxRequest(contractAddress.Hex(), hexutil.Encode(data)))
+	require.Nil(t, err)
+	return payload
+}
+
+func TestNewEvmAdapterWithBackend(t *testing.T) {
+	evmAdapter := newTestAdapter(t, newMockBackend())
+	require.Equal(t, testChainID, evmAdapter.GetChainID())
+}
+
+func TestEvmAdapter_Invoke(t *testing.T) {
+	backend := newMockBackend()
+	evmAdapter := newTestAdapter(t, backend)
+	payload := newExecutePayload(t, "crossID1")
+
+	resp, err := evmAdapter.Invoke(event.NewExecuteTransactionEvent("crossID1", testChainID, payload, "", nil))
+	require.Nil(t, err)
+	require.Equal(t, testChainID, resp.GetChainId())
+	require.Equal(t, contractAddress.Hex(), resp.GetContract().GetName())
+	require.Equal(t, ExecuteMethod, resp.GetContract().GetMethod())
+	require.Equal(t, "crossID1", resp.GetContract().GetParameters()[0].GetValue())
+
+	// the second transaction uses the next nonce
+	_, err = evmAdapter.Invoke(event.NewCommitTransactionEvent("crossID1", testChainID, payload))
+	require.Nil(t, err)
+
+	commonResp, err := evmAdapter.QueryByTxKey(resp.GetTxKey())
+	require.Nil(t, err)
+	require.Equal(t, int32(event.SuccessResp), commonResp.Code)
+	require.Equal(t, resp.GetTxKey(), commonResp.TxResponse.GetTxKey())
+}
+
+func TestEvmAdapter_InvokeFailed(t *testing.T) {
+	backend := newMockBackend()
+	evmAdapter := newTestAdapter(t, backend)
+	payload := newExecutePayload(t, "crossID2")
+
+	backend.failed = true
+	resp, err := evmAdapter.Invoke(event.NewExecuteTransactionEvent("crossID2", testChainID, payload, "", nil))
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "insufficient balance")
+	require.Nil(t, resp)
+
+	// the failed transaction can also be queried
+	commonResp, err := evmAdapter.QueryTx(payload)
+	require.Nil(t, err)
+	require.Equal(t, int32(event.FailureResp), commonResp.Code)
+
+	backend.failed, backend.reverted = false, true
+	_, err = evmAdapter.Invoke(event.NewExecuteTransactionEvent("crossID3", testChainID, newExecutePayload(t, "crossID3"), "", nil))
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "reverted")
+
+	_, err = evmAdapter.Invoke(event.NewExecuteTransactionEvent("crossID4", testChainID, []byte("{}"), "", nil))
+	require.NotNil(t, err)
+}
+
+func TestEvmAdapter_QueryTx(t *testing.T) {
+	evmAdapter := newTestAdapter(t, newMockBackend())
+	payload := newExecutePayload(t, "crossID5")
+
+	_, err := evmAdapter.QueryTx(payload)
+	require.NotNil(t, err)
+
+	resp, err := evmAdapter.Invoke(event.NewExecuteTransactionEvent("crossID5", testChainID, payload, "", nil))
+	require.Nil(t, err)
+	commonResp, err := evmAdapter.QueryTx(payload)
+	require.Nil(t, err)
+	require.Equal(t, int32(event.SuccessResp), commonResp.Code)
+	require.Equal(t, resp.GetTxKey(), commonResp.TxResponse.GetTxKey())
+}
+
+func TestEvmAdapter_SaveProof(t *testing.T) {
+	prover.GetProverDispatcher().Register(impl.NewTrustProver([]string{"chain1"}))
+	evmAdapter := newTestAdapter(t, newMockBackend())
+
+	proof := &eventproto.Proof{ChainId: "chain1", TxKey: "txKey"}
+	resp, err := evmAdapter.SaveProof("crossID6", "proofKey", proof, true)
+	require.Nil(t, err)
+	require.Equal(t, SaveProofMethod, resp.GetContract().GetMethod())
+
+	_, err = evmAdapter.SaveProof("crossID6", "proofKey", &eventproto.Proof{ChainId: "chainNotExist"}, true)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,31 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package evm
+
+// TxRequest the payload of evm transaction, which is a call of the transaction contract, the transaction is
+// signed and sent by the account of adapter, so that the nonce is managed by the adapter rather than the sdk
+type TxRequest struct {
+	// address of the contract to be called, hex encoded
+	To string `json:"to"`
+	// abi encoded call data, hex encoded with 0x prefix
+	Data string `json:"data"`
+}
+
+// NewTxRequest create TxRequest
+func NewTxRequest(to, data string) *TxRequest {
+	return &TxRequest{
+		To:   to,
+		Data: data,
+	}
+}
+
+// EvmConfig the config of evm adapter
+type EvmConfig struct {
+	RpcURL         string `mapstructure:"rpc_url"`          // 节点的JSON-RPC地址
+	ChainID        int64  `mapstructure:"chain_id"`         // EIP-155签名使用的链ID，为0时从节点查询
+	PrivateKeyFile string `mapstructure:"private_key_file"` // 发送交易的账户私钥文件，内容为hex编码的secp256k1私钥
+	GasLimit       uint64 `mapstructure:"gas_limit"`        // 交易的gas上限，为0时由节点估算
+}
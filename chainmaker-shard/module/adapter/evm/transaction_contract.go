@@ -0,0 +1,76 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package evm
+
+import (
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+const (
+	ExecuteMethod   = "execute"
+	CommitMethod    = "commit"
+	RollbackMethod  = "rollback"
+	ReadStateMethod = "readState"
+	SaveProofMethod = "saveProof"
+	ReadProofMethod = "readProof"
+
+	CrossResultEvent = "CrossResult"
+)
+
+// TransactionContractABI the abi of contract/evm/transaction_contract/TransactionContract.sol
+const TransactionContractABI = `[
+	{"type":"function","name":"execute","stateMutability":"nonpayable","outputs":[],"inputs":[
+		{"name":"crossID","type":"string"},
+		{"name":"executeContract","type":"address"},
+		{"name":"executeData","type":"bytes"},
+		{"name":"rollbackContract","type":"address"},
+		{"name":"rollbackData","type":"bytes"},
+		{"name":"deadline","type":"uint256"}]},
+	{"type":"function","name":"commit","stateMutability":"nonpayable","outputs":[],"inputs":[
+		{"name":"crossID","type":"string"}]},
+	{"type":"function","name":"rollback","stateMutability":"nonpayable","outputs":[],"inputs":[
+		{"name":"crossID","type":"string"}]},
+	{"type":"function","name":"readState","stateMutability":"view","outputs":[{"name":"","type":"string"}],"inputs":[
+		{"name":"crossID","type":"string"}]},
+	{"type":"function","name":"saveProof","stateMutability":"nonpayable","outputs":[],"inputs":[
+		{"name":"crossID","type":"string"},
+		{"name":"proofKey","type":"string"},
+		{"name":"txProof","type":"string"}]},
+	{"type":"function","name":"readProof","stateMutability":"view","outputs":[{"name":"","type":"string"}],"inputs":[
+		{"name":"crossID","type":"string"},
+		{"name":"proofKey","type":"string"}]},
+	{"type":"event","name":"CrossResult","anonymous":false,"inputs":[
+		{"name":"crossID","type":"string","indexed":false},
+		{"name":"method","type":"string","indexed":false},
+		{"name":"success","type":"bool","indexed":false},
+		{"name":"result","type":"bytes","indexed":false}]}
+]`
+
+// transactionABI the parsed abi of transaction contract
+var transactionABI abi.ABI
+
+func init() {
+	parsed, err := abi.JSON(strings.NewReader(TransactionContractABI))
+	if err != nil {
+		panic(err)
+	}
+	transactionABI = parsed
+}
+
+// GetTransactionABI return the abi of transaction contract
+func GetTransactionABI() abi.ABI {
+	return transactionABI
+}
+
+// CrossResult the CrossResult event of transaction contract
+type CrossResult struct {
+	CrossID string // 跨链ID
+	Method  string // 事务合约方法
+	Success bool   // 是否成功
+	Result  []byte // 业务合约的返回值或事务状态
+}
@@ -41,12 +41,20 @@ const (
 	//ContractResultCode_OK         = 0
 )
 
+// defaultRetryPolicy default retry policy for querying transaction, which can be overridden by adapter config
+var defaultRetryPolicy = &conf.RetryPolicy{
+	MaxAttempts:    RetryCount,
+	InitialBackoff: int64(RetryTimePeriod / time.Millisecond),
+	Multiplier:     1,
+}
+
 // FabricAdapter adapter of fabric
 type FabricAdapter struct {
 	chainID    		string                   	// chainID
 	proofContract 	*conf.ProofContract   		// 证据保存的合约信息
 	dispatcher 		*prover.ProverDispatcher 	// fabric 交易证明的证明模块分发入口
 	sdk        		*fabsdk.FabricSDK        	// fabric sdk 实例
+	retryPolicy		*conf.RetryPolicy			// 查询交易的重试策略
 	logger     		*zap.SugaredLogger       	// 日志模块
 }
 
@@ -61,6 +69,7 @@ func NewFabricAdapter(chainID, configPath string, proofContract *conf.ProofContr
 		proofContract: proofContract,
 		dispatcher: prover.GetProverDispatcher(),
 		sdk:        fabricSDK,
+		retryPolicy: defaultRetryPolicy.Merge(conf.Config.AdapterConfigs.GetRetryPolicy(chainID)),
 		logger:     logger,
 	}, nil
 }
@@ -75,7 +84,7 @@ func (f *FabricAdapter) SaveProof(crossID, proofKey string, txProof *eventproto.
 	if pr, exist = f.dispatcher.GetProver(f.chainID); !exist {
 		return nil, fmt.Errorf("can not find prover for chain[%s]", f.chainID)
 	}
-	verifiedProof := eventproto.NewVerifiedProof(txProof, verifyResult, fmt.Sprintf("%v", pr.GetType()), "")
+	verifiedProof := prover.NewVerifiedProof(pr, txProof, verifyResult)
 	// 允许重新保存
 	return f.saveProof(crossID, proofKey, verifiedProof)
 }
@@ -142,21 +151,13 @@ func (f *FabricAdapter) saveProof(crossID, proofKey string, verifiedProof *event
 	return txResp, nil
 }
 
-// Prove prove the proof
-func (f *FabricAdapter) Prove(txProof *eventproto.Proof) bool {
-	if txProof == nil {
-		f.logger.Info("the proof is nil, not need to prove, will return true")
-		return true
-	}
-	proveResult, err := f.dispatcher.Prove(txProof)
-	if err != nil {
-		f.logger.Errorf("prove the proof error, ", err)
-		return false
-	}
-	if !proveResult {
-		f.logger.Error("prove the proof failed, will return false")
+// Prove prove the proof by the proof policy, the missing proof is decided by the policy of this chain
+func (f *FabricAdapter) Prove(crossID string, txProof *eventproto.Proof) bool {
+	decision := f.dispatcher.Decide(crossID, f.chainID, txProof)
+	if !decision.Result {
+		f.logger.Errorf("cross[%s] prove the proof of chain[%s] failed, %s", crossID, decision.ChainID, decision.Reason)
 	}
-	return proveResult
+	return decision.Result
 }
 
 // GetChainID return chain id, in fabric equals channel name
@@ -318,7 +319,7 @@ func (f *FabricAdapter) invoke(txEvent *eventproto.TransactionEvent) (*eventprot
 	if err != nil {
 		return nil, fmt.Errorf("get adapter fabric user failed, ChainID: %s, UserKey: %s, %s", provider.ChainID, FabricPeer, err.Error())
 	}
-	blockHigh, err := RetryQueryTx(ledgerClient, txId, peersURLs)
+	blockHigh, err := RetryQueryTx(ledgerClient, txId, peersURLs, f.retryPolicy)
 	if err != nil {
 		f.logger.Errorf("cross[%s]->chain[%s]'s tx[%s] query failed, contract result code = ", txEvent.CrossId, txEvent.ChainId, resp.TransactionID,
 			resp.ChaincodeStatus)
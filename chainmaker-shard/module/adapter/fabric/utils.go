@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"time"
 
+	"chainmaker.org/chainmaker-cross/conf"
 	"chainmaker.org/chainmaker-cross/utils"
 	"github.com/Rican7/retry"
 	"github.com/Rican7/retry/strategy"
@@ -214,7 +215,10 @@ func getTargetFromConfig(ctx context.Client, channelID string) ([]fab.ProposalPr
 	return targets, nil
 }
 
-func RetryQueryTx(lc *ledger.Client, txId fab.TransactionID, peersUrls []string) (uint64, error) {
+func RetryQueryTx(lc *ledger.Client, txId fab.TransactionID, peersUrls []string, retryPolicy *conf.RetryPolicy) (uint64, error) {
+	if retryPolicy == nil {
+		retryPolicy = defaultRetryPolicy
+	}
 	// send request and handle response
 	reqPeers := ledger.WithTargetEndpoints(peersUrls...)
 	err := retry.Retry(func(uint) error {
@@ -229,8 +233,8 @@ func RetryQueryTx(lc *ledger.Client, txId fab.TransactionID, peersUrls []string)
 			return fmt.Errorf("try again")
 		}
 	},
-		strategy.Limit(RetryCount),
-		strategy.Wait(RetryTimePeriod), // 指定超时等待
+		strategy.Limit(uint(retryPolicy.MaxAttempts)),
+		strategy.Backoff(retryPolicy.Backoff), // 按重试策略等待
 	)
 	if err != nil {
 		return 0, err
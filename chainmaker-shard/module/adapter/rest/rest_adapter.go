@@ -0,0 +1,284 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package rest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/event"
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+	"chainmaker.org/chainmaker-cross/prover"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+const (
+	LocalProofContract = "local"     // 本地保存证明时TxResponse中的合约名
+	SaveProofMethod    = "SaveProof" // 本地保存证明时TxResponse中的方法名
+	ProofFileSuffix    = ".json"
+)
+
+// ErrTxNotFound the tx_key is not found by the status endpoint
+var ErrTxNotFound = errors.New("transaction is not found")
+
+// RestAdapter adapter of the traditional database or service, which maps the transaction events to the
+// try/confirm/cancel endpoints of TCC, and the proofs are saved in local files because the service has no ledger
+type RestAdapter struct {
+	sync.Mutex                          // 保存证明的锁
+	chainID    string                   // chainID
+	config     *RestConfig              // 业务系统的接口配置
+	proofDir   string                   // 证明保存目录
+	dispatcher *prover.ProverDispatcher // 证明模块分发入口
+	client     *http.Client             // http client
+	logger     *zap.SugaredLogger       // 日志模块
+}
+
+// NewRestAdapter create new instance of http adapter by the config file
+func NewRestAdapter(chainID, configPath string, logger *zap.SugaredLogger) (*RestAdapter, error) {
+	restViper := viper.New()
+	restViper.SetConfigFile(configPath)
+	if err := restViper.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("read http adapter config failed, %v", err)
+	}
+	config := &RestConfig{}
+	if err := restViper.Unmarshal(config); err != nil {
+		return nil, fmt.Errorf("unmarshal http adapter config failed, %v", err)
+	}
+	return NewRestAdapterWithConfig(chainID, config, logger)
+}
+
+// NewRestAdapterWithConfig create new instance of http adapter
+func NewRestAdapterWithConfig(chainID string, config *RestConfig, logger *zap.SugaredLogger) (*RestAdapter, error) {
+	if config.BaseURL == "" {
+		return nil, errors.New("base_url of http adapter is empty")
+	}
+	if _, err := url.Parse(config.BaseURL); err != nil {
+		return nil, fmt.Errorf("invalid base_url [%s], %v", config.BaseURL, err)
+	}
+	config.fillDefaults()
+	proofDir := filepath.Join(conf.FinalCfgPath(config.ProofDir), chainID)
+	if err := os.MkdirAll(proofDir, 0755); err != nil {
+		return nil, fmt.Errorf("create proof dir [%s] failed, %v", proofDir, err)
+	}
+	return &RestAdapter{
+		chainID:    chainID,
+		config:     config,
+		proofDir:   proofDir,
+		dispatcher: prover.GetProverDispatcher(),
+		client:     &http.Client{Timeout: time.Duration(config.RequestTimeout) * time.Millisecond},
+		logger:     logger,
+	}, nil
+}
+
+// GetChainID return chain id
+func (r *RestAdapter) GetChainID() string {
+	return r.chainID
+}
+
+// Prove prove the proof by the proof policy, the missing proof is decided by the policy of this chain
+func (r *RestAdapter) Prove(crossID string, txProof *eventproto.Proof) bool {
+	decision := r.dispatcher.Decide(crossID, r.chainID, txProof)
+	if !decision.Result {
+		r.logger.Errorf("cross[%s] prove the proof of chain[%s] failed, %s", crossID, decision.ChainID, decision.Reason)
+	}
+	return decision.Result
+}
+
+// SaveProof save the proof in local file, the saved proof will not be overwritten
+func (r *RestAdapter) SaveProof(crossID, proofKey string, txProof *eventproto.Proof, verifyResult bool) (*eventproto.TxResponse, error) {
+	r.logger.Infof("start save proof for cross[%s]->chain[%s]", crossID, txProof.GetChainID())
+	pr, exist := r.dispatcher.GetProver(txProof.GetChainID())
+	if !exist {
+		return nil, fmt.Errorf("can not find prover for chain[%s]", txProof.GetChainID())
+	}
+	jsonText, err := json.Marshal(prover.NewVerifiedProof(pr, txProof, verifyResult))
+	if err != nil {
+		r.logger.Errorf("marshal verified proof error crossID = [%s], %v", crossID, err)
+		return nil, err
+	}
+	content, err := r.saveProofFile(crossID, proofKey, jsonText)
+	if err != nil {
+		return nil, err
+	}
+	contract := event.NewContract(LocalProofContract, "", SaveProofMethod, nil)
+	contract.AddParameter(event.NewContractParameter("crossID", crossID))
+	contract.AddParameter(event.NewContractParameter("proofKey", proofKey))
+	return event.NewTxResponse(r.chainID, sha256Hex(content), 0, -1, contract, nil), nil
+}
+
+// ReadProof read the proof which is saved in local file
+func (r *RestAdapter) ReadProof(crossID, proofKey string) ([]byte, error) {
+	return ioutil.ReadFile(r.proofFile(crossID, proofKey))
+}
+
+// Invoke post the transaction event to the try/confirm/cancel endpoint
+func (r *RestAdapter) Invoke(txEvent *eventproto.TransactionEvent) (*eventproto.TxResponse, error) {
+	action, path, err := r.actionOf(txEvent.GetOpFunc())
+	if err != nil {
+		return nil, err
+	}
+	txKey, request, err := parseTxRequest(txEvent.GetPayload())
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(&TccRequest{
+		TxKey:   txKey,
+		CrossID: txEvent.GetCrossID(),
+		ChainID: r.chainID,
+		Action:  action,
+		Data:    request.Data,
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.logger.Infof("cross[%s]->chain[%s]'s %s request[%s] send", txEvent.GetCrossID(), r.chainID, action, txKey)
+	resp := &TccResponse{}
+	if err = r.do(http.MethodPost, r.config.BaseURL+path, body, resp); err != nil {
+		r.logger.Errorf("cross[%s]->chain[%s]'s %s request[%s] send failed, %v", txEvent.GetCrossID(), r.chainID, action, txKey, err)
+		return nil, err
+	}
+	if resp.Code != CodeSuccess {
+		r.logger.Errorf("cross[%s]->chain[%s]'s %s request[%s] failed, code = %d, %s", txEvent.GetCrossID(), r.chainID,
+			action, txKey, resp.Code, resp.Message)
+		return nil, errors.New(resp.Message)
+	}
+	r.logger.Infof("cross[%s]->chain[%s]'s %s request[%s] invoke success", txEvent.GetCrossID(), r.chainID, action, txKey)
+	return event.NewTxResponse(r.chainID, txKey, 0, -1, r.newContract(txEvent.GetCrossID(), action), nil), nil
+}
+
+// QueryByTxKey query the result of action by the status endpoint
+func (r *RestAdapter) QueryByTxKey(txKey string) (*event.CommonTxResponse, error) {
+	if len(txKey) == 0 {
+		return nil, fmt.Errorf("TxKey is <nil>")
+	}
+	status := &TccStatus{}
+	statusURL := r.config.BaseURL + r.config.StatusPath + "?" + TxKeyQueryParam + "=" + url.QueryEscape(txKey)
+	if err := r.do(http.MethodGet, statusURL, nil, status); err != nil {
+		return nil, err
+	}
+	txResponse := event.NewTxResponse(r.chainID, txKey, 0, -1, r.newContract(status.CrossID, status.Action), nil)
+	if status.Status == StatusSuccess {
+		return event.NewCommonTxResponse(txResponse, event.SuccessResp, ""), nil
+	}
+	return event.NewCommonTxResponse(txResponse, event.FailureResp, status.Message), nil
+}
+
+// QueryTx query transaction by the payload, the tx key is calculated in the same way as Invoke
+func (r *RestAdapter) QueryTx(payload []byte) (*event.CommonTxResponse, error) {
+	txKey, _, err := parseTxRequest(payload)
+	if err != nil {
+		return nil, err
+	}
+	r.logger.Infof("unmarshal find txKey = [%s]", txKey)
+	return r.QueryByTxKey(txKey)
+}
+
+// actionOf return the action and endpoint path of the op func
+func (r *RestAdapter) actionOf(opFunc eventproto.OpFuncType) (string, string, error) {
+	switch opFunc {
+	case eventproto.OpFuncType_ExecuteOpFunc:
+		return ActionTry, r.config.TryPath, nil
+	case eventproto.OpFuncType_CommitOpFunc:
+		return ActionConfirm, r.config.ConfirmPath, nil
+	case eventproto.OpFuncType_RollbackOpFunc:
+		return ActionCancel, r.config.CancelPath, nil
+	default:
+		return "", "", fmt.Errorf("op func [%s] is not supported by http adapter", opFunc.String())
+	}
+}
+
+// do send the request and decode the json response into out
+func (r *RestAdapter) do(method, reqURL string, body []byte, out interface{}) error {
+	req, err := http.NewRequest(method, reqURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range r.config.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrTxNotFound
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	if err = json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response failed, %v", err)
+	}
+	return nil
+}
+
+// newContract return the ContractInfo of action
+func (r *RestAdapter) newContract(crossID, action string) *eventproto.ContractInfo {
+	contract := event.NewContract(r.config.BaseURL, "", action, nil)
+	contract.AddParameter(event.NewContractParameter("crossID", crossID))
+	return contract
+}
+
+// saveProofFile save the proof if it does not exist, return the saved content
+func (r *RestAdapter) saveProofFile(crossID, proofKey string, content []byte) ([]byte, error) {
+	r.Lock()
+	defer r.Unlock()
+	file := r.proofFile(crossID, proofKey)
+	if saved, err := ioutil.ReadFile(file); err == nil {
+		// Proof 已存在，返回历史数据
+		return saved, nil
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	// 先写临时文件再重命名，避免进程退出时留下不完整的证明
+	tmpFile := file + ".tmp"
+	if err := ioutil.WriteFile(tmpFile, content, 0644); err != nil {
+		return nil, fmt.Errorf("write proof file [%s] failed, %v", tmpFile, err)
+	}
+	if err := os.Rename(tmpFile, file); err != nil {
+		return nil, fmt.Errorf("rename proof file [%s] failed, %v", tmpFile, err)
+	}
+	return content, nil
+}
+
+// proofFile return the file path of proof
+func (r *RestAdapter) proofFile(crossID, proofKey string) string {
+	return filepath.Join(r.proofDir, url.PathEscape(crossID)+"."+url.PathEscape(proofKey)+ProofFileSuffix)
+}
+
+// parseTxRequest parse the payload and return the tx key
+func parseTxRequest(payload []byte) (string, *TxRequest, error) {
+	request := &TxRequest{}
+	if err := json.Unmarshal(payload, request); err != nil {
+		return "", nil, fmt.Errorf("unmarshal transaction payload failed, %s", err.Error())
+	}
+	txKey := strings.TrimSpace(request.RequestID)
+	if txKey == "" {
+		txKey = sha256Hex(payload)
+	}
+	return txKey, request, nil
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,134 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package rest
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"chainmaker.org/chainmaker-cross/event"
+	"chainmaker.org/chainmaker-cross/logger"
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+	"chainmaker.org/chainmaker-cross/prover"
+	"chainmaker.org/chainmaker-cross/prover/impl"
+	"github.com/stretchr/testify/require"
+)
+
+const testChainID = "service1"
+
+func newTestAdapter(t *testing.T) (*RestAdapter, *StubServer, func()) {
+	stub := NewStubServer()
+	server := httptest.NewServer(stub)
+	proofDir, err := ioutil.TempDir("", "rest_adapter")
+	require.Nil(t, err)
+	restAdapter, err := NewRestAdapterWithConfig(testChainID, &RestConfig{BaseURL: server.URL, ProofDir: proofDir},
+		logger.GetLogger(logger.ModuleAdapter))
+	require.Nil(t, err)
+	return restAdapter, stub, func() {
+		server.Close()
+		_ = os.RemoveAll(proofDir)
+	}
+}
+
+func newPayload(t *testing.T, requestID string) []byte {
+	payload, err := json.Marshal(NewTxRequest(requestID, json.RawMessage(`{"account":"alice","amount":100}`)))
+	require.Nil(t, err)
+	return payload
+}
+
+func TestNewRestAdapterWithConfig(t *testing.T) {
+	_, err := NewRestAdapterWithConfig(testChainID, &RestConfig{}, nil)
+	require.NotNil(t, err)
+
+	restAdapter, _, closer := newTestAdapter(t)
+	defer closer()
+	require.Equal(t, testChainID, restAdapter.GetChainID())
+	require.Equal(t, DefaultTryPath, restAdapter.config.TryPath)
+}
+
+func TestRestAdapter_Invoke(t *testing.T) {
+	restAdapter, stub, closer := newTestAdapter(t)
+	defer closer()
+
+	resp, err := restAdapter.Invoke(event.NewExecuteTransactionEvent("crossID1", testChainID, newPayload(t, "try1"), "", nil))
+	require.Nil(t, err)
+	require.Equal(t, "try1", resp.GetTxKey())
+	require.Equal(t, ActionTry, resp.GetContract().GetMethod())
+	require.Equal(t, StubStateTried, stub.State("crossID1"))
+
+	// the same request is handled idempotently
+	_, err = restAdapter.Invoke(event.NewExecuteTransactionEvent("crossID1", testChainID, newPayload(t, "try1"), "", nil))
+	require.Nil(t, err)
+
+	_, err = restAdapter.Invoke(event.NewCommitTransactionEvent("crossID1", testChainID, newPayload(t, "confirm1")))
+	require.Nil(t, err)
+	require.Equal(t, StubStateConfirmed, stub.State("crossID1"))
+
+	// the confirmed cross can not be cancelled
+	_, err = restAdapter.Invoke(event.NewRollbackTransactionEvent("crossID1", testChainID, newPayload(t, "cancel1")))
+	require.NotNil(t, err)
+
+	commonResp, err := restAdapter.QueryByTxKey("confirm1")
+	require.Nil(t, err)
+	require.Equal(t, int32(event.SuccessResp), commonResp.Code)
+	commonResp, err = restAdapter.QueryTx(newPayload(t, "cancel1"))
+	require.Nil(t, err)
+	require.Equal(t, int32(event.FailureResp), commonResp.Code)
+
+	_, err = restAdapter.QueryByTxKey("notExist")
+	require.Equal(t, ErrTxNotFound, err)
+}
+
+func TestRestAdapter_InvokeCancel(t *testing.T) {
+	restAdapter, stub, closer := newTestAdapter(t)
+	defer closer()
+
+	// empty rollback succeeds and the late try is rejected
+	_, err := restAdapter.Invoke(event.NewRollbackTransactionEvent("crossID2", testChainID, newPayload(t, "")))
+	require.Nil(t, err)
+	require.Equal(t, StubStateCancelled, stub.State("crossID2"))
+	_, err = restAdapter.Invoke(event.NewExecuteTransactionEvent("crossID2", testChainID, newPayload(t, "try2"), "", nil))
+	require.NotNil(t, err)
+
+	// tx key is the sha256 of payload when request id is empty
+	commonResp, err := restAdapter.QueryTx(newPayload(t, ""))
+	require.Nil(t, err)
+	require.Equal(t, int32(event.SuccessResp), commonResp.Code)
+	require.Equal(t, sha256Hex(newPayload(t, "")), commonResp.TxResponse.GetTxKey())
+
+	stub.SetFailure(ActionTry, "insufficient balance")
+	_, err = restAdapter.Invoke(event.NewExecuteTransactionEvent("crossID3", testChainID, newPayload(t, "try3"), "", nil))
+	require.EqualError(t, err, "insufficient balance")
+
+	_, err = restAdapter.Invoke(event.NewExecuteTransactionEvent("crossID3", testChainID, []byte("payload"), "", nil))
+	require.NotNil(t, err)
+	_, err = restAdapter.Invoke(&eventproto.TransactionEvent{CrossId: "crossID3", OpFunc: eventproto.OpFuncType_AttestOpFunc})
+	require.NotNil(t, err)
+}
+
+func TestRestAdapter_SaveProof(t *testing.T) {
+	prover.GetProverDispatcher().Register(impl.NewTrustProver([]string{"chain1"}))
+	restAdapter, _, closer := newTestAdapter(t)
+	defer closer()
+
+	resp, err := restAdapter.SaveProof("crossID4", "proofKey", &eventproto.Proof{ChainId: "chain1", TxKey: "txKey1"}, true)
+	require.Nil(t, err)
+	require.Equal(t, SaveProofMethod, resp.GetContract().GetMethod())
+	saved, err := restAdapter.ReadProof("crossID4", "proofKey")
+	require.Nil(t, err)
+	require.Contains(t, string(saved), "txKey1")
+
+	// the saved proof will not be overwritten
+	again, err := restAdapter.SaveProof("crossID4", "proofKey", &eventproto.Proof{ChainId: "chain1", TxKey: "txKey2"}, true)
+	require.Nil(t, err)
+	require.Equal(t, resp.GetTxKey(), again.GetTxKey())
+
+	_, err = restAdapter.SaveProof("crossID4", "proofKey", &eventproto.Proof{ChainId: "chainNotExist"}, true)
+	require.NotNil(t, err)
+}
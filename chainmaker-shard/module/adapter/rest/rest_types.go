@@ -0,0 +1,110 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package rest
+
+import (
+	"encoding/json"
+)
+
+// TCC动作，与事务事件的OpFunc一一对应
+const (
+	ActionTry     = "try"     // 对应ExecuteOpFunc，预留资源
+	ActionConfirm = "confirm" // 对应CommitOpFunc，确认预留的资源
+	ActionCancel  = "cancel"  // 对应RollbackOpFunc，释放预留的资源
+)
+
+const (
+	CodeSuccess = 0 // 业务处理成功，非0表示业务处理失败
+
+	StatusSuccess = "success" // 动作执行成功
+	StatusFailed  = "failed"  // 动作执行失败
+)
+
+const (
+	DefaultTryPath        = "/tcc/try"
+	DefaultConfirmPath    = "/tcc/confirm"
+	DefaultCancelPath     = "/tcc/cancel"
+	DefaultStatusPath     = "/tcc/status"
+	DefaultRequestTimeout = 10000    // 默认请求超时时间(ms)
+	DefaultProofDir       = "proofs" // 默认证明保存目录，按链ID区分子目录
+	TxKeyQueryParam       = "tx_key" // 状态查询接口的参数名
+)
+
+// TxRequest the payload of http transaction, which is built by sdk and carried in the transaction event
+type TxRequest struct {
+	// 请求ID，作为业务系统的幂等键和交易的TxKey，为空时使用payload的sha256
+	RequestID string `json:"request_id,omitempty"`
+	// 业务数据，原样透传给业务系统
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// NewTxRequest create TxRequest
+func NewTxRequest(requestID string, data json.RawMessage) *TxRequest {
+	return &TxRequest{
+		RequestID: requestID,
+		Data:      data,
+	}
+}
+
+// TccRequest the body which is posted to the try/confirm/cancel endpoints,
+// the service should handle the same tx_key idempotently, and cancel without try should succeed (empty rollback)
+type TccRequest struct {
+	TxKey   string          `json:"tx_key"`         // 幂等键
+	CrossID string          `json:"cross_id"`       // 跨链ID，同一跨链事务的try/confirm/cancel相同
+	ChainID string          `json:"chain_id"`       // 适配器配置的链ID
+	Action  string          `json:"action"`         // try、confirm、cancel
+	Data    json.RawMessage `json:"data,omitempty"` // 业务数据
+}
+
+// TccResponse the response of try/confirm/cancel endpoints
+type TccResponse struct {
+	Code    int    `json:"code"`              // 0表示成功
+	Message string `json:"message,omitempty"` // 失败原因
+}
+
+// TccStatus the response of status endpoint, the service returns 404 when the tx_key is not found
+type TccStatus struct {
+	TxKey     string `json:"tx_key"`
+	CrossID   string `json:"cross_id"`
+	Action    string `json:"action"`
+	Status    string `json:"status"`              // success、failed
+	Message   string `json:"message,omitempty"`   // 失败原因
+	Timestamp int64  `json:"timestamp,omitempty"` // 执行时间，unix时间戳，单位：毫秒
+}
+
+// RestConfig the config of http adapter
+type RestConfig struct {
+	BaseURL        string            `mapstructure:"base_url"`        // 业务系统地址，如http://127.0.0.1:8080
+	TryPath        string            `mapstructure:"try_path"`        // try接口路径，默认/tcc/try
+	ConfirmPath    string            `mapstructure:"confirm_path"`    // confirm接口路径，默认/tcc/confirm
+	CancelPath     string            `mapstructure:"cancel_path"`     // cancel接口路径，默认/tcc/cancel
+	StatusPath     string            `mapstructure:"status_path"`     // 状态查询接口路径，默认/tcc/status
+	RequestTimeout int64             `mapstructure:"request_timeout"` // 请求超时时间(ms)
+	Headers        map[string]string `mapstructure:"headers"`         // 附加的请求头，如鉴权token
+	ProofDir       string            `mapstructure:"proof_dir"`       // 证明保存目录，默认proofs
+}
+
+// fillDefaults fill the zero fields with default values
+func (c *RestConfig) fillDefaults() {
+	if c.TryPath == "" {
+		c.TryPath = DefaultTryPath
+	}
+	if c.ConfirmPath == "" {
+		c.ConfirmPath = DefaultConfirmPath
+	}
+	if c.CancelPath == "" {
+		c.CancelPath = DefaultCancelPath
+	}
+	if c.StatusPath == "" {
+		c.StatusPath = DefaultStatusPath
+	}
+	if c.RequestTimeout <= 0 {
+		c.RequestTimeout = DefaultRequestTimeout
+	}
+	if c.ProofDir == "" {
+		c.ProofDir = DefaultProofDir
+	}
+}
@@ -0,0 +1,164 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// 参考业务系统中跨链事务的状态
+const (
+	StubStateTried     = "Tried"
+	StubStateConfirmed = "Confirmed"
+	StubStateCancelled = "Cancelled"
+)
+
+const stubFailureCode = 1
+
+// StubServer the reference implementation of the TCC service in memory, which is used for tests and shows
+// the JSON contract that a service should follow:
+//  1. the requests with the same tx_key are handled idempotently;
+//  2. cancel without try succeeds (empty rollback), and the late try after cancel is rejected (anti-suspension);
+//  3. confirm is only accepted after a successful try, and the confirmed cross can not be cancelled.
+type StubServer struct {
+	sync.Mutex
+	config   *RestConfig
+	mux      *http.ServeMux
+	records  map[string]*TccStatus // tx_key => 执行结果
+	states   map[string]string     // cross_id => 状态
+	failures map[string]string     // action => 注入的失败原因
+}
+
+// NewStubServer create the stub server with the default endpoint paths
+func NewStubServer() *StubServer {
+	config := &RestConfig{}
+	config.fillDefaults()
+	s := &StubServer{
+		config:   config,
+		mux:      http.NewServeMux(),
+		records:  make(map[string]*TccStatus),
+		states:   make(map[string]string),
+		failures: make(map[string]string),
+	}
+	s.mux.HandleFunc(config.TryPath, s.handleAction(ActionTry))
+	s.mux.HandleFunc(config.ConfirmPath, s.handleAction(ActionConfirm))
+	s.mux.HandleFunc(config.CancelPath, s.handleAction(ActionCancel))
+	s.mux.HandleFunc(config.StatusPath, s.handleStatus)
+	return s
+}
+
+// ServeHTTP implement http.Handler
+func (s *StubServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// SetFailure let the action fail with the message, empty message means recovering
+func (s *StubServer) SetFailure(action, message string) {
+	s.Lock()
+	defer s.Unlock()
+	if message == "" {
+		delete(s.failures, action)
+		return
+	}
+	s.failures[action] = message
+}
+
+// State return the state of cross transaction, empty means the cross is unknown
+func (s *StubServer) State(crossID string) string {
+	s.Lock()
+	defer s.Unlock()
+	return s.states[crossID]
+}
+
+func (s *StubServer) handleAction(action string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		req := &TccRequest{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil || req.TxKey == "" || req.CrossID == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, s.handle(action, req))
+	}
+}
+
+func (s *StubServer) handle(action string, req *TccRequest) *TccResponse {
+	s.Lock()
+	defer s.Unlock()
+	// 幂等处理，返回第一次执行的结果
+	if record, exist := s.records[req.TxKey]; exist {
+		return toTccResponse(record)
+	}
+	record := &TccStatus{
+		TxKey:     req.TxKey,
+		CrossID:   req.CrossID,
+		Action:    action,
+		Status:    StatusSuccess,
+		Timestamp: time.Now().UnixNano() / int64(time.Millisecond),
+	}
+	if message, exist := s.failures[action]; exist {
+		record.Status, record.Message = StatusFailed, message
+	} else if err := s.transit(action, req.CrossID); err != nil {
+		record.Status, record.Message = StatusFailed, err.Error()
+	}
+	s.records[req.TxKey] = record
+	return toTccResponse(record)
+}
+
+// transit change the state of cross transaction by the action
+func (s *StubServer) transit(action, crossID string) error {
+	state := s.states[crossID]
+	switch action {
+	case ActionTry:
+		if state != "" {
+			return fmt.Errorf("failed to try, unexpected state: %s", state)
+		}
+		s.states[crossID] = StubStateTried
+	case ActionConfirm:
+		if state != StubStateTried && state != StubStateConfirmed {
+			return fmt.Errorf("failed to confirm, unexpected state: %s", state)
+		}
+		s.states[crossID] = StubStateConfirmed
+	case ActionCancel:
+		if state == StubStateConfirmed {
+			return fmt.Errorf("failed to cancel, unexpected state: %s", state)
+		}
+		// 未try时为空回滚，同样记录为已取消，防止之后到达的try预留资源
+		s.states[crossID] = StubStateCancelled
+	}
+	return nil
+}
+
+func (s *StubServer) handleStatus(w http.ResponseWriter, r *http.Request) {
+	txKey := r.URL.Query().Get(TxKeyQueryParam)
+	s.Lock()
+	record, exist := s.records[txKey]
+	s.Unlock()
+	if !exist {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	writeJSON(w, record)
+}
+
+func toTccResponse(record *TccStatus) *TccResponse {
+	if record.Status == StatusSuccess {
+		return &TccResponse{Code: CodeSuccess}
+	}
+	return &TccResponse{Code: stubFailureCode, Message: record.Message}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,184 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package simulator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/event"
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+	"chainmaker.org/chainmaker-cross/prover"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// SimulatedAdapter adapter of the simulated chain, which is used to test the proxy without live networks
+type SimulatedAdapter struct {
+	chainID    string                   // chainID
+	chain      *SimChain                // 模拟链
+	dispatcher *prover.ProverDispatcher // 证明模块分发入口
+	logger     *zap.SugaredLogger       // 日志模块
+}
+
+// NewSimulatedAdapter create new instance of simulated adapter by the config file
+func NewSimulatedAdapter(chainID, configPath string, logger *zap.SugaredLogger) (*SimulatedAdapter, error) {
+	config := &SimConfig{}
+	if configPath != "" {
+		simViper := viper.New()
+		simViper.SetConfigFile(configPath)
+		if err := simViper.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("read simulated adapter config failed, %v", err)
+		}
+		if err := simViper.Unmarshal(config); err != nil {
+			return nil, fmt.Errorf("unmarshal simulated adapter config failed, %v", err)
+		}
+	}
+	if config.StateFile != "" {
+		config.StateFile = conf.FinalCfgPath(config.StateFile)
+	}
+	chain, err := NewSimChain(config)
+	if err != nil {
+		return nil, err
+	}
+	return NewSimulatedAdapterWithChain(chainID, chain, logger), nil
+}
+
+// NewSimulatedAdapterWithChain create new instance of simulated adapter with the chain
+func NewSimulatedAdapterWithChain(chainID string, chain *SimChain, logger *zap.SugaredLogger) *SimulatedAdapter {
+	return &SimulatedAdapter{
+		chainID:    chainID,
+		chain:      chain,
+		dispatcher: prover.GetProverDispatcher(),
+		logger:     logger,
+	}
+}
+
+// GetChain return the simulated chain, which can be used to inject faults and check state
+func (s *SimulatedAdapter) GetChain() *SimChain {
+	return s.chain
+}
+
+// GetChainID return chain id
+func (s *SimulatedAdapter) GetChainID() string {
+	return s.chainID
+}
+
+// Prove prove the proof by the proof policy, the missing proof is decided by the policy of this chain
+func (s *SimulatedAdapter) Prove(crossID string, txProof *eventproto.Proof) bool {
+	decision := s.dispatcher.Decide(crossID, s.chainID, txProof)
+	if !decision.Result {
+		s.logger.Errorf("cross[%s] prove the proof of chain[%s] failed, %s", crossID, decision.ChainID, decision.Reason)
+	}
+	return decision.Result
+}
+
+// SaveProof save the proof and verify in the chain
+func (s *SimulatedAdapter) SaveProof(crossID, proofKey string, txProof *eventproto.Proof, verifyResult bool) (*eventproto.TxResponse, error) {
+	s.logger.Infof("start save proof for cross[%s]->chain[%s]", crossID, txProof.GetChainID())
+	pr, exist := s.dispatcher.GetProver(txProof.GetChainID())
+	if !exist {
+		return nil, fmt.Errorf("can not find prover for chain[%s]", txProof.GetChainID())
+	}
+	jsonText, err := json.Marshal(prover.NewVerifiedProof(pr, txProof, verifyResult))
+	if err != nil {
+		s.logger.Errorf("marshal verified proof error crossID = [%s], %v", crossID, err)
+		return nil, err
+	}
+	tx, err := s.chain.SaveProof(crossID, proofKey, string(jsonText))
+	if err != nil {
+		return nil, err
+	}
+	if !tx.Success {
+		return nil, errors.New(tx.Message)
+	}
+	return s.newTxResponse(tx), nil
+}
+
+// Invoke call the transaction contract of simulated chain
+func (s *SimulatedAdapter) Invoke(txEvent *eventproto.TransactionEvent) (*eventproto.TxResponse, error) {
+	method, err := methodOf(txEvent.GetOpFunc())
+	if err != nil {
+		return nil, err
+	}
+	request, err := parseTxRequest(txEvent.GetPayload())
+	if err != nil {
+		return nil, err
+	}
+	request.ProofTxKey = txEvent.GetTxProof().GetTxKey()
+	s.logger.Infof("cross[%s]->chain[%s]'s tx-request[%s] send", txEvent.GetCrossID(), s.chainID, request.TxID)
+	tx, err := s.chain.Invoke(method, txEvent.GetCrossID(), request)
+	if err != nil {
+		s.logger.Errorf("cross[%s]->chain[%s]'s tx-request[%s] send failed, %v", txEvent.GetCrossID(), s.chainID, request.TxID, err)
+		return nil, err
+	}
+	if !tx.Success {
+		s.logger.Errorf("cross[%s]->chain[%s]'s tx[%s] invoke failed, %s", txEvent.GetCrossID(), s.chainID, tx.TxID, tx.Message)
+		return nil, errors.New(tx.Message)
+	}
+	s.logger.Infof("cross[%s]->chain[%s]'s tx[%s] invoke success", txEvent.GetCrossID(), s.chainID, tx.TxID)
+	return s.newTxResponse(tx), nil
+}
+
+// QueryByTxKey query transaction response by txkey
+func (s *SimulatedAdapter) QueryByTxKey(txKey string) (*event.CommonTxResponse, error) {
+	if len(txKey) == 0 {
+		return nil, fmt.Errorf("TxKey is <nil>")
+	}
+	tx, err := s.chain.GetTx(txKey)
+	if err != nil {
+		return nil, err
+	}
+	if tx.Success {
+		return event.NewCommonTxResponse(s.newTxResponse(tx), event.SuccessResp, ""), nil
+	}
+	return event.NewCommonTxResponse(s.newTxResponse(tx), event.FailureResp, tx.Message), nil
+}
+
+// QueryTx query transaction by the tx id in payload
+func (s *SimulatedAdapter) QueryTx(payload []byte) (*event.CommonTxResponse, error) {
+	request, err := parseTxRequest(payload)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Infof("unmarshal find txKey = [%s]", request.TxID)
+	return s.QueryByTxKey(request.TxID)
+}
+
+// newTxResponse convert the simulated transaction to TxResponse
+func (s *SimulatedAdapter) newTxResponse(tx *SimTx) *eventproto.TxResponse {
+	contract := event.NewContract(ContractName, "", tx.Method, nil)
+	contract.AddParameter(event.NewContractParameter("crossID", tx.CrossID))
+	return event.NewTxResponse(s.chainID, tx.TxID, tx.Height, 0, contract, nil)
+}
+
+// methodOf return the method of transaction contract by op func
+func methodOf(opFunc eventproto.OpFuncType) (string, error) {
+	switch opFunc {
+	case eventproto.OpFuncType_ExecuteOpFunc:
+		return ExecuteMethod, nil
+	case eventproto.OpFuncType_CommitOpFunc:
+		return CommitMethod, nil
+	case eventproto.OpFuncType_RollbackOpFunc:
+		return RollbackMethod, nil
+	default:
+		return "", fmt.Errorf("op func [%s] is not supported by simulated adapter", opFunc.String())
+	}
+}
+
+// parseTxRequest parse the payload of simulated transaction
+func parseTxRequest(payload []byte) (*TxRequest, error) {
+	request := &TxRequest{}
+	if err := json.Unmarshal(payload, request); err != nil {
+		return nil, fmt.Errorf("unmarshal transaction payload failed, %s", err.Error())
+	}
+	if request.TxID == "" {
+		return nil, errors.New("tx_id of transaction payload is empty")
+	}
+	return request, nil
+}
@@ -0,0 +1,109 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package simulator
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"chainmaker.org/chainmaker-cross/event"
+	"chainmaker.org/chainmaker-cross/logger"
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+	"chainmaker.org/chainmaker-cross/prover"
+	"chainmaker.org/chainmaker-cross/prover/impl"
+	"github.com/stretchr/testify/require"
+)
+
+const testChainID = "sim1"
+
+func newTestAdapter(t *testing.T) *SimulatedAdapter {
+	return NewSimulatedAdapterWithChain(testChainID, newTestChain(t, &SimConfig{}), logger.GetLogger(logger.ModuleAdapter))
+}
+
+func newPayload(t *testing.T, txID, key, value string) []byte {
+	payload, err := json.Marshal(NewTxRequest(txID, key, value))
+	require.Nil(t, err)
+	return payload
+}
+
+func TestNewSimulatedAdapter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sim_adapter")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	configPath := filepath.Join(dir, "simulator.yml")
+	config := "state_file: " + filepath.Join(dir, "state.json") + "\nfaults:\n  - method: Execute\n    error: failed\n"
+	require.Nil(t, ioutil.WriteFile(configPath, []byte(config), 0644))
+
+	simAdapter, err := NewSimulatedAdapter(testChainID, configPath, logger.GetLogger(logger.ModuleAdapter))
+	require.Nil(t, err)
+	require.Equal(t, testChainID, simAdapter.GetChainID())
+	_, err = simAdapter.Invoke(event.NewExecuteTransactionEvent("cross1", testChainID, newPayload(t, "exec1", "", ""), "", nil))
+	require.EqualError(t, err, "failed")
+	ledger, err := LoadLedger(filepath.Join(dir, "state.json"))
+	require.Nil(t, err)
+	require.Equal(t, StateExecuteFail, ledger.GetState("cross1"))
+
+	_, err = NewSimulatedAdapter(testChainID, filepath.Join(dir, "notExist.yml"), nil)
+	require.NotNil(t, err)
+}
+
+func TestSimulatedAdapter_Invoke(t *testing.T) {
+	simAdapter := newTestAdapter(t)
+
+	resp, err := simAdapter.Invoke(event.NewExecuteTransactionEvent("cross1", testChainID, newPayload(t, "exec1", "alice", "100"), "", nil))
+	require.Nil(t, err)
+	require.Equal(t, "exec1", resp.GetTxKey())
+	require.Equal(t, ExecuteMethod, resp.GetContract().GetMethod())
+	_, err = simAdapter.Invoke(event.NewCommitTransactionEvent("cross1", testChainID, newPayload(t, "commit1", "", "")))
+	require.Nil(t, err)
+	require.Equal(t, StateCommitSuccess, simAdapter.GetChain().ReadState("cross1"))
+	// 执行时记录传入的上一条链的证明
+	_, err = simAdapter.Invoke(event.NewExecuteTransactionEvent("cross4", testChainID, newPayload(t, "exec4", "carol", "10"), "",
+		&eventproto.Proof{ChainId: "chain1", TxKey: "prevExec"}))
+	require.Nil(t, err)
+	tx, err := simAdapter.GetChain().GetTx("exec4")
+	require.Nil(t, err)
+	require.Equal(t, "prevExec", tx.ProofTxKey)
+
+	commonResp, err := simAdapter.QueryTx(newPayload(t, "commit1", "", ""))
+	require.Nil(t, err)
+	require.Equal(t, int32(event.SuccessResp), commonResp.Code)
+
+	simAdapter.GetChain().InjectFault(&Fault{Method: RollbackMethod, Error: "rollback failed"})
+	_, err = simAdapter.Invoke(event.NewExecuteTransactionEvent("cross2", testChainID, newPayload(t, "exec2", "bob", "50"), "", nil))
+	require.Nil(t, err)
+	_, err = simAdapter.Invoke(event.NewRollbackTransactionEvent("cross2", testChainID, newPayload(t, "rollback2", "", "")))
+	require.EqualError(t, err, "rollback failed")
+	commonResp, err = simAdapter.QueryByTxKey("rollback2")
+	require.Nil(t, err)
+	require.Equal(t, int32(event.FailureResp), commonResp.Code)
+	require.Equal(t, StateRollbackFail, simAdapter.GetChain().ReadState("cross2"))
+
+	_, err = simAdapter.QueryByTxKey("")
+	require.NotNil(t, err)
+	_, err = simAdapter.Invoke(event.NewExecuteTransactionEvent("cross3", testChainID, []byte("{}"), "", nil))
+	require.NotNil(t, err)
+	_, err = simAdapter.Invoke(&eventproto.TransactionEvent{CrossId: "cross3", OpFunc: eventproto.OpFuncType_AttestOpFunc})
+	require.NotNil(t, err)
+}
+
+func TestSimulatedAdapter_SaveProof(t *testing.T) {
+	prover.GetProverDispatcher().Register(impl.NewTrustProver([]string{"chain1"}))
+	simAdapter := newTestAdapter(t)
+
+	resp, err := simAdapter.SaveProof("cross1", "proofKey", &eventproto.Proof{ChainId: "chain1", TxKey: "txKey1"}, true)
+	require.Nil(t, err)
+	require.Equal(t, SaveProofMethod, resp.GetContract().GetMethod())
+	proof, exist := simAdapter.GetChain().ReadProof("cross1", "proofKey")
+	require.True(t, exist)
+	require.Contains(t, proof, "txKey1")
+
+	_, err = simAdapter.SaveProof("cross1", "proofKey", &eventproto.Proof{ChainId: "chainNotExist"}, true)
+	require.NotNil(t, err)
+}
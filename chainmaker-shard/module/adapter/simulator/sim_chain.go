@@ -0,0 +1,332 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package simulator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrUnavailable the simulated node is unavailable, the transaction is not on chain
+	ErrUnavailable = errors.New("simulated chain is unavailable")
+	// ErrTxNotFound the transaction is not found on chain
+	ErrTxNotFound = errors.New("transaction is not found")
+)
+
+// SimChain the simulated chain which implements the semantics of transaction contract in memory,
+// the faults can be injected to simulate the business failure, latency, unavailable node and crash
+type SimChain struct {
+	sync.Mutex
+	ledger       *Ledger       // 账本
+	stateFile    string        // 账本持久化文件，为空时不持久化
+	blockLatency time.Duration // 出块延迟
+	faults       []*Fault      // 注入的故障
+	crashFn      func()        // 崩溃处理，默认退出进程
+}
+
+// NewSimChain create the simulated chain, the ledger is loaded from state file if it exists
+func NewSimChain(config *SimConfig) (*SimChain, error) {
+	ledger := NewLedger()
+	if config.StateFile != "" {
+		loaded, err := LoadLedger(config.StateFile)
+		if err == nil {
+			ledger = loaded
+		} else if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	faults := make([]*Fault, 0, len(config.Faults))
+	for _, f := range config.Faults {
+		fault := *f
+		faults = append(faults, &fault)
+	}
+	return &SimChain{
+		ledger:       ledger,
+		stateFile:    config.StateFile,
+		blockLatency: time.Duration(config.BlockLatency) * time.Millisecond,
+		faults:       faults,
+		crashFn: func() {
+			os.Exit(CrashExitCode)
+		},
+	}, nil
+}
+
+// LoadLedger load the ledger from state file, which can be used to check the state of chain from other process
+func LoadLedger(stateFile string) (*Ledger, error) {
+	content, err := ioutil.ReadFile(stateFile)
+	if err != nil {
+		return nil, err
+	}
+	ledger := NewLedger()
+	if err = json.Unmarshal(content, ledger); err != nil {
+		return nil, fmt.Errorf("unmarshal ledger [%s] failed, %v", stateFile, err)
+	}
+	return ledger, nil
+}
+
+// InjectFault inject the fault, the later injected fault has higher priority
+func (c *SimChain) InjectFault(fault *Fault) {
+	c.Lock()
+	defer c.Unlock()
+	c.faults = append([]*Fault{fault}, c.faults...)
+}
+
+// ClearFaults remove all the faults
+func (c *SimChain) ClearFaults() {
+	c.Lock()
+	defer c.Unlock()
+	c.faults = nil
+}
+
+// SetCrashHandler set the handler which is called when the crash fault is triggered
+func (c *SimChain) SetCrashHandler(crashFn func()) {
+	c.Lock()
+	defer c.Unlock()
+	c.crashFn = crashFn
+}
+
+// ReadState return the state of cross transaction
+func (c *SimChain) ReadState(crossID string) string {
+	c.Lock()
+	defer c.Unlock()
+	return c.ledger.GetState(crossID)
+}
+
+// ReadData return the business data
+func (c *SimChain) ReadData(key string) (string, bool) {
+	c.Lock()
+	defer c.Unlock()
+	value, exist := c.ledger.Data[key]
+	return value, exist
+}
+
+// ReadProof return the saved proof
+func (c *SimChain) ReadProof(crossID, proofKey string) (string, bool) {
+	c.Lock()
+	defer c.Unlock()
+	proof, exist := c.ledger.Proofs[proofMapKey(crossID, proofKey)]
+	return proof, exist
+}
+
+// GetTx return the transaction on chain
+func (c *SimChain) GetTx(txID string) (*SimTx, error) {
+	c.Lock()
+	defer c.Unlock()
+	tx, exist := c.ledger.Txs[txID]
+	if !exist {
+		return nil, ErrTxNotFound
+	}
+	copied := *tx
+	return &copied, nil
+}
+
+// Invoke call the method of transaction contract, the transaction with the same tx id is only executed once
+func (c *SimChain) Invoke(method, crossID string, request *TxRequest) (*SimTx, error) {
+	return c.invoke(method, crossID, request, func(l *Ledger) (bool, string) {
+		switch method {
+		case ExecuteMethod:
+			return execute(l, crossID, request)
+		case CommitMethod:
+			return commit(l, crossID)
+		case RollbackMethod:
+			return rollback(l, crossID)
+		default:
+			return false, fmt.Sprintf("unsupported method [%s]", method)
+		}
+	})
+}
+
+// SaveProof save the proof, the saved proof will not be overwritten
+func (c *SimChain) SaveProof(crossID, proofKey, proof string) (*SimTx, error) {
+	txID := SaveProofMethod + "." + proofMapKey(crossID, proofKey)
+	return c.invoke(SaveProofMethod, crossID, &TxRequest{TxID: txID}, func(l *Ledger) (bool, string) {
+		key := proofMapKey(crossID, proofKey)
+		if _, exist := l.Proofs[key]; !exist {
+			l.Proofs[key] = proof
+		}
+		return true, ""
+	})
+}
+
+// invoke apply the faults and the contract function, then the transaction is packed into a new block
+func (c *SimChain) invoke(method, crossID string, request *TxRequest, fn func(l *Ledger) (bool, string)) (*SimTx, error) {
+	txID := request.TxID
+	if txID == "" {
+		return nil, errors.New("tx id is empty")
+	}
+	fault := c.takeFault(method, crossID)
+	latency := c.blockLatency
+	if fault != nil {
+		latency += time.Duration(fault.Latency) * time.Millisecond
+	}
+	if latency > 0 {
+		time.Sleep(latency)
+	}
+	if fault != nil && fault.Unavailable {
+		return nil, ErrUnavailable
+	}
+	c.Lock()
+	if tx, exist := c.ledger.Txs[txID]; exist {
+		// 重复的交易不再执行，返回已上链的结果
+		copied := *tx
+		c.Unlock()
+		return &copied, nil
+	}
+	var (
+		success bool
+		message string
+	)
+	if fault != nil && fault.Error != "" {
+		success, message = failed(c.ledger, method, crossID, fault.Error)
+	} else {
+		success, message = fn(c.ledger)
+	}
+	c.ledger.Height++
+	tx := &SimTx{
+		TxID:    txID,
+		CrossID: crossID,
+		Method:  method,
+		Height:  c.ledger.Height,
+		Success: success,
+		Message: message,
+
+		ProofTxKey: request.ProofTxKey,
+	}
+	c.ledger.Txs[txID] = tx
+	err := c.persist()
+	crashFn := c.crashFn
+	copied := *tx
+	c.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if fault != nil && fault.Crash && crashFn != nil {
+		// 交易已上链，代理在获得结果前崩溃
+		crashFn()
+	}
+	return &copied, nil
+}
+
+// takeFault return the first matched fault and decrease its times
+func (c *SimChain) takeFault(method, crossID string) *Fault {
+	c.Lock()
+	defer c.Unlock()
+	for i, f := range c.faults {
+		if !f.match(method, crossID) {
+			continue
+		}
+		fault := *f
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				c.faults = append(c.faults[:i], c.faults[i+1:]...)
+			}
+		}
+		return &fault
+	}
+	return nil
+}
+
+// persist write the ledger to state file atomically
+func (c *SimChain) persist() error {
+	if c.stateFile == "" {
+		return nil
+	}
+	content, err := json.Marshal(c.ledger)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(c.stateFile), 0755); err != nil {
+		return err
+	}
+	tmpFile := c.stateFile + ".tmp"
+	if err = ioutil.WriteFile(tmpFile, content, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, c.stateFile)
+}
+
+// execute write the business data and record the undo data, the duplicated cross id is rejected
+func execute(l *Ledger, crossID string, request *TxRequest) (bool, string) {
+	if _, exist := l.States[crossID]; exist {
+		return false, fmt.Sprintf("duplicated crossID: %s", crossID)
+	}
+	if request.Key != "" {
+		previous, existed := l.Data[request.Key]
+		l.Undos[crossID] = &Undo{Key: request.Key, Value: previous, Existed: existed}
+		l.Data[request.Key] = request.Value
+	}
+	l.States[crossID] = StateExecuteSuccess
+	return true, ""
+}
+
+// commit confirm the executed cross transaction
+func commit(l *Ledger, crossID string) (bool, string) {
+	state := l.GetState(crossID)
+	switch state {
+	case StateExecuteSuccess, StateCommitFail:
+		l.States[crossID] = StateCommitSuccess
+		return true, ""
+	case StateCommitSuccess:
+		return true, ""
+	default:
+		return false, fmt.Sprintf("failed to Commit, unexpected pre-state: %s", state)
+	}
+}
+
+// rollback restore the business data, the cross transaction which is not executed is ignored
+func rollback(l *Ledger, crossID string) (bool, string) {
+	state := l.GetState(crossID)
+	switch state {
+	case StateExecuteSuccess, StateRollbackFail:
+		if undo, exist := l.Undos[crossID]; exist {
+			if undo.Existed {
+				l.Data[undo.Key] = undo.Value
+			} else {
+				delete(l.Data, undo.Key)
+			}
+		}
+		l.States[crossID] = StateRollbackSuccess
+		return true, ""
+	case StateUnknown, StateExecuteFail, StateRollbackIgnore:
+		l.States[crossID] = StateRollbackIgnore
+		return true, ""
+	case StateCommitSuccess, StateCommitFail, StateRollbackSuccess:
+		return true, ""
+	default:
+		return false, fmt.Sprintf("failed to Rollback, unexpected state: %s", state)
+	}
+}
+
+// failed record the failed state when the business contract fails
+func failed(l *Ledger, method, crossID, message string) (bool, string) {
+	switch method {
+	case ExecuteMethod:
+		if _, exist := l.States[crossID]; !exist {
+			l.States[crossID] = StateExecuteFail
+		}
+	case CommitMethod:
+		if l.GetState(crossID) == StateExecuteSuccess {
+			l.States[crossID] = StateCommitFail
+		}
+	case RollbackMethod:
+		if l.GetState(crossID) == StateExecuteSuccess {
+			l.States[crossID] = StateRollbackFail
+		}
+	}
+	return false, message
+}
+
+func proofMapKey(crossID, proofKey string) string {
+	return crossID + "." + proofKey
+}
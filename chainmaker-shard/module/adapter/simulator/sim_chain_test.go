@@ -0,0 +1,162 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package simulator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestChain(t *testing.T, config *SimConfig) *SimChain {
+	chain, err := NewSimChain(config)
+	require.Nil(t, err)
+	chain.SetCrashHandler(func() {
+		t.Log("simulated chain crashed")
+	})
+	return chain
+}
+
+func TestSimChain_ExecuteCommit(t *testing.T) {
+	chain := newTestChain(t, &SimConfig{})
+
+	tx, err := chain.Invoke(ExecuteMethod, "cross1", NewTxRequest("exec1", "alice", "100"))
+	require.Nil(t, err)
+	require.True(t, tx.Success)
+	require.Equal(t, int64(1), tx.Height)
+	require.Equal(t, StateExecuteSuccess, chain.ReadState("cross1"))
+	value, exist := chain.ReadData("alice")
+	require.True(t, exist)
+	require.Equal(t, "100", value)
+
+	// 重复的交易不再执行
+	again, err := chain.Invoke(ExecuteMethod, "cross1", NewTxRequest("exec1", "alice", "200"))
+	require.Nil(t, err)
+	require.Equal(t, tx.Height, again.Height)
+	value, _ = chain.ReadData("alice")
+	require.Equal(t, "100", value)
+
+	// 重复的跨链ID被拒绝
+	dup, err := chain.Invoke(ExecuteMethod, "cross1", NewTxRequest("exec2", "alice", "200"))
+	require.Nil(t, err)
+	require.False(t, dup.Success)
+
+	tx, err = chain.Invoke(CommitMethod, "cross1", NewTxRequest("commit1", "", ""))
+	require.Nil(t, err)
+	require.True(t, tx.Success)
+	require.Equal(t, StateCommitSuccess, chain.ReadState("cross1"))
+
+	// 已提交的事务回滚时不恢复数据
+	tx, err = chain.Invoke(RollbackMethod, "cross1", NewTxRequest("rollback1", "", ""))
+	require.Nil(t, err)
+	require.True(t, tx.Success)
+	require.Equal(t, StateCommitSuccess, chain.ReadState("cross1"))
+
+	_, err = chain.GetTx("notExist")
+	require.Equal(t, ErrTxNotFound, err)
+}
+
+func TestSimChain_Rollback(t *testing.T) {
+	chain := newTestChain(t, &SimConfig{})
+
+	_, err := chain.Invoke(ExecuteMethod, "cross1", NewTxRequest("exec1", "alice", "100"))
+	require.Nil(t, err)
+	_, err = chain.Invoke(ExecuteMethod, "cross2", NewTxRequest("exec2", "alice", "200"))
+	require.Nil(t, err)
+	_, err = chain.Invoke(RollbackMethod, "cross2", NewTxRequest("rollback2", "", ""))
+	require.Nil(t, err)
+	require.Equal(t, StateRollbackSuccess, chain.ReadState("cross2"))
+	value, _ := chain.ReadData("alice")
+	require.Equal(t, "100", value)
+	_, err = chain.Invoke(RollbackMethod, "cross1", NewTxRequest("rollback1", "", ""))
+	require.Nil(t, err)
+	_, exist := chain.ReadData("alice")
+	require.False(t, exist)
+
+	// 空回滚
+	tx, err := chain.Invoke(RollbackMethod, "cross3", NewTxRequest("rollback3", "", ""))
+	require.Nil(t, err)
+	require.True(t, tx.Success)
+	require.Equal(t, StateRollbackIgnore, chain.ReadState("cross3"))
+
+	// 未执行的事务不能提交
+	tx, err = chain.Invoke(CommitMethod, "cross4", NewTxRequest("commit4", "", ""))
+	require.Nil(t, err)
+	require.False(t, tx.Success)
+}
+
+func TestSimChain_Faults(t *testing.T) {
+	crashed := 0
+	chain := newTestChain(t, &SimConfig{Faults: []*Fault{{Method: ExecuteMethod, CrossID: "cross1", Error: "insufficient balance", Times: 1}}})
+	chain.SetCrashHandler(func() {
+		crashed++
+	})
+
+	tx, err := chain.Invoke(ExecuteMethod, "cross1", NewTxRequest("exec1", "alice", "100"))
+	require.Nil(t, err)
+	require.False(t, tx.Success)
+	require.Equal(t, "insufficient balance", tx.Message)
+	require.Equal(t, StateExecuteFail, chain.ReadState("cross1"))
+	_, exist := chain.ReadData("alice")
+	require.False(t, exist)
+
+	// 失败的事务回滚被忽略
+	tx, err = chain.Invoke(RollbackMethod, "cross1", NewTxRequest("rollback1", "", ""))
+	require.Nil(t, err)
+	require.True(t, tx.Success)
+	require.Equal(t, StateRollbackIgnore, chain.ReadState("cross1"))
+
+	chain.InjectFault(&Fault{Method: CommitMethod, Unavailable: true, Times: 1})
+	_, err = chain.Invoke(ExecuteMethod, "cross2", NewTxRequest("exec2", "bob", "50"))
+	require.Nil(t, err)
+	_, err = chain.Invoke(CommitMethod, "cross2", NewTxRequest("commit2", "", ""))
+	require.Equal(t, ErrUnavailable, err)
+	_, err = chain.GetTx("commit2")
+	require.Equal(t, ErrTxNotFound, err)
+
+	chain.InjectFault(&Fault{Method: CommitMethod, Crash: true})
+	tx, err = chain.Invoke(CommitMethod, "cross2", NewTxRequest("commit2", "", ""))
+	require.Nil(t, err)
+	require.True(t, tx.Success)
+	require.Equal(t, 1, crashed)
+
+	chain.ClearFaults()
+	_, err = chain.Invoke(ExecuteMethod, "cross3", NewTxRequest("exec3", "carol", "10"))
+	require.Nil(t, err)
+	require.Equal(t, 1, crashed)
+}
+
+func TestSimChain_Persist(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sim_chain")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	stateFile := filepath.Join(dir, "state", "state.json")
+
+	chain := newTestChain(t, &SimConfig{StateFile: stateFile})
+	_, err = chain.Invoke(ExecuteMethod, "cross1", NewTxRequest("exec1", "alice", "100"))
+	require.Nil(t, err)
+	_, err = chain.SaveProof("cross1", "proofKey", "proof1")
+	require.Nil(t, err)
+	_, err = chain.SaveProof("cross1", "proofKey", "proof2")
+	require.Nil(t, err)
+
+	ledger, err := LoadLedger(stateFile)
+	require.Nil(t, err)
+	require.Equal(t, int64(2), ledger.Height)
+	require.Equal(t, StateExecuteSuccess, ledger.GetState("cross1"))
+
+	// 重启后从账本文件恢复
+	restarted := newTestChain(t, &SimConfig{StateFile: stateFile})
+	proof, exist := restarted.ReadProof("cross1", "proofKey")
+	require.True(t, exist)
+	require.Equal(t, "proof1", proof)
+	tx, err := restarted.GetTx("exec1")
+	require.Nil(t, err)
+	require.True(t, tx.Success)
+}
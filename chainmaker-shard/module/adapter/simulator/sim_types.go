@@ -0,0 +1,123 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package simulator
+
+// 模拟链上事务合约的方法，与chainmaker事务合约一致
+const (
+	ExecuteMethod   = "Execute"
+	CommitMethod    = "Commit"
+	RollbackMethod  = "Rollback"
+	SaveProofMethod = "SaveProof"
+	ReadStateMethod = "ReadState"
+
+	ContractName = "CROSS_TRANSACTION" // 模拟链上事务合约的名称
+)
+
+// 跨链事务在模拟链上的状态，与事务合约一致
+const (
+	StateUnknown         = "Unknown"
+	StateExecuteSuccess  = "ExecuteSuccess"
+	StateExecuteFail     = "ExecuteFail"
+	StateCommitSuccess   = "CommitSuccess"
+	StateCommitFail      = "CommitFail"
+	StateRollbackSuccess = "RollbackSuccess"
+	StateRollbackFail    = "RollbackFail"
+	StateRollbackIgnore  = "RollbackIgnore"
+)
+
+const (
+	CrashExitCode = 3 // 注入崩溃故障时进程的退出码
+)
+
+// TxRequest the payload of simulated transaction, the business contract writes key=value when executing,
+// and restores the previous value when rolling back
+type TxRequest struct {
+	TxID  string `json:"tx_id"`           // 交易ID，同一交易ID重复发送时返回已上链的交易
+	Key   string `json:"key,omitempty"`   // 业务合约写入的键，仅execute使用
+	Value string `json:"value,omitempty"` // 业务合约写入的值，仅execute使用
+
+	ProofTxKey string `json:"-"` // 执行时传入的上一条链证明的交易key，由适配器根据交易事件填写
+}
+
+// NewTxRequest create TxRequest
+func NewTxRequest(txID, key, value string) *TxRequest {
+	return &TxRequest{
+		TxID:  txID,
+		Key:   key,
+		Value: value,
+	}
+}
+
+// Fault the fault injected into the simulated chain
+type Fault struct {
+	Method      string `mapstructure:"method" json:"method,omitempty"`           // 生效的方法，为空表示所有方法
+	CrossID     string `mapstructure:"cross_id" json:"cross_id,omitempty"`       // 生效的跨链ID，为空表示所有跨链事务
+	Latency     int64  `mapstructure:"latency" json:"latency,omitempty"`         // 交易上链前的延迟(ms)
+	Error       string `mapstructure:"error" json:"error,omitempty"`             // 非空时业务合约执行失败，交易以失败状态上链
+	Unavailable bool   `mapstructure:"unavailable" json:"unavailable,omitempty"` // 节点不可用，交易未上链
+	Crash       bool   `mapstructure:"crash" json:"crash,omitempty"`             // 交易上链后进程崩溃，模拟代理在获得结果前退出
+	Times       int    `mapstructure:"times" json:"times,omitempty"`             // 生效次数，0表示一直生效
+}
+
+// match return whether the fault is effective for the call
+func (f *Fault) match(method, crossID string) bool {
+	return (f.Method == "" || f.Method == method) && (f.CrossID == "" || f.CrossID == crossID)
+}
+
+// SimConfig the config of simulated adapter
+type SimConfig struct {
+	StateFile    string   `mapstructure:"state_file"`    // 账本持久化文件，为空时账本仅保存在内存中，重启后丢失
+	BlockLatency int64    `mapstructure:"block_latency"` // 每笔交易的出块延迟(ms)
+	Faults       []*Fault `mapstructure:"faults"`        // 启动时注入的故障
+}
+
+// SimTx the transaction on simulated chain
+type SimTx struct {
+	TxID    string `json:"tx_id"`
+	CrossID string `json:"cross_id"`
+	Method  string `json:"method"`
+	Height  int64  `json:"height"`
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+
+	ProofTxKey string `json:"proof_tx_key,omitempty"` // 执行时传入的上一条链证明的交易key，用于校验证明的传递
+}
+
+// Undo the rollback data which is recorded when executing
+type Undo struct {
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	Existed bool   `json:"existed"` // 执行前键是否存在，不存在时回滚删除该键
+}
+
+// Ledger the state of simulated chain, which is persisted in the state file
+type Ledger struct {
+	Height int64             `json:"height"`
+	States map[string]string `json:"states"` // crossID => 事务状态
+	Data   map[string]string `json:"data"`   // 业务合约数据
+	Undos  map[string]*Undo  `json:"undos"`  // crossID => 回滚数据
+	Proofs map[string]string `json:"proofs"` // crossID.proofKey => 证明
+	Txs    map[string]*SimTx `json:"txs"`    // txID => 交易
+}
+
+// NewLedger create empty ledger
+func NewLedger() *Ledger {
+	return &Ledger{
+		States: make(map[string]string),
+		Data:   make(map[string]string),
+		Undos:  make(map[string]*Undo),
+		Proofs: make(map[string]string),
+		Txs:    make(map[string]*SimTx),
+	}
+}
+
+// GetState return the state of cross transaction
+func (l *Ledger) GetState(crossID string) string {
+	if state, exist := l.States[crossID]; exist {
+		return state
+	}
+	return StateUnknown
+}
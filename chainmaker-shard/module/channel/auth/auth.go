@@ -0,0 +1,336 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package auth
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/event/coder"
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+	"chainmaker.org/chainmaker-cross/utils"
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+const (
+	DefaultReplayWindow = 60 // 默认消息时间戳允许的最大偏差，单位：秒
+
+	// 消息被拒绝的原因
+	ReasonUnsigned     = "unsigned"      // 消息未签名
+	ReasonUntrusted    = "untrusted"     // 签名者不在信任列表中
+	ReasonBadSignature = "bad_signature" // 签名错误
+	ReasonExpired      = "expired"       // 时间戳超出窗口
+	ReasonReplayed     = "replayed"      // 窗口内的重复消息
+	ReasonMalformed    = "malformed"     // 消息格式错误
+)
+
+// RejectError the error of message which is rejected by verification
+type RejectError struct {
+	Reason string // 拒绝原因
+	Msg    string // 详细信息
+}
+
+// Error return the message of error
+func (e *RejectError) Error() string {
+	return fmt.Sprintf("message is rejected for %s, %s", e.Reason, e.Msg)
+}
+
+// ReasonOf return the reject reason of error, the other errors are treated as malformed message
+func ReasonOf(err error) string {
+	var rejectErr *RejectError
+	if errors.As(err, &rejectErr) {
+		return rejectErr.Reason
+	}
+	return ReasonMalformed
+}
+
+func reject(reason, format string, args ...interface{}) error {
+	return &RejectError{Reason: reason, Msg: fmt.Sprintf(format, args...)}
+}
+
+var authenticator *Authenticator
+
+func init() {
+	authenticator = &Authenticator{
+		window: DefaultReplayWindow * time.Second,
+		peers:  make(map[string][]ed25519.PublicKey),
+		nonces: make(map[string]int64),
+		now:    time.Now,
+	}
+}
+
+// GetAuthenticator return the instance of authenticator
+func GetAuthenticator() *Authenticator {
+	return authenticator
+}
+
+// Authenticator sign the messages sent to other proxies by the identity key of current proxy,
+// and verify the messages received from other proxies, it does nothing until enabled
+type Authenticator struct {
+	sync.RWMutex
+	enabled       bool                           // 是否开启
+	privateKey    ed25519.PrivateKey             // 当前代理的身份私钥
+	signer        string                         // 当前代理的身份公钥，hex编码
+	trusted       []ed25519.PublicKey            // 允许通过任意连接发起请求的代理公钥
+	peers         map[string][]ed25519.PublicKey // 对端代理标识(libp2p节点ID或主机) => 只允许通过该连接发起请求的公钥
+	window        time.Duration                  // 时间戳允许的最大偏差
+	allowUnsigned bool                           // 是否接受未签名的消息
+	nonceLock     sync.Mutex                     // 随机数缓存锁
+	nonces        map[string]int64               // 签名者+随机数 => 过期时间
+	lastPurge     int64                          // 上次清理过期随机数的时间
+	now           func() time.Time               // 当前时间
+}
+
+// Init enable the authenticator by the config, the public keys of auth are trusted on any connection,
+// while the public keys of router are only trusted on the connection from the peer of that router
+func (a *Authenticator) Init(config *conf.AuthConfig, routers []*conf.RouterConfig) error {
+	if !config.IsEnabled() {
+		return nil
+	}
+	privateKey, err := utils.LoadPrivateKey(conf.FinalCfgPath(config.KeyFile))
+	if err != nil {
+		return err
+	}
+	trusted, err := utils.ParsePublicKeys(config.TrustedKeys)
+	if err != nil {
+		return err
+	}
+	peers := make(map[string][]ed25519.PublicKey)
+	for _, router := range routers {
+		if len(router.PublicKeys) == 0 {
+			continue
+		}
+		peerID, err := PeerOf(router)
+		if err != nil {
+			return err
+		}
+		keys, err := utils.ParsePublicKeys(router.PublicKeys)
+		if err != nil {
+			return err
+		}
+		peers[peerID] = append(peers[peerID], keys...)
+	}
+	window := config.ReplayWindow
+	if window == 0 {
+		window = DefaultReplayWindow
+	}
+	a.Enable(privateKey, trusted, time.Duration(window)*time.Second, config.AllowUnsigned)
+	a.SetPeerKeys(peers)
+	return nil
+}
+
+// SetPeerKeys set the public keys which are only trusted on the connection from the peer
+func (a *Authenticator) SetPeerKeys(peers map[string][]ed25519.PublicKey) {
+	a.Lock()
+	defer a.Unlock()
+	a.peers = peers
+}
+
+// PeerKeys return the public keys which are trusted on the connection from the peer,
+// the peer is the libp2p node id or the host of remote address
+func (a *Authenticator) PeerKeys(peerID string) []ed25519.PublicKey {
+	a.RLock()
+	defer a.RUnlock()
+	keys := make([]ed25519.PublicKey, 0, len(a.trusted)+len(a.peers[peerID]))
+	keys = append(keys, a.trusted...)
+	return append(keys, a.peers[peerID]...)
+}
+
+// Enable start signing and verifying the messages between proxies
+func (a *Authenticator) Enable(privateKey ed25519.PrivateKey, trusted []ed25519.PublicKey, window time.Duration, allowUnsigned bool) {
+	a.Lock()
+	defer a.Unlock()
+	a.enabled = true
+	a.privateKey = privateKey
+	a.signer = hex.EncodeToString(privateKey.Public().(ed25519.PublicKey))
+	a.trusted = trusted
+	a.window = window
+	a.allowUnsigned = allowUnsigned
+}
+
+// Disable stop signing and verifying the messages, the signed messages are still unwrapped
+func (a *Authenticator) Disable() {
+	a.Lock()
+	defer a.Unlock()
+	a.enabled = false
+	a.privateKey, a.signer, a.trusted = nil, "", nil
+	a.peers = make(map[string][]ed25519.PublicKey)
+}
+
+// IsEnabled return whether the authenticator is enabled
+func (a *Authenticator) IsEnabled() bool {
+	a.RLock()
+	defer a.RUnlock()
+	return a.enabled
+}
+
+// Seal wrap the binary of event into the signed message, the binary is returned directly if not enabled
+func (a *Authenticator) Seal(data []byte) ([]byte, error) {
+	a.RLock()
+	enabled, privateKey, signer := a.enabled, a.privateKey, a.signer
+	a.RUnlock()
+	if !enabled {
+		return data, nil
+	}
+	msg := &eventproto.SignedMessage{
+		Payload:   data,
+		Signer:    signer,
+		Nonce:     utils.NewUUID(),
+		Timestamp: a.now().Unix(),
+	}
+	msg.Signature = hex.EncodeToString(ed25519.Sign(privateKey, MessageHash(msg)))
+	return coder.JsonBinaryMarshal(eventproto.SignedMessageType, msg)
+}
+
+// Open verify the signed message and return the binary of event in it, the signer must be one of the trusted keys,
+// and the global trusted keys are used if it is empty. When not enabled, the signed message is unwrapped without verification
+func (a *Authenticator) Open(data []byte, trusted []ed25519.PublicKey) ([]byte, error) {
+	a.RLock()
+	enabled, window, allowUnsigned := a.enabled, a.window, a.allowUnsigned
+	if len(trusted) == 0 {
+		trusted = a.trusted
+	}
+	a.RUnlock()
+	if !IsSigned(data) {
+		if enabled && !allowUnsigned {
+			return nil, reject(ReasonUnsigned, "the signature is required")
+		}
+		return data, nil
+	}
+	msg := &eventproto.SignedMessage{}
+	if err := coder.JsonBinaryUnmarshal(data, byte(eventproto.SignedMessageType), msg); err != nil {
+		return nil, reject(ReasonMalformed, "unmarshal signed message failed, %v", err)
+	}
+	if !enabled {
+		return msg.Payload, nil
+	}
+	publicKey := findKey(trusted, msg.Signer)
+	if publicKey == nil {
+		return nil, reject(ReasonUntrusted, "signer [%s] is not trusted", msg.Signer)
+	}
+	signature, err := hex.DecodeString(msg.Signature)
+	if err != nil || !ed25519.Verify(publicKey, MessageHash(msg), signature) {
+		return nil, reject(ReasonBadSignature, "signature by [%s] is invalid", msg.Signer)
+	}
+	// 签名通过后再记录随机数，避免伪造的消息占用随机数
+	now := a.now().Unix()
+	if delta := now - msg.Timestamp; delta > int64(window.Seconds()) || -delta > int64(window.Seconds()) {
+		return nil, reject(ReasonExpired, "timestamp %d of signer [%s] is out of window %v", msg.Timestamp, msg.Signer, window)
+	}
+	if !a.checkNonce(msg.Signer+"/"+msg.Nonce, msg.Timestamp+int64(window.Seconds()), now) {
+		return nil, reject(ReasonReplayed, "nonce [%s] of signer [%s] has been used", msg.Nonce, msg.Signer)
+	}
+	return msg.Payload, nil
+}
+
+// SealEncoded seal the base64 encoded binary of event, which is the payload of http message
+func (a *Authenticator) SealEncoded(payload []byte) ([]byte, error) {
+	data, err := utils.Base64DecodeToBytes(string(payload))
+	if err != nil {
+		return nil, err
+	}
+	if data, err = a.Seal(data); err != nil {
+		return nil, err
+	}
+	return []byte(utils.Base64EncodeToString(data)), nil
+}
+
+// OpenEncoded open the base64 encoded signed message, and return the base64 encoded binary of event in it
+func (a *Authenticator) OpenEncoded(payload []byte, trusted []ed25519.PublicKey) ([]byte, error) {
+	data, err := utils.Base64DecodeToBytes(string(payload))
+	if err != nil {
+		return nil, reject(ReasonMalformed, "base64 decode failed, %v", err)
+	}
+	if data, err = a.Open(data, trusted); err != nil {
+		return nil, err
+	}
+	return []byte(utils.Base64EncodeToString(data)), nil
+}
+
+// checkNonce record the nonce until it expires, and return false if it has been recorded,
+// the expired nonces are purged once per second at most
+func (a *Authenticator) checkNonce(key string, expire, now int64) bool {
+	a.nonceLock.Lock()
+	defer a.nonceLock.Unlock()
+	if now > a.lastPurge {
+		for k, e := range a.nonces {
+			if e < now {
+				delete(a.nonces, k)
+			}
+		}
+		a.lastPurge = now
+	}
+	if _, exist := a.nonces[key]; exist {
+		return false
+	}
+	a.nonces[key] = expire
+	return true
+}
+
+// IsSigned return whether the binary is the signed message
+func IsSigned(data []byte) bool {
+	return len(data) > coder.EventTyIndex && eventproto.EventType(data[coder.EventTyIndex]) == eventproto.SignedMessageType
+}
+
+// MessageHash return the hash of signed message which is signed by the sender:
+// sha256(len(payload) | payload | len(signer) | signer | len(nonce) | nonce | timestamp),
+// the lengths are uint32 and timestamp is int64, all in big endian
+func MessageHash(msg *eventproto.SignedMessage) []byte {
+	var buf bytes.Buffer
+	for _, field := range [][]byte{msg.Payload, []byte(msg.Signer), []byte(msg.Nonce)} {
+		_ = binary.Write(&buf, binary.BigEndian, uint32(len(field)))
+		buf.Write(field)
+	}
+	_ = binary.Write(&buf, binary.BigEndian, msg.Timestamp)
+	hash := sha256.Sum256(buf.Bytes())
+	return hash[:]
+}
+
+// findKey return the trusted public key of hex encoded signer
+func findKey(trusted []ed25519.PublicKey, signer string) ed25519.PublicKey {
+	for _, publicKey := range trusted {
+		if strings.EqualFold(signer, hex.EncodeToString(publicKey)) {
+			return publicKey
+		}
+	}
+	return nil
+}
+
+// PeerOf return the identity of the peer proxy of router, which is the libp2p node id in the address of libp2p router,
+// or the host in the address of http router
+func PeerOf(router *conf.RouterConfig) (string, error) {
+	if router.LibP2PRouter != nil && router.LibP2PRouter.Address != "" {
+		parts := strings.Split(router.LibP2PRouter.Address, "/")
+		for i := 0; i+1 < len(parts); i++ {
+			if parts[i] == "p2p" || parts[i] == "ipfs" {
+				id, err := peer.Decode(parts[i+1])
+				if err != nil {
+					return "", fmt.Errorf("invalid peer id in address[%s], %v", router.LibP2PRouter.Address, err)
+				}
+				return id.Pretty(), nil
+			}
+		}
+		return "", fmt.Errorf("peer id is missing in address[%s]", router.LibP2PRouter.Address)
+	}
+	if router.HttpRouter != nil && router.HttpRouter.Address != "" {
+		u, err := url.Parse(router.HttpRouter.Address)
+		if err != nil || u.Hostname() == "" {
+			return "", fmt.Errorf("invalid http address[%s]", router.HttpRouter.Address)
+		}
+		return u.Hostname(), nil
+	}
+	return "", fmt.Errorf("can not identify the peer of router[%s]", router.Name)
+}
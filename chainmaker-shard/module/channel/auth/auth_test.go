@@ -0,0 +1,161 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/event/coder"
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func newAuthenticator(t *testing.T, trusted ...ed25519.PublicKey) (*Authenticator, ed25519.PublicKey) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.Nil(t, err)
+	a := &Authenticator{nonces: make(map[string]int64), now: time.Now}
+	a.Enable(privateKey, trusted, time.Minute, false)
+	return a, publicKey
+}
+
+func TestAuthenticator_SealAndOpen(t *testing.T) {
+	sender, senderKey := newAuthenticator(t)
+	receiver, receiverKey := newAuthenticator(t, senderKey)
+	data := []byte{byte(eventproto.TransactionCtxEventType), 0, '{', '}'}
+
+	sealed, err := sender.Seal(data)
+	require.Nil(t, err)
+	require.True(t, IsSigned(sealed))
+	opened, err := receiver.Open(sealed, nil)
+	require.Nil(t, err)
+	require.Equal(t, data, opened)
+
+	// 重放
+	_, err = receiver.Open(sealed, nil)
+	require.Equal(t, ReasonReplayed, ReasonOf(err))
+	// 路由的信任列表优先于全局信任列表
+	sealed, _ = sender.Seal(data)
+	_, err = receiver.Open(sealed, []ed25519.PublicKey{receiverKey})
+	require.Equal(t, ReasonUntrusted, ReasonOf(err))
+	// 未签名
+	_, err = receiver.Open(data, nil)
+	require.Equal(t, ReasonUnsigned, ReasonOf(err))
+	receiver.allowUnsigned = true
+	opened, err = receiver.Open(data, nil)
+	require.Nil(t, err)
+	require.Equal(t, data, opened)
+	// 篡改
+	msg := &eventproto.SignedMessage{}
+	require.Nil(t, coder.JsonBinaryUnmarshal(sealed, byte(eventproto.SignedMessageType), msg))
+	msg.Payload = []byte("tampered")
+	tampered, _ := coder.JsonBinaryMarshal(eventproto.SignedMessageType, msg)
+	_, err = receiver.Open(tampered, nil)
+	require.Equal(t, ReasonBadSignature, ReasonOf(err))
+	// 过期
+	receiver.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	_, err = receiver.Open(sealed, nil)
+	require.Equal(t, ReasonExpired, ReasonOf(err))
+	// 格式错误
+	_, err = receiver.Open([]byte{byte(eventproto.SignedMessageType), 0, '{'}, nil)
+	require.Equal(t, ReasonMalformed, ReasonOf(err))
+
+	// 未开启时不签名，签名的消息直接解包
+	receiver.Disable()
+	opened, err = receiver.Open(tampered, nil)
+	require.Nil(t, err)
+	require.Equal(t, []byte("tampered"), opened)
+	sealed, err = receiver.Seal(data)
+	require.Nil(t, err)
+	require.Equal(t, data, sealed)
+}
+
+func TestAuthenticator_Encoded(t *testing.T) {
+	sender, senderKey := newAuthenticator(t)
+	receiver, _ := newAuthenticator(t, senderKey)
+	payload := []byte("AAE=")
+	sealed, err := sender.SealEncoded(payload)
+	require.Nil(t, err)
+	opened, err := receiver.OpenEncoded(sealed, nil)
+	require.Nil(t, err)
+	require.Equal(t, payload, opened)
+	_, err = receiver.OpenEncoded([]byte("!"), nil)
+	require.Equal(t, ReasonMalformed, ReasonOf(err))
+}
+
+func TestAuthenticator_Init(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.Nil(t, err)
+	keyFile := filepath.Join(t.TempDir(), "proxy.key")
+	require.Nil(t, ioutil.WriteFile(keyFile, []byte(hex.EncodeToString(privateKey.Seed())), 0600))
+	a := &Authenticator{nonces: make(map[string]int64), now: time.Now}
+	require.Nil(t, a.Init(nil, nil))
+	require.False(t, a.IsEnabled())
+
+	routers := []*conf.RouterConfig{{
+		PublicKeys: []string{hex.EncodeToString(publicKey)},
+		HttpRouter: &conf.HttpRouterConfig{Address: "http://127.0.0.1:8080"},
+	}}
+	require.Nil(t, a.Init(&conf.AuthConfig{Enable: true, KeyFile: keyFile}, routers))
+	require.True(t, a.IsEnabled())
+	require.Equal(t, DefaultReplayWindow*time.Second, a.window)
+	require.Equal(t, hex.EncodeToString(publicKey), a.signer)
+	// 路由的公钥只对该路由对应的连接信任
+	require.Len(t, a.trusted, 0)
+	require.Len(t, a.PeerKeys("127.0.0.1"), 1)
+	require.Len(t, a.PeerKeys("127.0.0.2"), 0)
+	require.NotNil(t, a.Init(&conf.AuthConfig{Enable: true, KeyFile: keyFile + ".missing"}, nil))
+	// 无法确定对端的路由不能配置公钥
+	routers[0].HttpRouter = nil
+	require.NotNil(t, a.Init(&conf.AuthConfig{Enable: true, KeyFile: keyFile}, routers))
+}
+
+func TestAuthenticator_PeerKeys(t *testing.T) {
+	sender, senderKey := newAuthenticator(t)
+	otherSender, otherKey := newAuthenticator(t)
+	receiver, _ := newAuthenticator(t, otherKey)
+	receiver.SetPeerKeys(map[string][]ed25519.PublicKey{"peer1": {senderKey}})
+	data := []byte{byte(eventproto.TransactionCtxEventType), 0, '{', '}'}
+
+	// 路由公钥只能通过对应的连接发起请求
+	sealed, err := sender.Seal(data)
+	require.Nil(t, err)
+	_, err = receiver.Open(sealed, receiver.PeerKeys("peer2"))
+	require.Equal(t, ReasonUntrusted, ReasonOf(err))
+	_, err = receiver.Open(sealed, receiver.PeerKeys("peer1"))
+	require.Nil(t, err)
+	// 全局信任的公钥可通过任意连接发起请求
+	sealed, err = otherSender.Seal(data)
+	require.Nil(t, err)
+	_, err = receiver.Open(sealed, receiver.PeerKeys("peer2"))
+	require.Nil(t, err)
+}
+
+func TestPeerOf(t *testing.T) {
+	_, publicKey, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.Nil(t, err)
+	id, err := peer.IDFromPublicKey(publicKey)
+	require.Nil(t, err)
+	peerID, err := PeerOf(&conf.RouterConfig{
+		LibP2PRouter: &conf.LibP2PRouterConfig{Address: "/ip4/127.0.0.1/tcp/19527/p2p/" + id.Pretty()},
+	})
+	require.Nil(t, err)
+	require.Equal(t, id.Pretty(), peerID)
+	_, err = PeerOf(&conf.RouterConfig{LibP2PRouter: &conf.LibP2PRouterConfig{Address: "/ip4/127.0.0.1/tcp/19527"}})
+	require.NotNil(t, err)
+
+	peerID, err = PeerOf(&conf.RouterConfig{HttpRouter: &conf.HttpRouterConfig{Address: "https://proxy2.example.com:8080"}})
+	require.Nil(t, err)
+	require.Equal(t, "proxy2.example.com", peerID)
+}
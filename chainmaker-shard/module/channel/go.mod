@@ -6,6 +6,7 @@ require (
 	chainmaker.org/chainmaker-cross/conf v0.0.0
 	chainmaker.org/chainmaker-cross/event v0.0.0
 	chainmaker.org/chainmaker-cross/logger v0.0.0
+	chainmaker.org/chainmaker-cross/monitor v0.0.0
 	chainmaker.org/chainmaker-cross/net v0.0.0
 	chainmaker.org/chainmaker-cross/pb/protogo v0.0.0
 	chainmaker.org/chainmaker-cross/utils v0.0.0
@@ -18,6 +19,7 @@ replace (
 	chainmaker.org/chainmaker-cross/conf => ../conf
 	chainmaker.org/chainmaker-cross/event => ../event
 	chainmaker.org/chainmaker-cross/logger => ../logger
+	chainmaker.org/chainmaker-cross/monitor => ../monitor
 	chainmaker.org/chainmaker-cross/net => ../net
 	chainmaker.org/chainmaker-cross/pb/protogo => ../pb/protogo
 	chainmaker.org/chainmaker-cross/utils => ../utils
@@ -6,6 +6,7 @@
 package channel
 
 import (
+	"crypto/ed25519"
 	"time"
 
 	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
@@ -13,10 +14,12 @@ import (
 	"errors"
 	"fmt"
 
+	"chainmaker.org/chainmaker-cross/channel/auth"
 	"chainmaker.org/chainmaker-cross/conf"
 	"chainmaker.org/chainmaker-cross/event"
 	"chainmaker.org/chainmaker-cross/event/coder"
 	"chainmaker.org/chainmaker-cross/logger"
+	"chainmaker.org/chainmaker-cross/monitor"
 	"chainmaker.org/chainmaker-cross/net"
 	"chainmaker.org/chainmaker-cross/net/net_http"
 	"chainmaker.org/chainmaker-cross/net/net_libp2p"
@@ -35,6 +38,7 @@ type NetChannel struct {
 	log        *zap.SugaredLogger           // 日志
 	coders     *coder.EventCoderTools       // 消息编解码器
 	contexts   *event.ProofResponseContexts // 消息证明的Map
+	trusted    []ed25519.PublicKey          // 对端代理的公钥，为空时使用全局信任列表
 }
 
 // NewNetChannel create new net channel
@@ -47,6 +51,11 @@ func NewNetChannel(connection net.Connection) *NetChannel {
 	}
 }
 
+// SetTrustedKeys set the public keys of peer proxy, only the responses signed by these keys are accepted
+func (n *NetChannel) SetTrustedKeys(trusted []ed25519.PublicKey) {
+	n.trusted = trusted
+}
+
 // Init init channel connection
 func (n *NetChannel) Init() error {
 	dataChan, err := n.connection.ReadData()
@@ -81,9 +90,22 @@ func (n *NetChannel) handleReceivedData(msg net.Message) {
 		return
 	}
 	n.log.Debugf("receive data length = %v", len(msg.GetPayload()))
-	receivedData, err := utils.Base64DecodeToBytes(string(msg.GetPayload()))
-	if err != nil {
-		n.log.Error("base64 decode data failed, ", err)
+	// libp2p消息的载荷为二进制，http消息的载荷为base64编码
+	var err error
+	receivedData := msg.GetPayload()
+	if n.connection.GetProvider() != net.LibP2PConnection {
+		if receivedData, err = utils.Base64DecodeToBytes(string(receivedData)); err != nil {
+			n.log.Error("base64 decode data failed, ", err)
+			return
+		}
+	}
+	if receivedData, err = auth.GetAuthenticator().Open(receivedData, n.trusted); err != nil {
+		n.log.Warnf("reject data from peer[%s], %v", n.connection.PeerID(), err)
+		monitor.ObserveRejectedMessage(monitor.MessageSourceChannel, auth.ReasonOf(err))
+		return
+	}
+	if len(receivedData) < MinDataLength {
+		n.log.Error("receive data is illegal")
 		return
 	}
 	eventTy, marshalTy := eventproto.EventType(receivedData[coder.EventTyIndex]), event.MarshalType(receivedData[coder.MarshalTyIndex])
@@ -117,6 +139,14 @@ func (n *NetChannel) handleReceivedData(msg net.Message) {
 	n.log.Errorf("the event type = [%v] which id not ProofRespEvent", eventTy)
 }
 
+// Ping check whether the peer proxy is reachable, the connection which can not be probed is treated as reachable
+func (n *NetChannel) Ping(timeout time.Duration) error {
+	if pinger, ok := n.connection.(net.Pinger); ok {
+		return pinger.Ping(timeout)
+	}
+	return nil
+}
+
 // GetChanType return type of channel
 func (n *NetChannel) GetChanType() TransmissionChanType {
 	return NetTransmissionChan
@@ -137,21 +167,32 @@ func (n *NetChannel) Deliver(eve *event.TransactionEventContext) error {
 				eve.GetEvent().GetCrossID(), eve.GetEvent().GetChainID(), eve.GetKey(), err)
 			return err
 		}
-		// 将binary转换为Base64
-		base64String := utils.Base64EncodeToString(binary)
+		if binary, err = auth.GetAuthenticator().Seal(binary); err != nil {
+			n.log.Errorf("cross[%s]->chain[%s]->key[%s] sign binary bytes failed, %v",
+				eve.GetEvent().GetCrossID(), eve.GetEvent().GetChainID(), eve.GetKey(), err)
+			return err
+		}
+		// 二进制由libp2p连接按协商的协议编码
 		n.log.Infof("cross[%s]->chain[%s]->key[%s] begin write to net channel, length = [%v]",
-			eve.GetEvent().GetCrossID(), eve.GetEvent().GetChainID(), eve.GetKey(), len(base64String))
-		if msg, err = net_libp2p.NewLibP2pMessage(n.connection.PeerID(), []byte(base64String), false); err != nil {
+			eve.GetEvent().GetCrossID(), eve.GetEvent().GetChainID(), eve.GetKey(), len(binary))
+		if msg, err = net_libp2p.NewLibP2pMessage(n.connection.PeerID(), binary, false); err != nil {
 			n.log.Errorf("cross[%s]->chain[%s]->key[%s] generate LibP2p_message error, ",
 				eve.GetEvent().GetCrossID(), eve.GetEvent().GetChainID(), eve.GetKey(), err)
 		}
 	case net.HttpConnection:
 		//router, ok := conf.Config.RouterConfigs.RouterConfigs
-		if msg, err = net_http.NewRequest(eve, HttpCrossTransactionRouter, event.BinaryMarshalType); err != nil {
+		req, err := net_http.NewRequest(eve, HttpCrossTransactionRouter, event.BinaryMarshalType)
+		if err != nil {
 			n.log.Errorf("cross[%s]->chain[%s]->key[%s] generate http_message error, ",
 				eve.GetEvent().GetCrossID(), eve.GetEvent().GetChainID(), eve.GetKey(), err)
 			return err
 		}
+		if req.Payload, err = auth.GetAuthenticator().SealEncoded(req.Payload); err != nil {
+			n.log.Errorf("cross[%s]->chain[%s]->key[%s] sign http_message failed, %v",
+				eve.GetEvent().GetCrossID(), eve.GetEvent().GetChainID(), eve.GetKey(), err)
+			return err
+		}
+		msg = req
 	default:
 		err = errors.New(fmt.Sprintf("unsupported connection provider #{n.connection.Provider()}"))
 		n.log.Errorf("cross[%s]->chain[%s]->key[%s] write to channel failed, ",
@@ -160,6 +201,7 @@ func (n *NetChannel) Deliver(eve *event.TransactionEventContext) error {
 	}
 
 	err = n.connection.WriteData(msg)
+	monitor.SetConnectionState(string(n.connection.GetProvider()), n.connection.PeerID(), err == nil)
 	if err != nil {
 		n.log.Errorf("cross[%s]->chain[%s]->key[%s] write to net channel failed, ",
 			eve.GetEvent().GetCrossID(), eve.GetEvent().GetChainID(), eve.GetKey(), err)
@@ -13,10 +13,9 @@ import (
 
 	"chainmaker.org/chainmaker-cross/conf"
 	"chainmaker.org/chainmaker-cross/event"
-	"chainmaker.org/chainmaker-cross/event/coder"
 	"chainmaker.org/chainmaker-cross/net"
 	"chainmaker.org/chainmaker-cross/net/net_libp2p"
-	"chainmaker.org/chainmaker-cross/utils"
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
 	"github.com/libp2p/go-libp2p-core/protocol"
 	"github.com/stretchr/testify/require"
 )
@@ -80,46 +79,7 @@ func TestNetChannelInitReadData(t *testing.T) {
 			select {
 			case msg := <-dataChan:
 				// 从通道中读到数据
-				if len(msg.GetPayload()) < MinDataLength {
-					// 打印错误信息
-					n.log.Error("receive data is illegal")
-				} else {
-					n.log.Debugf("receive data length = %v", len(msg.GetPayload()))
-					receivedData, err := utils.Base64DecodeToBytes(string(msg.GetPayload()))
-					if err != nil {
-						n.log.Error("base64 decode data failed, ", err)
-						continue
-					}
-					eventTy, marshalTy := event.EventType(receivedData[coder.EventTyIndex]), event.MarshalType(receivedData[coder.MarshalTyIndex])
-					if eventTy == event.ProofRespEventType {
-						if eveCoder, exist := n.coders.GetDefaultCoder(eventTy); exist {
-							// 处理
-							if marshalTy == event.BinaryMarshalType {
-								eve, err := eveCoder.UnmarshalFromBinary(receivedData)
-								if err != nil {
-									// 打印错误信息
-									n.log.Error("unmarshal receive data failed, ", err)
-								} else {
-									if resp, ok := eve.(*event.ProofResponse); ok {
-										// 填充结果
-										if resp.Code == event.SuccessResp {
-											n.log.Infof("cross[%s]->chain[%s]->key[%s] response is success",
-												resp.CrossID, resp.ChainID, resp.Key)
-											// 操作成功，填充结果
-											n.contexts.DoneByProofResp(resp)
-										} else {
-											n.log.Errorf("cross[%s]->chain[%s]->key[%s] response is failed",
-												resp.CrossID, resp.ChainID, resp.Key)
-											n.contexts.DoneError(resp.Key, resp.Msg)
-										}
-									}
-								}
-							}
-						}
-					} else {
-						n.log.Errorf("")
-					}
-				}
+				n.handleReceivedData(msg)
 			case <-time.After(conf.LogWritePeriod):
 				// 打印日志，表明在正常活着
 				n.log.Info("net channel is running periodically!")
@@ -150,7 +110,7 @@ func TestNetChannel(t *testing.T) {
 	require.Equal(t, cType, NetTransmissionChan)
 
 	// test deliver
-	err = nc.Deliver(&event.TransactionEventContext{Key: "test", Event: &event.TransactionEvent{}})
+	err = nc.Deliver(&event.TransactionEventContext{Key: "test", Event: &eventproto.TransactionEvent{}})
 	require.NoError(t, err)
 }
 
@@ -0,0 +1,321 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package chaos inject crashes, errors and delays at the named points of the proxy by the scenario file,
+// which is used to verify that the restart recovery brings every cross transaction to a consistent terminal state
+package chaos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"chainmaker.org/chainmaker-cross/logger"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// Point the named point where the fault can be injected
+type Point string
+
+// 可注入故障的位置
+const (
+	AfterExecute     Point = "transaction.after_execute"     // 交易执行成功后，结果记录前
+	BeforeSaveProof  Point = "transaction.before_save_proof" // 证明保存到上一条链前
+	MidCommit        Point = "transaction.mid_commit"        // 单条链提交成功后，链状态记录前
+	MidRollback      Point = "transaction.mid_rollback"      // 单条链回滚成功后，链状态记录前
+	BeforeInvoke     Point = "router.before_invoke"          // 交易事件路由前，交易未发送
+	AfterInvoke      Point = "router.after_invoke"           // 交易事件路由后，结果未返回
+	BeforeStateWrite Point = "store.before_write"            // 状态写入存储前
+	AfterStateWrite  Point = "store.after_write"             // 状态写入存储后，结果未返回
+)
+
+var points = map[Point]struct{}{
+	AfterExecute: {}, BeforeSaveProof: {}, MidCommit: {}, MidRollback: {},
+	BeforeInvoke: {}, AfterInvoke: {}, BeforeStateWrite: {}, AfterStateWrite: {},
+}
+
+// Action the fault which is injected
+type Action string
+
+const (
+	ActionCrash Action = "crash" // 进程立即退出
+	ActionError Action = "error" // 返回错误
+	ActionDelay Action = "delay" // 延迟后继续执行
+
+	CrashExitCode = 3 // 注入崩溃时进程的退出码
+)
+
+// Rule the rule of fault injection, the empty filter matches all
+type Rule struct {
+	Name    string `mapstructure:"name" json:"name"`         // 规则名称，用于记录命中次数，默认为规则序号
+	Point   Point  `mapstructure:"point" json:"point"`       // 注入位置
+	Action  Action `mapstructure:"action" json:"action"`     // 注入的故障
+	CrossID string `mapstructure:"cross_id" json:"cross_id"` // 生效的跨链ID
+	ChainID string `mapstructure:"chain_id" json:"chain_id"` // 生效的链ID
+	Op      string `mapstructure:"op" json:"op"`             // 生效的操作，router为OpFunc(如CommitOpFunc)，store为目标状态(如StateCommitSuccess)
+	Skip    int    `mapstructure:"skip" json:"skip"`         // 跳过前skip次命中
+	Times   int    `mapstructure:"times" json:"times"`       // 生效次数，0表示一直生效
+	Delay   int64  `mapstructure:"delay" json:"delay"`       // 延迟时间(ms)，仅delay使用
+	Message string `mapstructure:"message" json:"message"`   // 错误信息，仅error使用
+}
+
+// Scenario the scenario of fault injection, the first matched rule takes effect
+type Scenario struct {
+	Name  string  `mapstructure:"name" json:"name"`
+	Rules []*Rule `mapstructure:"rules" json:"rules"`
+}
+
+// InjectedError the error which is injected by rule
+type InjectedError struct {
+	Point   Point
+	Rule    string
+	Message string
+}
+
+// Error return the message of injected error
+func (e *InjectedError) Error() string {
+	return fmt.Sprintf("chaos rule [%s] injected error at %s: %s", e.Rule, e.Point, e.Message)
+}
+
+// IsInjected return whether the error is injected by chaos
+func IsInjected(err error) bool {
+	var injectedErr *InjectedError
+	return errors.As(err, &injectedErr)
+}
+
+// LoadScenario load the scenario from yml file and validate it
+func LoadScenario(scenarioFile string) (*Scenario, error) {
+	scenarioViper := viper.New()
+	scenarioViper.SetConfigFile(scenarioFile)
+	if err := scenarioViper.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("read chaos scenario [%s] failed, %v", scenarioFile, err)
+	}
+	scenario := &Scenario{}
+	if err := scenarioViper.Unmarshal(scenario); err != nil {
+		return nil, fmt.Errorf("unmarshal chaos scenario [%s] failed, %v", scenarioFile, err)
+	}
+	if err := scenario.Validate(); err != nil {
+		return nil, err
+	}
+	return scenario, nil
+}
+
+// Validate check the points and actions of rules
+func (s *Scenario) Validate() error {
+	names := make(map[string]struct{}, len(s.Rules))
+	for i, rule := range s.Rules {
+		if rule.Name == "" {
+			rule.Name = strconv.Itoa(i)
+		}
+		if _, exist := names[rule.Name]; exist {
+			return fmt.Errorf("duplicated chaos rule name [%s]", rule.Name)
+		}
+		names[rule.Name] = struct{}{}
+		if _, exist := points[rule.Point]; !exist {
+			return fmt.Errorf("unknown point [%s] of chaos rule [%s]", rule.Point, rule.Name)
+		}
+		switch rule.Action {
+		case ActionCrash, ActionError:
+		case ActionDelay:
+			if rule.Delay <= 0 {
+				return fmt.Errorf("delay of chaos rule [%s] should be positive", rule.Name)
+			}
+		default:
+			return fmt.Errorf("unknown action [%s] of chaos rule [%s], it should be one of %s, %s and %s",
+				rule.Action, rule.Name, ActionCrash, ActionError, ActionDelay)
+		}
+		if rule.Skip < 0 || rule.Times < 0 {
+			return fmt.Errorf("skip and times of chaos rule [%s] can not be negative", rule.Name)
+		}
+	}
+	return nil
+}
+
+// match return whether the rule is effective for the call
+func (r *Rule) match(point Point, crossID, chainID, op string) bool {
+	return r.Point == point && (r.CrossID == "" || r.CrossID == crossID) &&
+		(r.ChainID == "" || r.ChainID == chainID) && (r.Op == "" || r.Op == op)
+}
+
+var injector *Injector
+
+func init() {
+	injector = &Injector{
+		hits: make(map[string]int),
+		crashFn: func() {
+			os.Exit(CrashExitCode)
+		},
+	}
+}
+
+// Injector inject the faults by the scenario, it does nothing until enabled
+type Injector struct {
+	sync.Mutex
+	enabled   int32              // 是否开启
+	scenario  *Scenario          // 注入场景
+	hits      map[string]int     // 规则名称 => 命中次数
+	stateFile string             // 命中次数持久化文件，重启后崩溃规则不再重复生效，为空时不持久化
+	crashFn   func()             // 崩溃处理，默认退出进程
+	logger    *zap.SugaredLogger // log
+}
+
+// GetInjector return the instance of injector
+func GetInjector() *Injector {
+	return injector
+}
+
+// Inject inject the fault at the point by the global injector
+func Inject(point Point, crossID, chainID, op string) error {
+	return injector.Inject(point, crossID, chainID, op)
+}
+
+// Enable start injecting the faults of the scenario, the hits are loaded from the state file if it exists
+func (i *Injector) Enable(scenario *Scenario, stateFile string) error {
+	if err := scenario.Validate(); err != nil {
+		return err
+	}
+	hits := make(map[string]int)
+	if stateFile != "" {
+		content, err := ioutil.ReadFile(stateFile)
+		if err == nil {
+			if err = json.Unmarshal(content, &hits); err != nil {
+				return fmt.Errorf("unmarshal chaos state [%s] failed, %v", stateFile, err)
+			}
+		} else if !os.IsNotExist(err) {
+			return err
+		}
+	}
+	i.Lock()
+	defer i.Unlock()
+	if i.logger == nil {
+		i.logger = logger.GetLogger(logger.ModuleServer)
+	}
+	i.scenario = scenario
+	i.hits = hits
+	i.stateFile = stateFile
+	atomic.StoreInt32(&i.enabled, 1)
+	i.logger.Warnf("chaos scenario [%s] is enabled with %d rules, do not use it in production", scenario.Name, len(scenario.Rules))
+	return nil
+}
+
+// Disable stop injecting the faults
+func (i *Injector) Disable() {
+	atomic.StoreInt32(&i.enabled, 0)
+	i.Lock()
+	defer i.Unlock()
+	i.scenario = nil
+	i.hits = make(map[string]int)
+}
+
+// IsEnabled return whether the injector is enabled
+func (i *Injector) IsEnabled() bool {
+	return atomic.LoadInt32(&i.enabled) == 1
+}
+
+// SetCrashHandler set the handler which is called when the crash is injected
+func (i *Injector) SetCrashHandler(crashFn func()) {
+	i.Lock()
+	defer i.Unlock()
+	i.crashFn = crashFn
+}
+
+// SetLogger set logger
+func (i *Injector) SetLogger(logger *zap.SugaredLogger) {
+	i.Lock()
+	defer i.Unlock()
+	i.logger = logger
+}
+
+// Hits return the hit count of the rule
+func (i *Injector) Hits(name string) int {
+	i.Lock()
+	defer i.Unlock()
+	return i.hits[name]
+}
+
+// Inject inject the fault at the point, the injected error is returned, nil is returned when nothing is injected
+func (i *Injector) Inject(point Point, crossID, chainID, op string) error {
+	if !i.IsEnabled() {
+		return nil
+	}
+	rule, crashFn := i.take(point, crossID, chainID, op)
+	if rule == nil {
+		return nil
+	}
+	switch rule.Action {
+	case ActionDelay:
+		i.logger.Warnf("chaos rule [%s] delay %dms at %s, cross[%s]->chain[%s]", rule.Name, rule.Delay, point, crossID, chainID)
+		time.Sleep(time.Duration(rule.Delay) * time.Millisecond)
+	case ActionError:
+		i.logger.Warnf("chaos rule [%s] inject error at %s, cross[%s]->chain[%s]", rule.Name, point, crossID, chainID)
+		message := rule.Message
+		if message == "" {
+			message = "injected error"
+		}
+		return &InjectedError{Point: point, Rule: rule.Name, Message: message}
+	case ActionCrash:
+		i.logger.Warnf("chaos rule [%s] crash at %s, cross[%s]->chain[%s]", rule.Name, point, crossID, chainID)
+		_ = i.logger.Sync()
+		if crashFn != nil {
+			crashFn()
+		}
+	}
+	return nil
+}
+
+// take return the first rule which takes effect and increase its hits
+func (i *Injector) take(point Point, crossID, chainID, op string) (*Rule, func()) {
+	i.Lock()
+	defer i.Unlock()
+	if i.scenario == nil {
+		return nil, nil
+	}
+	for _, rule := range i.scenario.Rules {
+		if !rule.match(point, crossID, chainID, op) {
+			continue
+		}
+		if rule.Times > 0 && i.hits[rule.Name] >= rule.Skip+rule.Times {
+			// 已失效
+			continue
+		}
+		i.hits[rule.Name]++
+		// 崩溃前先记录命中次数，重启后不再重复生效
+		if err := i.persist(); err != nil {
+			i.logger.Errorf("persist chaos state [%s] failed, %v", i.stateFile, err)
+		}
+		if i.hits[rule.Name] <= rule.Skip {
+			continue
+		}
+		return rule, i.crashFn
+	}
+	return nil, nil
+}
+
+// persist write the hits to the state file atomically
+func (i *Injector) persist() error {
+	if i.stateFile == "" {
+		return nil
+	}
+	content, err := json.Marshal(i.hits)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(i.stateFile), 0755); err != nil {
+		return err
+	}
+	tmpFile := i.stateFile + ".tmp"
+	if err = ioutil.WriteFile(tmpFile, content, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, i.stateFile)
+}
@@ -0,0 +1,110 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaos
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestInjector(t *testing.T, stateFile string, rules ...*Rule) (*Injector, *int) {
+	crashed := 0
+	i := &Injector{hits: make(map[string]int)}
+	i.SetCrashHandler(func() {
+		crashed++
+	})
+	require.Nil(t, i.Enable(&Scenario{Name: "test", Rules: rules}, stateFile))
+	return i, &crashed
+}
+
+func TestScenario_Validate(t *testing.T) {
+	scenario := &Scenario{Rules: []*Rule{{Point: MidCommit, Action: ActionCrash}}}
+	require.Nil(t, scenario.Validate())
+	require.Equal(t, "0", scenario.Rules[0].Name)
+
+	require.NotNil(t, (&Scenario{Rules: []*Rule{{Point: "unknown", Action: ActionCrash}}}).Validate())
+	require.NotNil(t, (&Scenario{Rules: []*Rule{{Point: MidCommit, Action: "unknown"}}}).Validate())
+	require.NotNil(t, (&Scenario{Rules: []*Rule{{Point: MidCommit, Action: ActionDelay}}}).Validate())
+	require.NotNil(t, (&Scenario{Rules: []*Rule{{Point: MidCommit, Action: ActionError, Times: -1}}}).Validate())
+	require.NotNil(t, (&Scenario{Rules: []*Rule{
+		{Name: "rule", Point: MidCommit, Action: ActionCrash},
+		{Name: "rule", Point: MidCommit, Action: ActionError},
+	}}).Validate())
+}
+
+func TestLoadScenario(t *testing.T) {
+	dir, err := ioutil.TempDir("", "chaos")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	scenarioFile := filepath.Join(dir, "chaos.yml")
+	content := "name: commit\nrules:\n  - name: crash-commit\n    point: transaction.mid_commit\n    action: crash\n    chain_id: chain1\n    times: 1\n"
+	require.Nil(t, ioutil.WriteFile(scenarioFile, []byte(content), 0644))
+
+	scenario, err := LoadScenario(scenarioFile)
+	require.Nil(t, err)
+	require.Equal(t, "commit", scenario.Name)
+	require.Equal(t, MidCommit, scenario.Rules[0].Point)
+	require.Equal(t, "chain1", scenario.Rules[0].ChainID)
+
+	_, err = LoadScenario(filepath.Join(dir, "notExist.yml"))
+	require.NotNil(t, err)
+}
+
+func TestInjector_Inject(t *testing.T) {
+	i, crashed := newTestInjector(t, "",
+		&Rule{Name: "error", Point: BeforeInvoke, Action: ActionError, ChainID: "chain1", Op: "CommitOpFunc", Skip: 1, Times: 2, Message: "network error"},
+		&Rule{Name: "crash", Point: MidCommit, Action: ActionCrash, CrossID: "cross1"},
+		&Rule{Name: "delay", Point: AfterExecute, Action: ActionDelay, Delay: 10},
+	)
+
+	// 跳过第一次命中，之后生效两次
+	require.Nil(t, i.Inject(BeforeInvoke, "cross1", "chain1", "CommitOpFunc"))
+	err := i.Inject(BeforeInvoke, "cross1", "chain1", "CommitOpFunc")
+	require.EqualError(t, err, "chaos rule [error] injected error at router.before_invoke: network error")
+	require.True(t, IsInjected(err))
+	require.NotNil(t, i.Inject(BeforeInvoke, "cross2", "chain1", "CommitOpFunc"))
+	require.Nil(t, i.Inject(BeforeInvoke, "cross3", "chain1", "CommitOpFunc"))
+	require.Equal(t, 3, i.Hits("error"))
+	require.Nil(t, i.Inject(BeforeInvoke, "cross1", "chain2", "CommitOpFunc"))
+	require.Nil(t, i.Inject(BeforeInvoke, "cross1", "chain1", "ExecuteOpFunc"))
+
+	require.Nil(t, i.Inject(MidCommit, "cross2", "chain1", ""))
+	require.Equal(t, 0, *crashed)
+	require.Nil(t, i.Inject(MidCommit, "cross1", "chain1", ""))
+	require.Equal(t, 1, *crashed)
+
+	start := time.Now()
+	require.Nil(t, i.Inject(AfterExecute, "cross1", "chain1", ""))
+	require.True(t, time.Since(start) >= 10*time.Millisecond)
+
+	i.Disable()
+	require.False(t, i.IsEnabled())
+	require.Nil(t, i.Inject(MidCommit, "cross1", "chain1", ""))
+	require.Equal(t, 1, *crashed)
+}
+
+func TestInjector_Persist(t *testing.T) {
+	dir, err := ioutil.TempDir("", "chaos")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	stateFile := filepath.Join(dir, "state", "chaos.json")
+	rule := &Rule{Name: "crash", Point: AfterStateWrite, Action: ActionCrash, Op: "StateCommitSuccess", Times: 1}
+
+	i, crashed := newTestInjector(t, stateFile, rule)
+	require.Nil(t, i.Inject(AfterStateWrite, "cross1", "chain1", "StateCommitSuccess"))
+	require.Equal(t, 1, *crashed)
+
+	// 重启后已生效的规则不再重复生效
+	restarted, crashedAgain := newTestInjector(t, stateFile, rule)
+	require.Equal(t, 1, restarted.Hits("crash"))
+	require.Nil(t, restarted.Inject(AfterStateWrite, "cross1", "chain1", "StateCommitSuccess"))
+	require.Equal(t, 0, *crashedAgain)
+}
@@ -36,6 +36,41 @@ func InitLocalConfig(cmd *cobra.Command) error {
 	}
 	// 2. set log config
 	logger.InitLogConfig(config.LogConfig)
+	// 存储配置错误时拒绝启动，避免跨链事务丢失
+	if err = config.StorageConfig.Validate(); err != nil {
+		return err
+	}
+	if err = config.HAConfig.Validate(config.StorageConfig); err != nil {
+		return err
+	}
+	// 证明器配置错误或链未配置证明器时拒绝启动，避免证明被默认信任
+	if err = config.ProverConfigs.Validate(config.AdapterConfigs); err != nil {
+		return err
+	}
+	// 分割符或分帧配置错误时拒绝启动
+	if listener := config.ListenerConfig; listener != nil && listener.ChannelConfig != nil {
+		if err = listener.ChannelConfig.LibP2PChannel.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, router := range config.RouterConfigs {
+		if err = router.LibP2PRouter.Validate(); err != nil {
+			return err
+		}
+	}
+	if err = config.RoutingConfig.Validate(); err != nil {
+		return err
+	}
+	if err = config.Discovery.Validate(config.ListenerConfig); err != nil {
+		return err
+	}
+	if err = config.AuthConfig.Validate(config.RouterConfigs); err != nil {
+		return err
+	}
+	// 故障场景错误时拒绝启动
+	if err = config.ChaosConfig.Validate(); err != nil {
+		return err
+	}
 	// 3. set global config and export
 	Config = config
 	return nil
@@ -8,6 +8,7 @@ require (
 	github.com/spf13/pflag v1.0.5
 	github.com/spf13/viper v1.7.1
 	github.com/stretchr/testify v1.4.0
+	go.uber.org/zap v1.16.0
 )
 
 replace chainmaker.org/chainmaker-cross/logger => ../logger
@@ -0,0 +1,119 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package conf
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+const (
+	DefaultRetryMaxAttempts    = 5000      // 默认最大重试次数
+	DefaultRetryInitialBackoff = 15 * 1000 // 默认首次重试间隔(ms)
+	DefaultRetryMultiplier     = 1         // 默认重试间隔增长倍数，1表示固定间隔
+	MaxRetryJitter             = 1         // 最大随机抖动比例
+
+	DefaultExecuteTimeout = int64(TxMsgResultMaxWaitTimeout / time.Millisecond) // 默认执行阶段等待结果的时间(ms)
+)
+
+// RetryPolicy retry policy of the cross transaction or adapter, zero value means using the default value
+type RetryPolicy struct {
+	MaxAttempts     int     `mapstructure:"max_attempts"`     // 最大重试次数
+	InitialBackoff  int64   `mapstructure:"initial_backoff"`  // 首次重试间隔(ms)
+	MaxBackoff      int64   `mapstructure:"max_backoff"`      // 最大重试间隔(ms)，0表示不限制
+	Multiplier      float64 `mapstructure:"multiplier"`       // 重试间隔增长倍数，1表示固定间隔
+	Jitter          float64 `mapstructure:"jitter"`           // 重试间隔随机抖动比例，取值[0,1]
+	ExecuteTimeout  int64   `mapstructure:"execute_timeout"`  // 执行阶段等待结果的时间(ms)
+	CommitTimeout   int64   `mapstructure:"commit_timeout"`   // 提交阶段重试的截止时间(ms)，0表示不限制
+	RollbackTimeout int64   `mapstructure:"rollback_timeout"` // 回滚阶段重试的截止时间(ms)，0表示不限制
+}
+
+// DefaultRetryPolicy return the default retry policy of cross transaction
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    DefaultRetryMaxAttempts,
+		InitialBackoff: DefaultRetryInitialBackoff,
+		Multiplier:     DefaultRetryMultiplier,
+		ExecuteTimeout: DefaultExecuteTimeout,
+	}
+}
+
+// GetRetryPolicy return the retry policy of cross transaction which is merged with default value
+func (c *LocalConf) GetRetryPolicy() *RetryPolicy {
+	if c == nil {
+		return DefaultRetryPolicy()
+	}
+	return DefaultRetryPolicy().Merge(c.RetryPolicy)
+}
+
+// Merge return a new retry policy, the non-zero fields of other will override the fields of p
+func (p *RetryPolicy) Merge(other *RetryPolicy) *RetryPolicy {
+	merged := *p
+	if other == nil {
+		return &merged
+	}
+	if other.MaxAttempts > 0 {
+		merged.MaxAttempts = other.MaxAttempts
+	}
+	if other.InitialBackoff > 0 {
+		merged.InitialBackoff = other.InitialBackoff
+	}
+	if other.MaxBackoff > 0 {
+		merged.MaxBackoff = other.MaxBackoff
+	}
+	if other.Multiplier > 0 {
+		merged.Multiplier = other.Multiplier
+	}
+	if other.Jitter > 0 {
+		merged.Jitter = math.Min(other.Jitter, MaxRetryJitter)
+	}
+	if other.ExecuteTimeout > 0 {
+		merged.ExecuteTimeout = other.ExecuteTimeout
+	}
+	if other.CommitTimeout > 0 {
+		merged.CommitTimeout = other.CommitTimeout
+	}
+	if other.RollbackTimeout > 0 {
+		merged.RollbackTimeout = other.RollbackTimeout
+	}
+	return &merged
+}
+
+// Backoff return the wait duration before the attempt, attempt starts from 1
+func (p *RetryPolicy) Backoff(attempt uint) time.Duration {
+	if attempt == 0 {
+		return 0
+	}
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	backoff := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		// 在[backoff*(1-jitter), backoff*(1+jitter)]之间随机
+		backoff += backoff * p.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(backoff) * time.Millisecond
+}
+
+// GetExecuteTimeout return the duration of waiting for the execute result
+func (p *RetryPolicy) GetExecuteTimeout() time.Duration {
+	return time.Duration(p.ExecuteTimeout) * time.Millisecond
+}
+
+// GetCommitTimeout return the deadline duration of commit retries, 0 means no deadline
+func (p *RetryPolicy) GetCommitTimeout() time.Duration {
+	return time.Duration(p.CommitTimeout) * time.Millisecond
+}
+
+// GetRollbackTimeout return the deadline duration of rollback retries, 0 means no deadline
+func (p *RetryPolicy) GetRollbackTimeout() time.Duration {
+	return time.Duration(p.RollbackTimeout) * time.Millisecond
+}
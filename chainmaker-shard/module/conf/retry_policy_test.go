@@ -0,0 +1,58 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package conf
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultRetryPolicy(t *testing.T) {
+	var c *LocalConf
+	policy := c.GetRetryPolicy()
+	require.Equal(t, DefaultRetryMaxAttempts, policy.MaxAttempts)
+	require.Equal(t, TxMsgResultMaxWaitTimeout, policy.GetExecuteTimeout())
+	require.Equal(t, 15*time.Second, policy.Backoff(1))
+	require.Equal(t, 15*time.Second, policy.Backoff(100))
+	require.Equal(t, time.Duration(0), policy.GetCommitTimeout())
+}
+
+func TestRetryPolicy_Merge(t *testing.T) {
+	base := DefaultRetryPolicy()
+	merged := base.Merge(&RetryPolicy{
+		MaxAttempts:   3,
+		Jitter:        2,
+		CommitTimeout: 1000,
+	})
+	require.Equal(t, 3, merged.MaxAttempts)
+	require.Equal(t, float64(MaxRetryJitter), merged.Jitter)
+	require.Equal(t, time.Second, merged.GetCommitTimeout())
+	require.Equal(t, base.InitialBackoff, merged.InitialBackoff)
+	// 原策略不变
+	require.Equal(t, DefaultRetryMaxAttempts, base.MaxAttempts)
+	require.Equal(t, *base, *base.Merge(nil))
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := &RetryPolicy{
+		InitialBackoff: 100,
+		MaxBackoff:     1000,
+		Multiplier:     2,
+	}
+	require.Equal(t, time.Duration(0), policy.Backoff(0))
+	require.Equal(t, 100*time.Millisecond, policy.Backoff(1))
+	require.Equal(t, 200*time.Millisecond, policy.Backoff(2))
+	require.Equal(t, 800*time.Millisecond, policy.Backoff(4))
+	require.Equal(t, time.Second, policy.Backoff(10))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		backoff := policy.Backoff(1)
+		require.True(t, backoff >= 50*time.Millisecond && backoff <= 150*time.Millisecond)
+	}
+}
@@ -6,24 +6,70 @@
 package conf
 
 import (
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
+	"chainmaker.org/chainmaker-cross/conf/chaos"
 	"chainmaker.org/chainmaker-cross/logger"
 )
 
 const (
 	StringToByteIndex = 0
+	DefaultDelimit    = '\n' // 未配置时的消息分割符
+)
+
+const (
+	CompressionNone = "none" // 不压缩
+	CompressionGzip = "gzip" // gzip压缩
+)
+
+const (
+	StorageLevelDB  = "leveldb" // leveldb存储
+	StorageBadger   = "badger"  // badger存储
+	StorageSQL      = "sql"     // sql数据库存储，可供多个运维人员查看状态
+	StorageMemory   = "memory"  // 内存存储，重启后数据丢失，仅用于测试
+	SQLDriverMySQL  = "mysql"   // mysql驱动
+	SQLDriverSQLite = "sqlite3" // sqlite驱动
+)
+
+const (
+	ProverTrust       = "trust"        // 不验证证明
+	ProverSpv         = "spv"          // spv节点验证证明
+	ProverLightClient = "light_client" // 内置轻客户端验证证明
+	ProverAttestation = "attestation"  // 多代理签名背书验证证明
+
+	ProofPolicyRequired = "required" // 必须携带证明且验证通过
+	ProofPolicyOptional = "optional" // 未携带证明时放行，携带时必须验证通过
+	ProofPolicyTrust    = "trust"    // 不验证证明，仅用于完全信任的链
+)
+
+const (
+	RouteStrategyPriority     = "priority"      // 按优先级选择，优先级相同时按配置顺序
+	RouteStrategyRoundRobin   = "round_robin"   // 轮询
+	RouteStrategyLeastLatency = "least_latency" // 选择平均时延最低的路由
 )
 
 // LocalConf Local config struct
 type LocalConf struct {
-	ListenerConfig *ListenerConfig           `mapstructure:"listener"` // 本地服务配置
-	AdapterConfigs AdapterConfigs            `mapstructure:"adapters"` // 转接器配置
-	RouterConfigs  []*RouterConfig           `mapstructure:"routers"`  // 路由配置
-	ProverConfigs  []*ProverConfig           `mapstructure:"provers"`  // 证明器配置
-	StorageConfig  *StorageConfig            `mapstructure:"storage"`  // 存储配置
-	LogConfig      []*logger.LogModuleConfig `mapstructure:"log"`      // 日志配置
+	ListenerConfig *ListenerConfig           `mapstructure:"listener"`      // 本地服务配置
+	AdapterConfigs AdapterConfigs            `mapstructure:"adapters"`      // 转接器配置
+	RouterConfigs  []*RouterConfig           `mapstructure:"routers"`       // 路由配置
+	ProverConfigs  ProverConfigs             `mapstructure:"provers"`       // 证明器配置
+	StorageConfig  *StorageConfig            `mapstructure:"storage"`       // 存储配置
+	LogConfig      []*logger.LogModuleConfig `mapstructure:"log"`           // 日志配置
+	MonitorConfig  *MonitorConfig            `mapstructure:"monitor"`       // 监控配置
+	RetryPolicy    *RetryPolicy              `mapstructure:"retry_policy"`  // 跨链事务重试策略
+	CrossTimeout   int64                     `mapstructure:"cross_timeout"` // 跨链事务默认超时时间(ms)，跨链事件未指定deadline时使用，0表示不限制
+	CommitMargin   int64                     `mapstructure:"commit_margin"` // 开始提交时距截止时间的最小剩余时间(ms)，不足时回滚
+	HAConfig       *HAConfig                 `mapstructure:"ha"`            // 高可用配置，未开启时单节点运行
+	AttestorConfig *AttestorConfig           `mapstructure:"attestor"`      // 背书配置，未配置时不响应其他代理的背书请求
+	ChaosConfig    *ChaosConfig              `mapstructure:"chaos"`         // 故障注入配置，仅用于测试恢复逻辑
+	RoutingConfig  *RoutingConfig            `mapstructure:"routing"`       // 多路由选择及故障切换配置
+	Discovery      *DiscoveryConfig          `mapstructure:"discovery"`     // 代理发现及链通告配置，未开启时仅使用静态路由配置
+	AuthConfig     *AuthConfig               `mapstructure:"auth"`          // 代理间消息签名及验证配置，未开启时不签名也不验证
 }
 
 // ListenerConfig Listener config
@@ -31,15 +77,18 @@ type ListenerConfig struct {
 	WebConfig     *WebConfig     `mapstructure:"web"`     // web服务配置
 	ChannelConfig *ChannelConfig `mapstructure:"channel"` // P2p网络配置
 	GrpcConfig    *GrpcConfig    `mapstructure:"grpc"`    // grpc服务配置
+	WebhookConfig *WebhookConfig `mapstructure:"webhook"` // 跨链状态回调配置
 }
 
 // WebConfig WebListener config
 type WebConfig struct {
-	Address     string             `mapstructure:"address"`        // web服务监听地址
-	Port        int                `mapstructure:"port"`           // web服务监听端口
-	OpenTxRoute bool               `mapstructure:"open_tx_router"` // web服务开启事务处理路由
-	EnableTLS   bool               `mapstructure:"enable_tls"`     //启用tls
-	Security    *TransportSecurity `mapstructure:"security"`       //传输安全配置
+	Address        string             `mapstructure:"address"`         // web服务监听地址
+	Port           int                `mapstructure:"port"`            // web服务监听端口
+	OpenTxRoute    bool               `mapstructure:"open_tx_router"`  // web服务开启事务处理路由
+	EnableTLS      bool               `mapstructure:"enable_tls"`      //启用tls
+	Security       *TransportSecurity `mapstructure:"security"`        //传输安全配置
+	AdminToken     string             `mapstructure:"admin_token"`     // 运维接口的Bearer Token，为空则不开启运维接口
+	AllowedOrigins []string           `mapstructure:"allowed_origins"` // WebSocket允许的跨域来源，"*"表示允许所有来源，同源请求不受限制
 }
 
 // ToUrl return url of web config
@@ -55,16 +104,42 @@ type ChannelConfig struct {
 
 // GrpcConfig Grpc config
 type GrpcConfig struct {
-	Network string `mapstructure:"protocol"`    // 网络类型
-	Address string `mapstructure:"listen_port"` // 监听地址
+	Network     string             `mapstructure:"protocol"`       // 网络类型
+	Address     string             `mapstructure:"listen_port"`    // 监听地址
+	OpenTxRoute bool               `mapstructure:"open_tx_router"` // grpc服务开启事务处理接口
+	EnableTLS   bool               `mapstructure:"enable_tls"`     // 启用tls
+	Security    *TransportSecurity `mapstructure:"security"`       // 传输安全配置，开启ca_auth时进行双向认证
+}
+
+// MonitorConfig prometheus metrics config
+type MonitorConfig struct {
+	Enable  bool   `mapstructure:"enable"`  // 是否开启监控
+	Address string `mapstructure:"address"` // metrics服务监听地址
+	Port    int    `mapstructure:"port"`    // metrics服务监听端口
+	Path    string `mapstructure:"path"`    // metrics路径，默认/metrics
+}
+
+// ToUrl return url of monitor config
+func (monitorConfig *MonitorConfig) ToUrl() string {
+	return monitorConfig.Address + ":" + strconv.Itoa(monitorConfig.Port)
+}
+
+// WebhookConfig webhook callback config
+type WebhookConfig struct {
+	Secret        string   `mapstructure:"secret"`         // HMAC-SHA256签名密钥，为空则不签名
+	MaxRetry      int      `mapstructure:"max_retry"`      // 回调失败最大重试次数
+	RetryInterval int      `mapstructure:"retry_interval"` // 首次重试间隔(ms)，之后每次翻倍
+	Timeout       int      `mapstructure:"timeout"`        // 单次回调超时时间(ms)
+	AllowedHosts  []string `mapstructure:"allowed_hosts"`  // 回调地址允许的主机，如example.com或127.0.0.1:8000，为空则不回调
 }
 
 // LibP2PChannelConfig LibP2P channel config
 type LibP2PChannelConfig struct {
-	Address     string `mapstructure:"address"`       // listen address
-	PrivKeyFile string `mapstructure:"priv_key_file"` // p2p network peer id derived form private key
-	ProtocolID  string `mapstructure:"protocol_id"`   // p2p network protocolID
-	Delimit     string `mapstructure:"delimit"`       // p2p network delimit
+	Address     string         `mapstructure:"address"`       // listen address
+	PrivKeyFile string         `mapstructure:"priv_key_file"` // p2p network peer id derived form private key
+	ProtocolID  string         `mapstructure:"protocol_id"`   // p2p network protocolID
+	Delimit     string         `mapstructure:"delimit"`       // p2p network delimit
+	Framing     *FramingConfig `mapstructure:"framing"`       // 长度前缀分帧配置，未开启时使用分割符
 }
 
 // GetDelimit return delimit of libp2p connection
@@ -73,13 +148,294 @@ func (c *LibP2PChannelConfig) GetDelimit() byte {
 	if len(bs) > 1 {
 		panic("delimit config more than one rune")
 	}
+	if len(bs) == 0 {
+		return DefaultDelimit
+	}
 	return bs[StringToByteIndex]
 }
 
+// Validate check the delimit and framing of libp2p listener
+func (c *LibP2PChannelConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if err := validateDelimit(c.Delimit); err != nil {
+		return err
+	}
+	return c.Framing.Validate()
+}
+
+// FramingConfig the config of length-prefixed framing of libp2p, the framed protocol is negotiated by protocol id
+// with the peer, and the delimited protocol is used if the peer does not support it
+type FramingConfig struct {
+	Enable       bool   `mapstructure:"enable"`         // 是否开启长度前缀分帧，开启后消息以二进制传输，不再base64编码
+	Compression  string `mapstructure:"compression"`    // 压缩算法，可选none、gzip，默认none
+	MaxFrameSize int    `mapstructure:"max_frame_size"` // 单帧最大字节数，超过时拒绝发送或接收，0表示默认值
+}
+
+// IsEnabled return whether the framing is enabled
+func (f *FramingConfig) IsEnabled() bool {
+	return f != nil && f.Enable
+}
+
+// Validate check the compression and max frame size of framing
+func (f *FramingConfig) Validate() error {
+	if !f.IsEnabled() {
+		return nil
+	}
+	switch f.Compression {
+	case "", CompressionNone, CompressionGzip:
+	default:
+		return fmt.Errorf("unknown compression [%s] of framing, it should be %s or %s", f.Compression, CompressionNone, CompressionGzip)
+	}
+	if f.MaxFrameSize < 0 {
+		return errors.New("max frame size of framing can not be negative")
+	}
+	return nil
+}
+
+// validateDelimit check that the delimit is one byte, the multi-byte delimit can not split the messages
+func validateDelimit(delimit string) error {
+	if len([]byte(delimit)) > 1 {
+		return fmt.Errorf("delimit [%q] of libp2p should be one byte", delimit)
+	}
+	return nil
+}
+
 // StorageConfig storage config
 type StorageConfig struct {
-	Provider string         `mapstructure:"provider"` // 存储类型
-	LevelDB  *LevelDBConfig `mapstructure:"leveldb"`  // levelBD 配置
+	Provider  string           `mapstructure:"provider"`  // 存储类型
+	LevelDB   *LevelDBConfig   `mapstructure:"leveldb"`   // levelBD 配置
+	Badger    *BadgerConfig    `mapstructure:"badger"`    // badger 配置
+	SQL       *SQLConfig       `mapstructure:"sql"`       // sql 配置
+	Retention *RetentionConfig `mapstructure:"retention"` // 归档配置，未配置时永久保存
+}
+
+// Validate check the provider and its config, the proxy should refuse to start when the config is invalid
+func (s *StorageConfig) Validate() error {
+	if s == nil {
+		return errors.New("storage config is missing")
+	}
+	switch s.Provider {
+	case StorageLevelDB:
+		if s.LevelDB == nil {
+			return errors.New("leveldb config of storage is missing")
+		}
+	case StorageBadger:
+		if s.Badger == nil {
+			return errors.New("badger config of storage is missing")
+		}
+	case StorageSQL:
+		if s.SQL == nil {
+			return errors.New("sql config of storage is missing")
+		}
+		if s.SQL.Driver != SQLDriverMySQL && s.SQL.Driver != SQLDriverSQLite {
+			return fmt.Errorf("unsupported sql driver [%s] of storage, it should be %s or %s",
+				s.SQL.Driver, SQLDriverMySQL, SQLDriverSQLite)
+		}
+		if s.SQL.DSN == "" {
+			return errors.New("dsn of sql storage is missing")
+		}
+	case StorageMemory:
+	default:
+		return fmt.Errorf("unsupported storage provider [%s], it should be one of %s, %s, %s and %s",
+			s.Provider, StorageLevelDB, StorageBadger, StorageSQL, StorageMemory)
+	}
+	return nil
+}
+
+// HAConfig high-availability config, the proxies which share the same sql storage elect one leader by lease,
+// only the leader handles the cross transactions
+type HAConfig struct {
+	Enable        bool   `mapstructure:"enable"`         // 是否开启高可用
+	NodeID        string `mapstructure:"node_id"`        // 节点ID，同一集群内唯一，默认为{hostname}-{pid}
+	LeaseTimeout  int    `mapstructure:"lease_timeout"`  // 租约时长，单位：秒，leader失效后其他节点最多等待该时长接管
+	RenewInterval int    `mapstructure:"renew_interval"` // 续约及抢占租约的间隔，单位：秒，需小于租约时长
+}
+
+// Validate check the config of high-availability, the lease can only be shared by sql storage
+func (h *HAConfig) Validate(storage *StorageConfig) error {
+	if h == nil || !h.Enable {
+		return nil
+	}
+	if storage == nil || storage.Provider != StorageSQL {
+		return fmt.Errorf("high-availability requires the shared %s storage", StorageSQL)
+	}
+	if h.LeaseTimeout < 0 || h.RenewInterval < 0 {
+		return errors.New("lease timeout and renew interval of high-availability can not be negative")
+	}
+	if h.LeaseTimeout > 0 && h.RenewInterval > 0 && h.RenewInterval >= h.LeaseTimeout {
+		return fmt.Errorf("renew interval [%ds] of high-availability should be less than lease timeout [%ds]",
+			h.RenewInterval, h.LeaseTimeout)
+	}
+	return nil
+}
+
+// RoutingConfig the config of choosing among the channel routers which can reach the same chain,
+// the unhealthy routers are skipped and the event fails over to the next router when delivery fails or times out
+type RoutingConfig struct {
+	Strategy          string `mapstructure:"strategy"`           // 路由选择策略，可选priority、round_robin、least_latency，默认priority
+	HeartbeatInterval int    `mapstructure:"heartbeat_interval"` // 心跳检测间隔，单位：秒，0表示默认值，负数表示关闭心跳
+	FailureThreshold  int    `mapstructure:"failure_threshold"`  // 连续失败次数达到该值后路由被标记为不健康，0表示默认值
+	RecoverInterval   int    `mapstructure:"recover_interval"`   // 不健康的路由在最后一次失败该时长后重新尝试，单位：秒，0表示默认值
+	MaxFailover       int    `mapstructure:"max_failover"`       // 单次调用最多切换的路由次数，0表示尝试全部路由
+	MaxHops           int    `mapstructure:"max_hops"`           // 事件最多经过的代理跳数，0表示默认值，1表示只发送到直连的代理，不经其他代理转发
+}
+
+// Validate check the strategy and thresholds of routing
+func (r *RoutingConfig) Validate() error {
+	if r == nil {
+		return nil
+	}
+	switch r.Strategy {
+	case "", RouteStrategyPriority, RouteStrategyRoundRobin, RouteStrategyLeastLatency:
+	default:
+		return fmt.Errorf("unknown routing strategy [%s], it should be one of %s, %s and %s",
+			r.Strategy, RouteStrategyPriority, RouteStrategyRoundRobin, RouteStrategyLeastLatency)
+	}
+	if r.FailureThreshold < 0 || r.RecoverInterval < 0 || r.MaxFailover < 0 || r.MaxHops < 0 {
+		return errors.New("failure threshold, recover interval, max failover and max hops of routing can not be negative")
+	}
+	return nil
+}
+
+// DiscoveryConfig the config of proxy discovery, the chains of peer proxies are learned from their announcements,
+// and the peer proxies can be found by the bootstrap list and mDNS besides the routers config
+type DiscoveryConfig struct {
+	Enable           bool        `mapstructure:"enable"`            // 是否开启代理发现及链通告
+	Name             string      `mapstructure:"name"`              // 当前代理名称，随通告发送给对端代理并记录在转发事件的路径中，各代理需唯一，默认为"主机名-进程号"
+	AnnounceInterval int         `mapstructure:"announce_interval"` // 向对端代理请求链通告的间隔，单位：秒
+	Bootstrap        []string    `mapstructure:"bootstrap"`         // 引导代理的libp2p地址，支持的链由通告获得
+	Mdns             *MdnsConfig `mapstructure:"mdns"`              // 局域网mDNS发现配置
+}
+
+// MdnsConfig the config of mDNS discovery in local network
+type MdnsConfig struct {
+	Enable     bool   `mapstructure:"enable"`      // 是否开启mDNS发现
+	Interval   int    `mapstructure:"interval"`    // 查询间隔，单位：秒
+	ServiceTag string `mapstructure:"service_tag"` // 服务标识，只发现相同标识的代理
+}
+
+// IsEnabled return whether the discovery is enabled
+func (d *DiscoveryConfig) IsEnabled() bool {
+	return d != nil && d.Enable
+}
+
+// IsMdnsEnabled return whether the mDNS discovery is enabled
+func (d *DiscoveryConfig) IsMdnsEnabled() bool {
+	return d.IsEnabled() && d.Mdns != nil && d.Mdns.Enable
+}
+
+// Validate check the config of discovery, the discovered proxies are connected by the libp2p channel config of listener
+func (d *DiscoveryConfig) Validate(listener *ListenerConfig) error {
+	if !d.IsEnabled() {
+		return nil
+	}
+	if d.AnnounceInterval < 0 || (d.Mdns != nil && d.Mdns.Interval < 0) {
+		return errors.New("announce interval and mdns interval of discovery can not be negative")
+	}
+	if len(d.Bootstrap) > 0 || d.IsMdnsEnabled() {
+		if listener == nil || listener.ChannelConfig == nil || listener.ChannelConfig.LibP2PChannel == nil {
+			return errors.New("bootstrap and mdns of discovery require the libp2p channel config of listener")
+		}
+	}
+	return nil
+}
+
+// ChaosConfig the config of fault injection, the faults of scenario are injected at the named points of proxy
+// to verify the restart recovery, it should never be enabled in production
+type ChaosConfig struct {
+	Enable       bool            `mapstructure:"enable"`        // 是否开启故障注入
+	ScenarioFile string          `mapstructure:"scenario_file"` // 故障场景文件，相对路径基于配置目录
+	StateFile    string          `mapstructure:"state_file"`    // 规则命中次数的持久化文件，重启后已生效的规则不再重复生效，为空时不持久化
+	Scenario     *chaos.Scenario `mapstructure:"-"`             // 校验时加载的故障场景
+}
+
+// IsEnabled return whether the fault injection is enabled
+func (c *ChaosConfig) IsEnabled() bool {
+	return c != nil && c.Enable
+}
+
+// Validate load and check the scenario file when the fault injection is enabled
+func (c *ChaosConfig) Validate() error {
+	if !c.IsEnabled() {
+		return nil
+	}
+	if c.ScenarioFile == "" {
+		return errors.New("scenario file of chaos is missing")
+	}
+	scenario, err := chaos.LoadScenario(FinalCfgPath(c.ScenarioFile))
+	if err != nil {
+		return err
+	}
+	c.Scenario = scenario
+	return nil
+}
+
+// AttestorConfig the config of attestor, the proxy signs the proofs of its directly connected chains
+// for the attestation provers of other proxies
+type AttestorConfig struct {
+	KeyFile string `mapstructure:"key_file"` // ed25519私钥文件，内容为hex编码的seed或私钥，相对路径基于配置目录
+}
+
+// AuthConfig the config of signing the messages between proxies, the transaction event contexts and proof responses
+// are signed by the identity key of proxy, and verified by the public keys of peer proxies with replay protection
+type AuthConfig struct {
+	Enable        bool     `mapstructure:"enable"`         // 是否开启代理间消息签名及验证
+	KeyFile       string   `mapstructure:"key_file"`       // 代理身份ed25519私钥文件，内容为hex编码的seed或私钥，相对路径基于配置目录
+	TrustedKeys   []string `mapstructure:"trusted_keys"`   // 允许通过任意连接发起请求的代理公钥，hex编码，路由配置的公钥只在该路由对端的连接上被信任
+	ReplayWindow  int      `mapstructure:"replay_window"`  // 消息时间戳与本地时间允许的最大偏差，窗口内的重复消息被拒绝，单位：秒，0表示默认值
+	AllowUnsigned bool     `mapstructure:"allow_unsigned"` // 是否接受未签名的消息，仅用于逐个代理升级期间
+}
+
+// IsEnabled return whether the messages between proxies are signed and verified
+func (a *AuthConfig) IsEnabled() bool {
+	return a != nil && a.Enable
+}
+
+// Validate check the key file, replay window and all the public keys of auth and routers
+func (a *AuthConfig) Validate(routers []*RouterConfig) error {
+	if !a.IsEnabled() {
+		return nil
+	}
+	if a.KeyFile == "" {
+		return errors.New("key file of auth is missing")
+	}
+	if a.ReplayWindow < 0 {
+		return errors.New("replay window of auth can not be negative")
+	}
+	keys := append([]string{}, a.TrustedKeys...)
+	for _, router := range routers {
+		keys = append(keys, router.PublicKeys...)
+	}
+	for _, key := range keys {
+		if publicKey, err := hex.DecodeString(strings.TrimSpace(key)); err != nil || len(publicKey) != 32 {
+			return fmt.Errorf("invalid ed25519 public key [%s] of auth", key)
+		}
+	}
+	return nil
+}
+
+// BadgerConfig badger config
+type BadgerConfig struct {
+	StorePath  string `mapstructure:"store_path"`  // 存储路径
+	SyncWrites bool   `mapstructure:"sync_writes"` // 是否同步落盘
+}
+
+// SQLConfig sql database config, the state is stored as key-values in one table
+type SQLConfig struct {
+	Driver       string `mapstructure:"driver"`         // 数据库驱动，mysql或sqlite3
+	DSN          string `mapstructure:"dsn"`            // 数据库连接串
+	Table        string `mapstructure:"table"`          // 表名，默认为cross_state
+	MaxOpenConns int    `mapstructure:"max_open_conns"` // 最大连接数，0表示不限制
+}
+
+// RetentionConfig retention policy of the finished cross transactions
+type RetentionConfig struct {
+	RetentionDays int    `mapstructure:"retention_days"` // 已完成的跨链事务保留天数，超过后归档，0表示不归档
+	ArchivePath   string `mapstructure:"archive_path"`   // 归档文件目录，文件格式为gzip压缩的json-lines
+	CheckInterval int    `mapstructure:"check_interval"` // 检查间隔，单位：分钟
+	BatchSize     int    `mapstructure:"batch_size"`     // 每个归档文件的最大跨链事务数
 }
 
 // LevelDBConfig leveldb config
@@ -91,19 +447,23 @@ type LevelDBConfig struct {
 
 // RouterConfig the config of router
 type RouterConfig struct {
-	Provider     string              `mapstructure:"provider"`  // 路由网络类型
-	ChainIDs     []string            `mapstructure:"chain_ids"` // 代理节点能直连的链
-	LibP2PRouter *LibP2PRouterConfig `mapstructure:"libp2p"`    // libp2p 网络配置
-	HttpRouter   *HttpRouterConfig   `mapstructure:"http"`      // http 网络配置
+	Name         string              `mapstructure:"name"`        // 对端代理名称，可选，用于按代理寻址，如请求背书
+	Priority     int                 `mapstructure:"priority"`    // 路由优先级，值越小越优先，默认0
+	Provider     string              `mapstructure:"provider"`    // 路由网络类型
+	ChainIDs     []string            `mapstructure:"chain_ids"`   // 代理节点能直连的链
+	PublicKeys   []string            `mapstructure:"public_keys"` // 对端代理的ed25519公钥，hex编码，开启auth时只接受这些公钥签名的响应
+	LibP2PRouter *LibP2PRouterConfig `mapstructure:"libp2p"`      // libp2p 网络配置
+	HttpRouter   *HttpRouterConfig   `mapstructure:"http"`        // http 网络配置
 }
 
 // LibP2PRouterConfig the config of libp2p router
 type LibP2PRouterConfig struct {
-	Address           string `mapstructure:"address"`            // libp2p 网络地址
-	ProtocolID        string `mapstructure:"protocol_id"`        // p2p network protocolID
-	Delimit           string `mapstructure:"delimit"`            // p2p network delimit
-	ReconnectLimit    int    `mapstructure:"reconnect_limit"`    // 连接断开重试次数
-	ReconnectInterval int    `mapstructure:"reconnect_interval"` // 连接间隔， 单位毫秒
+	Address           string         `mapstructure:"address"`            // libp2p 网络地址
+	ProtocolID        string         `mapstructure:"protocol_id"`        // p2p network protocolID
+	Delimit           string         `mapstructure:"delimit"`            // p2p network delimit
+	ReconnectLimit    int            `mapstructure:"reconnect_limit"`    // 连接断开重试次数
+	ReconnectInterval int            `mapstructure:"reconnect_interval"` // 连接间隔， 单位毫秒
+	Framing           *FramingConfig `mapstructure:"framing"`            // 长度前缀分帧配置，对端不支持时使用分割符
 }
 
 type HttpRouterConfig struct {
@@ -139,9 +499,23 @@ func (c *LibP2PRouterConfig) GetDelimit() byte {
 	if len(bs) > 1 {
 		panic("delimit config more than one rune")
 	}
+	if len(bs) == 0 {
+		return DefaultDelimit
+	}
 	return bs[StringToByteIndex]
 }
 
+// Validate check the delimit and framing of libp2p router
+func (c *LibP2PRouterConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if err := validateDelimit(c.Delimit); err != nil {
+		return err
+	}
+	return c.Framing.Validate()
+}
+
 // GetChainIDs return chain-ids of remote cross-chain proxy
 func (r *RouterConfig) GetChainIDs() []string {
 	return r.ChainIDs
@@ -154,6 +528,7 @@ type AdapterConfig struct {
 	ConfigPath    string              `mapstructure:"config_path"`    // 配置路径
 	ProofContract *ProofContract      `mapstructure:"proof_contract"` // 证据保存的合约信息
 	ExtraConf     map[string][]string `mapstructure:"extra_conf"`     // 各个平行链的个性化配置
+	RetryPolicy   *RetryPolicy        `mapstructure:"retry_policy"`   // 转接器查询交易结果的重试策略
 }
 
 // ProofContract contract for save proof
@@ -173,6 +548,16 @@ func (adapters AdapterConfigs) GetExtraConfigByProvider(provider string) (*Adapt
 	return nil, fmt.Errorf("cant find config by given provider: %s", provider)
 }
 
+// GetRetryPolicy return the retry policy of adapter for the chain, nil will be returned if not configured
+func (adapters AdapterConfigs) GetRetryPolicy(chainID string) *RetryPolicy {
+	for _, a := range adapters {
+		if a.ChainID == chainID {
+			return a.RetryPolicy
+		}
+	}
+	return nil
+}
+
 func (adapters AdapterConfigs) GetExtraConfigByKey(provider string, key string) ([]string, error) {
 	for _, a := range adapters {
 		if a.Provider == provider {
@@ -191,9 +576,72 @@ type ProverConfig struct {
 	Provider   string   `mapstructure:"provider"`    // 证明器类型，例如 spv，trust等
 	ChainIDs   []string `mapstructure:"chain_ids"`   // 证明器连接的链的ID
 	ConfigPath string   `mapstructure:"config_path"` // 证明器配置路径
+	Policy     string   `mapstructure:"policy"`      // 证明策略，required、optional或trust，trust证明器默认为trust，其他默认为required
 }
 
 // GetChainIDs return chain-ids of local provers
 func (p *ProverConfig) GetChainIDs() []string {
 	return p.ChainIDs
 }
+
+// GetPolicy return the proof policy of the chains, the trust prover is trust by default and the others are required
+func (p *ProverConfig) GetPolicy() string {
+	if p.Policy != "" {
+		return p.Policy
+	}
+	if p.Provider == ProverTrust {
+		return ProofPolicyTrust
+	}
+	return ProofPolicyRequired
+}
+
+// Validate check the provider and policy of prover
+func (p *ProverConfig) Validate() error {
+	switch p.Provider {
+	case ProverTrust, ProverSpv, ProverLightClient, ProverAttestation:
+	default:
+		return fmt.Errorf("unsupported prover provider [%s], it should be one of %s, %s, %s and %s",
+			p.Provider, ProverTrust, ProverSpv, ProverLightClient, ProverAttestation)
+	}
+	switch p.GetPolicy() {
+	case ProofPolicyRequired, ProofPolicyOptional:
+		// 不验证的证明器无法满足验证要求
+		if p.Provider == ProverTrust {
+			return fmt.Errorf("%s prover can only be used with %s policy", ProverTrust, ProofPolicyTrust)
+		}
+	case ProofPolicyTrust:
+	default:
+		return fmt.Errorf("unsupported proof policy [%s], it should be one of %s, %s and %s",
+			p.Policy, ProofPolicyRequired, ProofPolicyOptional, ProofPolicyTrust)
+	}
+	if len(p.ChainIDs) == 0 {
+		return fmt.Errorf("chain ids of %s prover are missing", p.Provider)
+	}
+	return nil
+}
+
+// ProverConfigs the configs of all provers
+type ProverConfigs []*ProverConfig
+
+// Validate check every prover, and every chain of adapters must be configured with one prover explicitly,
+// so the proofs are never trusted silently
+func (provers ProverConfigs) Validate(adapters AdapterConfigs) error {
+	proverChains := make(map[string]string)
+	for _, prover := range provers {
+		if err := prover.Validate(); err != nil {
+			return err
+		}
+		for _, chainID := range prover.ChainIDs {
+			if provider, exist := proverChains[chainID]; exist {
+				return fmt.Errorf("chain[%s] is configured with both %s and %s provers", chainID, provider, prover.Provider)
+			}
+			proverChains[chainID] = prover.Provider
+		}
+	}
+	for _, adapter := range adapters {
+		if _, exist := proverChains[adapter.ChainID]; !exist {
+			return fmt.Errorf("prover of chain[%s] is not configured", adapter.ChainID)
+		}
+	}
+	return nil
+}
@@ -72,6 +72,22 @@ func TestLibP2PRouterConfig_GetDelimit(t *testing.T) {
 	deli = libp2pRC.GetDelimit()
 }
 
+func TestLibP2PConfig_Validate(t *testing.T) {
+	var nilChannel *LibP2PChannelConfig
+	require.Nil(t, nilChannel.Validate())
+	require.Equal(t, byte(DefaultDelimit), (&LibP2PChannelConfig{}).GetDelimit())
+	require.Nil(t, (&LibP2PChannelConfig{Delimit: "\n"}).Validate())
+	require.NotNil(t, (&LibP2PChannelConfig{Delimit: "\\n"}).Validate())
+	require.NotNil(t, (&LibP2PRouterConfig{Delimit: "||"}).Validate())
+
+	framing := &FramingConfig{Enable: true, Compression: CompressionGzip, MaxFrameSize: 1024}
+	require.True(t, framing.IsEnabled())
+	require.Nil(t, (&LibP2PRouterConfig{Delimit: "\n", Framing: framing}).Validate())
+	require.NotNil(t, (&FramingConfig{Enable: true, Compression: "zstd"}).Validate())
+	require.NotNil(t, (&FramingConfig{Enable: true, MaxFrameSize: -1}).Validate())
+	require.Nil(t, (&FramingConfig{Compression: "zstd"}).Validate())
+}
+
 func TestProverConfig_GetChainIDs(t *testing.T) {
 	pc := ProverConfig{
 		Provider:   "spv",
@@ -100,3 +116,103 @@ func TestRouterConfig_GetChainIDs(t *testing.T) {
 	ids := rc.GetChainIDs()
 	require.Equal(t, ids, []string{"chain1", "chain2"})
 }
+
+func TestStorageConfig_Validate(t *testing.T) {
+	var nilConfig *StorageConfig
+	require.NotNil(t, nilConfig.Validate())
+	require.Nil(t, (&StorageConfig{Provider: StorageMemory}).Validate())
+	require.NotNil(t, (&StorageConfig{Provider: "unknown"}).Validate())
+	require.NotNil(t, (&StorageConfig{}).Validate())
+
+	require.NotNil(t, (&StorageConfig{Provider: StorageLevelDB}).Validate())
+	require.Nil(t, (&StorageConfig{Provider: StorageLevelDB, LevelDB: &LevelDBConfig{}}).Validate())
+	require.NotNil(t, (&StorageConfig{Provider: StorageBadger}).Validate())
+	require.Nil(t, (&StorageConfig{Provider: StorageBadger, Badger: &BadgerConfig{}}).Validate())
+
+	sqlConfig := &StorageConfig{Provider: StorageSQL, SQL: &SQLConfig{Driver: "postgres", DSN: "cross.db"}}
+	require.NotNil(t, sqlConfig.Validate())
+	sqlConfig.SQL.Driver = SQLDriverSQLite
+	require.Nil(t, sqlConfig.Validate())
+	sqlConfig.SQL.DSN = ""
+	require.NotNil(t, sqlConfig.Validate())
+}
+
+func TestHAConfig_Validate(t *testing.T) {
+	var nilConfig *HAConfig
+	require.Nil(t, nilConfig.Validate(nil))
+	require.Nil(t, (&HAConfig{}).Validate(&StorageConfig{Provider: StorageLevelDB}))
+
+	haConfig := &HAConfig{Enable: true, LeaseTimeout: 15, RenewInterval: 5}
+	require.NotNil(t, haConfig.Validate(&StorageConfig{Provider: StorageLevelDB}))
+	sqlStorage := &StorageConfig{Provider: StorageSQL, SQL: &SQLConfig{Driver: SQLDriverSQLite, DSN: "cross.db"}}
+	require.Nil(t, haConfig.Validate(sqlStorage))
+	haConfig.RenewInterval = 15
+	require.NotNil(t, haConfig.Validate(sqlStorage))
+}
+
+func TestRoutingConfig_Validate(t *testing.T) {
+	var nilConfig *RoutingConfig
+	require.Nil(t, nilConfig.Validate())
+	require.Nil(t, (&RoutingConfig{}).Validate())
+	require.Nil(t, (&RoutingConfig{Strategy: RouteStrategyLeastLatency, FailureThreshold: 3}).Validate())
+	require.NotNil(t, (&RoutingConfig{Strategy: "random"}).Validate())
+	require.NotNil(t, (&RoutingConfig{MaxFailover: -1}).Validate())
+	require.NotNil(t, (&RoutingConfig{MaxHops: -1}).Validate())
+}
+
+func TestDiscoveryConfig_Validate(t *testing.T) {
+	var nilConfig *DiscoveryConfig
+	require.False(t, nilConfig.IsEnabled())
+	require.Nil(t, nilConfig.Validate(nil))
+	require.Nil(t, (&DiscoveryConfig{Enable: true}).Validate(nil))
+	discovery := &DiscoveryConfig{Enable: true, Mdns: &MdnsConfig{Enable: true}}
+	require.True(t, discovery.IsMdnsEnabled())
+	require.NotNil(t, discovery.Validate(&ListenerConfig{}))
+	require.Nil(t, discovery.Validate(&ListenerConfig{ChannelConfig: &ChannelConfig{LibP2PChannel: &LibP2PChannelConfig{}}}))
+	require.NotNil(t, (&DiscoveryConfig{Enable: true, AnnounceInterval: -1}).Validate(nil))
+}
+
+func TestAuthConfig_Validate(t *testing.T) {
+	var nilConfig *AuthConfig
+	require.False(t, nilConfig.IsEnabled())
+	require.Nil(t, nilConfig.Validate(nil))
+	require.Nil(t, (&AuthConfig{KeyFile: ""}).Validate(nil))
+	key := "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a"
+	auth := &AuthConfig{Enable: true, KeyFile: "proxy.key", TrustedKeys: []string{key}}
+	require.Nil(t, auth.Validate([]*RouterConfig{{PublicKeys: []string{key}}}))
+	require.NotNil(t, auth.Validate([]*RouterConfig{{PublicKeys: []string{"invalid"}}}))
+	require.NotNil(t, (&AuthConfig{Enable: true}).Validate(nil))
+	require.NotNil(t, (&AuthConfig{Enable: true, KeyFile: "proxy.key", ReplayWindow: -1}).Validate(nil))
+	require.NotNil(t, (&AuthConfig{Enable: true, KeyFile: "proxy.key", TrustedKeys: []string{key[:10]}}).Validate(nil))
+}
+
+func TestProverConfigs_Validate(t *testing.T) {
+	adapters := AdapterConfigs{{ChainID: "chain1"}, {ChainID: "chain2"}}
+	provers := ProverConfigs{
+		{Provider: ProverSpv, ChainIDs: []string{"chain1"}},
+		{Provider: ProverTrust, ChainIDs: []string{"chain2"}},
+	}
+	require.Nil(t, provers.Validate(adapters))
+	require.Equal(t, ProofPolicyRequired, provers[0].GetPolicy())
+	require.Equal(t, ProofPolicyTrust, provers[1].GetPolicy())
+
+	invalidProvers := []ProverConfigs{
+		// 链未配置证明器
+		{{Provider: ProverSpv, ChainIDs: []string{"chain1"}}},
+		// 未知的证明器
+		{{Provider: "unknown", ChainIDs: []string{"chain1", "chain2"}}},
+		// 未知的策略
+		{{Provider: ProverSpv, ChainIDs: []string{"chain1", "chain2"}, Policy: "unknown"}},
+		// trust证明器不能用于需要验证的策略
+		{{Provider: ProverTrust, ChainIDs: []string{"chain1", "chain2"}, Policy: ProofPolicyRequired}},
+		// 同一条链配置了多个证明器
+		{{Provider: ProverSpv, ChainIDs: []string{"chain1", "chain2"}}, {Provider: ProverTrust, ChainIDs: []string{"chain2"}}},
+		// 未指定链
+		{{Provider: ProverSpv, ChainIDs: []string{"chain1", "chain2"}}, {Provider: ProverTrust}},
+	}
+	for _, invalid := range invalidProvers {
+		require.NotNil(t, invalid.Validate(adapters))
+	}
+	// 未配置转接器时无需证明器
+	require.Nil(t, ProverConfigs{}.Validate(nil))
+}
@@ -0,0 +1,55 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package event
+
+import (
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+)
+
+const (
+	AdminListAction    = "list"    // 列出某类跨链事务的ID
+	AdminShowAction    = "show"    // 查看某个跨链事务及各链的状态
+	AdminRetryAction   = "retry"   // 强制对某条链重试提交或回滚
+	AdminResolveAction = "resolve" // 手动标记跨链事务已处理
+	AdminHistoryAction = "history" // 导出跨链事务的状态历史
+	AdminRestoreAction = "restore" // 从归档文件恢复跨链事务
+
+	AdminCommitOp   = "commit"   // 重试提交
+	AdminRollbackOp = "rollback" // 重试回滚
+
+	AdminUnfinishedFilter = "unfinished"  // 未完成的跨链事务
+	AdminFailedFilter     = "failed"      // 失败的跨链事务
+	AdminDeadLetterFilter = "dead_letter" // 死信集合中的跨链事务
+	AdminAllFilter        = "all"         // 以上所有跨链事务
+)
+
+// AdminEvent operation of stuck cross transactions which is requested by operator
+type AdminEvent struct {
+	Action  string `json:"action"`             // 操作类型
+	CrossID string `json:"cross_id,omitempty"` // 跨链ID，list操作不需要
+	ChainID string `json:"chain_id,omitempty"` // 链ID，retry操作需要
+	Op      string `json:"op,omitempty"`       // 重试的操作，commit或rollback
+	Filter  string `json:"filter,omitempty"`   // list操作的过滤条件
+	Note    string `json:"note,omitempty"`     // resolve操作的备注
+}
+
+// NewAdminEvent create new admin event
+func NewAdminEvent(action, crossID string) *AdminEvent {
+	return &AdminEvent{
+		Action:  action,
+		CrossID: crossID,
+	}
+}
+
+// GetType return type of the event
+func (a *AdminEvent) GetType() eventproto.EventType {
+	return eventproto.AdminEventType
+}
+
+// GetCrossID return cross id
+func (a *AdminEvent) GetCrossID() string {
+	return a.CrossID
+}
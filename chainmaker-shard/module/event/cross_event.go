@@ -17,6 +17,11 @@ const (
 	DefaultVersion = "v0.9.0"
 )
 
+const (
+	SerialExecuteMode   = eventproto.ExecuteMode_SerialExecuteMode   // 顺序执行，前一笔交易的证明由后一笔交易携带
+	ParallelExecuteMode = eventproto.ExecuteMode_ParallelExecuteMode // 并发执行，各交易之间无数据依赖
+)
+
 // NewCrossEvent create cross event by array of CrossTx
 func NewCrossEvent(txEvents []*eventproto.CrossTx) *eventproto.CrossEvent {
 	crossID := utils.NewUUID()
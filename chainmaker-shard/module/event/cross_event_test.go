@@ -29,6 +29,9 @@ func TestCrossEvent(t *testing.T) {
 	ece.SetVersion("test")
 	ece.SetTimestamp(time.Now().Unix())
 	ece.SetCrossID("test")
+	require.False(t, ece.IsParallel())
+	ece.SetExecuteMode(ParallelExecuteMode)
+	require.True(t, ece.IsParallel())
 
 	// test get methods
 	require.Equal(t, ece.GetType(), eventproto.CrossEventType)
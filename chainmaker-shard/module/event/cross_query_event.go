@@ -0,0 +1,26 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package event
+
+import (
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+)
+
+// CrossQueryEvent search the history of cross transactions by conditions, empty condition means no limit
+type CrossQueryEvent struct {
+	ChainID   string `json:"chain_id,omitempty" form:"chain_id"`     // 涉及的链ID
+	State     string `json:"state,omitempty" form:"state"`           // 跨链事务的状态，如failed、success
+	Initiator string `json:"initiator,omitempty" form:"initiator"`   // 发起者
+	StartTime int64  `json:"start_time,omitempty" form:"start_time"` // 开始时间的下限，毫秒时间戳
+	EndTime   int64  `json:"end_time,omitempty" form:"end_time"`     // 开始时间的上限，毫秒时间戳，包含
+	Cursor    string `json:"cursor,omitempty" form:"cursor"`         // 上一页返回的游标
+	Limit     int    `json:"limit,omitempty" form:"limit"`           // 每页数量
+}
+
+// GetType return type of the event
+func (c *CrossQueryEvent) GetType() eventproto.EventType {
+	return eventproto.CrossEventSearchType
+}
@@ -0,0 +1,40 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package event
+
+import (
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+)
+
+const (
+	DeadLetterListAction    = "list"    // 列出死信集合中的所有记录
+	DeadLetterInspectAction = "inspect" // 查看某个跨链事务的死信详情
+	DeadLetterRedriveAction = "redrive" // 重新驱动某个跨链事务
+)
+
+// DeadLetterEvent operation of the dead-letter set which is requested by operator
+type DeadLetterEvent struct {
+	Action  string `json:"action"`             // 操作类型
+	CrossID string `json:"cross_id,omitempty"` // 跨链ID，list操作不需要
+}
+
+// NewDeadLetterEvent create new dead-letter event
+func NewDeadLetterEvent(action, crossID string) *DeadLetterEvent {
+	return &DeadLetterEvent{
+		Action:  action,
+		CrossID: crossID,
+	}
+}
+
+// GetType return type of the event
+func (d *DeadLetterEvent) GetType() eventproto.EventType {
+	return eventproto.DeadLetterEventType
+}
+
+// GetCrossID return cross id
+func (d *DeadLetterEvent) GetCrossID() string {
+	return d.CrossID
+}
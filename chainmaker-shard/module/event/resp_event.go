@@ -215,6 +215,13 @@ func (p *ProofResponse) Done(chainID, txKey string, blockHeight int64, index int
 	}
 }
 
+// IsCompleted return whether the response has been completed, false means waiting timeout
+func (p *ProofResponse) IsCompleted() bool {
+	p.Lock()
+	defer p.Unlock()
+	return p.isCompleted
+}
+
 // Wait wait until {waitTime} or completed
 func (p *ProofResponse) Wait(waitTime time.Duration) bool {
 	// 等待ch处理完成
@@ -6,15 +6,20 @@
 package event
 
 import (
+	"strconv"
+
 	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
 )
 
 //type OpFuncType int32
 
 const (
-	ExecuteOpFunc  = eventproto.OpFuncType_ExecuteOpFunc
-	CommitOpFunc   = eventproto.OpFuncType_CommitOpFunc
-	RollbackOpFunc = eventproto.OpFuncType_RollbackOpFunc
+	ExecuteOpFunc   = eventproto.OpFuncType_ExecuteOpFunc
+	CommitOpFunc    = eventproto.OpFuncType_CommitOpFunc
+	RollbackOpFunc  = eventproto.OpFuncType_RollbackOpFunc
+	AttestOpFunc    = eventproto.OpFuncType_AttestOpFunc
+	AnnounceOpFunc  = eventproto.OpFuncType_AnnounceOpFunc
+	SaveProofOpFunc = eventproto.OpFuncType_SaveProofOpFunc
 )
 
 // NewExecuteTransactionEvent create new execute transaction event
@@ -48,3 +53,35 @@ func NewCommitTransactionEvent(crossID string, chainID string, payload []byte) *
 		Payload: payload,
 	}
 }
+
+// NewAttestTransactionEvent create new transaction event which requests the peer proxy to attest the proof
+func NewAttestTransactionEvent(crossID string, txProof *eventproto.Proof) *eventproto.TransactionEvent {
+	return &eventproto.TransactionEvent{
+		CrossId: crossID,
+		OpFunc:  AttestOpFunc,
+		ChainId: txProof.GetChainID(),
+		TxProof: txProof,
+	}
+}
+
+// NewSaveProofTransactionEvent create new transaction event which saves the proof to the chain with the proof key,
+// the payload is the verified result of the proof
+func NewSaveProofTransactionEvent(crossID, chainID, proofKey string, txProof *eventproto.Proof, verifiedResult bool) *eventproto.TransactionEvent {
+	return &eventproto.TransactionEvent{
+		CrossId:  crossID,
+		OpFunc:   SaveProofOpFunc,
+		ChainId:  chainID,
+		Payload:  []byte(strconv.FormatBool(verifiedResult)),
+		ProofKey: proofKey,
+		TxProof:  txProof,
+	}
+}
+
+// NewAnnounceTransactionEvent create new transaction event which requests the peer proxy to announce its chains,
+// the payload is the announcement of the requester
+func NewAnnounceTransactionEvent(payload []byte) *eventproto.TransactionEvent {
+	return &eventproto.TransactionEvent{
+		OpFunc:  AnnounceOpFunc,
+		Payload: payload,
+	}
+}
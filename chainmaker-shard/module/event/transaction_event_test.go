@@ -42,3 +42,51 @@ func TestNewRollbackTransactionEvent(t *testing.T) {
 	require.Equal(t, re.GetPayload(), []byte{})
 	require.Equal(t, re.GetOpFunc(), RollbackOpFunc)
 }
+
+func TestNewAttestTransactionEvent(t *testing.T) {
+	proof := NewProof("chainID", "txKey", 10, 1, nil, nil)
+	ae := NewAttestTransactionEvent("crossID", proof)
+	require.NotNil(t, ae)
+
+	require.Equal(t, ae.GetType(), eventproto.TransactionEventType)
+	require.Equal(t, ae.GetChainID(), "chainID")
+	require.Equal(t, ae.GetOpFunc(), AttestOpFunc)
+	require.True(t, ae.NeedProve())
+}
+
+func TestNewSaveProofTransactionEvent(t *testing.T) {
+	proof := NewProof("chainID", "txKey", 10, 1, nil, nil)
+	se := NewSaveProofTransactionEvent("crossID", "prevChainID", "proofKey", proof, true)
+	require.NotNil(t, se)
+
+	require.Equal(t, se.GetType(), eventproto.TransactionEventType)
+	require.Equal(t, se.GetChainID(), "prevChainID")
+	require.Equal(t, se.GetOpFunc(), SaveProofOpFunc)
+	require.Equal(t, "proofKey", se.ProofKey)
+	require.Equal(t, []byte("true"), se.GetPayload())
+	require.Equal(t, proof, se.GetTxProof())
+}
+
+func TestNewAnnounceTransactionEvent(t *testing.T) {
+	ae := NewAnnounceTransactionEvent([]byte("announcement"))
+	require.NotNil(t, ae)
+
+	require.Equal(t, ae.GetType(), eventproto.TransactionEventType)
+	require.Equal(t, ae.GetOpFunc(), AnnounceOpFunc)
+	require.Equal(t, []byte("announcement"), ae.GetPayload())
+	require.False(t, ae.NeedProve())
+}
+
+func TestTransactionEvent_Forward(t *testing.T) {
+	te := NewExecuteTransactionEvent("crossID", "chainID", []byte{}, "", nil)
+	te.Ttl = 2
+	forwarded := te.Forward("proxy1").Forward("proxy2")
+	require.Equal(t, []string{"proxy1", "proxy2"}, forwarded.GetHops())
+	require.Equal(t, int32(0), forwarded.GetTtl())
+	require.True(t, forwarded.HasHop("proxy1"))
+	require.False(t, forwarded.HasHop("proxy3"))
+	// 原事件不变
+	require.Nil(t, te.GetHops())
+	require.Equal(t, int32(2), te.GetTtl())
+	require.Equal(t, "crossID", forwarded.GetCrossID())
+}
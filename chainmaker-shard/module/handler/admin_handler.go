@@ -0,0 +1,251 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package handler
+
+import (
+	"errors"
+	"fmt"
+
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+
+	"chainmaker.org/chainmaker-cross/event"
+	"chainmaker.org/chainmaker-cross/store"
+	storetype "chainmaker.org/chainmaker-cross/store/types"
+	"go.uber.org/zap"
+)
+
+var (
+	adminHandler *AdminHandler
+
+	missChainIDError     = errors.New("chain_id is required for this action")
+	nonChainRetrierError = errors.New("retrier of chain is not set")
+	nonResolverError     = errors.New("resolver of cross is not set")
+	notCrossFormat       = "can not find state of cross[%s]"
+)
+
+func init() {
+	adminHandler = &AdminHandler{}
+}
+
+// ChainRetrier retry commit or rollback of the cross transaction for one chain
+type ChainRetrier func(crossID, chainID string, opFunc eventproto.OpFuncType) error
+
+// CrossResolver mark the failed or dead-lettered cross transaction as resolved
+type CrossResolver func(crossID, note string) error
+
+// ChainCrossDetail state and result of the cross transaction for one chain
+type ChainCrossDetail struct {
+	State  string `json:"state"`            // 该链的状态
+	Result []byte `json:"result,omitempty"` // 该链的执行结果
+}
+
+// CrossDetail detail of the cross transaction which is used by operator
+type CrossDetail struct {
+	CrossID     string                       `json:"cross_id"`              // 跨链ID
+	State       string                       `json:"state"`                 // 跨链事务的整体状态
+	Result      string                       `json:"result,omitempty"`      // 失败原因或处理备注
+	ChainStates map[string]*ChainCrossDetail `json:"chain_states"`          // 各链的状态
+	DeadLetter  *storetype.DeadLetter        `json:"dead_letter,omitempty"` // 死信记录
+}
+
+// AdminHandler the struct of handler which is used by operator to handle stuck cross transactions
+type AdminHandler struct {
+	stateDB  store.StateDB      // 存储
+	retrier  ChainRetrier       // 单链重试函数，由事务模块提供
+	resolver CrossResolver      // 人工处理函数，由事务模块提供
+	checker  LeaderChecker      // 高可用模式下只有leader修改跨链状态，未开启时为nil
+	logger   *zap.SugaredLogger // log
+}
+
+// GetAdminHandler return instance of AdminHandler
+func GetAdminHandler() *AdminHandler {
+	return adminHandler
+}
+
+// SetStateDB set state database
+func (a *AdminHandler) SetStateDB(stateDB store.StateDB) {
+	a.stateDB = stateDB
+}
+
+// SetLogger set logger
+func (a *AdminHandler) SetLogger(logger *zap.SugaredLogger) {
+	a.logger = logger
+}
+
+// SetRetrier set the function which retry commit or rollback for one chain
+func (a *AdminHandler) SetRetrier(retrier ChainRetrier) {
+	a.retrier = retrier
+}
+
+// SetResolver set the function which marks the cross as resolved
+func (a *AdminHandler) SetResolver(resolver CrossResolver) {
+	a.resolver = resolver
+}
+
+// SetLeaderChecker set the function which checks the leadership in high-availability mode
+func (a *AdminHandler) SetLeaderChecker(checker LeaderChecker) {
+	a.checker = checker
+}
+
+// GetType return type of this handler
+func (a *AdminHandler) GetType() HandlerType {
+	return AdminProcess
+}
+
+// Handle handle the admin event by its action
+func (a *AdminHandler) Handle(eve event.Event, _ bool) (interface{}, error) {
+	adminEvent, ok := eve.(*event.AdminEvent)
+	if !ok {
+		a.logger.Error("event is not type of AdminEvent")
+		return nil, errors.New("event is not type of AdminEvent")
+	}
+	if adminEvent.Action == event.AdminListAction {
+		return a.List(adminEvent.Filter)
+	}
+	crossID := adminEvent.GetCrossID()
+	if crossID == "" {
+		return nil, missCrossIDError
+	}
+	switch adminEvent.Action {
+	case event.AdminShowAction:
+		return a.Show(crossID)
+	case event.AdminRetryAction:
+		return a.Retry(crossID, adminEvent.ChainID, adminEvent.Op)
+	case event.AdminResolveAction:
+		return a.Resolve(crossID, adminEvent.Note)
+	case event.AdminHistoryAction:
+		return a.History(crossID)
+	case event.AdminRestoreAction:
+		return a.Restore(crossID)
+	default:
+		return nil, fmt.Errorf("can not support admin action [%s]", adminEvent.Action)
+	}
+}
+
+// List return the crossIDs which match the filter, unfinished is the default filter
+func (a *AdminHandler) List(filter string) ([]string, error) {
+	switch filter {
+	case "", event.AdminUnfinishedFilter:
+		return a.stateDB.ReadUnfinishedCrossIDs(), nil
+	case event.AdminFailedFilter:
+		return a.stateDB.ReadFailedCrossIDs(), nil
+	case event.AdminDeadLetterFilter:
+		return a.stateDB.ReadDeadLetterCrossIDs(), nil
+	case event.AdminAllFilter:
+		crossIDs := make([]string, 0)
+		crossIDs = append(crossIDs, a.stateDB.ReadUnfinishedCrossIDs()...)
+		crossIDs = append(crossIDs, a.stateDB.ReadFailedCrossIDs()...)
+		crossIDs = append(crossIDs, a.stateDB.ReadDeadLetterCrossIDs()...)
+		return crossIDs, nil
+	default:
+		return nil, fmt.Errorf("can not support filter [%s]", filter)
+	}
+}
+
+// Show return the detail of the cross transaction with the state of each chain
+func (a *AdminHandler) Show(crossID string) (*CrossDetail, error) {
+	crossState, result, exist := a.stateDB.ReadCrossState(crossID)
+	if !exist {
+		return nil, fmt.Errorf(notCrossFormat, crossID)
+	}
+	detail := &CrossDetail{
+		CrossID:     crossID,
+		State:       crossState.String(),
+		ChainStates: make(map[string]*ChainCrossDetail),
+	}
+	if crossState != storetype.StateSuccess {
+		// 成功时结果为序列化后的响应，其他情况为失败原因或处理备注
+		detail.Result = string(result)
+	}
+	if chainIDs, exist := a.stateDB.ReadChainIDs(crossID); exist {
+		for _, chainID := range chainIDs {
+			chainState, chainResult, _ := a.stateDB.ReadChainCrossState(crossID, chainID)
+			detail.ChainStates[chainID] = &ChainCrossDetail{
+				State:  chainState.String(),
+				Result: chainResult,
+			}
+		}
+	}
+	if deadLetter, exist := a.stateDB.ReadDeadLetter(crossID); exist {
+		detail.DeadLetter = deadLetter
+	}
+	return detail, nil
+}
+
+// Retry force a retry of commit or rollback for one chain, the detail after retried will be returned
+func (a *AdminHandler) Retry(crossID, chainID, op string) (*CrossDetail, error) {
+	if chainID == "" {
+		return nil, missChainIDError
+	}
+	var opFunc eventproto.OpFuncType
+	switch op {
+	case event.AdminCommitOp:
+		opFunc = eventproto.OpFuncType_CommitOpFunc
+	case event.AdminRollbackOp:
+		opFunc = eventproto.OpFuncType_RollbackOpFunc
+	default:
+		return nil, fmt.Errorf("can not support retry op [%s], it should be %s or %s",
+			op, event.AdminCommitOp, event.AdminRollbackOp)
+	}
+	if a.retrier == nil {
+		return nil, nonChainRetrierError
+	}
+	// 重试由leader的事务模块执行，避免与其处理流程并发
+	if err := checkLeader(a.checker, "admin request"); err != nil {
+		return nil, err
+	}
+	if _, _, exist := a.stateDB.ReadCrossState(crossID); !exist {
+		return nil, fmt.Errorf(notCrossFormat, crossID)
+	}
+	if err := a.retrier(crossID, chainID, opFunc); err != nil {
+		a.logger.Errorf("retry %s of cross[%s] for chain[%s] error, %v", op, crossID, chainID, err)
+		return nil, err
+	}
+	a.logger.Infof("retry %s of cross[%s] for chain[%s] by operator", op, crossID, chainID)
+	return a.Show(crossID)
+}
+
+// Resolve mark the failed or dead-lettered cross transaction as resolved with the note of operator
+func (a *AdminHandler) Resolve(crossID, note string) (*CrossDetail, error) {
+	if a.resolver == nil {
+		return nil, nonResolverError
+	}
+	// 由leader的事务模块处理，避免与其处理流程并发
+	if err := checkLeader(a.checker, "admin request"); err != nil {
+		return nil, err
+	}
+	if err := a.resolver(crossID, note); err != nil {
+		a.logger.Errorf("resolve cross[%s] error, %v", crossID, err)
+		return nil, err
+	}
+	a.logger.Infof("cross[%s] is resolved by operator, note: %s", crossID, note)
+	return a.Show(crossID)
+}
+
+// Restore restore the archived cross transaction, then it can be searched and inspected again
+func (a *AdminHandler) Restore(crossID string) (*CrossDetail, error) {
+	if err := checkLeader(a.checker, "admin request"); err != nil {
+		return nil, err
+	}
+	if err := a.stateDB.RestoreCross(crossID); err != nil {
+		a.logger.Errorf("restore cross[%s] error, %v", crossID, err)
+		return nil, err
+	}
+	a.logger.Infof("cross[%s] is restored from archive by operator", crossID)
+	return a.Show(crossID)
+}
+
+// History return the full state history of the cross transaction
+func (a *AdminHandler) History(crossID string) ([]*storetype.StateRecord, error) {
+	if _, _, exist := a.stateDB.ReadCrossState(crossID); !exist {
+		return nil, fmt.Errorf(notCrossFormat, crossID)
+	}
+	history := a.stateDB.ReadCrossHistory(crossID)
+	if history == nil {
+		history = make([]*storetype.StateRecord, 0)
+	}
+	return history, nil
+}
@@ -0,0 +1,113 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package handler
+
+import (
+	"testing"
+
+	"chainmaker.org/chainmaker-cross/event"
+	"chainmaker.org/chainmaker-cross/logger"
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+	"chainmaker.org/chainmaker-cross/store/kvdb"
+	"chainmaker.org/chainmaker-cross/store/kvdb/memory"
+	storetype "chainmaker.org/chainmaker-cross/store/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminHandler(t *testing.T) {
+	AH := getAdminHandler(kvdb.NewKvStateDB(memory.NewMemProvider()))
+	require.Equal(t, AH.GetType(), AdminProcess)
+	AH.SetLogger(logger.GetLogger(logger.ModuleHandler))
+
+	crossID := "admin-cross"
+	require.Nil(t, AH.stateDB.StartCross(crossID, []byte("content")))
+	require.Nil(t, AH.stateDB.WriteChainIDs(crossID, []string{"chain1", "chain2"}))
+	require.Nil(t, AH.stateDB.WriteChainCrossState(crossID, "chain1", storetype.StateCommitFailed, nil))
+
+	// list
+	listEvent := event.NewAdminEvent(event.AdminListAction, "")
+	result, err := AH.Handle(listEvent, true)
+	require.Nil(t, err)
+	require.Contains(t, result.([]string), crossID)
+	listEvent.Filter = "unknown"
+	_, err = AH.Handle(listEvent, true)
+	require.NotNil(t, err)
+
+	// show
+	_, err = AH.Handle(event.NewAdminEvent(event.AdminShowAction, ""), true)
+	require.Equal(t, missCrossIDError, err)
+	result, err = AH.Handle(event.NewAdminEvent(event.AdminShowAction, crossID), true)
+	require.Nil(t, err)
+	detail := result.(*CrossDetail)
+	require.Equal(t, storetype.StateInit.String(), detail.State)
+	require.Equal(t, storetype.StateCommitFailed.String(), detail.ChainStates["chain1"].State)
+
+	// retry
+	retryEvent := event.NewAdminEvent(event.AdminRetryAction, crossID)
+	_, err = AH.Handle(retryEvent, true)
+	require.Equal(t, missChainIDError, err)
+	retryEvent.ChainID, retryEvent.Op = "chain1", "unknown"
+	_, err = AH.Handle(retryEvent, true)
+	require.NotNil(t, err)
+	retryEvent.Op = event.AdminCommitOp
+	AH.SetRetrier(nil)
+	_, err = AH.Handle(retryEvent, true)
+	require.Equal(t, nonChainRetrierError, err)
+	var retriedOp eventproto.OpFuncType
+	AH.SetRetrier(func(crossID, chainID string, opFunc eventproto.OpFuncType) error {
+		retriedOp = opFunc
+		return AH.stateDB.WriteChainCrossState(crossID, chainID, storetype.StateCommitSuccess, nil)
+	})
+	// 事务模块的人工处理最终由存储按状态转换表校验
+	AH.SetResolver(AH.stateDB.ResolveCross)
+	// 非leader拒绝重试、手动处理及归档恢复
+	AH.SetLeaderChecker(func() (bool, string) { return false, "node1" })
+	_, err = AH.Handle(retryEvent, true)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "node1")
+	require.Equal(t, eventproto.OpFuncType(0), retriedOp)
+	followerResolve := event.NewAdminEvent(event.AdminResolveAction, crossID)
+	_, err = AH.Handle(followerResolve, true)
+	require.Contains(t, err.Error(), "node1")
+	_, err = AH.Handle(event.NewAdminEvent(event.AdminRestoreAction, crossID), true)
+	require.Contains(t, err.Error(), "node1")
+	AH.SetLeaderChecker(func() (bool, string) { return true, "node2" })
+	result, err = AH.Handle(retryEvent, true)
+	require.Nil(t, err)
+	require.Equal(t, eventproto.OpFuncType_CommitOpFunc, retriedOp)
+	require.Equal(t, storetype.StateCommitSuccess.String(), result.(*CrossDetail).ChainStates["chain1"].State)
+
+	// resolve
+	resolveEvent := event.NewAdminEvent(event.AdminResolveAction, crossID)
+	resolveEvent.Note = "chain2 fixed manually"
+	AH.SetResolver(nil)
+	_, err = AH.Handle(resolveEvent, true)
+	require.Equal(t, nonResolverError, err)
+	AH.SetResolver(AH.stateDB.ResolveCross)
+	// 未完成且未进入死信的跨链事务不能手动处理
+	_, err = AH.Handle(resolveEvent, true)
+	require.NotNil(t, err)
+	require.Contains(t, AH.stateDB.ReadUnfinishedCrossIDs(), crossID)
+	require.Nil(t, AH.stateDB.AddDeadLetter(&storetype.DeadLetter{CrossID: crossID, ChainID: "chain2",
+		Phase: "commit", State: storetype.StateCommitFailed, Reason: "retries exhausted"}))
+	result, err = AH.Handle(resolveEvent, true)
+	require.Nil(t, err)
+	require.Equal(t, storetype.StateResolved.String(), result.(*CrossDetail).State)
+	require.Equal(t, resolveEvent.Note, result.(*CrossDetail).Result)
+	require.NotContains(t, AH.stateDB.ReadUnfinishedCrossIDs(), crossID)
+	require.NotContains(t, AH.stateDB.ReadDeadLetterCrossIDs(), crossID)
+
+	// history
+	result, err = AH.Handle(event.NewAdminEvent(event.AdminHistoryAction, crossID), true)
+	require.Nil(t, err)
+	history := result.([]*storetype.StateRecord)
+	require.Equal(t, storetype.StateResolved, history[len(history)-1].State)
+	_, err = AH.Handle(event.NewAdminEvent(event.AdminHistoryAction, "not-exist"), true)
+	require.NotNil(t, err)
+
+	_, err = AH.Handle(event.NewAdminEvent("unknown", crossID), true)
+	require.NotNil(t, err)
+}
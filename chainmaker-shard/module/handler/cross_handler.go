@@ -19,6 +19,9 @@ import (
 
 var crossProcessHandler *CrossProcessHandler
 
+// LeaderChecker return whether this proxy is the leader and the node id of current leader
+type LeaderChecker func() (bool, string)
+
 func init() {
 	crossEventCoder, exist := coder.GetEventCoderTools().GetDefaultCoder(eventproto.CrossEventType)
 	if !exist {
@@ -34,6 +37,7 @@ type CrossProcessHandler struct {
 	eventChan chan event.Event   // CrossEvent 消息
 	stateDB   store.StateDB      // 存储
 	coder     event.EventCoder   // 编解码器
+	checker   LeaderChecker      // 高可用模式下只有leader处理跨链事件，未开启时为nil
 	log       *zap.SugaredLogger // log
 }
 
@@ -52,6 +56,11 @@ func (c *CrossProcessHandler) SetStateDB(stateDB store.StateDB) {
 	c.stateDB = stateDB
 }
 
+// SetLeaderChecker set the function which checks the leadership in high-availability mode
+func (c *CrossProcessHandler) SetLeaderChecker(checker LeaderChecker) {
+	c.checker = checker
+}
+
 // SetLogger set logger
 func (c *CrossProcessHandler) SetLogger(logger *zap.SugaredLogger) {
 	c.log = logger
@@ -73,6 +82,9 @@ func (c *CrossProcessHandler) Handle(eve event.Event, _ bool) (interface{}, erro
 	// 进行强制类型转换
 	if crossEvent, ok := eve.(*eventproto.CrossEvent); ok {
 		c.log.Infof("receive cross event cross = %s", crossEvent.GetCrossID())
+		if err := checkLeader(c.checker, "cross event"); err != nil {
+			return nil, err
+		}
 		// 放入channel即可
 		c.eventChan <- crossEvent
 		return nil, nil
@@ -80,3 +92,14 @@ func (c *CrossProcessHandler) Handle(eve event.Event, _ bool) (interface{}, erro
 		return nil, errors.New("can not support this event")
 	}
 }
+
+// checkLeader reject the request on the follower in high-availability mode, the node id of leader is returned by the error
+func checkLeader(checker LeaderChecker, request string) error {
+	if checker == nil {
+		return nil
+	}
+	if isLeader, leader := checker(); !isLeader {
+		return fmt.Errorf("this proxy is not the leader, please send %s to leader [%s]", request, leader)
+	}
+	return nil
+}
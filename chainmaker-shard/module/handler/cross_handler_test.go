@@ -8,7 +8,9 @@ package handler
 import (
 	"testing"
 
+	"chainmaker.org/chainmaker-cross/event"
 	"chainmaker.org/chainmaker-cross/logger"
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
 	"github.com/stretchr/testify/require"
 )
 
@@ -27,3 +29,22 @@ func TestCrossProcessHandler(t *testing.T) {
 	ht := CPH.GetType()
 	require.Equal(t, ht, CrossProcess)
 }
+
+func TestCrossProcessHandler_LeaderChecker(t *testing.T) {
+	handler := &CrossProcessHandler{
+		eventChan: make(chan event.Event, 1),
+		log:       logger.GetLogger(logger.ModuleHandler),
+	}
+	crossEvent := &eventproto.CrossEvent{CrossId: "ha-cross"}
+	// 非leader拒绝跨链事件
+	handler.SetLeaderChecker(func() (bool, string) { return false, "node1" })
+	_, err := handler.Handle(crossEvent, false)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "node1")
+	require.Equal(t, 0, len(handler.eventChan))
+	// leader放入事件通道
+	handler.SetLeaderChecker(func() (bool, string) { return true, "node2" })
+	_, err = handler.Handle(crossEvent, false)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(handler.eventChan))
+}
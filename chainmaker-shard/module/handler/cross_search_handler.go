@@ -7,6 +7,7 @@ package handler
 
 import (
 	"errors"
+	"fmt"
 
 	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
 
@@ -64,14 +65,55 @@ func (c *CrossSearchHandler) Handle(eve event.Event, _ bool) (interface{}, error
 	// 首先查询本地
 	if crossSearchEvent, ok := eve.(*eventproto.CrossSearchEvent); ok {
 		return c.LoadCrossEventResp(crossSearchEvent.GetCrossID()), nil
+	} else if crossQueryEvent, ok := eve.(*event.CrossQueryEvent); ok {
+		return c.QueryCross(crossQueryEvent)
 	} else {
 		c.logger.Error("event is not type of CrossSearchEvent")
 		return nil, errors.New("event is not type of CrossSearchEvent")
 	}
 }
 
-// LoadCrossEventResp load cross event response
+// QueryCross search the history of cross transactions by the conditions of query event
+func (c *CrossSearchHandler) QueryCross(eve *event.CrossQueryEvent) (*storetype.CrossQueryResult, error) {
+	query := &storetype.CrossQuery{
+		ChainID:   eve.ChainID,
+		Initiator: eve.Initiator,
+		StartTime: eve.StartTime,
+		EndTime:   eve.EndTime,
+		Cursor:    eve.Cursor,
+		Limit:     eve.Limit,
+	}
+	if eve.State != "" {
+		state, ok := storetype.ParseState(eve.State)
+		if !ok {
+			return nil, fmt.Errorf("state[%s] is invalid", eve.State)
+		}
+		query.State = state
+	}
+	return c.stateDB.QueryCross(query)
+}
+
+// LoadCrossEventResp load cross event response, the audit records of prove decisions are attached to it
 func (c *CrossSearchHandler) LoadCrossEventResp(crossID string) event.Event {
+	eve := c.loadCrossEventResp(crossID)
+	if crossResp, ok := eve.(*eventproto.CrossResponse); ok {
+		for _, audit := range c.stateDB.ReadProveAudits(crossID) {
+			crossResp.ProveAudits = append(crossResp.ProveAudits, &eventproto.ProveAudit{
+				ChainId:    audit.ChainID,
+				TxKey:      audit.TxKey,
+				ProverType: audit.ProverType,
+				Policy:     audit.Policy,
+				Result:     audit.Result,
+				Reason:     audit.Reason,
+				Timestamp:  audit.Timestamp,
+			})
+		}
+	}
+	return eve
+}
+
+// loadCrossEventResp load cross event response by the state of cross
+func (c *CrossSearchHandler) loadCrossEventResp(crossID string) event.Event {
 	crossState, valBytes, exist := c.stateDB.ReadCrossState(crossID)
 	if exist {
 		if crossState == storetype.StateSuccess {
@@ -84,6 +126,10 @@ func (c *CrossSearchHandler) LoadCrossEventResp(crossID string) event.Event {
 		if crossState == storetype.StateFailed {
 			return event.NewCrossResponse(crossID, event.FailureResp, string(valBytes))
 		}
+		if crossState == storetype.StateResolved {
+			// 运维人员手动处理，结果为处理备注
+			return event.NewCrossResponse(crossID, event.FailureResp, fmt.Sprintf("resolved by operator: %s", valBytes))
+		}
 		return event.NewCrossResponse(crossID, event.UnknownResp, "you should research again")
 	} else {
 		return event.NewCrossResponse(crossID, event.ErrorResp, "can not find cross state from db")
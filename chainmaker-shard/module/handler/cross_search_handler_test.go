@@ -8,7 +8,11 @@ package handler
 import (
 	"testing"
 
+	"chainmaker.org/chainmaker-cross/event"
 	"chainmaker.org/chainmaker-cross/logger"
+	"chainmaker.org/chainmaker-cross/store/kvdb"
+	"chainmaker.org/chainmaker-cross/store/kvdb/memory"
+	storetype "chainmaker.org/chainmaker-cross/store/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -27,3 +31,27 @@ func TestCrossSearchHandler(t *testing.T) {
 	ht := CSH.GetType()
 	require.Equal(t, ht, CrossSearch)
 }
+
+func TestCrossSearchHandler_QueryCross(t *testing.T) {
+	CSH := &CrossSearchHandler{
+		stateDB: kvdb.NewKvStateDB(memory.NewMemProvider()),
+		logger:  logger.GetLogger(logger.ModuleHandler),
+	}
+	crossID := "query-cross"
+	require.Nil(t, CSH.stateDB.StartCross(crossID, []byte("content")))
+	require.Nil(t, CSH.stateDB.IndexCross(&storetype.CrossIndex{CrossID: crossID, ChainIDs: []string{"chain1"}}))
+	require.Nil(t, CSH.stateDB.FinishCross(crossID, nil, storetype.StateFailed))
+
+	result, err := CSH.Handle(&event.CrossQueryEvent{ChainID: "chain1", State: "failed"}, true)
+	require.Nil(t, err)
+	records := result.(*storetype.CrossQueryResult).Records
+	require.Equal(t, 1, len(records))
+	require.Equal(t, crossID, records[0].CrossID)
+
+	result, err = CSH.Handle(&event.CrossQueryEvent{ChainID: "chain1", State: "success"}, true)
+	require.Nil(t, err)
+	require.Empty(t, result.(*storetype.CrossQueryResult).Records)
+
+	_, err = CSH.Handle(&event.CrossQueryEvent{State: "unknown-state"}, true)
+	require.NotNil(t, err)
+}
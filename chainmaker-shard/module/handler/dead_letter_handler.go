@@ -0,0 +1,178 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package handler
+
+import (
+	"errors"
+	"fmt"
+
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+
+	"chainmaker.org/chainmaker-cross/event"
+	"chainmaker.org/chainmaker-cross/event/coder"
+	"chainmaker.org/chainmaker-cross/store"
+	storetype "chainmaker.org/chainmaker-cross/store/types"
+	"go.uber.org/zap"
+)
+
+var (
+	deadLetterHandler *DeadLetterHandler
+
+	missCrossIDError    = errors.New("cross_id is required for this action")
+	nonRedriverError    = errors.New("redriver of dead letter is not set")
+	notDeadLetterFormat = "cross[%s] is not in the dead-letter set"
+)
+
+func init() {
+	deadLetterHandler = &DeadLetterHandler{
+		coder: coder.GetCrossEventCoder(),
+	}
+}
+
+// Redriver re-drive the cross transaction which is in the dead-letter set
+type Redriver func(crossID string) error
+
+// DeadLetterDetail detail of the dead letter which is used for inspecting
+type DeadLetterDetail struct {
+	*storetype.DeadLetter
+	CrossState  string                 `json:"cross_state"`           // 跨链事务的整体状态
+	ChainStates map[string]string      `json:"chain_states"`          // 各链的状态
+	CrossEvent  *eventproto.CrossEvent `json:"cross_event,omitempty"` // 跨链事件内容
+}
+
+// DeadLetterHandler the struct of handler which list, inspect and re-drive the dead letters
+type DeadLetterHandler struct {
+	stateDB  store.StateDB      // 存储
+	coder    event.EventCoder   // 跨链事件编解码器
+	redriver Redriver           // 重新驱动函数，由事务模块提供
+	checker  LeaderChecker      // 高可用模式下只有leader重新驱动，未开启时为nil
+	logger   *zap.SugaredLogger // log
+}
+
+// GetDeadLetterHandler return instance of DeadLetterHandler
+func GetDeadLetterHandler() *DeadLetterHandler {
+	return deadLetterHandler
+}
+
+// SetStateDB set state database
+func (d *DeadLetterHandler) SetStateDB(stateDB store.StateDB) {
+	d.stateDB = stateDB
+}
+
+// SetLogger set logger
+func (d *DeadLetterHandler) SetLogger(logger *zap.SugaredLogger) {
+	d.logger = logger
+}
+
+// SetRedriver set the function which re-drive the cross transaction
+func (d *DeadLetterHandler) SetRedriver(redriver Redriver) {
+	d.redriver = redriver
+}
+
+// SetLeaderChecker set the function which checks the leadership in high-availability mode
+func (d *DeadLetterHandler) SetLeaderChecker(checker LeaderChecker) {
+	d.checker = checker
+}
+
+// GetType return type of this handler
+func (d *DeadLetterHandler) GetType() HandlerType {
+	return DeadLetterProcess
+}
+
+// Handle handle the dead-letter event by its action
+func (d *DeadLetterHandler) Handle(eve event.Event, _ bool) (interface{}, error) {
+	deadLetterEvent, ok := eve.(*event.DeadLetterEvent)
+	if !ok {
+		d.logger.Error("event is not type of DeadLetterEvent")
+		return nil, errors.New("event is not type of DeadLetterEvent")
+	}
+	crossID := deadLetterEvent.GetCrossID()
+	switch deadLetterEvent.Action {
+	case event.DeadLetterListAction:
+		return d.List(), nil
+	case event.DeadLetterInspectAction:
+		if crossID == "" {
+			return nil, missCrossIDError
+		}
+		return d.Inspect(crossID)
+	case event.DeadLetterRedriveAction:
+		if crossID == "" {
+			return nil, missCrossIDError
+		}
+		return d.Redrive(crossID)
+	default:
+		return nil, fmt.Errorf("can not support dead-letter action [%s]", deadLetterEvent.Action)
+	}
+}
+
+// List return all the dead letters
+func (d *DeadLetterHandler) List() []*storetype.DeadLetter {
+	crossIDs := d.stateDB.ReadDeadLetterCrossIDs()
+	deadLetters := make([]*storetype.DeadLetter, 0, len(crossIDs))
+	for _, crossID := range crossIDs {
+		if deadLetter, exist := d.stateDB.ReadDeadLetter(crossID); exist {
+			deadLetters = append(deadLetters, deadLetter)
+		} else {
+			d.logger.Warnf("can not find dead letter record for cross[%s]", crossID)
+		}
+	}
+	return deadLetters
+}
+
+// Inspect return the detail of dead letter for the crossID
+func (d *DeadLetterHandler) Inspect(crossID string) (*DeadLetterDetail, error) {
+	deadLetter, exist := d.stateDB.ReadDeadLetter(crossID)
+	if !exist {
+		return nil, fmt.Errorf(notDeadLetterFormat, crossID)
+	}
+	detail := &DeadLetterDetail{
+		DeadLetter:  deadLetter,
+		ChainStates: make(map[string]string),
+	}
+	crossState, _, _ := d.stateDB.ReadCrossState(crossID)
+	detail.CrossState = crossState.String()
+	if chainIDs, exist := d.stateDB.ReadChainIDs(crossID); exist {
+		for _, chainID := range chainIDs {
+			chainState, _, _ := d.stateDB.ReadChainCrossState(crossID, chainID)
+			detail.ChainStates[chainID] = chainState.String()
+		}
+	}
+	content, err := d.stateDB.ReadCross(crossID)
+	if err != nil {
+		d.logger.Warnf("read content of cross[%s] error, %v", crossID, err)
+		return detail, nil
+	}
+	eve, err := d.coder.UnmarshalFromBinary(content)
+	if err != nil {
+		d.logger.Warnf("unmarshal content of cross[%s] error, %v", crossID, err)
+		return detail, nil
+	}
+	if crossEvent, ok := eve.(*eventproto.CrossEvent); ok {
+		detail.CrossEvent = crossEvent
+	}
+	return detail, nil
+}
+
+// Redrive re-drive the cross transaction in the dead-letter set, the dead letter will be returned
+func (d *DeadLetterHandler) Redrive(crossID string) (*storetype.DeadLetter, error) {
+	if d.redriver == nil {
+		return nil, nonRedriverError
+	}
+	// 重新驱动由leader的事务模块处理
+	if err := checkLeader(d.checker, "dead letter request"); err != nil {
+		return nil, err
+	}
+	deadLetter, exist := d.stateDB.ReadDeadLetter(crossID)
+	if !exist {
+		return nil, fmt.Errorf(notDeadLetterFormat, crossID)
+	}
+	if err := d.redriver(crossID); err != nil {
+		d.logger.Errorf("redrive cross[%s] error, %v", crossID, err)
+		return nil, err
+	}
+	d.logger.Infof("cross[%s] is redriven from dead-letter set", crossID)
+	return deadLetter, nil
+}
@@ -0,0 +1,70 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package handler
+
+import (
+	"testing"
+
+	"chainmaker.org/chainmaker-cross/event"
+	"chainmaker.org/chainmaker-cross/logger"
+	"chainmaker.org/chainmaker-cross/store/kvdb"
+	"chainmaker.org/chainmaker-cross/store/kvdb/memory"
+	storetype "chainmaker.org/chainmaker-cross/store/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadLetterHandler(t *testing.T) {
+	DLH := getDeadLetterHandler(kvdb.NewKvStateDB(memory.NewMemProvider()))
+	require.Equal(t, DLH.GetType(), DeadLetterProcess)
+	DLH.SetLogger(logger.GetLogger(logger.ModuleHandler))
+
+	crossID := "dead-letter-cross"
+	require.Nil(t, DLH.stateDB.StartCross(crossID, []byte("content")))
+	require.Nil(t, DLH.stateDB.AddDeadLetter(&storetype.DeadLetter{
+		CrossID: crossID,
+		ChainID: "chain1",
+		Phase:   "rollback",
+		State:   storetype.StateRollbackFailed,
+	}))
+
+	// list
+	result, err := DLH.Handle(event.NewDeadLetterEvent(event.DeadLetterListAction, ""), true)
+	require.Nil(t, err)
+	deadLetters := result.([]*storetype.DeadLetter)
+	require.Equal(t, 1, len(deadLetters))
+	require.Equal(t, crossID, deadLetters[0].CrossID)
+
+	// inspect
+	_, err = DLH.Handle(event.NewDeadLetterEvent(event.DeadLetterInspectAction, ""), true)
+	require.Equal(t, missCrossIDError, err)
+	result, err = DLH.Handle(event.NewDeadLetterEvent(event.DeadLetterInspectAction, crossID), true)
+	require.Nil(t, err)
+	require.Equal(t, "chain1", result.(*DeadLetterDetail).ChainID)
+
+	// redrive
+	DLH.SetRedriver(nil)
+	_, err = DLH.Handle(event.NewDeadLetterEvent(event.DeadLetterRedriveAction, crossID), true)
+	require.Equal(t, nonRedriverError, err)
+	var redriven string
+	DLH.SetRedriver(func(crossID string) error {
+		redriven = crossID
+		return DLH.stateDB.RedriveDeadLetter(crossID)
+	})
+	// 非leader拒绝重新驱动
+	DLH.SetLeaderChecker(func() (bool, string) { return false, "node1" })
+	_, err = DLH.Handle(event.NewDeadLetterEvent(event.DeadLetterRedriveAction, crossID), true)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "node1")
+	require.Equal(t, "", redriven)
+	DLH.SetLeaderChecker(nil)
+	_, err = DLH.Handle(event.NewDeadLetterEvent(event.DeadLetterRedriveAction, crossID), true)
+	require.Nil(t, err)
+	require.Equal(t, crossID, redriven)
+	require.Equal(t, 0, len(DLH.List()))
+
+	_, err = DLH.Handle(event.NewDeadLetterEvent("unknown", crossID), true)
+	require.NotNil(t, err)
+}
@@ -18,6 +18,8 @@ const (
 	TransactionProcess
 	CrossProcess
 	CrossSearch
+	DeadLetterProcess
+	AdminProcess
 )
 
 type EventHandler interface {
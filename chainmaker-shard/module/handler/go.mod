@@ -20,6 +20,7 @@ replace (
 	chainmaker.org/chainmaker-cross/conf => ../conf
 	chainmaker.org/chainmaker-cross/event => ../event
 	chainmaker.org/chainmaker-cross/logger => ../logger
+	chainmaker.org/chainmaker-cross/monitor => ../monitor
 	chainmaker.org/chainmaker-cross/net => ../net
 	chainmaker.org/chainmaker-cross/pb/protogo => ../pb/protogo
 	chainmaker.org/chainmaker-cross/prover => ../prover
@@ -21,6 +21,10 @@ func InitEventHandlers(stateDB store.StateDB, eventChan chan event.Event) *Event
 	handlerTools.Register(getCrossProcessHandler(stateDB, eventChan))
 	// 注册CrossSearchHandler
 	handlerTools.Register(getCrossSearchHandler(stateDB))
+	// 注册DeadLetterHandler
+	handlerTools.Register(getDeadLetterHandler(stateDB))
+	// 注册AdminHandler
+	handlerTools.Register(getAdminHandler(stateDB))
 	return handlerTools
 }
 
@@ -57,3 +61,19 @@ func getCrossSearchHandler(stateDB store.StateDB) *CrossSearchHandler {
 	crossSearchHandler.SetLogger(logger.GetLogger(logger.ModuleHandler))
 	return crossSearchHandler
 }
+
+// getDeadLetterHandler return dead letter handler
+func getDeadLetterHandler(stateDB store.StateDB) *DeadLetterHandler {
+	deadLetterHandler := GetDeadLetterHandler()
+	deadLetterHandler.SetStateDB(stateDB)
+	deadLetterHandler.SetLogger(logger.GetLogger(logger.ModuleHandler))
+	return deadLetterHandler
+}
+
+// getAdminHandler return admin handler
+func getAdminHandler(stateDB store.StateDB) *AdminHandler {
+	adminHandler := GetAdminHandler()
+	adminHandler.SetStateDB(stateDB)
+	adminHandler.SetLogger(logger.GetLogger(logger.ModuleHandler))
+	return adminHandler
+}
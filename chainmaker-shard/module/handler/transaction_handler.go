@@ -6,6 +6,7 @@
 package handler
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -68,8 +69,16 @@ func (t *TransactionProcessHandler) Handle(eve event.Event, _ bool) (interface{}
 	// 进行强制类型转换
 	if txEventCtx, ok := eve.(*event.TransactionEventContext); ok {
 		ctxKey := txEventCtx.GetKey()
-		t.recordReceivedEvent(txEventCtx)
 		txEvent := txEventCtx.GetEvent()
+		if txEvent.OpFunc == event.AttestOpFunc || txEvent.OpFunc == event.SaveProofOpFunc {
+			// 背书及保存证明请求不改变跨链事务的状态，不记录状态
+			return t.handleStateless(ctxKey, txEvent)
+		}
+		if txEvent.OpFunc == event.AnnounceOpFunc {
+			// 链通告请求不属于跨链事务，不记录状态
+			return t.handleAnnouncement(ctxKey, txEvent), nil
+		}
+		t.recordReceivedEvent(txEventCtx)
 		opFuncType := txEvent.OpFunc
 		crossID, chainID := txEvent.GetCrossID(), txEvent.GetChainID()
 		proofResponse, err := t.dispatcher.Invoke(txEvent, conf.TxMsgResultMaxWaitTimeout)
@@ -132,6 +141,45 @@ func (t *TransactionProcessHandler) Handle(eve event.Event, _ bool) (interface{}
 	}
 }
 
+// handleStateless transfer the attestation or proof saving request to the directly connected chain
+func (t *TransactionProcessHandler) handleStateless(ctxKey string, txEvent *eventproto.TransactionEvent) (interface{}, error) {
+	proofResponse, err := t.dispatcher.Invoke(txEvent, conf.TxMsgResultMaxWaitTimeout)
+	if err != nil {
+		t.log.Errorf("%v of proof tx[%v] to chain[%v] failed, ", txEvent.OpFunc, txEvent.GetTxProof().GetTxKey(), txEvent.GetChainID(), err)
+		pResp := &event.ProofResponse{
+			ProofResponse: eventproto.ProofResponse{
+				CrossId:    txEvent.GetCrossID(),
+				Key:        ctxKey,
+				Code:       event.FailureResp,
+				Msg:        err.Error(),
+				OpFunc:     txEvent.OpFunc,
+				TxResponse: &eventproto.TxResponse{},
+			},
+		}
+		pResp.SetChainID(txEvent.GetChainID())
+		return pResp, err
+	}
+	proofResponse.SetKey(ctxKey)
+	return proofResponse, nil
+}
+
+// handleAnnouncement reply the announcement of chains connected directly, the payload is the announcement of requester
+func (t *TransactionProcessHandler) handleAnnouncement(ctxKey string, txEvent *eventproto.TransactionEvent) *event.ProofResponse {
+	requester := &eventproto.ChainAnnouncement{}
+	if err := json.Unmarshal(txEvent.GetPayload(), requester); err == nil {
+		t.log.Infof("receive announcement request from proxy[%v] with chains %v", requester.ProxyName, requester.ChainIDs)
+	}
+	proofResponse := event.NewProofResponse(txEvent.GetCrossID(), txEvent.GetChainID(), txEvent.OpFunc)
+	proofResponse.SetKey(ctxKey)
+	announcement, err := json.Marshal(t.dispatcher.LocalAnnouncement(requester.ProxyName))
+	if err != nil {
+		proofResponse.DoneError(err.Error())
+		return proofResponse
+	}
+	proofResponse.Done("", "", 0, 0, nil, announcement)
+	return proofResponse
+}
+
 func (t *TransactionProcessHandler) recordReceivedEvent(eve *event.TransactionEventContext) {
 	if err := t.db.WriteChainCrossState(eve.GetEvent().GetCrossID(), eve.GetEvent().GetChainID(), storetype.StateReceived, nil); err != nil {
 		t.log.Errorf("cross[%v]->chain[%v] write chain cross state failed, ", eve.GetEvent().GetCrossID(), eve.GetEvent().GetChainID(), err)
@@ -6,9 +6,15 @@
 package handler
 
 import (
+	"encoding/json"
 	"testing"
 
+	"chainmaker.org/chainmaker-cross/event"
 	"chainmaker.org/chainmaker-cross/logger"
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+	"chainmaker.org/chainmaker-cross/router"
+	"chainmaker.org/chainmaker-cross/store/kvdb"
+	"chainmaker.org/chainmaker-cross/store/kvdb/memory"
 	"github.com/stretchr/testify/require"
 )
 
@@ -27,3 +33,44 @@ func TestTransactionProcessHandler(t *testing.T) {
 	ht := TPH.GetType()
 	require.Equal(t, ht, TransactionProcess)
 }
+
+func TestTransactionProcessHandler_Announce(t *testing.T) {
+	TPH := GetTransactionProcessHandler()
+	TPH.SetLogger(logger.GetLogger(logger.ModuleHandler))
+	innerRouter := router.GetInnerRouter()
+	innerRouter.Init([]string{"chain-announce"})
+	require.Nil(t, router.GetDispatcher().Register(innerRouter))
+	payload, err := json.Marshal(&eventproto.ChainAnnouncement{ProxyName: "proxy-requester", ChainIDs: []string{"chain-peer"}})
+	require.Nil(t, err)
+	txEvent := event.NewTransactionEventContext("ctx-key", event.NewAnnounceTransactionEvent(payload))
+	result, err := TPH.Handle(txEvent, true)
+	require.Nil(t, err)
+	resp, ok := result.(*event.ProofResponse)
+	require.True(t, ok)
+	require.True(t, resp.IsSuccess())
+	require.Equal(t, "ctx-key", resp.GetKey())
+	announcement := &eventproto.ChainAnnouncement{}
+	require.Nil(t, json.Unmarshal(resp.GetExtra(), announcement))
+	require.Contains(t, announcement.ChainIDs, "chain-announce")
+}
+
+func TestTransactionProcessHandler_SaveProof(t *testing.T) {
+	TPH := GetTransactionProcessHandler()
+	TPH.SetLogger(logger.GetLogger(logger.ModuleHandler))
+	router.GetDispatcher().SetLogger(logger.GetLogger(logger.ModuleRouter))
+	stateDB := kvdb.NewKvStateDB(memory.NewMemProvider())
+	TPH.SetStateDB(stateDB)
+	defer TPH.SetStateDB(nil)
+	proof := event.NewProof("chain2", "txKey", 10, 1, nil, nil)
+	// 无法路由到该链，保存失败但不影响该链的跨链状态
+	txEvent := event.NewTransactionEventContext("ctx-key",
+		event.NewSaveProofTransactionEvent("cross-save-proof", "chain-unknown", "proofKey", proof, true))
+	result, err := TPH.Handle(txEvent, true)
+	require.NotNil(t, err)
+	resp, ok := result.(*event.ProofResponse)
+	require.True(t, ok)
+	require.False(t, resp.IsSuccess())
+	require.Equal(t, "ctx-key", resp.GetKey())
+	_, _, exist := stateDB.ReadChainCrossState("cross-save-proof", "chain-unknown")
+	require.False(t, exist)
+}
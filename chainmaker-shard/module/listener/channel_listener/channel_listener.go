@@ -8,18 +8,21 @@ package channel_listener
 import (
 	"context"
 	"errors"
+	"io"
 	"time"
 
 	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
 
+	"chainmaker.org/chainmaker-cross/channel/auth"
 	"chainmaker.org/chainmaker-cross/conf"
 	"chainmaker.org/chainmaker-cross/event"
 	"chainmaker.org/chainmaker-cross/event/coder"
 	"chainmaker.org/chainmaker-cross/handler"
 	"chainmaker.org/chainmaker-cross/logger"
+	"chainmaker.org/chainmaker-cross/monitor"
 	"chainmaker.org/chainmaker-cross/net"
 	libp2p "chainmaker.org/chainmaker-cross/net/net_libp2p"
-	"chainmaker.org/chainmaker-cross/utils"
+	"chainmaker.org/chainmaker-cross/router"
 	"github.com/libp2p/go-libp2p-core/protocol"
 	"go.uber.org/zap"
 )
@@ -34,6 +37,7 @@ type ChannelListener struct {
 	log        *zap.SugaredLogger     // log
 	coders     *coder.EventCoderTools // 编解码器
 	cancelFunc context.CancelFunc     // 退出信号函数
+	mdns       io.Closer              // 局域网代理发现服务，未开启时为nil
 }
 
 // NewChannelListener create new channel listener
@@ -50,6 +54,8 @@ func NewChannelListener() *ChannelListener {
 		if err != nil {
 			panic(err)
 		}
+		// 开启分帧时同时监听分帧协议，不支持分帧的代理仍使用分割符协议
+		monitorHost.SetFraming(libp2pChannelConfig.Framing)
 		peer = monitorHost
 	}
 	return &ChannelListener{
@@ -72,6 +78,10 @@ func (cl *ChannelListener) ListenStart() error {
 		cl.log.Errorf("eventHandler[%d] not exist", handler.TransactionProcess)
 		return errors.New("can not find handler to handle transaction event")
 	}
+	if err = cl.startMdns(); err != nil {
+		cl.log.Error("start mdns discovery failed, ", err)
+		return err
+	}
 	ctx, cancelFunc := context.WithCancel(context.Background())
 	cl.cancelFunc = cancelFunc
 	go func(ctx context.Context, ch chan net.Message) {
@@ -93,7 +103,18 @@ func (cl *ChannelListener) ListenStart() error {
 						// 打印错误信息
 						cl.log.Error("receive data length is illegal")
 					} else {
-						receivedData, _ := utils.Base64DecodeToBytes(string(msg.GetPayload()))
+						// 验证对端代理的签名，只信任全局公钥及该节点对应路由的公钥，未通过的请求直接丢弃，载荷由libp2p连接解码为二进制
+						authenticator := auth.GetAuthenticator()
+						receivedData, err := authenticator.Open(msg.GetPayload(), authenticator.PeerKeys(msg.GetNodeID()))
+						if err != nil {
+							cl.log.Warnf("reject data from peer[%s], %v", msg.GetNodeID(), err)
+							monitor.ObserveRejectedMessage(monitor.MessageSourceListener, auth.ReasonOf(err))
+							return
+						}
+						if len(receivedData) < coder.MinLength {
+							cl.log.Error("receive data length is illegal")
+							return
+						}
 						eventTy, marshalTy := eventproto.EventType(receivedData[coder.EventTyIndex]), event.MarshalType(receivedData[coder.MarshalTyIndex])
 						if eventTy == eventproto.TransactionCtxEventType {
 							if eveCoder, exist := cl.coders.GetDefaultCoder(eventTy); exist {
@@ -115,8 +136,10 @@ func (cl *ChannelListener) ListenStart() error {
 													// 进行二进制的序列化
 													binary, err := respCoder.MarshalToBinary(resp)
 													if err == nil {
-														sendData := utils.Base64EncodeToString(binary)
-														if m, err := libp2p.NewLibP2pMessage(msg.GetNodeID(), []byte(sendData), false); err != nil {
+														binary, err = auth.GetAuthenticator().Seal(binary)
+													}
+													if err == nil {
+														if m, err := libp2p.NewLibP2pMessage(msg.GetNodeID(), binary, false); err != nil {
 															cl.log.Error("generate LibP2pMessage failed, ", err)
 														} else {
 															if err := cl.peer.Write(m); err != nil {
@@ -157,8 +180,35 @@ func (cl *ChannelListener) ListenStart() error {
 	return nil
 }
 
+// startMdns advertise current proxy in local network, and add the proxies found to the routers
+func (cl *ChannelListener) startMdns() error {
+	discovery := conf.Config.Discovery
+	if !discovery.IsMdnsEnabled() {
+		return nil
+	}
+	node, ok := cl.peer.(*libp2p.LibP2pNode)
+	if !ok {
+		return errors.New("mdns discovery requires the libp2p channel listener")
+	}
+	mdns, err := node.StartMdns(time.Duration(discovery.Mdns.Interval)*time.Second, discovery.Mdns.ServiceTag, func(address string) {
+		if err := router.GetDispatcher().AddPeer(address); err != nil {
+			cl.log.Warnf("add peer found by mdns failed, %v", err)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	cl.mdns = mdns
+	return nil
+}
+
 // Stop stop listener server
 func (cl *ChannelListener) Stop() error {
+	if cl.mdns != nil {
+		if err := cl.mdns.Close(); err != nil {
+			cl.log.Warn("close mdns discovery failed, ", err)
+		}
+	}
 	cl.cancelFunc()
 	cl.log.Info("Module channel-listener stopped")
 	return nil
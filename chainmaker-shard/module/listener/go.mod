@@ -8,10 +8,14 @@ require (
 	chainmaker.org/chainmaker-cross/event v0.0.0
 	chainmaker.org/chainmaker-cross/handler v0.0.0
 	chainmaker.org/chainmaker-cross/logger v0.0.0
+	chainmaker.org/chainmaker-cross/monitor v0.0.0
 	chainmaker.org/chainmaker-cross/net v0.0.0
 	chainmaker.org/chainmaker-cross/pb/protogo v0.0.0
+	chainmaker.org/chainmaker-cross/router v0.0.0
+	chainmaker.org/chainmaker-cross/store v0.0.0
 	chainmaker.org/chainmaker-cross/utils v0.0.0
 	github.com/gin-gonic/gin v1.7.2
+	github.com/gorilla/websocket v1.4.2
 	github.com/libp2p/go-libp2p v0.13.0
 	github.com/libp2p/go-libp2p-core v0.8.5
 	github.com/multiformats/go-multiaddr v0.3.1
@@ -28,6 +32,7 @@ replace (
 	chainmaker.org/chainmaker-cross/event => ../event
 	chainmaker.org/chainmaker-cross/handler => ../handler
 	chainmaker.org/chainmaker-cross/logger => ../logger
+	chainmaker.org/chainmaker-cross/monitor => ../monitor
 	chainmaker.org/chainmaker-cross/net => ./../net
 	chainmaker.org/chainmaker-cross/pb/protogo => ../pb/protogo
 	chainmaker.org/chainmaker-cross/prover => ../prover
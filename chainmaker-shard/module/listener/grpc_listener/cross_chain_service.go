@@ -0,0 +1,201 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package grpc_listener
+
+import (
+	"context"
+	"net"
+	"time"
+
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+
+	"chainmaker.org/chainmaker-cross/channel/auth"
+	"chainmaker.org/chainmaker-cross/event"
+	"chainmaker.org/chainmaker-cross/event/coder"
+	"chainmaker.org/chainmaker-cross/handler"
+	"chainmaker.org/chainmaker-cross/monitor"
+	"chainmaker.org/chainmaker-cross/store"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	WatchInterval = 5 * time.Second // 跨链结果推送的兜底查询间隔，状态变更时会立即查询
+)
+
+var _ eventproto.CrossChainServiceServer = (*CrossChainService)(nil)
+
+// CrossChainService implement of grpc CrossChainServiceServer, which is backed by the event handlers
+type CrossChainService struct {
+	handlerTools *handler.EventHandlerTools // 事件处理器
+	openTxRoute  bool                       // 是否开启事务处理接口
+	log          *zap.SugaredLogger         // log
+}
+
+// NewCrossChainService create new instance of CrossChainService
+func NewCrossChainService(openTxRoute bool, log *zap.SugaredLogger) *CrossChainService {
+	return &CrossChainService{
+		handlerTools: handler.GetEventHandlerTools(),
+		openTxRoute:  openTxRoute,
+		log:          log,
+	}
+}
+
+// InvokeCrossEvent receive cross event and start handle, the cross id will be returned
+func (s *CrossChainService) InvokeCrossEvent(_ context.Context, crossEvent *eventproto.CrossEvent) (*eventproto.CrossResponse, error) {
+	if err := crossEvent.Validate(); err != nil {
+		s.log.Errorf("cross event[%s] is invalid, %v", crossEvent.GetCrossId(), err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	eveHandler, err := s.getHandler(handler.CrossProcess)
+	if err != nil {
+		return nil, err
+	}
+	go func(crossEvent *eventproto.CrossEvent) {
+		// 独立goroutine处理该状态
+		if _, err := eveHandler.Handle(crossEvent, false); err != nil {
+			s.log.Errorf("handle cross event[%s] error, %v", crossEvent.GetCrossId(), err)
+		}
+	}(crossEvent)
+	return event.NewCrossResponse(crossEvent.GetCrossId(), event.UnknownResp, ""), nil
+}
+
+// GetCrossEvent search the result of cross event
+func (s *CrossChainService) GetCrossEvent(_ context.Context, searchEvent *eventproto.CrossSearchEvent) (*eventproto.CrossResponse, error) {
+	return s.searchCrossEvent(searchEvent)
+}
+
+// TransactionEvent handle transaction event which is sent by other cross-chain proxy
+func (s *CrossChainService) TransactionEvent(ctx context.Context, req *eventproto.TransactionRequest) (*eventproto.ProofResponse, error) {
+	if !s.openTxRoute {
+		return nil, status.Error(codes.Unimplemented, "transaction route is not opened")
+	}
+	eveHandler, err := s.getHandler(handler.TransactionProcess)
+	if err != nil {
+		return nil, err
+	}
+	tec, err := s.openTransactionEvent(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	result, err := eveHandler.Handle(tec, true)
+	if err != nil {
+		s.log.Error("handle transaction event failed: ", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	// 需要结果是*event.ProofResponse
+	if resp, ok := result.(*event.ProofResponse); ok {
+		return &resp.ProofResponse, nil
+	}
+	s.log.Error("grpc resp result is not the type of ProofResponse")
+	return nil, status.Error(codes.Internal, "resp result can not convert to ProofResponse")
+}
+
+// WatchCrossEvent push the result of cross event when it changed, until the cross event is finished
+func (s *CrossChainService) WatchCrossEvent(searchEvent *eventproto.CrossSearchEvent, stream eventproto.CrossChainService_WatchCrossEventServer) error {
+	var last *eventproto.CrossResponse
+	// 先订阅再查询，避免遗漏状态变更
+	notifier := store.GetStateNotifier()
+	sub := notifier.Subscribe(searchEvent.GetCrossId())
+	defer notifier.Unsubscribe(sub)
+	ticker := time.NewTicker(WatchInterval)
+	defer ticker.Stop()
+	for {
+		resp, err := s.searchCrossEvent(searchEvent)
+		if err != nil {
+			return err
+		}
+		if last == nil || last.Code != resp.Code || last.Msg != resp.Msg {
+			if err := stream.Send(resp); err != nil {
+				s.log.Errorf("push cross[%s]'s result error, %v", searchEvent.GetCrossId(), err)
+				return err
+			}
+			last = resp
+		}
+		// 跨链事务已结束
+		if resp.Code == event.SuccessResp || resp.Code == event.FailureResp {
+			return nil
+		}
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-ticker.C:
+		case <-sub.C():
+		}
+	}
+}
+
+// openTransactionEvent verify the signed event of request by the keys trusted on the connection from the peer,
+// and return the transaction event context in it. The unsigned key and event are only accepted when the
+// authenticator is disabled or allows unsigned messages
+func (s *CrossChainService) openTransactionEvent(ctx context.Context, req *eventproto.TransactionRequest) (*event.TransactionEventContext, error) {
+	host := peerHost(ctx)
+	authenticator := auth.GetAuthenticator()
+	data, err := authenticator.Open(req.GetSignedEvent(), authenticator.PeerKeys(host))
+	if err != nil {
+		s.log.Warnf("reject request from [%s], %v", host, err)
+		monitor.ObserveRejectedMessage(monitor.MessageSourceGrpc, auth.ReasonOf(err))
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if len(data) == 0 {
+		if req.GetEvent() == nil {
+			return nil, status.Error(codes.InvalidArgument, "transaction event is nil")
+		}
+		return event.NewTransactionEventContext(req.GetKey(), req.GetEvent()), nil
+	}
+	eveCoder, exist := coder.GetEventCoderTools().GetDefaultCoder(eventproto.TransactionCtxEventType)
+	if !exist {
+		return nil, status.Error(codes.Unavailable, "can not find coder for transaction event")
+	}
+	eve, err := eveCoder.UnmarshalFromBinary(data)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unmarshal signed event failed, %v", err)
+	}
+	tec, ok := eve.(*event.TransactionEventContext)
+	if !ok || tec.GetEvent() == nil {
+		return nil, status.Error(codes.InvalidArgument, "signed event is not transaction event")
+	}
+	return tec, nil
+}
+
+// peerHost return the host of remote address, which identifies the peer proxy
+func peerHost(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+func (s *CrossChainService) searchCrossEvent(searchEvent *eventproto.CrossSearchEvent) (*eventproto.CrossResponse, error) {
+	eveHandler, err := s.getHandler(handler.CrossSearch)
+	if err != nil {
+		return nil, err
+	}
+	result, err := eveHandler.Handle(searchEvent, true)
+	if err != nil {
+		s.log.Errorf("handle cross search event[%s] error, %v", searchEvent.GetCrossId(), err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if resp, ok := result.(*eventproto.CrossResponse); ok {
+		return resp, nil
+	}
+	return nil, status.Error(codes.Internal, "search result can not convert to CrossResponse")
+}
+
+func (s *CrossChainService) getHandler(handlerType handler.HandlerType) (handler.EventHandler, error) {
+	if eveHandler, exist := s.handlerTools.GetHandler(handlerType); exist {
+		return eveHandler, nil
+	}
+	s.log.Errorf("can not find handler for [%v]", handlerType)
+	return nil, status.Errorf(codes.Unavailable, "can not find handler for [%v]", handlerType)
+}
@@ -6,55 +6,107 @@
 package grpc_listener
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
 	"net"
+	"strings"
 
 	"chainmaker.org/chainmaker-cross/conf"
 	"chainmaker.org/chainmaker-cross/logger"
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 // GrpcListener - grpc server
 type GrpcListener struct {
 	server   *grpc.Server       // server
 	listener net.Listener       // listener
+	config   *conf.GrpcConfig   // grpc服务配置
 	log      *zap.SugaredLogger // log
 }
 
 // NewGrpcListener create new grpc server listener
 func NewGrpcListener() *GrpcListener {
 	log := logger.GetLogger(logger.ModuleGrpcListener)
-	grpcConf := conf.Config.ListenerConfig.GrpcConfig
-
-	lis, err := net.Listen(grpcConf.Network, grpcConf.Address)
-	if err != nil {
-		log.Errorf("failed to listen: %v", err)
-	}
-
-	s := grpc.NewServer()
 	return &GrpcListener{
-		server:   s,
-		listener: lis,
-		log:      log,
+		config: conf.Config.ListenerConfig.GrpcConfig,
+		log:    log,
 	}
 }
 
 // ListenStart start grpc listener
 func (gl *GrpcListener) ListenStart() error {
-	var err error
-	// TODO register methods
+	opts := make([]grpc.ServerOption, 0)
+	if gl.config.EnableTLS {
+		tlsConfig, err := newServerTLSConfig(gl.config.Security)
+		if err != nil {
+			gl.log.Error("Grpc Server TLS config:", err)
+			return err
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	lis, err := net.Listen(gl.config.Network, gl.config.Address)
+	if err != nil {
+		gl.log.Errorf("failed to listen: %v", err)
+		return err
+	}
+	gl.listener = lis
+	gl.server = grpc.NewServer(opts...)
+	// 注册跨链服务
+	eventproto.RegisterCrossChainServiceServer(gl.server, NewCrossChainService(gl.config.OpenTxRoute, gl.log))
 	go func() {
-		if err = gl.server.Serve(gl.listener); err != nil {
+		if err := gl.server.Serve(gl.listener); err != nil {
 			gl.log.Errorf("failed to start grpc serve: %v", err)
 		}
 	}()
-
-	return err
+	gl.log.Infof("Module grpc-listener started, listen on %s://%s", gl.config.Network, gl.config.Address)
+	return nil
 }
 
 // Stop stop listener server
 func (gl *GrpcListener) Stop() error {
-	gl.server.Stop()
+	if gl.server != nil {
+		gl.server.Stop()
+	}
 	gl.log.Info("Module grpc-listener stopped")
 	return nil
 }
+
+// newServerTLSConfig create tls config of server, client cert will be verified when ca_auth is enabled
+func newServerTLSConfig(security *conf.TransportSecurity) (*tls.Config, error) {
+	if security == nil {
+		return nil, errors.New("missing tls security configuration")
+	}
+	if security.CertFile == "" || security.KeyFile == "" {
+		return nil, errors.New("missing server's cert/key configuration")
+	}
+	cert, err := tls.LoadX509KeyPair(security.CertFile, security.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+	}
+	if security.EnableCertAuth {
+		caFiles := strings.Split(security.CAFile, ",")
+		if len(caFiles) == 0 {
+			return nil, errors.New("missing ca files in security config")
+		}
+		pool := x509.NewCertPool()
+		for _, caFile := range caFiles {
+			caCrt, err := ioutil.ReadFile(caFile)
+			if err != nil {
+				return nil, fmt.Errorf("reading ca[%s]: %v", caFile, err)
+			}
+			pool.AppendCertsFromPEM(caCrt)
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsConfig, nil
+}
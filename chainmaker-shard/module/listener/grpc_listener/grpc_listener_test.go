@@ -0,0 +1,106 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package grpc_listener
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"net"
+	"testing"
+	"time"
+
+	"chainmaker.org/chainmaker-cross/channel/auth"
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/event"
+	"chainmaker.org/chainmaker-cross/event/coder"
+	"chainmaker.org/chainmaker-cross/logger"
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewServerTLSConfig(t *testing.T) {
+	_, err := newServerTLSConfig(nil)
+	require.Error(t, err)
+
+	_, err = newServerTLSConfig(&conf.TransportSecurity{CertFile: "server.crt"})
+	require.Error(t, err)
+
+	_, err = newServerTLSConfig(&conf.TransportSecurity{CertFile: "not_exist.crt", KeyFile: "not_exist.key"})
+	require.Error(t, err)
+}
+
+func TestListenStart(t *testing.T) {
+	conf.Config.ListenerConfig = &conf.ListenerConfig{
+		GrpcConfig: &conf.GrpcConfig{
+			Network: "tcp",
+			Address: "127.0.0.1:0",
+		},
+	}
+	gl := NewGrpcListener()
+	require.NoError(t, gl.ListenStart())
+	require.NoError(t, gl.Stop())
+}
+
+func TestCrossChainService_InvokeInvalidCrossEvent(t *testing.T) {
+	service := NewCrossChainService(false, logger.GetLogger(logger.ModuleGrpcListener))
+	invalidEvents := []*eventproto.CrossEvent{
+		{CrossId: "empty"},
+		{CrossId: "index", TxEvents: &eventproto.CrossTxs{Events: []*eventproto.CrossTx{{ChainId: "chain1", Index: 1}}}},
+		{CrossId: "chain", TxEvents: &eventproto.CrossTxs{Events: []*eventproto.CrossTx{{ChainId: "chain1"}, {Index: 1}}}},
+	}
+	for _, crossEvent := range invalidEvents {
+		_, err := service.InvokeCrossEvent(context.Background(), crossEvent)
+		require.Equal(t, codes.InvalidArgument, status.Code(err), crossEvent.CrossId)
+	}
+}
+
+func TestCrossChainService_OpenTransactionEvent(t *testing.T) {
+	service := NewCrossChainService(true, logger.GetLogger(logger.ModuleGrpcListener))
+	senderKey, senderPrivateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	_, receiverPrivateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	authenticator := auth.GetAuthenticator()
+	authenticator.Enable(receiverPrivateKey, nil, time.Minute, false)
+	authenticator.SetPeerKeys(map[string][]ed25519.PublicKey{"127.0.0.1": {senderKey}})
+	defer authenticator.Disable()
+
+	txEvent := &eventproto.TransactionEvent{CrossId: "cross", ChainId: "chain1"}
+	eveCoder, exist := coder.GetEventCoderTools().GetDefaultCoder(eventproto.TransactionCtxEventType)
+	require.True(t, exist)
+	data, err := eveCoder.MarshalToBinary(event.NewTransactionEventContext("key", txEvent))
+	require.NoError(t, err)
+	msg := &eventproto.SignedMessage{
+		Payload:   data,
+		Signer:    hex.EncodeToString(senderKey),
+		Nonce:     "nonce",
+		Timestamp: time.Now().Unix(),
+	}
+	msg.Signature = hex.EncodeToString(ed25519.Sign(senderPrivateKey, auth.MessageHash(msg)))
+	signed, err := coder.JsonBinaryMarshal(eventproto.SignedMessageType, msg)
+	require.NoError(t, err)
+	peerContext := func(ip string) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 19527}})
+	}
+
+	// 路由公钥不能通过其他主机的连接发起请求
+	_, err = service.openTransactionEvent(peerContext("127.0.0.2"), &eventproto.TransactionRequest{SignedEvent: signed})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	tec, err := service.openTransactionEvent(peerContext("127.0.0.1"), &eventproto.TransactionRequest{SignedEvent: signed})
+	require.NoError(t, err)
+	require.Equal(t, "key", tec.GetKey())
+	require.Equal(t, txEvent.CrossId, tec.GetEvent().CrossId)
+	// 重放
+	_, err = service.openTransactionEvent(peerContext("127.0.0.1"), &eventproto.TransactionRequest{SignedEvent: signed})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	// 未签名
+	_, err = service.openTransactionEvent(peerContext("127.0.0.1"), &eventproto.TransactionRequest{Key: "key", Event: txEvent})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -6,10 +6,13 @@
 package listener
 
 import (
+	"chainmaker.org/chainmaker-cross/conf"
 	"chainmaker.org/chainmaker-cross/handler"
 	"chainmaker.org/chainmaker-cross/listener/channel_listener"
+	"chainmaker.org/chainmaker-cross/listener/grpc_listener"
 	"chainmaker.org/chainmaker-cross/listener/inner_listener"
 	"chainmaker.org/chainmaker-cross/listener/web_listener"
+	"chainmaker.org/chainmaker-cross/listener/webhook"
 )
 
 // Listener is listener
@@ -48,7 +51,11 @@ func (m *Manager) InitListeners() {
 	cl := channel_listener.NewChannelListener()
 	il := inner_listener.NewInnerListener()
 	wl := web_listener.NewWebListener()
-	m.listeners = append(m.listeners, cl, il, wl)
+	m.listeners = append(m.listeners, cl, il, wl, webhook.GetDispatcher())
+	// grpc监听服务为可选配置
+	if conf.Config.ListenerConfig != nil && conf.Config.ListenerConfig.GrpcConfig != nil {
+		m.listeners = append(m.listeners, grpc_listener.NewGrpcListener())
+	}
 }
 
 // Start all the listener start
@@ -0,0 +1,126 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package methods
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+
+	"chainmaker.org/chainmaker-cross/event"
+	"chainmaker.org/chainmaker-cross/handler"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	AdminTag         = "admin"         // 运维接口路径
+	AdminCrossIDPath = ":cross_id"     // 跨链ID路径参数
+	AdminRetryTag    = "retry"         // 单链重试路径
+	AdminResolveTag  = "resolve"       // 手动处理路径
+	AdminHistoryTag  = "history"       // 状态历史路径
+	AdminRestoreTag  = "restore"       // 归档恢复路径
+	AdminStateParam  = "state"         // 列表过滤参数
+	AuthHeader       = "Authorization" // 认证头
+	BearerPrefix     = "Bearer "       // 认证头前缀
+)
+
+var (
+	unauthorizedError = errors.New("invalid admin token")
+	nonAdminHandler   = errors.New("can not find handler for admin event")
+)
+
+// AdminResp response of admin request
+type AdminResp = DeadLetterResp
+
+// adminRetryReq body of retry request
+type adminRetryReq struct {
+	ChainID string `json:"chain_id"` // 重试的链ID
+	Op      string `json:"op"`       // commit或rollback
+}
+
+// adminResolveReq body of resolve request
+type adminResolveReq struct {
+	Note string `json:"note"` // 处理备注
+}
+
+// AdminAuth return the middleware which check the bearer token of admin request
+func AdminAuth(token string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		header := ctx.GetHeader(AuthHeader)
+		if !strings.HasPrefix(header, BearerPrefix) ||
+			subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(header, BearerPrefix)), []byte(token)) != 1 {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, &AdminResp{Code: event.ErrorResp, Message: unauthorizedError.Error()})
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// ListAdminCross list the crossIDs of unfinished, failed or dead-letter cross transactions
+func ListAdminCross(ctx *gin.Context) {
+	adminEvent := event.NewAdminEvent(event.AdminListAction, "")
+	adminEvent.Filter = ctx.Query(AdminStateParam)
+	handleAdminEvent(ctx, adminEvent)
+}
+
+// ShowAdminCross show the state of cross transaction with the detail of each chain
+func ShowAdminCross(ctx *gin.Context) {
+	handleAdminEvent(ctx, event.NewAdminEvent(event.AdminShowAction, ctx.Param(CrossIDParam)))
+}
+
+// RetryAdminCross force a retry of commit or rollback for one chain
+func RetryAdminCross(ctx *gin.Context) {
+	req := &adminRetryReq{}
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		log.Error("resolve param error:", err)
+		jsonResponse(ctx, http.StatusBadRequest, &AdminResp{Code: event.ErrorResp, Message: err.Error()})
+		return
+	}
+	adminEvent := event.NewAdminEvent(event.AdminRetryAction, ctx.Param(CrossIDParam))
+	adminEvent.ChainID, adminEvent.Op = req.ChainID, req.Op
+	handleAdminEvent(ctx, adminEvent)
+}
+
+// ResolveAdminCross mark the cross transaction as resolved with the note of operator
+func ResolveAdminCross(ctx *gin.Context) {
+	req := &adminResolveReq{}
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		log.Error("resolve param error:", err)
+		jsonResponse(ctx, http.StatusBadRequest, &AdminResp{Code: event.ErrorResp, Message: err.Error()})
+		return
+	}
+	adminEvent := event.NewAdminEvent(event.AdminResolveAction, ctx.Param(CrossIDParam))
+	adminEvent.Note = req.Note
+	handleAdminEvent(ctx, adminEvent)
+}
+
+// AdminCrossHistory export the full state history of cross transaction
+func AdminCrossHistory(ctx *gin.Context) {
+	handleAdminEvent(ctx, event.NewAdminEvent(event.AdminHistoryAction, ctx.Param(CrossIDParam)))
+}
+
+// RestoreAdminCross restore the archived cross transaction for search
+func RestoreAdminCross(ctx *gin.Context) {
+	handleAdminEvent(ctx, event.NewAdminEvent(event.AdminRestoreAction, ctx.Param(CrossIDParam)))
+}
+
+func handleAdminEvent(ctx *gin.Context, adminEvent *event.AdminEvent) {
+	eveHandler, exist := handler.GetEventHandlerTools().GetHandler(handler.AdminProcess)
+	if !exist {
+		log.Error(nonAdminHandler.Error())
+		jsonResponse(ctx, http.StatusNotImplemented, &AdminResp{Code: event.ErrorResp, Message: nonAdminHandler.Error()})
+		return
+	}
+	log.Infof("receive admin request[%s] of cross[%s]", adminEvent.Action, adminEvent.GetCrossID())
+	result, err := eveHandler.Handle(adminEvent, true)
+	if err != nil {
+		log.Errorf("handle admin event[%s] of cross[%s] error, %v", adminEvent.Action, adminEvent.GetCrossID(), err)
+		jsonResponse(ctx, http.StatusOK, &AdminResp{Code: event.FailureResp, Message: err.Error()})
+		return
+	}
+	jsonResponse(ctx, http.StatusOK, &AdminResp{Code: event.SuccessResp, Data: result})
+}
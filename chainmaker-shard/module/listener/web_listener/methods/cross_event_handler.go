@@ -6,12 +6,15 @@
 package methods
 
 import (
+	"net"
 	"net/http"
 
 	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
 
+	"chainmaker.org/chainmaker-cross/channel/auth"
 	"chainmaker.org/chainmaker-cross/event"
 	"chainmaker.org/chainmaker-cross/handler"
+	"chainmaker.org/chainmaker-cross/monitor"
 	"chainmaker.org/chainmaker-cross/net/net_http"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -44,6 +47,11 @@ func (c *CrossEventContextHandler) Handle(ctx *gin.Context) {
 		log.Error("resolve param error:", err)
 		return
 	}
+	if err := crossEvent.Validate(); err != nil {
+		log.Errorf("cross event[%s] is invalid, %v", crossEvent.GetCrossID(), err)
+		jsonResponse(ctx, http.StatusBadRequest, event.NewCrossResponse(crossEvent.GetCrossID(), event.ErrorResp, err.Error()))
+		return
+	}
 	go func(crossEvent *eventproto.CrossEvent) {
 		// 独立goroutine处理该状态
 		if _, err := c.eventHandler.Handle(crossEvent, false); err != nil {
@@ -126,6 +134,28 @@ func (t *TransactionEventContextHandler) Handle(ctx *gin.Context) {
 		})
 		return
 	}
+	// 验证对端代理的签名，只有二进制编码的消息能够被签名
+	// 只信任全局公钥及连接来源主机对应路由的公钥，来源取自连接地址，不使用可伪造的转发头
+	var err error
+	authenticator := auth.GetAuthenticator()
+	host, _, splitErr := net.SplitHostPort(ctx.Request.RemoteAddr)
+	if splitErr != nil {
+		host = ctx.Request.RemoteAddr
+	}
+	if req.EncodeType == event.BinaryMarshalType {
+		req.Payload, err = authenticator.OpenEncoded(req.Payload, authenticator.PeerKeys(host))
+	} else {
+		_, err = authenticator.Open(req.Payload, authenticator.PeerKeys(host))
+	}
+	if err != nil {
+		log.Warnf("reject request from [%s], %v", host, err)
+		monitor.ObserveRejectedMessage(monitor.MessageSourceWeb, auth.ReasonOf(err))
+		jsonOkResponse(ctx, Response{
+			Code:    100,
+			Message: err.Error(),
+		})
+		return
+	}
 	tec := &event.TransactionEventContext{}
 	if err := req.Unmarshal(tec); err != nil {
 		log.Error("request unmarshal error:", err)
@@ -147,6 +177,9 @@ func (t *TransactionEventContextHandler) Handle(ctx *gin.Context) {
 		// 需要结果是*event.ProofResponse
 		if resp, ok := result.(*event.ProofResponse); ok {
 			data, err := net_http.NewMessage(resp, event.BinaryMarshalType)
+			if err == nil {
+				data.Payload, err = auth.GetAuthenticator().SealEncoded(data.Payload)
+			}
 			if err != nil {
 				log.Error("ProofResponse convert to NewMessage fail", err)
 				return
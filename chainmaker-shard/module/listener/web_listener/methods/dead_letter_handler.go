@@ -0,0 +1,61 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package methods
+
+import (
+	"errors"
+	"net/http"
+
+	"chainmaker.org/chainmaker-cross/event"
+	"chainmaker.org/chainmaker-cross/handler"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	AdminDeadLetterTag = "deadletter" // 死信接口路径
+	AdminRedriveTag    = "redrive"    // 重新驱动路径
+)
+
+var nonDeadLetterHandler = errors.New("can not find handler for dead letter event")
+
+// DeadLetterResp response of dead-letter request
+type DeadLetterResp struct {
+	Code    int32       `json:"code"`
+	Message string      `json:"message,omitempty"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// ListDeadLetters list the crossIDs in the dead-letter set
+func ListDeadLetters(ctx *gin.Context) {
+	handleDeadLetterEvent(ctx, event.NewDeadLetterEvent(event.DeadLetterListAction, ""))
+}
+
+// InspectDeadLetter show the dead-letter record of cross transaction
+func InspectDeadLetter(ctx *gin.Context) {
+	handleDeadLetterEvent(ctx, event.NewDeadLetterEvent(event.DeadLetterInspectAction, ctx.Param(CrossIDParam)))
+}
+
+// RedriveDeadLetter move the cross transaction back to the unfinished set and handle it again
+func RedriveDeadLetter(ctx *gin.Context) {
+	handleDeadLetterEvent(ctx, event.NewDeadLetterEvent(event.DeadLetterRedriveAction, ctx.Param(CrossIDParam)))
+}
+
+func handleDeadLetterEvent(ctx *gin.Context, deadLetterEvent *event.DeadLetterEvent) {
+	eveHandler, exist := handler.GetEventHandlerTools().GetHandler(handler.DeadLetterProcess)
+	if !exist {
+		log.Error(nonDeadLetterHandler.Error())
+		jsonResponse(ctx, http.StatusNotImplemented, &DeadLetterResp{Code: event.ErrorResp, Message: nonDeadLetterHandler.Error()})
+		return
+	}
+	log.Infof("receive dead letter request[%s] of cross[%s]", deadLetterEvent.Action, deadLetterEvent.GetCrossID())
+	result, err := eveHandler.Handle(deadLetterEvent, true)
+	if err != nil {
+		log.Errorf("handle dead letter event[%s] of cross[%s] error, %v", deadLetterEvent.Action, deadLetterEvent.GetCrossID(), err)
+		jsonResponse(ctx, http.StatusOK, &DeadLetterResp{Code: event.FailureResp, Message: err.Error()})
+		return
+	}
+	jsonResponse(ctx, http.StatusOK, &DeadLetterResp{Code: event.SuccessResp, Data: result})
+}
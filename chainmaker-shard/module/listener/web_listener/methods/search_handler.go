@@ -0,0 +1,48 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package methods
+
+import (
+	"errors"
+	"net/http"
+
+	"chainmaker.org/chainmaker-cross/event"
+	"chainmaker.org/chainmaker-cross/handler"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	SearchTag = "search" // 历史查询路径
+)
+
+var nonSearchHandler = errors.New("can not find handler for cross search event")
+
+// SearchResp response of search request
+type SearchResp = DeadLetterResp
+
+// SearchCrossEvent search the history of cross transactions by chain_id, state, initiator and time range,
+// the next page can be loaded by the cursor of response
+func SearchCrossEvent(ctx *gin.Context) {
+	queryEvent := &event.CrossQueryEvent{}
+	if err := ctx.ShouldBindQuery(queryEvent); err != nil {
+		log.Error("resolve param error:", err)
+		jsonResponse(ctx, http.StatusBadRequest, &SearchResp{Code: event.ErrorResp, Message: err.Error()})
+		return
+	}
+	eveHandler, exist := handler.GetEventHandlerTools().GetHandler(handler.CrossSearch)
+	if !exist {
+		log.Error(nonSearchHandler.Error())
+		jsonResponse(ctx, http.StatusNotImplemented, &SearchResp{Code: event.ErrorResp, Message: nonSearchHandler.Error()})
+		return
+	}
+	result, err := eveHandler.Handle(queryEvent, true)
+	if err != nil {
+		log.Errorf("handle cross query event error, %v", err)
+		jsonResponse(ctx, http.StatusOK, &SearchResp{Code: event.FailureResp, Message: err.Error()})
+		return
+	}
+	jsonResponse(ctx, http.StatusOK, &SearchResp{Code: event.SuccessResp, Data: result})
+}
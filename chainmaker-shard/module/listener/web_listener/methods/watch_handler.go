@@ -0,0 +1,184 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package methods
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/event"
+	"chainmaker.org/chainmaker-cross/handler"
+	"chainmaker.org/chainmaker-cross/store"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	WatchTag          = "watch"          // SSE订阅路径
+	WebSocketTag      = "ws"             // WebSocket订阅路径
+	CrossIDParam      = "cross_id"       // 订阅的跨链ID参数
+	StateEventName    = "state"          // 状态变更事件
+	ResultEventName   = "result"         // 跨链结果事件
+	PingEventName     = "ping"           // 心跳事件
+	HeartbeatInterval = 15 * time.Second // 心跳间隔
+)
+
+var (
+	missCrossIDError = errors.New("missing cross_id in request")
+	upgrader         = websocket.Upgrader{
+		CheckOrigin: checkOrigin,
+	}
+)
+
+// wsMessage message pushed by websocket
+type wsMessage struct {
+	Event string      `json:"event"`
+	Data  interface{} `json:"data,omitempty"`
+}
+
+// WatchCrossEvent push the state changes of cross transaction by Server-Sent Events
+func WatchCrossEvent(ctx *gin.Context) {
+	crossID, ok := ctx.GetQuery(CrossIDParam)
+	if !ok || crossID == "" {
+		jsonResponse(ctx, http.StatusBadRequest, missCrossIDError.Error())
+		return
+	}
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+	err := watchCross(ctx.Request.Context(), crossID, func(name string, data interface{}) error {
+		ctx.SSEvent(name, data)
+		ctx.Writer.Flush()
+		return ctx.Request.Context().Err()
+	})
+	if err != nil {
+		log.Warnf("watch cross[%s] by sse stopped, %v", crossID, err)
+	}
+}
+
+// WatchCrossEventByWebSocket push the state changes of cross transaction by WebSocket
+func WatchCrossEventByWebSocket(ctx *gin.Context) {
+	crossID, ok := ctx.GetQuery(CrossIDParam)
+	if !ok || crossID == "" {
+		jsonResponse(ctx, http.StatusBadRequest, missCrossIDError.Error())
+		return
+	}
+	conn, err := upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
+	if err != nil {
+		log.Error("upgrade to websocket failed: ", err)
+		return
+	}
+	defer conn.Close()
+	wsCtx, cancel := context.WithCancel(ctx.Request.Context())
+	defer cancel()
+	// 读取客户端消息，连接关闭时结束推送
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+	err = watchCross(wsCtx, crossID, func(name string, data interface{}) error {
+		return conn.WriteJSON(&wsMessage{Event: name, Data: data})
+	})
+	if err != nil {
+		log.Warnf("watch cross[%s] by websocket stopped, %v", crossID, err)
+		return
+	}
+	_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+}
+
+// checkOrigin allow the request without origin (non-browser client), from the same origin,
+// or from the origins configured in allowed_origins, to avoid cross-site websocket hijacking
+func checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	var allowedOrigins []string
+	if listenerConfig := conf.Config.ListenerConfig; listenerConfig != nil && listenerConfig.WebConfig != nil {
+		allowedOrigins = listenerConfig.WebConfig.AllowedOrigins
+	}
+	for _, allowed := range allowedOrigins {
+		if allowed == "*" || strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	log.Warnf("websocket origin %s is not allowed", origin)
+	return false
+}
+
+// watchCross send the state changes of crossID until it is finished, the final result will be sent at last
+func watchCross(ctx context.Context, crossID string, send func(name string, data interface{}) error) error {
+	notifier := store.GetStateNotifier()
+	sub := notifier.Subscribe(crossID)
+	defer notifier.Unsubscribe(sub)
+	// 先订阅再查询，避免遗漏状态变更
+	if resp := searchCrossResult(crossID); resp != nil && isFinished(resp) {
+		return send(ResultEventName, resp)
+	}
+	heartbeat := time.NewTicker(HeartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-heartbeat.C:
+			// 状态变更可能因订阅者较慢被丢弃，心跳时检查是否已结束
+			if resp := searchCrossResult(crossID); resp != nil && isFinished(resp) {
+				return send(ResultEventName, resp)
+			}
+			if err := send(PingEventName, nil); err != nil {
+				return err
+			}
+		case change, ok := <-sub.C():
+			if !ok {
+				return nil
+			}
+			if err := send(StateEventName, change); err != nil {
+				return err
+			}
+			if change.IsFinal() {
+				return send(ResultEventName, searchCrossResult(crossID))
+			}
+		}
+	}
+}
+
+func searchCrossResult(crossID string) *eventproto.CrossResponse {
+	eveHandler, exist := handler.GetEventHandlerTools().GetHandler(handler.CrossSearch)
+	if !exist {
+		return nil
+	}
+	result, err := eveHandler.Handle(&eventproto.CrossSearchEvent{CrossId: crossID}, true)
+	if err != nil {
+		return nil
+	}
+	if resp, ok := result.(*eventproto.CrossResponse); ok {
+		return resp
+	}
+	return nil
+}
+
+func isFinished(resp *eventproto.CrossResponse) bool {
+	return resp.Code == event.SuccessResp || resp.Code == event.FailureResp
+}
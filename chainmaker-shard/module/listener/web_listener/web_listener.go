@@ -119,16 +119,40 @@ func (wl *WebListener) checkConfig() error {
 func initRouter(router *gin.Engine) {
 	group := router.Group("/")
 	initControllers(group) // 定义接口
+	// 未配置admin_token时不开启运维接口
+	if token := conf.Config.ListenerConfig.WebConfig.AdminToken; token != "" {
+		initAdminControllers(router.Group("/"+methods.AdminTag, methods.AdminAuth(token)))
+	}
 }
 
 // initControllers 初始化Controller配置
 func initControllers(routeGroup *gin.RouterGroup) {
 	routeGroup.POST(methods.CrossTag, methods.Dispatch)
+	// 跨链事务状态推送
+	routeGroup.GET(methods.CrossTag+"/"+methods.WatchTag, methods.WatchCrossEvent)
+	routeGroup.GET(methods.CrossTag+"/"+methods.WebSocketTag, methods.WatchCrossEventByWebSocket)
+	// 跨链事务历史查询
+	routeGroup.GET(methods.CrossTag+"/"+methods.SearchTag, methods.SearchCrossEvent)
 	//routeGroup.GET(methods.CrossTag, func(ctx *gin.Context) {
 	//	ctx.JSON(http.StatusOK, "hello world!!!")
 	//})
 }
 
+// initAdminControllers 初始化运维接口，需要通过Bearer Token认证
+func initAdminControllers(routeGroup *gin.RouterGroup) {
+	crossPath := methods.CrossTag + "/" + methods.AdminCrossIDPath
+	routeGroup.GET(methods.CrossTag, methods.ListAdminCross)
+	routeGroup.GET(crossPath, methods.ShowAdminCross)
+	routeGroup.POST(crossPath+"/"+methods.AdminRetryTag, methods.RetryAdminCross)
+	routeGroup.POST(crossPath+"/"+methods.AdminResolveTag, methods.ResolveAdminCross)
+	routeGroup.GET(crossPath+"/"+methods.AdminHistoryTag, methods.AdminCrossHistory)
+	routeGroup.POST(crossPath+"/"+methods.AdminRestoreTag, methods.RestoreAdminCross)
+	deadLetterPath := methods.AdminDeadLetterTag + "/" + methods.AdminCrossIDPath
+	routeGroup.GET(methods.AdminDeadLetterTag, methods.ListDeadLetters)
+	routeGroup.GET(deadLetterPath, methods.InspectDeadLetter)
+	routeGroup.POST(deadLetterPath+"/"+methods.AdminRedriveTag, methods.RedriveDeadLetter)
+}
+
 // Stop web listener server stop
 func (wl *WebListener) Stop() error {
 	// delay
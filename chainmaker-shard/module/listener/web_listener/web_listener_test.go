@@ -6,9 +6,16 @@
 package web_listener
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/listener/web_listener/methods"
+	"chainmaker.org/chainmaker-cross/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/require"
 )
 
@@ -57,3 +64,93 @@ func TestListenStop(t *testing.T) {
 	err = webListener.Stop()
 	require.NoError(t, err)
 }
+
+func TestAdminAuth(t *testing.T) {
+	adminPath := "/" + methods.AdminTag + "/" + methods.CrossTag
+	// 未配置token时不开启运维接口
+	conf.Config.ListenerConfig = &conf.ListenerConfig{WebConfig: &conf.WebConfig{}}
+	methods.InitHandlers(logger.GetLogger(logger.ModuleWebListener))
+	ginRouter := gin.New()
+	initRouter(ginRouter)
+	recorder := httptest.NewRecorder()
+	ginRouter.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, adminPath, nil))
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+
+	conf.Config.ListenerConfig = &conf.ListenerConfig{WebConfig: &conf.WebConfig{AdminToken: "secret"}}
+	ginRouter = gin.New()
+	initRouter(ginRouter)
+	for _, header := range []string{"", "secret", "Bearer wrong"} {
+		recorder = httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, adminPath, nil)
+		req.Header.Set(methods.AuthHeader, header)
+		ginRouter.ServeHTTP(recorder, req)
+		require.Equal(t, http.StatusUnauthorized, recorder.Code)
+	}
+	recorder = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, adminPath, nil)
+	req.Header.Set(methods.AuthHeader, methods.BearerPrefix+"secret")
+	ginRouter.ServeHTTP(recorder, req)
+	require.NotEqual(t, http.StatusUnauthorized, recorder.Code)
+}
+
+func TestDeadLetterRoute(t *testing.T) {
+	conf.Config.ListenerConfig = &conf.ListenerConfig{WebConfig: &conf.WebConfig{AdminToken: "secret"}}
+	methods.InitHandlers(logger.GetLogger(logger.ModuleWebListener))
+	ginRouter := gin.New()
+	initRouter(ginRouter)
+	// 公开的/cross接口不再提供死信操作
+	recorder := httptest.NewRecorder()
+	ginRouter.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/"+methods.CrossTag+"?method=DeadLetter",
+		strings.NewReader(`{"action":"list"}`)))
+	require.Equal(t, http.StatusNotImplemented, recorder.Code)
+
+	deadLetterPath := "/" + methods.AdminTag + "/" + methods.AdminDeadLetterTag
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, deadLetterPath, nil),
+		httptest.NewRequest(http.MethodGet, deadLetterPath+"/1", nil),
+		httptest.NewRequest(http.MethodPost, deadLetterPath+"/1/"+methods.AdminRedriveTag, nil),
+	} {
+		recorder = httptest.NewRecorder()
+		ginRouter.ServeHTTP(recorder, req)
+		require.Equal(t, http.StatusUnauthorized, recorder.Code)
+	}
+	recorder = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, deadLetterPath, nil)
+	req.Header.Set(methods.AuthHeader, methods.BearerPrefix+"secret")
+	ginRouter.ServeHTTP(recorder, req)
+	require.NotEqual(t, http.StatusUnauthorized, recorder.Code)
+	require.NotEqual(t, http.StatusNotFound, recorder.Code)
+}
+
+func TestWebSocketOrigin(t *testing.T) {
+	wsPath := "/" + methods.CrossTag + "/" + methods.WebSocketTag + "?cross_id=1"
+	conf.Config.ListenerConfig = &conf.ListenerConfig{WebConfig: &conf.WebConfig{
+		AllowedOrigins: []string{"https://app.example.com"},
+	}}
+	methods.InitHandlers(logger.GetLogger(logger.ModuleWebListener))
+	ginRouter := gin.New()
+	initRouter(ginRouter)
+	// 升级需要支持Hijack的连接，使用真实的http服务
+	server := httptest.NewServer(ginRouter)
+	defer server.Close()
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + wsPath
+	for origin, allowed := range map[string]bool{
+		"":                        true,
+		server.URL:                true, // 同源
+		"https://app.example.com": true,
+		"https://evil.com":        false,
+	} {
+		header := http.Header{}
+		if origin != "" {
+			header.Set("Origin", origin)
+		}
+		conn, resp, err := websocket.DefaultDialer.Dial(wsURL, header)
+		if allowed {
+			require.NoError(t, err, origin)
+			require.NoError(t, conn.Close())
+		} else {
+			require.Error(t, err, origin)
+			require.Equal(t, http.StatusForbidden, resp.StatusCode)
+		}
+	}
+}
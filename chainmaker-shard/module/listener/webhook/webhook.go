@@ -0,0 +1,254 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/event/coder"
+	"chainmaker.org/chainmaker-cross/logger"
+	"chainmaker.org/chainmaker-cross/store"
+	"go.uber.org/zap"
+)
+
+const (
+	SignatureHeader      = "X-Cross-Signature" // 签名头，值为hex(HMAC-SHA256(secret, timestamp + "." + body))
+	TimestampHeader      = "X-Cross-Timestamp" // 签名时间戳头(ms)
+	DefaultMaxRetry      = 3                   // 默认最大重试次数
+	DefaultRetryInterval = 1000                // 默认首次重试间隔(ms)
+	DefaultTimeout       = 5000                // 默认回调超时时间(ms)
+)
+
+var dispatcher *Dispatcher
+
+func init() {
+	dispatcher = &Dispatcher{
+		notifier:  store.GetStateNotifier(),
+		callbacks: make(map[string][]string),
+	}
+}
+
+// GetDispatcher return the global webhook dispatcher
+func GetDispatcher() *Dispatcher {
+	return dispatcher
+}
+
+// Dispatcher push the state changes of cross transaction to the callback urls carried in the CrossEvent
+type Dispatcher struct {
+	sync.Mutex
+	config    *conf.WebhookConfig  // 回调配置
+	stateDB   store.StateDB        // 存储，用于加载跨链事件中的回调地址
+	notifier  *store.StateNotifier // 状态变更通知
+	sub       *store.Subscription  // 状态变更订阅
+	callbacks map[string][]string  // crossID -> 回调地址缓存
+	client    *http.Client         // http client
+	stopCh    chan struct{}        // 停止信号
+	doneCh    chan struct{}        // 分发循环退出信号
+	wg        sync.WaitGroup       // 等待回调goroutine退出
+	log       *zap.SugaredLogger   // log
+}
+
+// SetStateDB set state database
+func (d *Dispatcher) SetStateDB(stateDB store.StateDB) {
+	d.stateDB = stateDB
+}
+
+// ListenStart subscribe all the state changes and start dispatching
+func (d *Dispatcher) ListenStart() error {
+	d.config = newWebhookConfig(conf.Config.ListenerConfig)
+	d.log = logger.GetLogger(logger.ModuleWebListener)
+	d.client = &http.Client{
+		Timeout: time.Duration(d.config.Timeout) * time.Millisecond,
+		// 不跟随重定向，避免通过重定向绕过允许的主机列表
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	d.stopCh = make(chan struct{})
+	d.doneCh = make(chan struct{})
+	// 排队订阅，分发较慢时状态变更不会丢失，保证终态被推送且回调地址缓存被清理
+	d.sub = d.notifier.SubscribeQueued(store.AllCrossIDs)
+	go d.loop(d.sub)
+	d.log.Info("Module webhook dispatcher started")
+	return nil
+}
+
+// Stop stop dispatching, the retrying callbacks will be canceled
+func (d *Dispatcher) Stop() error {
+	if d.sub == nil {
+		return nil
+	}
+	d.notifier.Unsubscribe(d.sub)
+	<-d.doneCh
+	close(d.stopCh)
+	d.wg.Wait()
+	d.sub = nil
+	d.log.Info("Module webhook dispatcher stopped")
+	return nil
+}
+
+func (d *Dispatcher) loop(sub *store.Subscription) {
+	defer close(d.doneCh)
+	for change := range sub.C() {
+		urls := d.loadCallbackURLs(change.CrossID)
+		if change.IsFinal() {
+			d.removeCallbackURLs(change.CrossID)
+		}
+		if len(urls) == 0 {
+			continue
+		}
+		body, err := json.Marshal(change)
+		if err != nil {
+			d.log.Errorf("marshal state change of cross[%s] failed, %v", change.CrossID, err)
+			continue
+		}
+		for _, url := range urls {
+			d.wg.Add(1)
+			go d.deliver(url, change.CrossID, body)
+		}
+	}
+}
+
+// deliver post the body to url, it will retry with exponential backoff when failed
+func (d *Dispatcher) deliver(url, crossID string, body []byte) {
+	defer d.wg.Done()
+	interval := time.Duration(d.config.RetryInterval) * time.Millisecond
+	for attempt := 0; ; attempt++ {
+		err := d.post(url, body)
+		if err == nil {
+			return
+		}
+		if attempt >= d.config.MaxRetry {
+			d.log.Errorf("callback cross[%s] to %s failed after %d retries, %v", crossID, url, attempt, err)
+			return
+		}
+		d.log.Warnf("callback cross[%s] to %s failed, retry after %v, %v", crossID, url, interval, err)
+		select {
+		case <-d.stopCh:
+			return
+		case <-time.After(interval):
+		}
+		interval *= 2
+	}
+}
+
+func (d *Dispatcher) post(url string, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if d.config.Secret != "" {
+		timestamp := strconv.FormatInt(time.Now().UnixNano()/int64(time.Millisecond), 10)
+		req.Header.Set(TimestampHeader, timestamp)
+		req.Header.Set(SignatureHeader, Sign(d.config.Secret, timestamp, body))
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (d *Dispatcher) loadCallbackURLs(crossID string) []string {
+	d.Lock()
+	defer d.Unlock()
+	if urls, exist := d.callbacks[crossID]; exist {
+		return urls
+	}
+	if d.stateDB == nil {
+		return nil
+	}
+	content, err := d.stateDB.ReadCross(crossID)
+	if err != nil {
+		return nil
+	}
+	eve, err := coder.GetCrossEventCoder().UnmarshalFromBinary(content)
+	if err != nil {
+		d.log.Errorf("unmarshal cross[%s] failed, %v", crossID, err)
+		return nil
+	}
+	crossEvent, ok := eve.(*eventproto.CrossEvent)
+	if !ok {
+		return nil
+	}
+	// 无回调地址的跨链事件同样缓存，避免重复加载
+	urls := make([]string, 0, len(crossEvent.GetCallbackUrls()))
+	for _, callbackURL := range crossEvent.GetCallbackUrls() {
+		if !d.isAllowed(callbackURL) {
+			d.log.Warnf("callback url %s of cross[%s] is not allowed, ignore it", callbackURL, crossID)
+			continue
+		}
+		urls = append(urls, callbackURL)
+	}
+	d.callbacks[crossID] = urls
+	return urls
+}
+
+// isAllowed return whether the callback url is http(s) and its host is in the allowed hosts,
+// the host is matched with or without port
+func (d *Dispatcher) isAllowed(callbackURL string) bool {
+	u, err := url.Parse(callbackURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return false
+	}
+	for _, host := range d.config.AllowedHosts {
+		if strings.EqualFold(host, u.Host) || strings.EqualFold(host, u.Hostname()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Dispatcher) removeCallbackURLs(crossID string) {
+	d.Lock()
+	defer d.Unlock()
+	delete(d.callbacks, crossID)
+}
+
+// Sign return hex(HMAC-SHA256(secret, timestamp + "." + body)), receivers should verify it with the same secret
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func newWebhookConfig(listenerConfig *conf.ListenerConfig) *conf.WebhookConfig {
+	config := &conf.WebhookConfig{}
+	if listenerConfig != nil && listenerConfig.WebhookConfig != nil {
+		*config = *listenerConfig.WebhookConfig
+	}
+	if config.MaxRetry <= 0 {
+		config.MaxRetry = DefaultMaxRetry
+	}
+	if config.RetryInterval <= 0 {
+		config.RetryInterval = DefaultRetryInterval
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultTimeout
+	}
+	return config
+}
@@ -0,0 +1,94 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/event/coder"
+	"chainmaker.org/chainmaker-cross/store"
+	"chainmaker.org/chainmaker-cross/store/kvdb"
+	"chainmaker.org/chainmaker-cross/store/kvdb/factory"
+	storetypes "chainmaker.org/chainmaker-cross/store/types"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "webhook-secret"
+
+func TestDispatcher(t *testing.T) {
+	var requests int32
+	received := make(chan *store.StateChange, 8)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 首次请求失败，验证重试
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		if Sign(testSecret, r.Header.Get(TimestampHeader), body) != r.Header.Get(SignatureHeader) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		change := &store.StateChange{}
+		_ = json.Unmarshal(body, change)
+		received <- change
+	}))
+	defer server.Close()
+
+	conf.Config.ListenerConfig = &conf.ListenerConfig{
+		WebhookConfig: &conf.WebhookConfig{Secret: testSecret, MaxRetry: 2, RetryInterval: 10, AllowedHosts: []string{"127.0.0.1"}},
+	}
+	provider, err := factory.NewKvDBProvider(storetypes.Memory, nil)
+	require.NoError(t, err)
+	stateDB := store.NewNotifyStateDB(kvdb.NewKvStateDB(provider), store.GetStateNotifier())
+	defer stateDB.Close()
+	d := GetDispatcher()
+	d.SetStateDB(stateDB)
+	require.NoError(t, d.ListenStart())
+	defer d.Stop()
+
+	// 不在允许列表中的回调地址被忽略
+	crossEvent := &eventproto.CrossEvent{CrossId: "webhook-cross", CallbackUrls: []string{server.URL, "http://169.254.169.254/latest"}}
+	content, err := coder.GetCrossEventCoder().MarshalToBinary(crossEvent)
+	require.NoError(t, err)
+	require.NoError(t, stateDB.StartCross(crossEvent.CrossId, content))
+
+	select {
+	case change := <-received:
+		require.Equal(t, crossEvent.CrossId, change.CrossID)
+		require.Equal(t, storetypes.StateInit, change.State)
+	case <-time.After(3 * time.Second):
+		t.Fatal("webhook callback timeout")
+	}
+	require.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestDispatcher_IsAllowed(t *testing.T) {
+	d := &Dispatcher{config: &conf.WebhookConfig{AllowedHosts: []string{"example.com", "127.0.0.1:8000"}}}
+	require.True(t, d.isAllowed("https://example.com/callback"))
+	require.True(t, d.isAllowed("http://Example.com:8080/callback"))
+	require.True(t, d.isAllowed("http://127.0.0.1:8000/callback"))
+	require.False(t, d.isAllowed("http://127.0.0.1:9000/callback"))
+	require.False(t, d.isAllowed("ftp://example.com/callback"))
+	require.False(t, d.isAllowed("http://evil.com/?example.com"))
+	require.False(t, (&Dispatcher{config: &conf.WebhookConfig{}}).isAllowed("https://example.com/callback"))
+}
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"cross_id":"1"}`)
+	require.Equal(t, Sign("secret", "1", body), Sign("secret", "1", body))
+	require.NotEqual(t, Sign("secret", "1", body), Sign("secret", "2", body))
+	require.NotEqual(t, Sign("secret", "1", body), Sign("other", "1", body))
+}
@@ -29,6 +29,7 @@ const (
 	ModuleChannelListener = "[CHANNEL_LISTENER]"
 	ModuleGrpcListener    = "[GRPC_LISTENER]"
 	ModuleTransactionMgr  = "[TRANSACTION_MGR]"
+	ModuleMonitor         = "[MONITOR]"
 
 	defaultLogPath = "./logs/default.log" // TODO release struct need this path
 )
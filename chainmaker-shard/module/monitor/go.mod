@@ -1,3 +1,17 @@
 module chainmaker.org/chainmaker-cross/monitor
 
-go 1.15
\ No newline at end of file
+go 1.15
+
+require (
+	chainmaker.org/chainmaker-cross/conf v0.0.0
+	chainmaker.org/chainmaker-cross/logger v0.0.0
+	github.com/prometheus/client_golang v1.1.0
+	github.com/stretchr/testify v1.7.0
+	go.uber.org/zap v1.16.0
+)
+
+replace (
+	chainmaker.org/chainmaker-cross/conf => ../conf
+	chainmaker.org/chainmaker-cross/logger => ../logger
+	chainmaker.org/chainmaker-cross/monitor => ./
+)
@@ -0,0 +1,182 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package monitor
+
+import (
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	Namespace = "cross_chain"
+
+	// 跨链事务结果
+	CrossReceived   = "received"
+	CrossSucceeded  = "succeeded"
+	CrossFailed     = "failed"
+	CrossRolledBack = "rolled_back"
+
+	// 事务处理阶段
+	PhaseExecute   = "execute"
+	PhaseSaveProof = "save_proof"
+	PhaseCommit    = "commit"
+	PhaseRollback  = "rollback"
+
+	// 证明结果
+	ProveSuccess = "success"
+	ProveFailure = "failure"
+
+	// 代理间消息的接收方
+	MessageSourceChannel  = "channel"  // 路由通道收到的响应
+	MessageSourceListener = "listener" // libp2p监听收到的请求
+	MessageSourceWeb      = "web"      // http监听收到的请求
+	MessageSourceGrpc     = "grpc"     // grpc监听收到的请求
+
+	ChainPairSep   = "->"
+	UnknownChainID = "unknown"
+)
+
+var (
+	registry = prometheus.NewRegistry()
+
+	crossEventCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "cross_events_total",
+		Help:      "Number of cross events grouped by chain pair and result.",
+	}, []string{"chain_pair", "result"})
+
+	phaseLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Name:      "transaction_phase_duration_seconds",
+		Help:      "Latency of each phase of cross transaction in transaction manager.",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 14),
+	}, []string{"phase", "chain_id"})
+
+	routerLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Name:      "router_invoke_duration_seconds",
+		Help:      "Latency of router invoke.",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 14),
+	}, []string{"router", "chain_id"})
+
+	routerTimeoutCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "router_invoke_timeouts_total",
+		Help:      "Number of router invokes which wait response timeout.",
+	}, []string{"router", "chain_id"})
+
+	adapterErrorCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "adapter_errors_total",
+		Help:      "Number of chain adapter call errors.",
+	}, []string{"chain_id", "method"})
+
+	proverResultCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "prover_results_total",
+		Help:      "Number of proof verification results.",
+	}, []string{"chain_id", "result"})
+
+	rejectedMessageCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "rejected_messages_total",
+		Help:      "Number of messages from other cross-chain proxies rejected by signature verification.",
+	}, []string{"source", "reason"})
+
+	connectionState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Name:      "connection_up",
+		Help:      "State of connection to other cross-chain proxy, 1 means the last write succeeded.",
+	}, []string{"provider", "peer"})
+)
+
+func init() {
+	registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		crossEventCounter,
+		phaseLatency,
+		routerLatency,
+		routerTimeoutCounter,
+		adapterErrorCounter,
+		proverResultCounter,
+		rejectedMessageCounter,
+		connectionState,
+	)
+}
+
+// GetRegistry return the registry which contains all the metrics of cross chain proxy
+func GetRegistry() *prometheus.Registry {
+	return registry
+}
+
+// ChainPair return label of the chain ids, such as chain1->chain2
+func ChainPair(chainIDs []string) string {
+	if len(chainIDs) == 0 {
+		return UnknownChainID
+	}
+	return strings.Join(chainIDs, ChainPairSep)
+}
+
+// ObserveCrossEvent increase the counter of cross events for the result
+func ObserveCrossEvent(chainIDs []string, result string) {
+	crossEventCounter.WithLabelValues(ChainPair(chainIDs), result).Inc()
+}
+
+// ObservePhase observe latency of the phase from start
+func ObservePhase(phase, chainID string, start time.Time) {
+	phaseLatency.WithLabelValues(phase, chainID).Observe(time.Since(start).Seconds())
+}
+
+// ObserveRouterInvoke observe latency of router invoke, the timeout counter will be increased when timeout
+func ObserveRouterInvoke(router, chainID string, start time.Time, timeout bool) {
+	routerLatency.WithLabelValues(router, chainID).Observe(time.Since(start).Seconds())
+	if timeout {
+		routerTimeoutCounter.WithLabelValues(router, chainID).Inc()
+	}
+}
+
+// ObserveAdapterError increase the error counter of adapter method
+func ObserveAdapterError(chainID, method string) {
+	adapterErrorCounter.WithLabelValues(chainID, method).Inc()
+}
+
+// ObserveProve increase the counter of prover verification result
+func ObserveProve(chainID string, success bool) {
+	result := ProveFailure
+	if success {
+		result = ProveSuccess
+	}
+	proverResultCounter.WithLabelValues(chainID, result).Inc()
+}
+
+// ObserveRejectedMessage increase the counter of messages from other proxies rejected for the reason
+func ObserveRejectedMessage(source, reason string) {
+	rejectedMessageCounter.WithLabelValues(source, reason).Inc()
+}
+
+// SetConnectionState set the state of connection to peer
+func SetConnectionState(provider, peer string, up bool) {
+	var value float64
+	if up {
+		value = 1
+	}
+	connectionState.WithLabelValues(provider, peer).Set(value)
+}
+
+// RegisterEventChanDepth register gauge which report the depth of event channel
+func RegisterEventChanDepth(name string, depth func() int) error {
+	return registry.Register(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace:   Namespace,
+		Name:        "event_channel_depth",
+		Help:        "Number of events waiting in the channel.",
+		ConstLabels: prometheus.Labels{"channel": name},
+	}, func() float64 {
+		return float64(depth())
+	}))
+}
@@ -0,0 +1,67 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package monitor
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainPair(t *testing.T) {
+	require.Equal(t, UnknownChainID, ChainPair(nil))
+	require.Equal(t, "chain1->chain2->chain3", ChainPair([]string{"chain1", "chain2", "chain3"}))
+}
+
+func TestObserve(t *testing.T) {
+	ObserveCrossEvent([]string{"chain1", "chain2"}, CrossReceived)
+	ObserveCrossEvent([]string{"chain1", "chain2"}, CrossReceived)
+	require.Equal(t, float64(2), testutil.ToFloat64(crossEventCounter.WithLabelValues("chain1->chain2", CrossReceived)))
+
+	ObserveRouterInvoke("inner", "chain1", time.Now(), true)
+	ObserveRouterInvoke("inner", "chain1", time.Now(), false)
+	require.Equal(t, float64(1), testutil.ToFloat64(routerTimeoutCounter.WithLabelValues("inner", "chain1")))
+
+	ObserveProve("chain1", true)
+	ObserveProve("chain1", false)
+	require.Equal(t, float64(1), testutil.ToFloat64(proverResultCounter.WithLabelValues("chain1", ProveFailure)))
+
+	ObserveRejectedMessage(MessageSourceListener, "replayed")
+	require.Equal(t, float64(1), testutil.ToFloat64(rejectedMessageCounter.WithLabelValues(MessageSourceListener, "replayed")))
+
+	SetConnectionState("libp2p", "peer1", true)
+	require.Equal(t, float64(1), testutil.ToFloat64(connectionState.WithLabelValues("libp2p", "peer1")))
+	SetConnectionState("libp2p", "peer1", false)
+	require.Equal(t, float64(0), testutil.ToFloat64(connectionState.WithLabelValues("libp2p", "peer1")))
+}
+
+func TestMonitorServer(t *testing.T) {
+	require.Nil(t, NewMonitorServer(nil))
+	require.Nil(t, NewMonitorServer(&conf.MonitorConfig{Enable: false}))
+
+	ch := make(chan int, 4)
+	ch <- 1
+	require.NoError(t, RegisterEventChanDepth("test", func() int { return len(ch) }))
+
+	server := NewMonitorServer(&conf.MonitorConfig{Enable: true, Address: "127.0.0.1", Port: 12309})
+	require.NotNil(t, server)
+	require.NoError(t, server.Start())
+	defer server.Stop()
+	time.Sleep(100 * time.Millisecond)
+
+	resp, err := http.Get("http://127.0.0.1:12309" + DefaultMetricsPath)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.True(t, strings.Contains(string(body), `cross_chain_event_channel_depth{channel="test"} 1`))
+}
@@ -0,0 +1,73 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package monitor
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/logger"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultMetricsPath = "/metrics"
+	ShutdownTimeout    = 5 * time.Second
+)
+
+// MonitorServer is http server which expose the prometheus metrics
+type MonitorServer struct {
+	server *http.Server        // http server
+	config *conf.MonitorConfig // 监控配置
+	log    *zap.SugaredLogger  // log
+}
+
+// NewMonitorServer create new instance of monitor server, nil will be returned when monitor is disabled
+func NewMonitorServer(config *conf.MonitorConfig) *MonitorServer {
+	if config == nil || !config.Enable {
+		return nil
+	}
+	path := config.Path
+	if path == "" {
+		path = DefaultMetricsPath
+	}
+	mux := http.NewServeMux()
+	mux.Handle(path, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	return &MonitorServer{
+		server: &http.Server{
+			Addr:    config.ToUrl(),
+			Handler: mux,
+		},
+		config: config,
+		log:    logger.GetLogger(logger.ModuleMonitor),
+	}
+}
+
+// Start start the metrics server
+func (m *MonitorServer) Start() error {
+	go func() {
+		if err := m.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			m.log.Error("Monitor Server Listen:", err)
+		}
+	}()
+	m.log.Infof("Module monitor started, listen on %s", m.server.Addr)
+	return nil
+}
+
+// Stop stop the metrics server
+func (m *MonitorServer) Stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+	defer cancel()
+	if err := m.server.Shutdown(ctx); err != nil {
+		m.log.Error("Monitor Server Shutdown:", err)
+		return err
+	}
+	m.log.Info("Module monitor stopped")
+	return nil
+}
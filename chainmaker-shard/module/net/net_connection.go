@@ -7,6 +7,7 @@ package net
 
 import (
 	"bufio"
+	"time"
 )
 
 // Read loop read data from connection
@@ -32,3 +33,9 @@ type Connection interface {
 	// Close close the connection
 	Close() error
 }
+
+// Pinger is the connection which can check whether the peer is reachable, it is used by the heartbeat of router
+type Pinger interface {
+	// Ping return error if the peer can not be reached in the timeout
+	Ping(timeout time.Duration) error
+}
@@ -137,6 +137,21 @@ func (c *Connection) deliverMessage(resp *http.Response) error {
 	return nil
 }
 
+// Ping check whether the remote proxy is reachable, any http response means the proxy is alive
+func (c *Connection) Ping(timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, c.address, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.transport.RoundTrip(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
 func (c *Connection) ReadData() (chan net.Message, error) {
 	return c.ch, nil
 }
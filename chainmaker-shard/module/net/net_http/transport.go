@@ -82,6 +82,11 @@ func genTransport(config *conf.HttpTransport) (tr *http.Transport, err error) {
 	return
 }
 
+// GetClientTlsConfig create tls config of client by security config
+func GetClientTlsConfig(security *conf.TransportSecurity) (*tls.Config, error) {
+	return genClientTlsConfig(security)
+}
+
 func genClientTlsConfig(security *conf.TransportSecurity) (*tls.Config, error) {
 	if security == nil {
 		return nil, errors.New("tls config is nil")
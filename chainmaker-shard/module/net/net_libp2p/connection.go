@@ -6,10 +6,10 @@
 package net_libp2p
 
 import (
-	"bufio"
 	"context"
 	"time"
 
+	"chainmaker.org/chainmaker-cross/conf"
 	"chainmaker.org/chainmaker-cross/logger"
 	"chainmaker.org/chainmaker-cross/net"
 	"github.com/Rican7/retry"
@@ -123,6 +123,11 @@ func (c *LibP2pConnection) ResetLibP2pConnection() error {
 	return nil
 }
 
+// SetFraming set the framing of connection, it should be called before reading and writing data
+func (c *LibP2pConnection) SetFraming(config *conf.FramingConfig) {
+	c.host.SetFraming(config)
+}
+
 // ReadData read data from the connection
 func (c *LibP2pConnection) ReadData() (chan net.Message, error) {
 	ch, err := c.host.Listen()
@@ -135,12 +140,12 @@ func (c *LibP2pConnection) ReadData() (chan net.Message, error) {
 // WriteData write the data to connection
 func (c *LibP2pConnection) WriteData(msg net.Message) error {
 	ctx := context.Background()
-	stream, err := c.host.NewStream(ctx, c.peerID, c.pid)
+	// 对端支持分帧时使用分帧协议，否则使用分割符协议
+	_, s, err := c.host.OpenStream(ctx, c.peerID)
 	if err != nil {
-		return nil
+		// 对端不可达时返回错误，路由据此切换到其他代理
+		return err
 	}
-	rw := bufio.NewReadWriter(bufio.NewReaderSize(stream, 1024*1024), bufio.NewWriterSize(stream, 1024*1024))
-	s := NewLibP2PSteam(rw, c.delimit)
 	m := msg.(*LibP2pMessage)
 	err = s.WriteStream(m)
 	if err != nil {
@@ -149,6 +154,16 @@ func (c *LibP2pConnection) WriteData(msg net.Message) error {
 	return nil
 }
 
+// Ping check whether the remote node is reachable, the connection is established if it is not connected
+func (c *LibP2pConnection) Ping(timeout time.Duration) error {
+	if c.host.Network().Connectedness(c.peerID) == network.Connected {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return c.host.Connect(ctx, peer.AddrInfo{ID: c.peerID})
+}
+
 // PeerID return the peerID of remote node
 func (c *LibP2pConnection) PeerID() string {
 	return c.host.ID()
@@ -35,7 +35,7 @@ func TestConnection(t *testing.T) {
 		//for {
 		cnt++
 		// auto write data
-		msg := NewLibP2pMessage(connection.PeerID(), []byte(fmt.Sprintf("This is test!, num is:%d", cnt)), false)
+		msg, _ := NewLibP2pMessage(connection.PeerID(), []byte(fmt.Sprintf("This is test!, num is:%d", cnt)), false)
 		err = connection.WriteData(msg)
 		t.Log(fmt.Sprintf("write data! %d", cnt))
 		if err != nil {
@@ -87,7 +87,7 @@ func TestMultiConnection(t *testing.T) {
 		require.NoError(t, err)
 
 		// new message
-		msg := NewLibP2pMessage(connection.PeerID(), []byte(fmt.Sprintf("This is test!, num is:%d", cnt)), false)
+		msg, _ := NewLibP2pMessage(connection.PeerID(), []byte(fmt.Sprintf("This is test!, num is:%d", cnt)), false)
 
 		// write data
 		err = connection.WriteData(msg)
@@ -119,7 +119,7 @@ func TestResetConnection(t *testing.T) {
 		//for {
 		cnt++
 		// auto write data
-		msg := NewLibP2pMessage(connection.PeerID(), []byte(fmt.Sprintf("This is test!, num is:%d", cnt)), false)
+		msg, _ := NewLibP2pMessage(connection.PeerID(), []byte(fmt.Sprintf("This is test!, num is:%d", cnt)), false)
 		t.Log(fmt.Sprintf("write data! %d", cnt))
 		err = connection.WriteData(msg)
 		t.Log("current connection peer id: ", connection.PeerID())
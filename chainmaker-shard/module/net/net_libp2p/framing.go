@@ -0,0 +1,199 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package net_libp2p
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/logger"
+	"chainmaker.org/chainmaker-cross/net"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	"go.uber.org/zap"
+)
+
+const (
+	FramedProtocolSuffix = "/framed/1.0.0"  // 分帧协议号后缀，与分割符协议号区分
+	DefaultMaxFrameSize  = 16 * 1024 * 1024 // 默认单帧最大字节数
+	CompressThreshold    = 1024             // 开启压缩时，超过该字节数的帧才压缩
+
+	frameFlagCompressed = 1 << 0 // 帧内容已压缩
+)
+
+// FramedProtocolID return the protocol id of framed version, which is negotiated before the delimited protocol id
+func FramedProtocolID(pid protocol.ID) protocol.ID {
+	return pid + FramedProtocolSuffix
+}
+
+// Framing encode the message into the length-prefixed frame, the payload is transmitted in binary
+// without base64 and delimiter, the frame is: uvarint(len(body)) | body,
+// the body is: flag | content, and the content which may be compressed is: uvarint(len(header)) | header | payload,
+// where the header is the json of message without payload
+type Framing struct {
+	compress     bool // 是否压缩
+	maxFrameSize int  // 单帧最大字节数
+}
+
+// NewFraming create the framing by the config, nil is returned if the framing is not enabled
+func NewFraming(config *conf.FramingConfig) *Framing {
+	if !config.IsEnabled() {
+		return nil
+	}
+	maxFrameSize := config.MaxFrameSize
+	if maxFrameSize == 0 {
+		maxFrameSize = DefaultMaxFrameSize
+	}
+	return &Framing{
+		compress:     config.Compression == conf.CompressionGzip,
+		maxFrameSize: maxFrameSize,
+	}
+}
+
+// Encode return the frame of message, the content is compressed only if it is larger than the threshold
+// and becomes smaller after compression
+func (f *Framing) Encode(msg *LibP2pMessage) ([]byte, error) {
+	header := *msg
+	header.Payload = nil
+	headerBytes, err := json.Marshal(&header)
+	if err != nil {
+		return nil, err
+	}
+	content := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(headerBytes)+len(msg.Payload))
+	content = append(content[:binary.PutUvarint(content, uint64(len(headerBytes)))], headerBytes...)
+	content = append(content, msg.Payload...)
+	flag := byte(0)
+	if f.compress && len(content) > CompressThreshold {
+		var buf bytes.Buffer
+		writer := gzip.NewWriter(&buf)
+		if _, err = writer.Write(content); err != nil {
+			return nil, err
+		}
+		if err = writer.Close(); err != nil {
+			return nil, err
+		}
+		if buf.Len() < len(content) {
+			flag, content = frameFlagCompressed, buf.Bytes()
+		}
+	}
+	bodySize := 1 + len(content)
+	if bodySize > f.maxFrameSize {
+		return nil, fmt.Errorf("frame size %d exceeds max frame size %d", bodySize, f.maxFrameSize)
+	}
+	frame := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+bodySize)
+	frame = append(frame[:binary.PutUvarint(frame, uint64(bodySize))], flag)
+	return append(frame, content...), nil
+}
+
+// Decode read one frame from the reader and return the message in it, the frame larger than max frame size
+// is rejected before reading its body
+func (f *Framing) Decode(reader *bufio.Reader) (*LibP2pMessage, error) {
+	size, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, err
+	}
+	if size == 0 || size > uint64(f.maxFrameSize) {
+		return nil, fmt.Errorf("invalid frame size %d, max frame size is %d", size, f.maxFrameSize)
+	}
+	body := make([]byte, size)
+	if _, err = io.ReadFull(reader, body); err != nil {
+		return nil, err
+	}
+	content := body[1:]
+	if body[0]&frameFlagCompressed != 0 {
+		gzipReader, err := gzip.NewReader(bytes.NewReader(content))
+		if err != nil {
+			return nil, err
+		}
+		// 限制解压后的大小，避免压缩炸弹
+		if content, err = ioutil.ReadAll(io.LimitReader(gzipReader, int64(f.maxFrameSize)+1)); err != nil {
+			return nil, err
+		}
+		if len(content) > f.maxFrameSize {
+			return nil, fmt.Errorf("decompressed frame exceeds max frame size %d", f.maxFrameSize)
+		}
+	}
+	headerSize, n := binary.Uvarint(content)
+	if n <= 0 || headerSize > uint64(len(content)-n) {
+		return nil, errors.New("invalid header of frame")
+	}
+	msg := &LibP2pMessage{}
+	if err = json.Unmarshal(content[n:n+int(headerSize)], msg); err != nil {
+		return nil, err
+	}
+	msg.Payload = content[n+int(headerSize):]
+	return msg, nil
+}
+
+// FramedStream is stream of p2p connection which transmits the length-prefixed frames
+type FramedStream struct {
+	sync.Mutex
+	rw      *bufio.ReadWriter
+	log     *zap.SugaredLogger
+	framing *Framing
+}
+
+// NewFramedStream create new framed stream
+func NewFramedStream(rw *bufio.ReadWriter, framing *Framing) *FramedStream {
+	return &FramedStream{
+		rw:      rw,
+		log:     logger.GetLogger(logger.ModuleP2P),
+		framing: framing,
+	}
+}
+
+// ReadStream read frames from connection until the stream is closed, the stream is abandoned on invalid frame
+// since the following frames can not be located
+func (s *FramedStream) ReadStream(ch chan net.Message) error {
+	go func(c chan net.Message) {
+		for {
+			msg, err := s.framing.Decode(s.rw.Reader)
+			if err != nil {
+				if err == io.EOF || err.Error() == "stream reset" {
+					s.log.Debug(err)
+				} else {
+					s.log.Error("read frame error: ", err)
+				}
+				return
+			}
+			s.log.Debug("new incoming frame, put into read stream channel")
+			c <- msg
+		}
+	}(ch)
+	return nil
+}
+
+// WriteStream write message to stream as one frame
+func (s *FramedStream) WriteStream(msg *LibP2pMessage) error {
+	s.Lock()
+	defer s.Unlock()
+	frame, err := s.framing.Encode(msg)
+	if err != nil {
+		s.log.Error("encode libp2p message error ", err)
+		return err
+	}
+	if _, err = s.rw.Write(frame); err != nil {
+		if err.Error() == "stream reset" {
+			s.log.Debug(err)
+		} else {
+			s.log.Error("write stream error: ", err)
+		}
+		return err
+	}
+	if err = s.rw.Flush(); err != nil {
+		s.log.Debug("write stream flush error: ", err)
+		return err
+	}
+	return nil
+}
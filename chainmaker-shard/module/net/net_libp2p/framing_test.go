@@ -0,0 +1,113 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package net_libp2p
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/net"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	"github.com/stretchr/testify/require"
+)
+
+const testPeerID = "QmSVuE22fYPeTYg4iUJJbVmwtaumYavSApR3PSm4Gb6upV"
+
+func newTestMessage(t *testing.T, payload []byte) *LibP2pMessage {
+	peerID, err := peer.Decode(testPeerID)
+	require.NoError(t, err)
+	return &LibP2pMessage{Timestamp: time.Now().Unix(), ID: "id", NodeId: peerID, Payload: payload}
+}
+
+func TestFraming(t *testing.T) {
+	require.Nil(t, NewFraming(nil))
+	require.Equal(t, protocol.ID("/listener/framed/1.0.0"), FramedProtocolID("/listener"))
+
+	framing := NewFraming(&conf.FramingConfig{Enable: true})
+	require.Equal(t, DefaultMaxFrameSize, framing.maxFrameSize)
+	// 二进制数据中包含分割符
+	msg := newTestMessage(t, []byte{0, '\n', 1, 2, '\n'})
+	frame, err := framing.Encode(msg)
+	require.NoError(t, err)
+	reader := bufio.NewReader(bytes.NewReader(append(frame, frame...)))
+	for i := 0; i < 2; i++ {
+		decoded, err := framing.Decode(reader)
+		require.NoError(t, err)
+		require.Equal(t, msg, decoded)
+	}
+
+	// 超过阈值的帧被压缩
+	compressed := NewFraming(&conf.FramingConfig{Enable: true, Compression: conf.CompressionGzip, MaxFrameSize: 4096})
+	msg = newTestMessage(t, bytes.Repeat([]byte("proof"), 600))
+	frame, err = compressed.Encode(msg)
+	require.NoError(t, err)
+	require.Less(t, len(frame), len(msg.Payload))
+	// 未开启压缩的一端也能解压
+	decoded, err := framing.Decode(bufio.NewReader(bytes.NewReader(frame)))
+	require.NoError(t, err)
+	require.Equal(t, msg, decoded)
+	// 解压后超过最大帧
+	small := NewFraming(&conf.FramingConfig{Enable: true, MaxFrameSize: 1024})
+	_, err = small.Decode(bufio.NewReader(bytes.NewReader(frame)))
+	require.Error(t, err)
+	// 超过最大帧
+	_, err = small.Encode(msg)
+	require.Error(t, err)
+	frame, err = framing.Encode(msg)
+	require.NoError(t, err)
+	_, err = small.Decode(bufio.NewReader(bytes.NewReader(frame)))
+	require.Error(t, err)
+}
+
+func TestFramedStream(t *testing.T) {
+	framing := NewFraming(&conf.FramingConfig{Enable: true})
+	var buf bytes.Buffer
+	writer := NewFramedStream(bufio.NewReadWriter(nil, bufio.NewWriter(&buf)), framing)
+	msg := newTestMessage(t, []byte("payload"))
+	require.NoError(t, writer.WriteStream(msg))
+	require.NoError(t, writer.WriteStream(msg))
+
+	ch := make(chan net.Message, 2)
+	reader := NewFramedStream(bufio.NewReadWriter(bufio.NewReader(&buf), nil), framing)
+	require.NoError(t, reader.ReadStream(ch))
+	for i := 0; i < 2; i++ {
+		select {
+		case received := <-ch:
+			require.Equal(t, msg, received)
+		case <-time.After(time.Second):
+			t.Fatal("wait frame timeout")
+		}
+	}
+}
+
+func TestLibP2pSteam_Compatible(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewLibP2PSteam(bufio.NewReadWriter(nil, bufio.NewWriter(&buf)), testDelimit)
+	msg := newTestMessage(t, []byte{0, 1, '\n'})
+	require.NoError(t, writer.WriteStream(msg))
+
+	// 不支持分帧的代理收到的载荷为base64字符串
+	legacy := &LibP2pMessage{}
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimSuffix(buf.String(), "\n")), legacy))
+	require.Equal(t, base64.StdEncoding.EncodeToString(msg.Payload), string(legacy.Payload))
+
+	ch := make(chan net.Message, 1)
+	reader := NewLibP2PSteam(bufio.NewReadWriter(bufio.NewReader(&buf), nil), testDelimit)
+	require.NoError(t, reader.ReadStream(ch))
+	select {
+	case received := <-ch:
+		require.Equal(t, msg.Payload, received.GetPayload())
+	case <-time.After(time.Second):
+		t.Fatal("wait message timeout")
+	}
+}
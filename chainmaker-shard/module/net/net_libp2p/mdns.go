@@ -0,0 +1,54 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package net_libp2p
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p/p2p/discovery"
+)
+
+const (
+	DefaultMdnsInterval   = 10                       // 默认mDNS查询间隔，单位：秒
+	DefaultMdnsServiceTag = "chainmaker-cross-proxy" // 默认mDNS服务标识
+)
+
+// PeerFound is called with the libp2p address of the proxy found in local network
+type PeerFound func(address string)
+
+// mdnsNotifee convert the found peer to the libp2p address of proxy
+type mdnsNotifee struct {
+	found PeerFound
+}
+
+// HandlePeerFound implement discovery.Notifee, the peer is ignored if it has no address
+func (n *mdnsNotifee) HandlePeerFound(info peer.AddrInfo) {
+	if len(info.Addrs) > 0 {
+		n.found(fmt.Sprintf("%s/p2p/%s", info.Addrs[0].String(), info.ID.Pretty()))
+	}
+}
+
+// StartMdns advertise the node in local network by mDNS and find other nodes with the same service tag,
+// the returned closer stops the mDNS service
+func (l *LibP2pNode) StartMdns(interval time.Duration, serviceTag string, found PeerFound) (io.Closer, error) {
+	if interval <= 0 {
+		interval = DefaultMdnsInterval * time.Second
+	}
+	if serviceTag == "" {
+		serviceTag = DefaultMdnsServiceTag
+	}
+	service, err := discovery.NewMdnsService(context.Background(), l.Host, interval, serviceTag)
+	if err != nil {
+		return nil, err
+	}
+	service.RegisterNotifee(&mdnsNotifee{found: found})
+	l.log.Infof("mdns discovery started, service tag [%s], interval %v", serviceTag, interval)
+	return service, nil
+}
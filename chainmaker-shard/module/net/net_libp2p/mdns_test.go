@@ -0,0 +1,31 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package net_libp2p
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMdnsNotifee(t *testing.T) {
+	priv, _, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
+	require.NoError(t, err)
+	id, err := peer.IDFromPrivateKey(priv)
+	require.NoError(t, err)
+	var found []string
+	notifee := &mdnsNotifee{found: func(address string) {
+		found = append(found, address)
+	}}
+	// 无地址的节点被忽略
+	notifee.HandlePeerFound(peer.AddrInfo{ID: id})
+	require.Len(t, found, 0)
+	notifee.HandlePeerFound(peer.AddrInfo{ID: id, Addrs: []ma.Multiaddr{ma.StringCast(testLocal)}})
+	require.Equal(t, []string{testLocal + "/p2p/" + id.Pretty()}, found)
+}
@@ -11,7 +11,9 @@ import (
 	"fmt"
 	"io/ioutil"
 	"reflect"
+	"sync"
 
+	"chainmaker.org/chainmaker-cross/conf"
 	"chainmaker.org/chainmaker-cross/logger"
 	"chainmaker.org/chainmaker-cross/net"
 	"github.com/libp2p/go-libp2p"
@@ -39,6 +41,8 @@ type LibP2pNode struct {
 	pid       protocol.ID        // 通信的协议号
 	delimit   byte               // 消息分割符
 	log       *zap.SugaredLogger // log
+	framing   *Framing           // 长度前缀分帧，未开启时为nil
+	listening sync.Once          // 协议处理只注册一次
 }
 
 // ID return the id of libp2p node
@@ -46,26 +50,37 @@ func (l *LibP2pNode) ID() string {
 	return l.Host.ID().Pretty()
 }
 
-// Listen node server start
+// SetFraming set the framing of node, it should be called before listen and write
+func (l *LibP2pNode) SetFraming(config *conf.FramingConfig) {
+	l.framing = NewFraming(config)
+}
+
+// Listen node server start, the framed protocol is also handled if the framing is enabled
 func (l *LibP2pNode) Listen() (chan net.Message, error) {
-	var err error
-	if l.readChan != nil {
-		return l.readChan, nil
-	}
-	ch := make(chan net.Message, ReadChannelLength)
-	l.SetStreamHandler(l.pid, func(s network.Stream) {
-		// Create a buffer stream for non blocking read and write.
-		// stream 's' will stay open until you close it.
-		rw := bufio.NewReadWriter(bufio.NewReader(s), bufio.NewWriter(s))
+	// 读通道在创建节点时生成，重复调用返回同一个通道
+	l.listening.Do(func() {
+		l.SetStreamHandler(l.pid, func(s network.Stream) {
+			// Create a buffer stream for non blocking read and write.
+			// stream 's' will stay open until you close it.
+			rw := bufio.NewReadWriter(bufio.NewReader(s), bufio.NewWriter(s))
 
+			go func() {
+				_ = readData(NewLibP2PSteam(rw, l.delimit), l.readChan)
+			}()
+		})
+		if framing := l.framing; framing != nil {
+			l.SetStreamHandler(FramedProtocolID(l.pid), func(s network.Stream) {
+				rw := bufio.NewReadWriter(bufio.NewReader(s), bufio.NewWriter(s))
+				go func() {
+					_ = readData(NewFramedStream(rw, framing), l.readChan)
+				}()
+			})
+		}
 		go func() {
-			err = readData(rw, ch, l.delimit)
+			writeData(l)
 		}()
 	})
-	go func() {
-		writeData(l)
-	}()
-	return ch, err
+	return l.readChan, nil
 }
 
 // Write write message to node
@@ -98,10 +113,16 @@ func NewDummyHost(pid protocol.ID, delimit byte) (*LibP2pNode, error) {
 	if err != nil {
 		return nil, err
 	}
-	readChan := make(chan net.Message, WriteChannelLength)
+	readChan := make(chan net.Message, ReadChannelLength)
 	writeChan := make(chan net.Message, WriteChannelLength)
 	return &LibP2pNode{
-		node, readChan, writeChan, pid, delimit, logger.GetLogger(logger.ModuleNet)}, nil
+		Host:      node,
+		readChan:  readChan,
+		writeChan: writeChan,
+		pid:       pid,
+		delimit:   delimit,
+		log:       logger.GetLogger(logger.ModuleNet),
+	}, nil
 }
 
 // NewMonitorHost creates a LibP2P host with known peer ID listening on the given address.
@@ -139,9 +160,16 @@ func NewMonitorHost(address, privKeyFile string, pid protocol.ID, delimit byte)
 	if multiAddr != nil {
 		log.Info(fmt.Sprintf("channel start listen %s\n", multiAddr))
 	}
-	readChan := make(chan net.Message, WriteChannelLength)
+	readChan := make(chan net.Message, ReadChannelLength)
 	writeChan := make(chan net.Message, WriteChannelLength)
-	return &LibP2pNode{basicHost, readChan, writeChan, pid, delimit, log}, nil
+	return &LibP2pNode{
+		Host:      basicHost,
+		readChan:  readChan,
+		writeChan: writeChan,
+		pid:       pid,
+		delimit:   delimit,
+		log:       log,
+	}, nil
 }
 
 // prepareKey read private key from listener config
@@ -171,11 +199,28 @@ func hostToMultiAddr(host host.Host) (ma.Multiaddr, error) {
 	return fullAddr, nil
 }
 
+// OpenStream open the stream to peer, the framed protocol is preferred if the framing is enabled,
+// and the delimited protocol is used when the peer does not support framing
+func (l *LibP2pNode) OpenStream(ctx context.Context, peerID peer.ID) (network.Stream, MessageStream, error) {
+	pids := []protocol.ID{l.pid}
+	if l.framing != nil {
+		pids = []protocol.ID{FramedProtocolID(l.pid), l.pid}
+	}
+	s, err := l.NewStream(ctx, peerID, pids...)
+	if err != nil {
+		return nil, nil, err
+	}
+	rw := bufio.NewReadWriter(bufio.NewReader(s), bufio.NewWriter(s))
+	if l.framing != nil && s.Protocol() == FramedProtocolID(l.pid) {
+		return s, NewFramedStream(rw, l.framing), nil
+	}
+	return s, NewLibP2PSteam(rw, l.delimit), nil
+}
+
 // readData read channel listener stream inputs, dispatch every event to handler
-func readData(rw *bufio.ReadWriter, ch chan net.Message, delimit byte) error {
+func readData(handle MessageStream, ch chan net.Message) error {
 	var err error
 	log := logger.GetLogger(logger.ModuleNet)
-	handle := NewLibP2PSteam(rw, delimit)
 	err = handle.ReadStream(ch)
 	if err != nil {
 		log.Error(err)
@@ -194,19 +239,17 @@ func writeData(l *LibP2pNode) {
 			l.log.Error("load date from write-channel failed")
 			return
 		}
-		// create new stream
-		s, err := l.NewStream(context.Background(), peer.ID(msg.GetNodeID()), l.pid)
-		if err != nil {
-			l.log.Error("create stream error: ", err)
-			continue
-		}
-		rw := bufio.NewReadWriter(nil, bufio.NewWriter(s))
-		handle := NewLibP2PSteam(rw, l.delimit)
 		m, ok := msg.(*LibP2pMessage)
 		if !ok {
 			l.log.Error("unsupported libp2p message type", reflect.TypeOf(msg))
 			continue
 		}
+		// create new stream
+		s, handle, err := l.OpenStream(context.Background(), m.NodeId)
+		if err != nil {
+			l.log.Error("create stream error: ", err)
+			continue
+		}
 		err = handle.WriteStream(m)
 		if err != nil {
 			l.log.Error("write stream error: ", err)
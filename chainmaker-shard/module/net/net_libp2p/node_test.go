@@ -39,7 +39,8 @@ func TestHost(t *testing.T) {
 	require.NoError(t, err)
 
 	// Test new message
-	msg := NewLibP2pMessage("QmSVuE22fYPeTYg4iUJJbVmwtaumYavSApR3PSm4Gb6upV", []byte("This is a test!"), false)
+	msg, err := NewLibP2pMessage("QmSVuE22fYPeTYg4iUJJbVmwtaumYavSApR3PSm4Gb6upV", []byte("This is a test!"), false)
+	require.NoError(t, err)
 
 	// Test write
 	err = mock.Write(msg)
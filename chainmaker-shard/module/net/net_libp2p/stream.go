@@ -7,6 +7,7 @@ package net_libp2p
 
 import (
 	"bufio"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -17,7 +18,18 @@ import (
 	"go.uber.org/zap"
 )
 
-// LibP2pSteam is stream of p2p connection
+// MessageStream read and write the libp2p messages on the stream by the negotiated protocol
+type MessageStream interface {
+
+	// ReadStream read messages from stream into the channel
+	ReadStream(ch chan net.Message) error
+
+	// WriteStream write message to stream
+	WriteStream(msg *LibP2pMessage) error
+}
+
+// LibP2pSteam is stream of p2p connection, the messages are split by the delimit and
+// the payload is base64 encoded, so that it is compatible with the proxies which do not support framing
 type LibP2pSteam struct {
 	sync.Mutex
 	rw    *bufio.ReadWriter
@@ -69,6 +81,11 @@ func (s *LibP2pSteam) ReadStream(ch chan net.Message) error {
 				err := json.Unmarshal([]byte(str), &msg)
 				if err != nil {
 					s.log.Error("unmarshal libp2p message error ", err)
+					continue
+				}
+				if msg.Payload, err = base64.StdEncoding.DecodeString(string(msg.Payload)); err != nil {
+					s.log.Error("base64 decode libp2p message payload error ", err)
+					continue
 				}
 				c <- &msg
 			}
@@ -83,8 +100,10 @@ func (s *LibP2pSteam) WriteStream(msg *LibP2pMessage) error {
 	s.Lock()
 	defer s.Unlock()
 	var err error
-	// marshal message
-	bz, err := json.Marshal(msg)
+	// marshal message, the payload is base64 encoded before marshal
+	encoded := *msg
+	encoded.Payload = []byte(base64.StdEncoding.EncodeToString(msg.Payload))
+	bz, err := json.Marshal(&encoded)
 	if err != nil {
 		s.log.Error("marshall libp2p message error", err)
 		return err
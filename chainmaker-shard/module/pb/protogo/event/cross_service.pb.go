@@ -0,0 +1,685 @@
+/*
+Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: module/pb/proto/event/cross_service.proto
+
+package event
+
+import (
+	context "context"
+	fmt "fmt"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+
+	proto "github.com/gogo/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+//TransactionRequest represents a transaction event sent from other cross-chain proxy
+type TransactionRequest struct {
+	Key   string            `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Event *TransactionEvent `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
+	//signed binary of the transaction event context, key and event are ignored when it is set
+	SignedEvent          []byte   `protobuf:"bytes,3,opt,name=signed_event,json=signedEvent,proto3" json:"signed_event,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransactionRequest) Reset()         { *m = TransactionRequest{} }
+func (m *TransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*TransactionRequest) ProtoMessage()    {}
+func (*TransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_491f1952cefd44b1, []int{0}
+}
+func (m *TransactionRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TransactionRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionRequest.Merge(m, src)
+}
+func (m *TransactionRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *TransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionRequest proto.InternalMessageInfo
+
+func (m *TransactionRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *TransactionRequest) GetEvent() *TransactionEvent {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (m *TransactionRequest) GetSignedEvent() []byte {
+	if m != nil {
+		return m.SignedEvent
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*TransactionRequest)(nil), "event.TransactionRequest")
+}
+
+func init() {
+	proto.RegisterFile("module/pb/proto/event/cross_service.proto", fileDescriptor_491f1952cefd44b1)
+}
+
+var fileDescriptor_491f1952cefd44b1 = []byte{
+	// 300 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xd2, 0xcc, 0xcd, 0x4f, 0x29,
+	0xcd, 0x49, 0xd5, 0x2f, 0x48, 0xd2, 0x2f, 0x28, 0xca, 0x2f, 0xc9, 0xd7, 0x4f, 0x2d, 0x4b, 0xcd,
+	0x2b, 0xd1, 0x4f, 0x2e, 0xca, 0x2f, 0x2e, 0x8e, 0x2f, 0x4e, 0x2d, 0x2a, 0xcb, 0x4c, 0x4e, 0xd5,
+	0x03, 0xcb, 0x08, 0xb1, 0x82, 0xa5, 0xa4, 0x14, 0xb1, 0xeb, 0x00, 0x93, 0x10, 0x95, 0x52, 0x5a,
+	0xd8, 0x95, 0x14, 0xa5, 0x16, 0x17, 0xe4, 0xe7, 0x15, 0xa7, 0xc6, 0x23, 0xa9, 0x55, 0xaa, 0xe0,
+	0x12, 0x0a, 0x29, 0x4a, 0xcc, 0x2b, 0x4e, 0x4c, 0x2e, 0xc9, 0xcc, 0xcf, 0x0b, 0x4a, 0x2d, 0x2c,
+	0x4d, 0x2d, 0x2e, 0x11, 0x12, 0xe0, 0x62, 0xce, 0x4e, 0xad, 0x94, 0x60, 0x54, 0x60, 0xd4, 0xe0,
+	0x0c, 0x02, 0x31, 0x85, 0x74, 0xb9, 0x20, 0xf6, 0x4b, 0x30, 0x29, 0x30, 0x6a, 0x70, 0x1b, 0x89,
+	0xeb, 0x41, 0x0c, 0x41, 0xd2, 0xeb, 0x0a, 0x12, 0x08, 0x82, 0xa8, 0x12, 0x52, 0xe4, 0xe2, 0x29,
+	0xce, 0x4c, 0xcf, 0x4b, 0x4d, 0x81, 0x58, 0x26, 0xc1, 0xac, 0xc0, 0xa8, 0xc1, 0x13, 0xc4, 0x0d,
+	0x11, 0x03, 0xab, 0x34, 0x9a, 0xc9, 0xc4, 0x25, 0xe8, 0x0c, 0xf2, 0xa7, 0x73, 0x46, 0x62, 0x66,
+	0x5e, 0x30, 0xc4, 0xaf, 0x42, 0xd6, 0x5c, 0x02, 0x9e, 0x79, 0x65, 0xf9, 0xd9, 0xa9, 0x60, 0x29,
+	0xb0, 0x4a, 0x21, 0x41, 0xa8, 0x65, 0x08, 0x21, 0x29, 0x11, 0x64, 0xa1, 0x20, 0xa8, 0xc7, 0x84,
+	0xec, 0xb8, 0x78, 0xdd, 0x53, 0x4b, 0x90, 0x74, 0x8a, 0x23, 0x2b, 0x0b, 0x4e, 0x4d, 0x2c, 0x4a,
+	0xce, 0xc0, 0xa7, 0xdf, 0x99, 0x4b, 0x00, 0xdd, 0x43, 0x42, 0x92, 0x98, 0x3e, 0x85, 0x86, 0x12,
+	0xdc, 0x90, 0x80, 0xa2, 0xfc, 0xfc, 0x34, 0xb8, 0x21, 0x4e, 0x5c, 0xfc, 0xe1, 0x89, 0x25, 0xc9,
+	0x19, 0x64, 0x3b, 0xc3, 0x80, 0xd1, 0x49, 0xf5, 0xc4, 0x23, 0x39, 0xc6, 0x0b, 0x8f, 0xe4, 0x18,
+	0x1f, 0x3c, 0x92, 0x63, 0x8c, 0x12, 0x47, 0x8b, 0xcf, 0x74, 0x68, 0x8c, 0x26, 0xb1, 0x81, 0xb9,
+	0xc6, 0x80, 0x01, 0x00, 0x93, 0xcb, 0x5e, 0x3a, 0x46, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// CrossChainServiceClient is the client API for CrossChainService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type CrossChainServiceClient interface {
+	//InvokeCrossEvent receive a cross event and return its cross id
+	InvokeCrossEvent(ctx context.Context, in *CrossEvent, opts ...grpc.CallOption) (*CrossResponse, error)
+	//GetCrossEvent search the result of cross event
+	GetCrossEvent(ctx context.Context, in *CrossSearchEvent, opts ...grpc.CallOption) (*CrossResponse, error)
+	//TransactionEvent handle the transaction event and return its proof
+	TransactionEvent(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*ProofResponse, error)
+	//WatchCrossEvent push the result of cross event until it is finished
+	WatchCrossEvent(ctx context.Context, in *CrossSearchEvent, opts ...grpc.CallOption) (CrossChainService_WatchCrossEventClient, error)
+}
+
+type crossChainServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewCrossChainServiceClient(cc *grpc.ClientConn) CrossChainServiceClient {
+	return &crossChainServiceClient{cc}
+}
+
+func (c *crossChainServiceClient) InvokeCrossEvent(ctx context.Context, in *CrossEvent, opts ...grpc.CallOption) (*CrossResponse, error) {
+	out := new(CrossResponse)
+	err := c.cc.Invoke(ctx, "/event.CrossChainService/InvokeCrossEvent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crossChainServiceClient) GetCrossEvent(ctx context.Context, in *CrossSearchEvent, opts ...grpc.CallOption) (*CrossResponse, error) {
+	out := new(CrossResponse)
+	err := c.cc.Invoke(ctx, "/event.CrossChainService/GetCrossEvent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crossChainServiceClient) TransactionEvent(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*ProofResponse, error) {
+	out := new(ProofResponse)
+	err := c.cc.Invoke(ctx, "/event.CrossChainService/TransactionEvent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crossChainServiceClient) WatchCrossEvent(ctx context.Context, in *CrossSearchEvent, opts ...grpc.CallOption) (CrossChainService_WatchCrossEventClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CrossChainService_serviceDesc.Streams[0], "/event.CrossChainService/WatchCrossEvent", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &crossChainServiceWatchCrossEventClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CrossChainService_WatchCrossEventClient interface {
+	Recv() (*CrossResponse, error)
+	grpc.ClientStream
+}
+
+type crossChainServiceWatchCrossEventClient struct {
+	grpc.ClientStream
+}
+
+func (x *crossChainServiceWatchCrossEventClient) Recv() (*CrossResponse, error) {
+	m := new(CrossResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// CrossChainServiceServer is the server API for CrossChainService service.
+type CrossChainServiceServer interface {
+	//InvokeCrossEvent receive a cross event and return its cross id
+	InvokeCrossEvent(context.Context, *CrossEvent) (*CrossResponse, error)
+	//GetCrossEvent search the result of cross event
+	GetCrossEvent(context.Context, *CrossSearchEvent) (*CrossResponse, error)
+	//TransactionEvent handle the transaction event and return its proof
+	TransactionEvent(context.Context, *TransactionRequest) (*ProofResponse, error)
+	//WatchCrossEvent push the result of cross event until it is finished
+	WatchCrossEvent(*CrossSearchEvent, CrossChainService_WatchCrossEventServer) error
+}
+
+// UnimplementedCrossChainServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedCrossChainServiceServer struct {
+}
+
+func (*UnimplementedCrossChainServiceServer) InvokeCrossEvent(ctx context.Context, req *CrossEvent) (*CrossResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InvokeCrossEvent not implemented")
+}
+func (*UnimplementedCrossChainServiceServer) GetCrossEvent(ctx context.Context, req *CrossSearchEvent) (*CrossResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCrossEvent not implemented")
+}
+func (*UnimplementedCrossChainServiceServer) TransactionEvent(ctx context.Context, req *TransactionRequest) (*ProofResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransactionEvent not implemented")
+}
+func (*UnimplementedCrossChainServiceServer) WatchCrossEvent(req *CrossSearchEvent, srv CrossChainService_WatchCrossEventServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchCrossEvent not implemented")
+}
+
+func RegisterCrossChainServiceServer(s *grpc.Server, srv CrossChainServiceServer) {
+	s.RegisterService(&_CrossChainService_serviceDesc, srv)
+}
+
+func _CrossChainService_InvokeCrossEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CrossEvent)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrossChainServiceServer).InvokeCrossEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/event.CrossChainService/InvokeCrossEvent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrossChainServiceServer).InvokeCrossEvent(ctx, req.(*CrossEvent))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CrossChainService_GetCrossEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CrossSearchEvent)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrossChainServiceServer).GetCrossEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/event.CrossChainService/GetCrossEvent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrossChainServiceServer).GetCrossEvent(ctx, req.(*CrossSearchEvent))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CrossChainService_TransactionEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrossChainServiceServer).TransactionEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/event.CrossChainService/TransactionEvent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrossChainServiceServer).TransactionEvent(ctx, req.(*TransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CrossChainService_WatchCrossEvent_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CrossSearchEvent)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CrossChainServiceServer).WatchCrossEvent(m, &crossChainServiceWatchCrossEventServer{stream})
+}
+
+type CrossChainService_WatchCrossEventServer interface {
+	Send(*CrossResponse) error
+	grpc.ServerStream
+}
+
+type crossChainServiceWatchCrossEventServer struct {
+	grpc.ServerStream
+}
+
+func (x *crossChainServiceWatchCrossEventServer) Send(m *CrossResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _CrossChainService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "event.CrossChainService",
+	HandlerType: (*CrossChainServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "InvokeCrossEvent",
+			Handler:    _CrossChainService_InvokeCrossEvent_Handler,
+		},
+		{
+			MethodName: "GetCrossEvent",
+			Handler:    _CrossChainService_GetCrossEvent_Handler,
+		},
+		{
+			MethodName: "TransactionEvent",
+			Handler:    _CrossChainService_TransactionEvent_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchCrossEvent",
+			Handler:       _CrossChainService_WatchCrossEvent_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "module/pb/proto/event/cross_service.proto",
+}
+
+func (m *TransactionRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TransactionRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TransactionRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.SignedEvent) > 0 {
+		i -= len(m.SignedEvent)
+		copy(dAtA[i:], m.SignedEvent)
+		i = encodeVarintCrossService(dAtA, i, uint64(len(m.SignedEvent)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Event != nil {
+		{
+			size, err := m.Event.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCrossService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintCrossService(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintCrossService(dAtA []byte, offset int, v uint64) int {
+	offset -= sovCrossService(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *TransactionRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovCrossService(uint64(l))
+	}
+	if m.Event != nil {
+		l = m.Event.Size()
+		n += 1 + l + sovCrossService(uint64(l))
+	}
+	l = len(m.SignedEvent)
+	if l > 0 {
+		n += 1 + l + sovCrossService(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovCrossService(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozCrossService(x uint64) (n int) {
+	return sovCrossService(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *TransactionRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCrossService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TransactionRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TransactionRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCrossService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCrossService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCrossService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Event", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCrossService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCrossService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCrossService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Event == nil {
+				m.Event = &TransactionEvent{}
+			}
+			if err := m.Event.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignedEvent", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCrossService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCrossService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCrossService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SignedEvent = append(m.SignedEvent[:0], dAtA[iNdEx:postIndex]...)
+			if m.SignedEvent == nil {
+				m.SignedEvent = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCrossService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCrossService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipCrossService(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowCrossService
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowCrossService
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowCrossService
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthCrossService
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupCrossService
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthCrossService
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthCrossService        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowCrossService          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupCrossService = fmt.Errorf("proto: unexpected end of group")
+)
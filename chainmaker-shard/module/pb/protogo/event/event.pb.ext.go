@@ -4,6 +4,12 @@
 */
 package event
 
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
 // Type is the base type for all event type by each event implements
 type EventType byte
 
@@ -17,6 +23,9 @@ const (
 	ProofRespEventType
 	TransactionCtxEventType
 	TxProofType
+	DeadLetterEventType // dead-letter operation event, send from operator to Proxy
+	AdminEventType      // admin operation event, send from operator to Proxy
+	SignedMessageType   // signed message between proxies, which wraps the binary of other events
 )
 
 // SetExtra set extra
@@ -34,6 +43,26 @@ func (c *CrossEvent) SetTimestamp(timestamp int64) {
 	c.Timestamp = timestamp
 }
 
+// SetDeadline set deadline, unix timestamp in seconds
+func (c *CrossEvent) SetDeadline(deadline int64) {
+	c.Deadline = deadline
+}
+
+// IsExpired return whether the deadline of cross event has passed, always false without deadline
+func (c *CrossEvent) IsExpired(now time.Time) bool {
+	return c.GetDeadline() > 0 && now.Unix() >= c.GetDeadline()
+}
+
+// SetExecuteMode set execute mode
+func (c *CrossEvent) SetExecuteMode(mode ExecuteMode) {
+	c.ExecuteMode = mode
+}
+
+// IsParallel return whether the cross txs should be executed concurrently
+func (c *CrossEvent) IsParallel() bool {
+	return c.GetExecuteMode() == ExecuteMode_ParallelExecuteMode
+}
+
 // SetCrossID set cross-id
 func (c *CrossEvent) SetCrossID(crossID string) {
 	c.CrossId = crossID
@@ -59,6 +88,22 @@ func (c *CrossEvent) IsValid() bool {
 	return true
 }
 
+// Validate check the cross event received from client, the txs must be indexed in order and each has chain-id
+func (c *CrossEvent) Validate() error {
+	if len(c.TxEvents.GetEvents()) == 0 {
+		return errors.New("cross txs of cross event is empty")
+	}
+	if !c.IsValid() {
+		return errors.New("cross txs of cross event do not match their indexes")
+	}
+	for _, tx := range c.TxEvents.GetEvents() {
+		if tx.GetChainID() == "" {
+			return fmt.Errorf("chain-id of cross tx[%d] is empty", tx.GetIndex())
+		}
+	}
+	return nil
+}
+
 // GetChainIDs return array of chain-id
 func (c *CrossEvent) GetChainIDs() []string {
 	chainIDs := make([]string, 0)
@@ -134,7 +179,40 @@ type VerifiedProof struct {
 	TxProof        *Proof
 	VerifiedResult bool
 	ProverType     string
-	Identity       string // 预留，暂不实现
+	Identity       string         // 预留，暂不实现
+	Attestations   []*Attestation `json:",omitempty"` // 对端代理的签名背书，供链上合约重新验证
+}
+
+// ChainAnnouncement the chains which are served by the adapters of proxy, it is announced to the peer proxies
+// so that they can build the routing table dynamically
+type ChainAnnouncement struct {
+	ProxyName string         `json:"proxy_name"`       // 代理名称
+	ChainIDs  []string       `json:"chain_ids"`        // 代理的转接器服务的链
+	Relays    map[string]int `json:"relays,omitempty"` // 代理可转发的链 => 到达该链需经过的代理数，不含直连的链
+	Timestamp int64          `json:"timestamp"`        // 通告时间，unix时间戳，单位：秒
+}
+
+// SignedMessage the message between proxies which is signed by the identity key of sender,
+// the payload is the binary of transaction event context or proof response
+type SignedMessage struct {
+	Payload   []byte `json:"payload"`   // 被签名消息的二进制数据
+	Signer    string `json:"signer"`    // 签名者公钥，hex编码
+	Nonce     string `json:"nonce"`     // 随机数，用于防重放
+	Timestamp int64  `json:"timestamp"` // 签名时间，unix时间戳，单位：秒
+	Signature string `json:"signature"` // 签名，hex编码
+}
+
+// GetType return the type of signed message
+func (m *SignedMessage) GetType() EventType {
+	return SignedMessageType
+}
+
+// Attestation the signature of peer proxy on the proof, the signed hash is computed by the chain id, tx key,
+// block height, index of proof and the result
+type Attestation struct {
+	Signer    string `json:"signer"`    // 背书代理的ed25519公钥，hex编码
+	Result    bool   `json:"result"`    // 背书代理对该证明的验证结果
+	Signature string `json:"signature"` // 签名，hex编码
 }
 
 func NewVerifiedProof(txProof *Proof, verifiedResult bool, proverType, identity string) *VerifiedProof {
@@ -178,3 +256,23 @@ func (te *TransactionEvent) SetProofKey(key string) {
 		te.ProofKey = key
 	}
 }
+
+// HasHop return whether the event has passed the proxy
+func (te *TransactionEvent) HasHop(proxyName string) bool {
+	for _, hop := range te.GetHops() {
+		if hop == proxyName {
+			return true
+		}
+	}
+	return false
+}
+
+// Forward return the copy of event which is forwarded by the proxy, the proxy is appended to the hops and the ttl decreases,
+// the original event is not changed so that it can be forwarded by other routers again
+func (te *TransactionEvent) Forward(proxyName string) *TransactionEvent {
+	forwarded := *te
+	forwarded.Hops = make([]string, 0, len(te.Hops)+1)
+	forwarded.Hops = append(append(forwarded.Hops, te.Hops...), proxyName)
+	forwarded.Ttl = te.Ttl - 1
+	return &forwarded
+}
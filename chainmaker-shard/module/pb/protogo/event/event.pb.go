@@ -9,6 +9,7 @@ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
 package event
 
 import (
+	encoding_binary "encoding/binary"
 	fmt "fmt"
 	io "io"
 	math "math"
@@ -31,21 +32,30 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 type OpFuncType int32
 
 const (
-	OpFuncType_ExecuteOpFunc  OpFuncType = 0
-	OpFuncType_CommitOpFunc   OpFuncType = 1
-	OpFuncType_RollbackOpFunc OpFuncType = -1
+	OpFuncType_ExecuteOpFunc   OpFuncType = 0
+	OpFuncType_CommitOpFunc    OpFuncType = 1
+	OpFuncType_RollbackOpFunc  OpFuncType = -1
+	OpFuncType_AttestOpFunc    OpFuncType = 2
+	OpFuncType_AnnounceOpFunc  OpFuncType = 3
+	OpFuncType_SaveProofOpFunc OpFuncType = 4
 )
 
 var OpFuncType_name = map[int32]string{
 	0:  "ExecuteOpFunc",
 	1:  "CommitOpFunc",
 	-1: "RollbackOpFunc",
+	2:  "AttestOpFunc",
+	3:  "AnnounceOpFunc",
+	4:  "SaveProofOpFunc",
 }
 
 var OpFuncType_value = map[string]int32{
-	"ExecuteOpFunc":  0,
-	"CommitOpFunc":   1,
-	"RollbackOpFunc": -1,
+	"ExecuteOpFunc":   0,
+	"CommitOpFunc":    1,
+	"RollbackOpFunc":  -1,
+	"AttestOpFunc":    2,
+	"AnnounceOpFunc":  3,
+	"SaveProofOpFunc": 4,
 }
 
 func (x OpFuncType) String() string {
@@ -56,16 +66,47 @@ func (OpFuncType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_9130e9af8b9107bb, []int{0}
 }
 
+//ExecuteMode represents how the cross-chain transactions are executed
+type ExecuteMode int32
+
+const (
+	ExecuteMode_SerialExecuteMode   ExecuteMode = 0
+	ExecuteMode_ParallelExecuteMode ExecuteMode = 1
+)
+
+var ExecuteMode_name = map[int32]string{
+	0: "SerialExecuteMode",
+	1: "ParallelExecuteMode",
+}
+
+var ExecuteMode_value = map[string]int32{
+	"SerialExecuteMode":   0,
+	"ParallelExecuteMode": 1,
+}
+
+func (x ExecuteMode) String() string {
+	return proto.EnumName(ExecuteMode_name, int32(x))
+}
+
+func (ExecuteMode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_9130e9af8b9107bb, []int{1}
+}
+
 //CrossEvent represents a cross-chain event
 type CrossEvent struct {
-	CrossId              string    `protobuf:"bytes,1,opt,name=cross_id,json=crossId,proto3" json:"cross_id,omitempty"`
-	TxEvents             *CrossTxs `protobuf:"bytes,2,opt,name=tx_events,json=txEvents,proto3" json:"tx_events,omitempty"`
-	Version              string    `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
-	Timestamp            int64     `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Extra                []byte    `protobuf:"bytes,5,opt,name=extra,proto3" json:"extra,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	CrossId              string       `protobuf:"bytes,1,opt,name=cross_id,json=crossId,proto3" json:"cross_id,omitempty"`
+	TxEvents             *CrossTxs    `protobuf:"bytes,2,opt,name=tx_events,json=txEvents,proto3" json:"tx_events,omitempty"`
+	Version              string       `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	Timestamp            int64        `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Extra                []byte       `protobuf:"bytes,5,opt,name=extra,proto3" json:"extra,omitempty"`
+	ExecuteMode          ExecuteMode  `protobuf:"varint,6,opt,name=execute_mode,json=executeMode,proto3,enum=event.ExecuteMode" json:"execute_mode,omitempty"`
+	CallbackUrls         []string     `protobuf:"bytes,7,rep,name=callback_urls,json=callbackUrls,proto3" json:"callback_urls,omitempty"`
+	RetryPolicy          *RetryPolicy `protobuf:"bytes,8,opt,name=retry_policy,json=retryPolicy,proto3" json:"retry_policy,omitempty"`
+	Deadline             int64        `protobuf:"varint,9,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	Initiator            string       `protobuf:"bytes,10,opt,name=initiator,proto3" json:"initiator,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *CrossEvent) Reset()         { *m = CrossEvent{} }
@@ -136,6 +177,145 @@ func (m *CrossEvent) GetExtra() []byte {
 	return nil
 }
 
+func (m *CrossEvent) GetExecuteMode() ExecuteMode {
+	if m != nil {
+		return m.ExecuteMode
+	}
+	return ExecuteMode_SerialExecuteMode
+}
+
+func (m *CrossEvent) GetCallbackUrls() []string {
+	if m != nil {
+		return m.CallbackUrls
+	}
+	return nil
+}
+
+func (m *CrossEvent) GetRetryPolicy() *RetryPolicy {
+	if m != nil {
+		return m.RetryPolicy
+	}
+	return nil
+}
+
+func (m *CrossEvent) GetDeadline() int64 {
+	if m != nil {
+		return m.Deadline
+	}
+	return 0
+}
+
+func (m *CrossEvent) GetInitiator() string {
+	if m != nil {
+		return m.Initiator
+	}
+	return ""
+}
+
+//RetryPolicy overrides the retry policy of proxy for the cross-chain event, zero means using proxy's config
+type RetryPolicy struct {
+	MaxAttempts          int32    `protobuf:"varint,1,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	InitialBackoff       int64    `protobuf:"varint,2,opt,name=initial_backoff,json=initialBackoff,proto3" json:"initial_backoff,omitempty"`
+	MaxBackoff           int64    `protobuf:"varint,3,opt,name=max_backoff,json=maxBackoff,proto3" json:"max_backoff,omitempty"`
+	Multiplier           float64  `protobuf:"fixed64,4,opt,name=multiplier,proto3" json:"multiplier,omitempty"`
+	Jitter               float64  `protobuf:"fixed64,5,opt,name=jitter,proto3" json:"jitter,omitempty"`
+	ExecuteTimeout       int64    `protobuf:"varint,6,opt,name=execute_timeout,json=executeTimeout,proto3" json:"execute_timeout,omitempty"`
+	CommitTimeout        int64    `protobuf:"varint,7,opt,name=commit_timeout,json=commitTimeout,proto3" json:"commit_timeout,omitempty"`
+	RollbackTimeout      int64    `protobuf:"varint,8,opt,name=rollback_timeout,json=rollbackTimeout,proto3" json:"rollback_timeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RetryPolicy) Reset()         { *m = RetryPolicy{} }
+func (m *RetryPolicy) String() string { return proto.CompactTextString(m) }
+func (*RetryPolicy) ProtoMessage()    {}
+func (*RetryPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9130e9af8b9107bb, []int{1}
+}
+func (m *RetryPolicy) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RetryPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RetryPolicy.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RetryPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RetryPolicy.Merge(m, src)
+}
+func (m *RetryPolicy) XXX_Size() int {
+	return m.Size()
+}
+func (m *RetryPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_RetryPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RetryPolicy proto.InternalMessageInfo
+
+func (m *RetryPolicy) GetMaxAttempts() int32 {
+	if m != nil {
+		return m.MaxAttempts
+	}
+	return 0
+}
+
+func (m *RetryPolicy) GetInitialBackoff() int64 {
+	if m != nil {
+		return m.InitialBackoff
+	}
+	return 0
+}
+
+func (m *RetryPolicy) GetMaxBackoff() int64 {
+	if m != nil {
+		return m.MaxBackoff
+	}
+	return 0
+}
+
+func (m *RetryPolicy) GetMultiplier() float64 {
+	if m != nil {
+		return m.Multiplier
+	}
+	return 0
+}
+
+func (m *RetryPolicy) GetJitter() float64 {
+	if m != nil {
+		return m.Jitter
+	}
+	return 0
+}
+
+func (m *RetryPolicy) GetExecuteTimeout() int64 {
+	if m != nil {
+		return m.ExecuteTimeout
+	}
+	return 0
+}
+
+func (m *RetryPolicy) GetCommitTimeout() int64 {
+	if m != nil {
+		return m.CommitTimeout
+	}
+	return 0
+}
+
+func (m *RetryPolicy) GetRollbackTimeout() int64 {
+	if m != nil {
+		return m.RollbackTimeout
+	}
+	return 0
+}
+
 //CrossTxs a set of cross-chain transaction
 type CrossTxs struct {
 	Events               []*CrossTx `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
@@ -148,7 +328,7 @@ func (m *CrossTxs) Reset()         { *m = CrossTxs{} }
 func (m *CrossTxs) String() string { return proto.CompactTextString(m) }
 func (*CrossTxs) ProtoMessage()    {}
 func (*CrossTxs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9130e9af8b9107bb, []int{1}
+	return fileDescriptor_9130e9af8b9107bb, []int{2}
 }
 func (m *CrossTxs) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -201,7 +381,7 @@ func (m *CrossTx) Reset()         { *m = CrossTx{} }
 func (m *CrossTx) String() string { return proto.CompactTextString(m) }
 func (*CrossTx) ProtoMessage()    {}
 func (*CrossTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9130e9af8b9107bb, []int{2}
+	return fileDescriptor_9130e9af8b9107bb, []int{3}
 }
 func (m *CrossTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -284,7 +464,7 @@ func (m *CrossSearchEvent) Reset()         { *m = CrossSearchEvent{} }
 func (m *CrossSearchEvent) String() string { return proto.CompactTextString(m) }
 func (*CrossSearchEvent) ProtoMessage()    {}
 func (*CrossSearchEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9130e9af8b9107bb, []int{3}
+	return fileDescriptor_9130e9af8b9107bb, []int{4}
 }
 func (m *CrossSearchEvent) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -328,6 +508,8 @@ type TransactionEvent struct {
 	Payload              []byte     `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
 	ProofKey             string     `protobuf:"bytes,5,opt,name=proof_key,json=proofKey,proto3" json:"proof_key,omitempty"`
 	TxProof              *Proof     `protobuf:"bytes,6,opt,name=tx_proof,json=txProof,proto3" json:"tx_proof,omitempty"`
+	Hops                 []string   `protobuf:"bytes,7,rep,name=hops,proto3" json:"hops,omitempty"`
+	Ttl                  int32      `protobuf:"varint,8,opt,name=ttl,proto3" json:"ttl,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
 	XXX_unrecognized     []byte     `json:"-"`
 	XXX_sizecache        int32      `json:"-"`
@@ -337,7 +519,7 @@ func (m *TransactionEvent) Reset()         { *m = TransactionEvent{} }
 func (m *TransactionEvent) String() string { return proto.CompactTextString(m) }
 func (*TransactionEvent) ProtoMessage()    {}
 func (*TransactionEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9130e9af8b9107bb, []int{4}
+	return fileDescriptor_9130e9af8b9107bb, []int{5}
 }
 func (m *TransactionEvent) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -408,6 +590,20 @@ func (m *TransactionEvent) GetTxProof() *Proof {
 	return nil
 }
 
+func (m *TransactionEvent) GetHops() []string {
+	if m != nil {
+		return m.Hops
+	}
+	return nil
+}
+
+func (m *TransactionEvent) GetTtl() int32 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
 //Proof represents proof of a transaction
 type Proof struct {
 	ChainId              string        `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
@@ -425,7 +621,7 @@ func (m *Proof) Reset()         { *m = Proof{} }
 func (m *Proof) String() string { return proto.CompactTextString(m) }
 func (*Proof) ProtoMessage()    {}
 func (*Proof) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9130e9af8b9107bb, []int{5}
+	return fileDescriptor_9130e9af8b9107bb, []int{6}
 }
 func (m *Proof) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -512,7 +708,7 @@ func (m *ContractInfo) Reset()         { *m = ContractInfo{} }
 func (m *ContractInfo) String() string { return proto.CompactTextString(m) }
 func (*ContractInfo) ProtoMessage()    {}
 func (*ContractInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9130e9af8b9107bb, []int{6}
+	return fileDescriptor_9130e9af8b9107bb, []int{7}
 }
 func (m *ContractInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -589,7 +785,7 @@ func (m *ContractParameter) Reset()         { *m = ContractParameter{} }
 func (m *ContractParameter) String() string { return proto.CompactTextString(m) }
 func (*ContractParameter) ProtoMessage()    {}
 func (*ContractParameter) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9130e9af8b9107bb, []int{7}
+	return fileDescriptor_9130e9af8b9107bb, []int{8}
 }
 func (m *ContractParameter) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -634,7 +830,9 @@ func (m *ContractParameter) GetValue() string {
 
 func init() {
 	proto.RegisterEnum("event.OpFuncType", OpFuncType_name, OpFuncType_value)
+	proto.RegisterEnum("event.ExecuteMode", ExecuteMode_name, ExecuteMode_value)
 	proto.RegisterType((*CrossEvent)(nil), "event.CrossEvent")
+	proto.RegisterType((*RetryPolicy)(nil), "event.RetryPolicy")
 	proto.RegisterType((*CrossTxs)(nil), "event.CrossTxs")
 	proto.RegisterType((*CrossTx)(nil), "event.CrossTx")
 	proto.RegisterType((*CrossSearchEvent)(nil), "event.CrossSearchEvent")
@@ -647,48 +845,66 @@ func init() {
 func init() { proto.RegisterFile("module/pb/proto/event/event.proto", fileDescriptor_9130e9af8b9107bb) }
 
 var fileDescriptor_9130e9af8b9107bb = []byte{
-	// 641 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0xcd, 0x6e, 0xd3, 0x40,
-	0x10, 0x66, 0x9b, 0xd8, 0x49, 0x26, 0x69, 0x9a, 0x2e, 0x7f, 0x46, 0x85, 0x2a, 0xb5, 0x54, 0x08,
-	0x15, 0x34, 0x92, 0xb9, 0x20, 0x71, 0x03, 0x8a, 0xa8, 0x90, 0xa0, 0x5a, 0x7a, 0xe2, 0x62, 0x6d,
-	0xec, 0x6d, 0x63, 0xd5, 0xf6, 0x5a, 0xf6, 0xa6, 0x72, 0x9e, 0x87, 0x33, 0x0f, 0xc0, 0x1b, 0x70,
-	0x44, 0x88, 0x07, 0x40, 0x7d, 0x11, 0x90, 0xc7, 0xeb, 0xc6, 0x6e, 0x25, 0x9a, 0x43, 0x34, 0xdf,
-	0xe7, 0xf1, 0xce, 0x7c, 0xdf, 0xce, 0x18, 0x76, 0x22, 0xe9, 0x2f, 0x42, 0x31, 0x4d, 0x66, 0xd3,
-	0x24, 0x95, 0x4a, 0x4e, 0xc5, 0xb9, 0x88, 0x55, 0xf9, 0xbf, 0x8f, 0x0c, 0x35, 0x10, 0xd8, 0x5f,
-	0x09, 0xc0, 0x9b, 0x54, 0x66, 0xd9, 0x41, 0x01, 0xe9, 0x03, 0xe8, 0x7a, 0x05, 0x72, 0x03, 0xdf,
-	0x22, 0x63, 0x32, 0xe9, 0xb1, 0x0e, 0xe2, 0x43, 0x9f, 0x3e, 0x83, 0x9e, 0xca, 0x5d, 0x7c, 0x2b,
-	0xb3, 0xd6, 0xc6, 0x64, 0xd2, 0x77, 0x36, 0xf6, 0xcb, 0x13, 0xf1, 0x80, 0xe3, 0x3c, 0x63, 0x5d,
-	0x95, 0xe3, 0x39, 0x19, 0xb5, 0xa0, 0x73, 0x2e, 0xd2, 0x2c, 0x90, 0xb1, 0xd5, 0x2a, 0xcf, 0xd1,
-	0x90, 0x3e, 0x84, 0x9e, 0x0a, 0x22, 0x91, 0x29, 0x1e, 0x25, 0x56, 0x7b, 0x4c, 0x26, 0x2d, 0xb6,
-	0x22, 0xe8, 0x1d, 0x30, 0x44, 0xae, 0x52, 0x6e, 0x19, 0x63, 0x32, 0x19, 0xb0, 0x12, 0xd8, 0x0e,
-	0x74, 0xab, 0x1a, 0xf4, 0x31, 0x98, 0xba, 0x09, 0x32, 0x6e, 0x4d, 0xfa, 0xce, 0xb0, 0xd9, 0x04,
-	0xd3, 0x4f, 0xed, 0x5f, 0x04, 0x3a, 0x9a, 0x43, 0x59, 0x73, 0x1e, 0xc4, 0x75, 0x59, 0x05, 0x3e,
-	0xf4, 0x8b, 0x82, 0x41, 0xec, 0x8b, 0x1c, 0x25, 0x19, 0xac, 0x04, 0x74, 0x0b, 0x7a, 0x49, 0x2a,
-	0xe5, 0x89, 0x7b, 0x26, 0x96, 0x5a, 0x40, 0x17, 0x89, 0x0f, 0x62, 0x49, 0x9f, 0xc0, 0x86, 0xc8,
-	0x85, 0xb7, 0x50, 0xc2, 0x4d, 0xf8, 0x32, 0x94, 0xdc, 0x47, 0x1d, 0x03, 0x36, 0xd4, 0xf4, 0x51,
-	0xc9, 0xd2, 0x5d, 0x18, 0x7a, 0x32, 0x8a, 0x02, 0x75, 0x99, 0x57, 0xaa, 0x5a, 0x2f, 0xd9, 0x2a,
-	0xed, 0x29, 0x8c, 0x52, 0x19, 0x86, 0x33, 0xee, 0x9d, 0x5d, 0x26, 0x9a, 0x98, 0xb8, 0x51, 0xf1,
-	0x3a, 0xd5, 0x7e, 0x0e, 0x23, 0xd4, 0xf4, 0x59, 0xf0, 0xd4, 0x9b, 0xdf, 0x74, 0x67, 0xf6, 0x6f,
-	0x02, 0xa3, 0xe3, 0x94, 0xc7, 0x19, 0xf7, 0x54, 0x20, 0xe3, 0x1b, 0xef, 0x78, 0x0f, 0x3a, 0x32,
-	0x71, 0x4f, 0x16, 0xb1, 0x87, 0x76, 0x0c, 0x9d, 0x4d, 0x6d, 0xee, 0xa7, 0xe4, 0xdd, 0x22, 0xf6,
-	0x8e, 0x97, 0x89, 0x60, 0xa6, 0xc4, 0xb8, 0xe1, 0x69, 0xab, 0xe9, 0xa9, 0x05, 0x9d, 0xa6, 0x31,
-	0x15, 0x6c, 0xfa, 0x6a, 0x5c, 0xf3, 0xb5, 0xab, 0x72, 0x17, 0x21, 0xea, 0xef, 0x3b, 0x03, 0x5d,
-	0xfe, 0xa8, 0xe0, 0x58, 0x47, 0xe5, 0x18, 0xd8, 0xdf, 0x09, 0x18, 0x18, 0xfd, 0xef, 0x62, 0xef,
-	0x82, 0xa9, 0x72, 0xac, 0xb3, 0x86, 0x0f, 0x0c, 0x95, 0x17, 0x45, 0x76, 0x60, 0x30, 0x0b, 0xa5,
-	0x77, 0xe6, 0xce, 0x45, 0x70, 0x3a, 0x57, 0xd8, 0x7a, 0x8b, 0xf5, 0x91, 0x7b, 0x8f, 0xd4, 0x6a,
-	0x24, 0xda, 0xf5, 0x91, 0x98, 0x42, 0xd7, 0x93, 0xb1, 0x4a, 0xb9, 0xa7, 0xb0, 0xf3, 0xbe, 0x73,
-	0xbb, 0x9a, 0x3c, 0x4d, 0x1f, 0xc6, 0x27, 0x92, 0x5d, 0x26, 0xad, 0x46, 0xd9, 0xac, 0x8f, 0xf2,
-	0x37, 0x02, 0x83, 0xfa, 0x0b, 0x94, 0x42, 0x3b, 0xe6, 0x91, 0xd0, 0xed, 0x63, 0x5c, 0xdf, 0x9e,
-	0xb5, 0xe6, 0xf6, 0xdc, 0x03, 0x33, 0x12, 0x6a, 0x2e, 0x2b, 0xcf, 0x35, 0xa2, 0x2f, 0x01, 0x12,
-	0x9e, 0xf2, 0x48, 0x28, 0x91, 0x66, 0x56, 0x1b, 0x37, 0xc3, 0xba, 0xd2, 0xdf, 0x51, 0x95, 0xc0,
-	0x6a, 0xb9, 0xf4, 0x11, 0x00, 0x76, 0xe6, 0xfa, 0x5c, 0x55, 0x6b, 0xd7, 0x43, 0xe6, 0x2d, 0x57,
-	0xdc, 0x7e, 0x05, 0x9b, 0xd7, 0xde, 0xa7, 0x23, 0x68, 0x15, 0xc6, 0x96, 0x2d, 0x17, 0x61, 0x21,
-	0xf6, 0x9c, 0x87, 0x0b, 0x51, 0x99, 0x8d, 0x60, 0xef, 0x23, 0xc0, 0x6a, 0x72, 0xe8, 0x26, 0xac,
-	0x1f, 0x94, 0x0b, 0x52, 0x92, 0xa3, 0x5b, 0x74, 0x54, 0x98, 0x51, 0xec, 0x82, 0x66, 0x08, 0xdd,
-	0x82, 0x21, 0xd3, 0x43, 0xaf, 0xb9, 0xbf, 0xd5, 0x8f, 0xbc, 0xde, 0xfd, 0x71, 0xb1, 0x4d, 0x7e,
-	0x5e, 0x6c, 0x93, 0x3f, 0x17, 0xdb, 0xe4, 0xcb, 0xfd, 0x2b, 0x5f, 0xb9, 0x53, 0xfd, 0x9d, 0x9b,
-	0x99, 0x08, 0x5f, 0xfc, 0x0b, 0x00, 0x00, 0xff, 0xff, 0x09, 0x9c, 0x92, 0x0d, 0x07, 0x05, 0x00,
-	0x00,
+	// 939 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x55, 0xdd, 0x6e, 0xe3, 0x44,
+	0x14, 0xde, 0x89, 0x9b, 0xbf, 0xe3, 0x34, 0x4d, 0xa7, 0xec, 0xae, 0x61, 0xa1, 0xa4, 0x41, 0x0b,
+	0xa1, 0x82, 0xad, 0x14, 0x84, 0x84, 0x84, 0xb8, 0xd8, 0x85, 0x45, 0x54, 0x08, 0x51, 0x4d, 0xcb,
+	0x0d, 0x37, 0xd6, 0xd4, 0x9e, 0x6c, 0x4c, 0x6d, 0x8f, 0x35, 0x1e, 0x57, 0xce, 0x23, 0xf0, 0x30,
+	0xfb, 0x00, 0xbc, 0x01, 0x97, 0x88, 0x27, 0x40, 0x7d, 0x02, 0xae, 0xb9, 0x01, 0xcd, 0xf1, 0x4c,
+	0xe2, 0xec, 0x4a, 0x6c, 0x2f, 0xaa, 0xf9, 0xbe, 0xf9, 0xe6, 0xe4, 0x9c, 0xef, 0x9c, 0x19, 0xc3,
+	0x49, 0x26, 0xe3, 0x2a, 0x15, 0x67, 0xc5, 0xf5, 0x59, 0xa1, 0xa4, 0x96, 0x67, 0xe2, 0x56, 0xe4,
+	0xba, 0xf9, 0xff, 0x04, 0x19, 0xda, 0x45, 0x30, 0xfb, 0xbb, 0x03, 0xf0, 0xb5, 0x92, 0x65, 0xf9,
+	0xdc, 0x40, 0xfa, 0x36, 0x0c, 0x22, 0x83, 0xc2, 0x24, 0x0e, 0xc8, 0x94, 0xcc, 0x87, 0xac, 0x8f,
+	0xf8, 0x3c, 0xa6, 0x9f, 0xc0, 0x50, 0xd7, 0x21, 0x9e, 0x2a, 0x83, 0xce, 0x94, 0xcc, 0xfd, 0xc5,
+	0xc1, 0x93, 0x26, 0x22, 0x06, 0xb8, 0xaa, 0x4b, 0x36, 0xd0, 0x35, 0xc6, 0x29, 0x69, 0x00, 0xfd,
+	0x5b, 0xa1, 0xca, 0x44, 0xe6, 0x81, 0xd7, 0xc4, 0xb1, 0x90, 0xbe, 0x0b, 0x43, 0x9d, 0x64, 0xa2,
+	0xd4, 0x3c, 0x2b, 0x82, 0xbd, 0x29, 0x99, 0x7b, 0x6c, 0x4b, 0xd0, 0xb7, 0xa0, 0x2b, 0x6a, 0xad,
+	0x78, 0xd0, 0x9d, 0x92, 0xf9, 0x88, 0x35, 0x80, 0x7e, 0x0e, 0x23, 0x51, 0x8b, 0xa8, 0xd2, 0x22,
+	0xcc, 0x64, 0x2c, 0x82, 0xde, 0x94, 0xcc, 0xc7, 0x0b, 0x6a, 0x7f, 0xfe, 0x79, 0xb3, 0xf5, 0x83,
+	0x8c, 0x05, 0xf3, 0xc5, 0x16, 0xd0, 0x0f, 0x60, 0x3f, 0xe2, 0x69, 0x7a, 0xcd, 0xa3, 0x9b, 0xb0,
+	0x52, 0x69, 0x19, 0xf4, 0xa7, 0xde, 0x7c, 0xc8, 0x46, 0x8e, 0xfc, 0x49, 0xa5, 0xa5, 0x89, 0xad,
+	0x84, 0x56, 0xeb, 0xb0, 0x90, 0x69, 0x12, 0xad, 0x83, 0x01, 0x96, 0xe6, 0x62, 0x33, 0xb3, 0x75,
+	0x81, 0x3b, 0xcc, 0x57, 0x5b, 0x40, 0xdf, 0x81, 0x41, 0x2c, 0x78, 0x9c, 0x26, 0xb9, 0x08, 0x86,
+	0x58, 0xc5, 0x06, 0x9b, 0x12, 0x93, 0x3c, 0xd1, 0x09, 0xd7, 0x52, 0x05, 0x80, 0xe5, 0x6f, 0x89,
+	0xd9, 0xcb, 0x0e, 0xf8, 0xad, 0xb0, 0xf4, 0x04, 0x46, 0x19, 0xaf, 0x43, 0xae, 0xb5, 0xc8, 0x0a,
+	0x5d, 0xa2, 0xef, 0x5d, 0xe6, 0x67, 0xbc, 0x7e, 0x6a, 0x29, 0xfa, 0x11, 0x1c, 0x34, 0xe7, 0xd3,
+	0xd0, 0xe4, 0x2d, 0x97, 0x4b, 0xec, 0x80, 0xc7, 0xc6, 0x96, 0x7e, 0xd6, 0xb0, 0xf4, 0x7d, 0x30,
+	0xe7, 0x36, 0x22, 0x0f, 0x45, 0x90, 0xf1, 0xda, 0x09, 0x8e, 0x01, 0xb2, 0x2a, 0xd5, 0x49, 0x91,
+	0x26, 0x42, 0xa1, 0xfd, 0x84, 0xb5, 0x18, 0xfa, 0x00, 0x7a, 0xbf, 0x24, 0x5a, 0x0b, 0x85, 0x0d,
+	0x20, 0xcc, 0x22, 0x93, 0x81, 0xeb, 0x80, 0x69, 0x96, 0xac, 0x34, 0x36, 0xc1, 0x63, 0x63, 0x4b,
+	0x5f, 0x35, 0x2c, 0x7d, 0x0c, 0xe3, 0x48, 0x66, 0x59, 0xa2, 0x37, 0xba, 0x3e, 0xea, 0xf6, 0x1b,
+	0xd6, 0xc9, 0x3e, 0x86, 0x89, 0x92, 0xb6, 0x35, 0x4e, 0x38, 0x40, 0xe1, 0x81, 0xe3, 0xad, 0x74,
+	0xb6, 0x80, 0x81, 0x1b, 0x30, 0xfa, 0x21, 0xf4, 0xec, 0x04, 0x92, 0xa9, 0x37, 0xf7, 0x17, 0xe3,
+	0xdd, 0x09, 0x64, 0x76, 0x77, 0xf6, 0x27, 0x81, 0xbe, 0xe5, 0x70, 0xa6, 0x57, 0x3c, 0xc9, 0xdb,
+	0x33, 0x6d, 0xf0, 0x79, 0x6c, 0xa6, 0x2d, 0xc9, 0x63, 0x51, 0xa3, 0x9b, 0x5d, 0xd6, 0x00, 0xfa,
+	0x08, 0x86, 0x85, 0x92, 0x72, 0x19, 0xde, 0x88, 0xb5, 0x9d, 0xde, 0x01, 0x12, 0xdf, 0x8b, 0x75,
+	0xdb, 0x88, 0x82, 0xaf, 0x53, 0xc9, 0x63, 0x74, 0x71, 0xb4, 0x31, 0xe2, 0xa2, 0x61, 0x5b, 0x46,
+	0x38, 0x5d, 0x33, 0xd2, 0xd6, 0x08, 0x27, 0x6b, 0x1b, 0xe1, 0x84, 0x3d, 0x14, 0x6e, 0x8c, 0xb0,
+	0xd2, 0xd9, 0xa7, 0x30, 0xc1, 0x9a, 0x2e, 0x05, 0x57, 0xd1, 0xea, 0x4d, 0x17, 0x76, 0xf6, 0x0f,
+	0x81, 0xc9, 0x95, 0xe2, 0x79, 0xc9, 0x23, 0x9d, 0xc8, 0xfc, 0x8d, 0x17, 0xfc, 0x14, 0xfa, 0xb2,
+	0x08, 0x97, 0x55, 0x1e, 0xa1, 0x1d, 0xe3, 0xc5, 0xa1, 0x35, 0xf7, 0xc7, 0xe2, 0xdb, 0x2a, 0x8f,
+	0xae, 0xd6, 0x85, 0x60, 0x3d, 0x89, 0xeb, 0x1d, 0x4f, 0xbd, 0x5d, 0x4f, 0x03, 0xe8, 0xef, 0x1a,
+	0xe3, 0xe0, 0xae, 0xaf, 0xdd, 0xd7, 0x7c, 0x1d, 0xe8, 0x3a, 0x44, 0x88, 0xf5, 0xfb, 0x8b, 0x91,
+	0xfd, 0xf9, 0x0b, 0xc3, 0xb1, 0xbe, 0xae, 0x71, 0x41, 0x29, 0xec, 0xad, 0x64, 0xe1, 0xee, 0x32,
+	0xae, 0xe9, 0x04, 0x3c, 0xad, 0x53, 0x1c, 0xa0, 0x2e, 0x33, 0xcb, 0xd9, 0x6f, 0x04, 0xba, 0x8d,
+	0xfe, 0x7f, 0xda, 0x7f, 0x1f, 0x7a, 0xba, 0xc6, 0x6c, 0x3a, 0xb8, 0xd1, 0xd5, 0xb5, 0x49, 0xe5,
+	0x04, 0x46, 0xd7, 0xa9, 0x8c, 0x6e, 0xc2, 0x95, 0x48, 0x5e, 0xac, 0xb4, 0xbd, 0x45, 0x3e, 0x72,
+	0xdf, 0x21, 0xb5, 0x1d, 0x9c, 0xbd, 0xf6, 0xe0, 0x9c, 0xc1, 0x20, 0x92, 0xb9, 0x56, 0x3c, 0xd2,
+	0x58, 0x9f, 0xbf, 0x38, 0x72, 0xf3, 0x69, 0xe9, 0xf3, 0x7c, 0x29, 0xd9, 0x46, 0xb4, 0x7d, 0xed,
+	0x7a, 0xad, 0xd7, 0x6e, 0xf6, 0x92, 0xc0, 0xa8, 0x7d, 0xc0, 0x94, 0x9c, 0xf3, 0x4c, 0xd8, 0xf4,
+	0x71, 0xdd, 0x7e, 0x60, 0x3b, 0xbb, 0x0f, 0xec, 0x03, 0xe8, 0x65, 0x42, 0xaf, 0xa4, 0xeb, 0x8c,
+	0x45, 0xf4, 0x0b, 0x80, 0x82, 0x2b, 0x9e, 0x09, 0x2d, 0x54, 0x19, 0xec, 0xe1, 0xfd, 0x09, 0x5e,
+	0xc9, 0xef, 0xc2, 0x09, 0x58, 0x4b, 0x4b, 0xdf, 0x03, 0xc0, 0xcc, 0xc2, 0x98, 0x6b, 0xf7, 0x32,
+	0x0f, 0x91, 0xf9, 0x86, 0x6b, 0x3e, 0xfb, 0x12, 0x0e, 0x5f, 0x3b, 0x6f, 0x5a, 0x62, 0x8c, 0x6d,
+	0x52, 0x36, 0x4b, 0x53, 0xec, 0x2d, 0x4f, 0x2b, 0xe1, 0xcc, 0x46, 0x70, 0xfa, 0x2b, 0x01, 0xd8,
+	0x0e, 0x18, 0x3d, 0x84, 0x7d, 0xfb, 0x9c, 0x37, 0xe4, 0xe4, 0x1e, 0x9d, 0x18, 0x37, 0xcc, 0x95,
+	0xb1, 0x0c, 0xa1, 0x8f, 0x60, 0xcc, 0xec, 0xdd, 0xb0, 0xdc, 0xbf, 0xee, 0x8f, 0x18, 0xb9, 0x79,
+	0x37, 0x4b, 0x27, 0xef, 0x50, 0x0a, 0xe3, 0xa7, 0x79, 0x2e, 0xab, 0x3c, 0x72, 0x41, 0x3d, 0x7a,
+	0x04, 0x07, 0x97, 0xfc, 0x56, 0xe0, 0x88, 0x58, 0x72, 0xef, 0xf4, 0x2b, 0xf0, 0x5b, 0xdf, 0x12,
+	0x7a, 0x1f, 0x0e, 0x2f, 0x85, 0x4a, 0x78, 0xda, 0x22, 0x27, 0xf7, 0xe8, 0x43, 0x38, 0x32, 0x65,
+	0xa6, 0xa9, 0xd8, 0xd9, 0x20, 0xcf, 0x1e, 0xff, 0x7e, 0x77, 0x4c, 0xfe, 0xb8, 0x3b, 0x26, 0x7f,
+	0xdd, 0x1d, 0x93, 0x9f, 0x1f, 0xbe, 0xf2, 0x0d, 0x7e, 0x61, 0xbf, 0xc2, 0xd7, 0x3d, 0x84, 0x9f,
+	0xfd, 0x37, 0x00, 0xe4, 0x52, 0xcd, 0xa1, 0xa5, 0x07, 0x00, 0x00,
 }
 
 func (m *CrossEvent) Marshal() (dAtA []byte, err error) {
@@ -715,6 +931,44 @@ func (m *CrossEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Initiator) > 0 {
+		i -= len(m.Initiator)
+		copy(dAtA[i:], m.Initiator)
+		i = encodeVarintEvent(dAtA, i, uint64(len(m.Initiator)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if m.Deadline != 0 {
+		i = encodeVarintEvent(dAtA, i, uint64(m.Deadline))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.RetryPolicy != nil {
+		{
+			size, err := m.RetryPolicy.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvent(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
+	if len(m.CallbackUrls) > 0 {
+		for iNdEx := len(m.CallbackUrls) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.CallbackUrls[iNdEx])
+			copy(dAtA[i:], m.CallbackUrls[iNdEx])
+			i = encodeVarintEvent(dAtA, i, uint64(len(m.CallbackUrls[iNdEx])))
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if m.ExecuteMode != 0 {
+		i = encodeVarintEvent(dAtA, i, uint64(m.ExecuteMode))
+		i--
+		dAtA[i] = 0x30
+	}
 	if len(m.Extra) > 0 {
 		i -= len(m.Extra)
 		copy(dAtA[i:], m.Extra)
@@ -756,6 +1010,75 @@ func (m *CrossEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *RetryPolicy) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RetryPolicy) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RetryPolicy) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.RollbackTimeout != 0 {
+		i = encodeVarintEvent(dAtA, i, uint64(m.RollbackTimeout))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.CommitTimeout != 0 {
+		i = encodeVarintEvent(dAtA, i, uint64(m.CommitTimeout))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.ExecuteTimeout != 0 {
+		i = encodeVarintEvent(dAtA, i, uint64(m.ExecuteTimeout))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Jitter != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Jitter))))
+		i--
+		dAtA[i] = 0x29
+	}
+	if m.Multiplier != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Multiplier))))
+		i--
+		dAtA[i] = 0x21
+	}
+	if m.MaxBackoff != 0 {
+		i = encodeVarintEvent(dAtA, i, uint64(m.MaxBackoff))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.InitialBackoff != 0 {
+		i = encodeVarintEvent(dAtA, i, uint64(m.InitialBackoff))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.MaxAttempts != 0 {
+		i = encodeVarintEvent(dAtA, i, uint64(m.MaxAttempts))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *CrossTxs) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -922,6 +1245,20 @@ func (m *TransactionEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.Ttl != 0 {
+		i = encodeVarintEvent(dAtA, i, uint64(m.Ttl))
+		i--
+		dAtA[i] = 0x40
+	}
+	if len(m.Hops) > 0 {
+		for iNdEx := len(m.Hops) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Hops[iNdEx])
+			copy(dAtA[i:], m.Hops[iNdEx])
+			i = encodeVarintEvent(dAtA, i, uint64(len(m.Hops[iNdEx])))
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
 	if m.TxProof != nil {
 		{
 			size, err := m.TxProof.MarshalToSizedBuffer(dAtA[:i])
@@ -1186,6 +1523,62 @@ func (m *CrossEvent) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovEvent(uint64(l))
 	}
+	if m.ExecuteMode != 0 {
+		n += 1 + sovEvent(uint64(m.ExecuteMode))
+	}
+	if len(m.CallbackUrls) > 0 {
+		for _, s := range m.CallbackUrls {
+			l = len(s)
+			n += 1 + l + sovEvent(uint64(l))
+		}
+	}
+	if m.RetryPolicy != nil {
+		l = m.RetryPolicy.Size()
+		n += 1 + l + sovEvent(uint64(l))
+	}
+	if m.Deadline != 0 {
+		n += 1 + sovEvent(uint64(m.Deadline))
+	}
+	l = len(m.Initiator)
+	if l > 0 {
+		n += 1 + l + sovEvent(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RetryPolicy) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MaxAttempts != 0 {
+		n += 1 + sovEvent(uint64(m.MaxAttempts))
+	}
+	if m.InitialBackoff != 0 {
+		n += 1 + sovEvent(uint64(m.InitialBackoff))
+	}
+	if m.MaxBackoff != 0 {
+		n += 1 + sovEvent(uint64(m.MaxBackoff))
+	}
+	if m.Multiplier != 0 {
+		n += 9
+	}
+	if m.Jitter != 0 {
+		n += 9
+	}
+	if m.ExecuteTimeout != 0 {
+		n += 1 + sovEvent(uint64(m.ExecuteTimeout))
+	}
+	if m.CommitTimeout != 0 {
+		n += 1 + sovEvent(uint64(m.CommitTimeout))
+	}
+	if m.RollbackTimeout != 0 {
+		n += 1 + sovEvent(uint64(m.RollbackTimeout))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1290,6 +1683,15 @@ func (m *TransactionEvent) Size() (n int) {
 		l = m.TxProof.Size()
 		n += 1 + l + sovEvent(uint64(l))
 	}
+	if len(m.Hops) > 0 {
+		for _, s := range m.Hops {
+			l = len(s)
+			n += 1 + l + sovEvent(uint64(l))
+		}
+	}
+	if m.Ttl != 0 {
+		n += 1 + sovEvent(uint64(m.Ttl))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1572,27 +1974,352 @@ func (m *CrossEvent) Unmarshal(dAtA []byte) error {
 				m.Extra = []byte{}
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipEvent(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecuteMode", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthEvent
+			m.ExecuteMode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExecuteMode |= ExecuteMode(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CallbackUrls", wireType)
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CallbackUrls = append(m.CallbackUrls, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RetryPolicy", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RetryPolicy == nil {
+				m.RetryPolicy = &RetryPolicy{}
+			}
+			if err := m.RetryPolicy.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Deadline", wireType)
+			}
+			m.Deadline = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Deadline |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Initiator", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Initiator = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEvent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RetryPolicy) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RetryPolicy: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RetryPolicy: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxAttempts", wireType)
+			}
+			m.MaxAttempts = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxAttempts |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InitialBackoff", wireType)
+			}
+			m.InitialBackoff = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.InitialBackoff |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBackoff", wireType)
+			}
+			m.MaxBackoff = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBackoff |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Multiplier", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Multiplier = float64(math.Float64frombits(v))
+		case 5:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Jitter", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Jitter = float64(math.Float64frombits(v))
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecuteTimeout", wireType)
+			}
+			m.ExecuteTimeout = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExecuteTimeout |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CommitTimeout", wireType)
+			}
+			m.CommitTimeout = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CommitTimeout |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RollbackTimeout", wireType)
+			}
+			m.RollbackTimeout = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RollbackTimeout |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEvent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
 func (m *CrossTxs) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
@@ -2212,6 +2939,57 @@ func (m *TransactionEvent) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hops", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hops = append(m.Hops, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ttl", wireType)
+			}
+			m.Ttl = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Ttl |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvent(dAtA[iNdEx:])
@@ -207,6 +207,7 @@ type CrossResponse struct {
 	Code                 int32              `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
 	Msg                  string             `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
 	TxResponses          []*CrossTxResponse `protobuf:"bytes,4,rep,name=tx_responses,json=txResponses,proto3" json:"tx_responses,omitempty"`
+	ProveAudits          []*ProveAudit      `protobuf:"bytes,5,rep,name=prove_audits,json=proveAudits,proto3" json:"prove_audits,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
 	XXX_unrecognized     []byte             `json:"-"`
 	XXX_sizecache        int32              `json:"-"`
@@ -273,6 +274,13 @@ func (m *CrossResponse) GetTxResponses() []*CrossTxResponse {
 	return nil
 }
 
+func (m *CrossResponse) GetProveAudits() []*ProveAudit {
+	if m != nil {
+		return m.ProveAudits
+	}
+	return nil
+}
+
 type CrossTxResponse struct {
 	ChainId              string   `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
 	TxKey                string   `protobuf:"bytes,2,opt,name=tx_key,json=txKey,proto3" json:"tx_key,omitempty"`
@@ -352,11 +360,107 @@ func (m *CrossTxResponse) GetExtra() []byte {
 	return nil
 }
 
+type ProveAudit struct {
+	ChainId              string   `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	TxKey                string   `protobuf:"bytes,2,opt,name=tx_key,json=txKey,proto3" json:"tx_key,omitempty"`
+	ProverType           string   `protobuf:"bytes,3,opt,name=prover_type,json=proverType,proto3" json:"prover_type,omitempty"`
+	Policy               string   `protobuf:"bytes,4,opt,name=policy,proto3" json:"policy,omitempty"`
+	Result               bool     `protobuf:"varint,5,opt,name=result,proto3" json:"result,omitempty"`
+	Reason               string   `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	Timestamp            int64    `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProveAudit) Reset()         { *m = ProveAudit{} }
+func (m *ProveAudit) String() string { return proto.CompactTextString(m) }
+func (*ProveAudit) ProtoMessage()    {}
+func (*ProveAudit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c3b79c0de2f4823, []int{4}
+}
+func (m *ProveAudit) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ProveAudit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ProveAudit.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ProveAudit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProveAudit.Merge(m, src)
+}
+func (m *ProveAudit) XXX_Size() int {
+	return m.Size()
+}
+func (m *ProveAudit) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProveAudit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProveAudit proto.InternalMessageInfo
+
+func (m *ProveAudit) GetChainId() string {
+	if m != nil {
+		return m.ChainId
+	}
+	return ""
+}
+
+func (m *ProveAudit) GetTxKey() string {
+	if m != nil {
+		return m.TxKey
+	}
+	return ""
+}
+
+func (m *ProveAudit) GetProverType() string {
+	if m != nil {
+		return m.ProverType
+	}
+	return ""
+}
+
+func (m *ProveAudit) GetPolicy() string {
+	if m != nil {
+		return m.Policy
+	}
+	return ""
+}
+
+func (m *ProveAudit) GetResult() bool {
+	if m != nil {
+		return m.Result
+	}
+	return false
+}
+
+func (m *ProveAudit) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *ProveAudit) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*ProofResponse)(nil), "event.ProofResponse")
 	proto.RegisterType((*TxResponse)(nil), "event.TxResponse")
 	proto.RegisterType((*CrossResponse)(nil), "event.CrossResponse")
 	proto.RegisterType((*CrossTxResponse)(nil), "event.CrossTxResponse")
+	proto.RegisterType((*ProveAudit)(nil), "event.ProveAudit")
 }
 
 func init() {
@@ -364,33 +468,39 @@ func init() {
 }
 
 var fileDescriptor_0c3b79c0de2f4823 = []byte{
-	// 410 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x92, 0xcb, 0xee, 0xd2, 0x40,
-	0x14, 0xc6, 0x33, 0x94, 0x16, 0x3c, 0x05, 0x2f, 0xe3, 0xad, 0xba, 0x20, 0xa5, 0x89, 0x49, 0xc3,
-	0x82, 0x26, 0x75, 0xe5, 0x56, 0x12, 0x23, 0x71, 0xa1, 0x99, 0xb0, 0x72, 0xd3, 0x40, 0x3b, 0x40,
-	0x03, 0xcc, 0x34, 0xed, 0x60, 0xda, 0x67, 0x70, 0xe7, 0x03, 0xb9, 0x70, 0xe5, 0xd2, 0x47, 0x30,
-	0x3c, 0x89, 0xe9, 0xe9, 0x85, 0x06, 0x5d, 0xb8, 0xfa, 0xef, 0xce, 0x77, 0xe6, 0xf4, 0xcc, 0xf7,
-	0xfd, 0x3a, 0x30, 0x3b, 0xc9, 0xe8, 0x7c, 0xe4, 0x5e, 0xb2, 0xf1, 0x92, 0x54, 0x2a, 0xe9, 0xf1,
-	0x2f, 0x5c, 0x28, 0x2f, 0xe5, 0x59, 0x22, 0x45, 0xc6, 0x03, 0x94, 0x73, 0x3c, 0xa2, 0x3a, 0x8a,
-	0x97, 0xd3, 0x7f, 0x7f, 0xd2, 0x99, 0x74, 0x7e, 0x10, 0x18, 0x7f, 0x4a, 0xa5, 0xdc, 0xb2, 0x7a,
-	0x0f, 0x7d, 0x01, 0xc3, 0x30, 0x95, 0x59, 0x16, 0xc4, 0x91, 0x45, 0x6c, 0xe2, 0xde, 0x63, 0x03,
-	0xd4, 0xcb, 0x88, 0xce, 0x60, 0x20, 0x93, 0x60, 0x7b, 0x16, 0xa1, 0xd5, 0xb3, 0x89, 0x7b, 0xdf,
-	0x7f, 0x34, 0xaf, 0x76, 0x7d, 0x4c, 0xde, 0x9d, 0x45, 0xb8, 0x2a, 0x12, 0xce, 0x0c, 0x89, 0x35,
-	0xa5, 0xd0, 0x0f, 0x65, 0xc4, 0x2d, 0xcd, 0x26, 0xae, 0xce, 0xb0, 0xa6, 0x0f, 0x41, 0x3b, 0x65,
-	0x3b, 0xab, 0x8f, 0x5b, 0xcb, 0xb2, 0xec, 0x1c, 0x78, 0x61, 0xe9, 0x55, 0xe7, 0xc0, 0x0b, 0xea,
-	0x83, 0xa9, 0xf2, 0xa0, 0x49, 0x65, 0x19, 0x36, 0x71, 0xcd, 0xf6, 0x9e, 0x55, 0xde, 0xd8, 0x64,
-	0xa0, 0xda, 0xda, 0xf9, 0x4e, 0x00, 0xae, 0x47, 0x98, 0x60, 0xbf, 0x8e, 0x45, 0x37, 0x41, 0xa9,
-	0x97, 0x11, 0x7d, 0x0a, 0x86, 0xca, 0x83, 0xf2, 0xca, 0x1e, 0x1e, 0xe8, 0x2a, 0xff, 0xc0, 0x0b,
-	0x3a, 0x85, 0xd1, 0xe6, 0x28, 0xc3, 0x43, 0xb0, 0xe7, 0xf1, 0x6e, 0xaf, 0xd0, 0xb4, 0xc6, 0x4c,
-	0xec, 0xbd, 0xc7, 0x16, 0x7d, 0x02, 0x7a, 0x2c, 0x22, 0x9e, 0xa3, 0x7b, 0x9d, 0x55, 0x82, 0x7a,
-	0x30, 0x0c, 0xa5, 0x50, 0xe9, 0x3a, 0x54, 0x18, 0xc2, 0xf4, 0x1f, 0xd7, 0x56, 0x17, 0x75, 0x7b,
-	0x29, 0xb6, 0x92, 0xb5, 0x43, 0xe5, 0x1a, 0x9e, 0xab, 0x74, 0x8d, 0xc1, 0x46, 0xac, 0x12, 0xce,
-	0x57, 0x02, 0xe3, 0x45, 0x09, 0xf9, 0x7f, 0xfe, 0x42, 0x43, 0xb6, 0xf7, 0x37, 0x59, 0xed, 0x4a,
-	0xf6, 0x0d, 0x8c, 0x3a, 0x1c, 0x33, 0xab, 0x6f, 0x6b, 0xae, 0xe9, 0x3f, 0x6b, 0xdc, 0x95, 0xbb,
-	0x3a, 0x34, 0xcd, 0x2b, 0xcd, 0xcc, 0xf9, 0x46, 0xe0, 0xc1, 0xcd, 0xc0, 0x5d, 0x32, 0x6d, 0x11,
-	0xe9, 0x1d, 0x44, 0x6f, 0x5f, 0xfd, 0xbc, 0x4c, 0xc8, 0xaf, 0xcb, 0x84, 0xfc, 0xbe, 0x4c, 0xc8,
-	0xe7, 0xe7, 0x37, 0x2f, 0x7b, 0x57, 0xbf, 0xed, 0x8d, 0x81, 0xf2, 0xf5, 0x9f, 0x00, 0x00, 0x00,
-	0xff, 0xff, 0x2f, 0x8a, 0x66, 0xb5, 0x2e, 0x03, 0x00, 0x00,
+	// 505 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x53, 0x4d, 0xab, 0xd3, 0x40,
+	0x14, 0x65, 0x9a, 0xa6, 0x1f, 0x37, 0x7d, 0x7e, 0x8c, 0xfa, 0x8c, 0x22, 0x35, 0xaf, 0x20, 0x84,
+	0xb7, 0x78, 0x81, 0xea, 0xc6, 0xa5, 0x3e, 0x10, 0x8b, 0x0b, 0x1f, 0x43, 0x57, 0x6e, 0x42, 0x9a,
+	0x4c, 0xdb, 0xd0, 0x36, 0x33, 0x64, 0x26, 0x8f, 0xe4, 0x6f, 0xf8, 0x83, 0x04, 0x05, 0xc1, 0xa5,
+	0x3f, 0x41, 0xfa, 0x4b, 0x64, 0x26, 0xd3, 0x34, 0x3e, 0x5d, 0x88, 0x0b, 0x77, 0xf7, 0x9c, 0x9b,
+	0xdc, 0x39, 0xe7, 0xcc, 0x1d, 0x38, 0xdf, 0xb1, 0xa4, 0xd8, 0xd2, 0x80, 0x2f, 0x02, 0x9e, 0x33,
+	0xc9, 0x02, 0x7a, 0x4d, 0x33, 0x19, 0xe4, 0x54, 0x70, 0x96, 0x09, 0x1a, 0x6a, 0x78, 0xa1, 0x5b,
+	0xd8, 0xd6, 0xe0, 0xf1, 0xd9, 0x9f, 0x7f, 0x69, 0x7d, 0x39, 0xf9, 0x82, 0xe0, 0xe4, 0x2a, 0x67,
+	0x6c, 0x49, 0xcc, 0x1c, 0xfc, 0x08, 0x06, 0x71, 0xce, 0x84, 0x08, 0xd3, 0xc4, 0x45, 0x1e, 0xf2,
+	0x87, 0xa4, 0xaf, 0xf1, 0x2c, 0xc1, 0xe7, 0xd0, 0x67, 0x3c, 0x5c, 0x16, 0x59, 0xec, 0x76, 0x3c,
+	0xe4, 0xdf, 0x9a, 0xde, 0xbd, 0xa8, 0x67, 0xbd, 0xe7, 0x6f, 0x8a, 0x2c, 0x9e, 0x57, 0x9c, 0x92,
+	0x1e, 0xd3, 0x35, 0xc6, 0xd0, 0x8d, 0x59, 0x42, 0x5d, 0xcb, 0x43, 0xbe, 0x4d, 0x74, 0x8d, 0xef,
+	0x80, 0xb5, 0x13, 0x2b, 0xb7, 0xab, 0xa7, 0xaa, 0x52, 0x31, 0x1b, 0x5a, 0xb9, 0x76, 0xcd, 0x6c,
+	0x68, 0x85, 0xa7, 0xe0, 0xc8, 0x32, 0x3c, 0xb8, 0x72, 0x7b, 0x1e, 0xf2, 0x9d, 0xe6, 0x9c, 0x79,
+	0x79, 0x90, 0x49, 0x40, 0x36, 0xf5, 0xe4, 0x13, 0x02, 0x98, 0x97, 0xbf, 0x38, 0x58, 0x47, 0x69,
+	0xd6, 0x76, 0xa0, 0xf0, 0x2c, 0xc1, 0x0f, 0xa0, 0x27, 0xcb, 0x50, 0x1d, 0xd9, 0xd1, 0x0d, 0x5b,
+	0x96, 0xef, 0x68, 0x85, 0xcf, 0x60, 0xb4, 0xd8, 0xb2, 0x78, 0x13, 0xae, 0x69, 0xba, 0x5a, 0x4b,
+	0x2d, 0xda, 0x22, 0x8e, 0xe6, 0xde, 0x6a, 0x0a, 0xdf, 0x07, 0x3b, 0xcd, 0x12, 0x5a, 0x6a, 0xf5,
+	0x36, 0xa9, 0x01, 0x0e, 0x60, 0x10, 0xb3, 0x4c, 0xe6, 0x51, 0x2c, 0xb5, 0x09, 0x67, 0x7a, 0xcf,
+	0x48, 0xbd, 0x34, 0xf4, 0x2c, 0x5b, 0x32, 0xd2, 0x7c, 0xa4, 0xc6, 0xd0, 0x52, 0xe6, 0x91, 0x36,
+	0x36, 0x22, 0x35, 0x98, 0x7c, 0x46, 0x70, 0x72, 0xa9, 0x42, 0xfe, 0x9b, 0x5b, 0x38, 0x24, 0xdb,
+	0xf9, 0x3d, 0x59, 0xeb, 0x98, 0xec, 0x4b, 0x18, 0xb5, 0x72, 0x14, 0x6e, 0xd7, 0xb3, 0x7c, 0x67,
+	0x7a, 0x7a, 0x50, 0xa7, 0x66, 0xb5, 0xd2, 0x74, 0x8e, 0x69, 0x0a, 0xfc, 0x02, 0x46, 0x3c, 0x67,
+	0xd7, 0x34, 0x8c, 0x8a, 0x24, 0x95, 0xc2, 0xb5, 0x3d, 0xab, 0x75, 0x07, 0x57, 0xaa, 0xf5, 0x4a,
+	0x75, 0x88, 0xc3, 0x9b, 0x5a, 0x4c, 0x3e, 0x22, 0xb8, 0x7d, 0x63, 0xec, 0xff, 0xbc, 0x89, 0x26,
+	0x58, 0xbb, 0x1d, 0xec, 0x57, 0x04, 0x70, 0x14, 0xfc, 0x0f, 0x7a, 0x9e, 0x42, 0x6d, 0x32, 0x0f,
+	0x65, 0xc5, 0xa9, 0x09, 0x18, 0x6a, 0x4a, 0xed, 0x3b, 0x3e, 0x85, 0x1e, 0x67, 0xdb, 0x34, 0xae,
+	0xcc, 0x5a, 0x1b, 0xa4, 0xf8, 0x9c, 0x8a, 0x62, 0x5b, 0xef, 0xc5, 0x80, 0x18, 0x54, 0xf3, 0x91,
+	0x60, 0x99, 0xde, 0x80, 0x21, 0x31, 0x08, 0x3f, 0x81, 0xa1, 0x4c, 0x77, 0x54, 0xc8, 0x68, 0xc7,
+	0xdd, 0xbe, 0x76, 0x7d, 0x24, 0x5e, 0x3f, 0xfb, 0xb6, 0x1f, 0xa3, 0xef, 0xfb, 0x31, 0xfa, 0xb1,
+	0x1f, 0xa3, 0x0f, 0x0f, 0x6f, 0xbc, 0xeb, 0x95, 0x79, 0xd9, 0x8b, 0x9e, 0x86, 0xcf, 0x7f, 0x0e,
+	0x00, 0xce, 0x24, 0x34, 0x1b, 0x2c, 0x04, 0x00, 0x00,
 }
 
 func (m *ProofResponse) Marshal() (dAtA []byte, err error) {
@@ -557,6 +667,20 @@ func (m *CrossResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.ProveAudits) > 0 {
+		for iNdEx := len(m.ProveAudits) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ProveAudits[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintResponseEvent(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
 	if len(m.TxResponses) > 0 {
 		for iNdEx := len(m.TxResponses) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -651,6 +775,83 @@ func (m *CrossTxResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *ProveAudit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProveAudit) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ProveAudit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Timestamp != 0 {
+		i = encodeVarintResponseEvent(dAtA, i, uint64(m.Timestamp))
+		i--
+		dAtA[i] = 0x38
+	}
+	if len(m.Reason) > 0 {
+		i -= len(m.Reason)
+		copy(dAtA[i:], m.Reason)
+		i = encodeVarintResponseEvent(dAtA, i, uint64(len(m.Reason)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Result {
+		i--
+		if m.Result {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Policy) > 0 {
+		i -= len(m.Policy)
+		copy(dAtA[i:], m.Policy)
+		i = encodeVarintResponseEvent(dAtA, i, uint64(len(m.Policy)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.ProverType) > 0 {
+		i -= len(m.ProverType)
+		copy(dAtA[i:], m.ProverType)
+		i = encodeVarintResponseEvent(dAtA, i, uint64(len(m.ProverType)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.TxKey) > 0 {
+		i -= len(m.TxKey)
+		copy(dAtA[i:], m.TxKey)
+		i = encodeVarintResponseEvent(dAtA, i, uint64(len(m.TxKey)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ChainId) > 0 {
+		i -= len(m.ChainId)
+		copy(dAtA[i:], m.ChainId)
+		i = encodeVarintResponseEvent(dAtA, i, uint64(len(m.ChainId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintResponseEvent(dAtA []byte, offset int, v uint64) int {
 	offset -= sovResponseEvent(v)
 	base := offset
@@ -753,6 +954,12 @@ func (m *CrossResponse) Size() (n int) {
 			n += 1 + l + sovResponseEvent(uint64(l))
 		}
 	}
+	if len(m.ProveAudits) > 0 {
+		for _, e := range m.ProveAudits {
+			l = e.Size()
+			n += 1 + l + sovResponseEvent(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -789,6 +996,44 @@ func (m *CrossTxResponse) Size() (n int) {
 	return n
 }
 
+func (m *ProveAudit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ChainId)
+	if l > 0 {
+		n += 1 + l + sovResponseEvent(uint64(l))
+	}
+	l = len(m.TxKey)
+	if l > 0 {
+		n += 1 + l + sovResponseEvent(uint64(l))
+	}
+	l = len(m.ProverType)
+	if l > 0 {
+		n += 1 + l + sovResponseEvent(uint64(l))
+	}
+	l = len(m.Policy)
+	if l > 0 {
+		n += 1 + l + sovResponseEvent(uint64(l))
+	}
+	if m.Result {
+		n += 2
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovResponseEvent(uint64(l))
+	}
+	if m.Timestamp != 0 {
+		n += 1 + sovResponseEvent(uint64(m.Timestamp))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func sovResponseEvent(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -1385,6 +1630,40 @@ func (m *CrossResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProveAudits", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowResponseEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthResponseEvent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthResponseEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProveAudits = append(m.ProveAudits, &ProveAudit{})
+			if err := m.ProveAudits[len(m.ProveAudits)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipResponseEvent(dAtA[iNdEx:])
@@ -1594,6 +1873,256 @@ func (m *CrossTxResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ProveAudit) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowResponseEvent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProveAudit: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProveAudit: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowResponseEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthResponseEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthResponseEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowResponseEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthResponseEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthResponseEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TxKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProverType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowResponseEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthResponseEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthResponseEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProverType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Policy", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowResponseEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthResponseEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthResponseEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Policy = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowResponseEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Result = bool(v != 0)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowResponseEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthResponseEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthResponseEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowResponseEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipResponseEvent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthResponseEvent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipResponseEvent(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
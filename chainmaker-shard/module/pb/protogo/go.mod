@@ -2,4 +2,7 @@ module chainmaker.org/chainmaker-cross/pb/protogo
 
 go 1.15
 
-require github.com/gogo/protobuf v1.3.2
+require (
+	github.com/gogo/protobuf v1.3.2
+	google.golang.org/grpc v1.36.0
+)
@@ -0,0 +1,71 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package impl
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+)
+
+// AttestationHash return the hash of proof and result which is signed by the attestor,
+// the fields are length-prefixed so that the on-chain contract can compute it in the same way:
+// sha256(len(chainID) | chainID | len(txKey) | txKey | blockHeight | index | result),
+// the lengths are uint32, blockHeight is int64, index is int32 and result is one byte, all in big endian
+func AttestationHash(proof *eventproto.Proof, result bool) []byte {
+	var buf bytes.Buffer
+	for _, field := range [][]byte{[]byte(proof.GetChainID()), []byte(proof.GetTxKey())} {
+		_ = binary.Write(&buf, binary.BigEndian, uint32(len(field)))
+		buf.Write(field)
+	}
+	_ = binary.Write(&buf, binary.BigEndian, proof.GetBlockHeight())
+	_ = binary.Write(&buf, binary.BigEndian, proof.GetIndex())
+	if result {
+		buf.WriteByte(1)
+	} else {
+		buf.WriteByte(0)
+	}
+	hash := sha256.Sum256(buf.Bytes())
+	return hash[:]
+}
+
+// SignAttestation sign the proof and result by the private key of attestor
+func SignAttestation(privateKey ed25519.PrivateKey, proof *eventproto.Proof, result bool) (*eventproto.Attestation, error) {
+	publicKey, ok := privateKey.Public().(ed25519.PublicKey)
+	if !ok || len(privateKey) != ed25519.PrivateKeySize {
+		return nil, errors.New("invalid ed25519 private key")
+	}
+	return &eventproto.Attestation{
+		Signer:    hex.EncodeToString(publicKey),
+		Result:    result,
+		Signature: hex.EncodeToString(ed25519.Sign(privateKey, AttestationHash(proof, result))),
+	}, nil
+}
+
+// VerifyAttestation check that the attestation is signed by the public key for the proof
+func VerifyAttestation(publicKey ed25519.PublicKey, proof *eventproto.Proof, attestation *eventproto.Attestation) error {
+	if attestation == nil {
+		return errors.New("attestation is nil")
+	}
+	if !strings.EqualFold(attestation.Signer, hex.EncodeToString(publicKey)) {
+		return fmt.Errorf("attestation is signed by [%s] rather than [%x]", attestation.Signer, []byte(publicKey))
+	}
+	signature, err := hex.DecodeString(attestation.Signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature of attestation, %v", err)
+	}
+	if !ed25519.Verify(publicKey, AttestationHash(proof, attestation.Result), signature) {
+		return fmt.Errorf("signature of attestation by [%s] is invalid", attestation.Signer)
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package impl
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"chainmaker.org/chainmaker-cross/event"
+	"chainmaker.org/chainmaker-cross/logger"
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+	"chainmaker.org/chainmaker-cross/utils"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultAttestationTimeout  = 10  // 默认等待背书的时长，单位：秒
+	DefaultAttestationCacheTTL = 600 // 默认背书缓存时长，单位：秒
+)
+
+// AttestationConfig the config of attestation prover
+type AttestationConfig struct {
+	Threshold int                      `mapstructure:"threshold"` // 至少需要的一致背书数，默认超过2/3
+	Timeout   int                      `mapstructure:"timeout"`   // 等待背书的时长，单位：秒
+	CacheTTL  int                      `mapstructure:"cache_ttl"` // 背书缓存时长，用于保存证明时写入链上，单位：秒
+	Peers     []*AttestationPeerConfig `mapstructure:"peers"`     // 背书代理
+}
+
+// AttestationPeerConfig the peer proxy which attests the proof
+type AttestationPeerConfig struct {
+	Name      string `mapstructure:"name"`       // 对端代理的路由名称，与router配置中的name一致
+	PublicKey string `mapstructure:"public_key"` // 对端代理背书签名的ed25519公钥，hex编码
+}
+
+// AttestationRequester request the attestation of proof from the peer proxy
+type AttestationRequester func(peer string, proof *eventproto.Proof, timeout time.Duration) (*eventproto.Attestation, error)
+
+// attestationPeer the peer proxy and its public key
+type attestationPeer struct {
+	name      string
+	publicKey ed25519.PublicKey
+}
+
+// attestationCache the attestations of one proof
+type attestationCache struct {
+	attestations []*eventproto.Attestation
+	expireAt     time.Time
+}
+
+// AttestationProver verify the proof by the signed attestations of peer proxies, the proof is accepted only when
+// at least threshold of the peers attest it as valid, the attestations are cached to be saved with the proof
+type AttestationProver struct {
+	sync.RWMutex                              // lock of requester and cache
+	chainIDs     []string                     // 支持的链
+	peers        []*attestationPeer           // 背书代理
+	threshold    int                          // 背书阈值
+	timeout      time.Duration                // 等待背书的时长
+	cacheTTL     time.Duration                // 背书缓存时长
+	requester    AttestationRequester         // 背书请求，由路由模块提供
+	cache        map[string]*attestationCache // 已通过的证明的背书，key为证明的唯一标识
+	log          *zap.SugaredLogger           // log
+}
+
+// NewAttestationProver create new instance of attestation prover by the config file
+func NewAttestationProver(ymlFile string, chainIDs []string) *AttestationProver {
+	cmViper := viper.New()
+	cmViper.SetConfigFile(ymlFile)
+	if err := cmViper.ReadInConfig(); err != nil {
+		panic(fmt.Errorf("read attestation prover config failed, %v", err))
+	}
+	config := &AttestationConfig{}
+	if err := cmViper.Unmarshal(config); err != nil {
+		panic(fmt.Errorf("unmarshal attestation prover config failed, %v", err))
+	}
+	prover, err := newAttestationProver(config, chainIDs)
+	if err != nil {
+		panic(fmt.Errorf("create attestation prover failed, %v", err))
+	}
+	return prover
+}
+
+// newAttestationProver create the prover by the config, the requester should be set before proving
+func newAttestationProver(config *AttestationConfig, chainIDs []string) (*AttestationProver, error) {
+	peers := make([]*attestationPeer, 0, len(config.Peers))
+	names := make(map[string]struct{}, len(config.Peers))
+	for _, peerConfig := range config.Peers {
+		if peerConfig.Name == "" {
+			return nil, errors.New("name of attestation peer is missing")
+		}
+		if _, exist := names[peerConfig.Name]; exist {
+			return nil, fmt.Errorf("attestation peer [%s] is duplicated", peerConfig.Name)
+		}
+		publicKey, err := utils.ParsePublicKey(peerConfig.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("public key of attestation peer [%s] error, %v", peerConfig.Name, err)
+		}
+		names[peerConfig.Name] = struct{}{}
+		peers = append(peers, &attestationPeer{name: peerConfig.Name, publicKey: publicKey})
+	}
+	if len(peers) == 0 {
+		return nil, errors.New("attestation peers are missing")
+	}
+	threshold := config.Threshold
+	if threshold <= 0 {
+		threshold = len(peers)*2/3 + 1
+	}
+	if threshold > len(peers) {
+		return nil, fmt.Errorf("threshold %d is more than the number of attestation peers", threshold)
+	}
+	timeout, cacheTTL := config.Timeout, config.CacheTTL
+	if timeout <= 0 {
+		timeout = DefaultAttestationTimeout
+	}
+	if cacheTTL <= 0 {
+		cacheTTL = DefaultAttestationCacheTTL
+	}
+	return &AttestationProver{
+		chainIDs:  chainIDs,
+		peers:     peers,
+		threshold: threshold,
+		timeout:   time.Duration(timeout) * time.Second,
+		cacheTTL:  time.Duration(cacheTTL) * time.Second,
+		cache:     make(map[string]*attestationCache),
+		log:       logger.GetLogger(logger.ModuleProver),
+	}, nil
+}
+
+// GetType return type of prover
+func (a *AttestationProver) GetType() ProverType {
+	return AttestationProverType
+}
+
+// GetChainIDs return chain-ids
+func (a *AttestationProver) GetChainIDs() []string {
+	return a.chainIDs
+}
+
+// SetRequester set the requester which sends the attestation request to peer proxy
+func (a *AttestationProver) SetRequester(requester AttestationRequester) {
+	a.Lock()
+	defer a.Unlock()
+	a.requester = requester
+}
+
+// ToProof convert to Proof for the inputs
+func (a *AttestationProver) ToProof(chainID, txKey string, blockHeight int64, index int32, contract *eventproto.ContractInfo, extra []byte) (*eventproto.Proof, error) {
+	return event.NewProof(chainID, txKey, blockHeight, index, contract, extra), nil
+}
+
+// Prove request the attestations from all the peers concurrently, the proof is valid when at least threshold of
+// the peers sign it as valid by their configured public keys
+func (a *AttestationProver) Prove(proof *eventproto.Proof) (bool, error) {
+	if proof == nil {
+		return false, errors.New("proof is nil")
+	}
+	a.RLock()
+	requester := a.requester
+	a.RUnlock()
+	if requester == nil {
+		return false, errors.New("attestation requester is not set")
+	}
+	results := make([]*eventproto.Attestation, len(a.peers))
+	var wg sync.WaitGroup
+	wg.Add(len(a.peers))
+	for i, peer := range a.peers {
+		go func(i int, peer *attestationPeer) {
+			defer wg.Done()
+			attestation, err := requester(peer.name, proof, a.timeout)
+			if err != nil {
+				a.log.Warnf("request attestation of tx[%s] from peer[%s] failed, %v", proof.GetTxKey(), peer.name, err)
+				return
+			}
+			if err = VerifyAttestation(peer.publicKey, proof, attestation); err != nil {
+				a.log.Warnf("verify attestation of tx[%s] from peer[%s] failed, %v", proof.GetTxKey(), peer.name, err)
+				return
+			}
+			results[i] = attestation
+		}(i, peer)
+	}
+	wg.Wait()
+	attestations := make([]*eventproto.Attestation, 0, len(results))
+	for _, attestation := range results {
+		if attestation != nil && attestation.Result {
+			attestations = append(attestations, attestation)
+		}
+	}
+	if len(attestations) < a.threshold {
+		return false, fmt.Errorf("tx[%s] of chain[%s] is attested by %d peers, less than %d",
+			proof.GetTxKey(), proof.GetChainID(), len(attestations), a.threshold)
+	}
+	a.putAttestations(proof, attestations)
+	return true, nil
+}
+
+// GetAttestations return the attestations which are collected when the proof is proved
+func (a *AttestationProver) GetAttestations(proof *eventproto.Proof) []*eventproto.Attestation {
+	a.RLock()
+	defer a.RUnlock()
+	if cached, exist := a.cache[attestationCacheKey(proof)]; exist && time.Now().Before(cached.expireAt) {
+		return cached.attestations
+	}
+	return nil
+}
+
+// putAttestations cache the attestations of proof and remove the expired ones
+func (a *AttestationProver) putAttestations(proof *eventproto.Proof, attestations []*eventproto.Attestation) {
+	a.Lock()
+	defer a.Unlock()
+	now := time.Now()
+	for key, cached := range a.cache {
+		if !now.Before(cached.expireAt) {
+			delete(a.cache, key)
+		}
+	}
+	a.cache[attestationCacheKey(proof)] = &attestationCache{
+		attestations: attestations,
+		expireAt:     now.Add(a.cacheTTL),
+	}
+}
+
+// attestationCacheKey return the unique key of proof
+func attestationCacheKey(proof *eventproto.Proof) string {
+	return fmt.Sprintf("%s#%s#%d#%d", proof.GetChainID(), proof.GetTxKey(), proof.GetBlockHeight(), proof.GetIndex())
+}
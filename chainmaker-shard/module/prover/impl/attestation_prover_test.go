@@ -0,0 +1,131 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package impl
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"chainmaker.org/chainmaker-cross/event"
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+	"github.com/stretchr/testify/require"
+)
+
+const attestationFixture = "testdata/attestation/attestation.yml"
+
+// fixturePeerKey return the private key of the fixture peer proxy{i}
+func fixturePeerKey(i int) ed25519.PrivateKey {
+	seed := sha256.Sum256([]byte(fmt.Sprintf("attestation-fixture-peer-%d", i)))
+	return ed25519.NewKeyFromSeed(seed[:])
+}
+
+// fixtureRequester return the requester which attests by the fixture keys, the results of peers are given by results,
+// the peer which is not in results refuses to attest
+func fixtureRequester(results map[string]bool) AttestationRequester {
+	return func(peer string, proof *eventproto.Proof, timeout time.Duration) (*eventproto.Attestation, error) {
+		result, exist := results[peer]
+		if !exist {
+			return nil, errors.New("peer is unavailable")
+		}
+		var i int
+		if _, err := fmt.Sscanf(peer, "proxy%d", &i); err != nil {
+			return nil, err
+		}
+		return SignAttestation(fixturePeerKey(i), proof, result)
+	}
+}
+
+func TestAttestation_SignAndVerify(t *testing.T) {
+	proof := event.NewProof("chain1", "tx1", 10, 2, nil, nil)
+	attestation, err := SignAttestation(fixturePeerKey(0), proof, true)
+	require.NoError(t, err)
+	publicKey := fixturePeerKey(0).Public().(ed25519.PublicKey)
+	require.Equal(t, hex.EncodeToString(publicKey), attestation.Signer)
+	require.NoError(t, VerifyAttestation(publicKey, proof, attestation))
+
+	// 其他公钥、篡改的结果或证明均无法通过校验
+	require.Error(t, VerifyAttestation(fixturePeerKey(1).Public().(ed25519.PublicKey), proof, attestation))
+	forged := *attestation
+	forged.Result = false
+	require.Error(t, VerifyAttestation(publicKey, proof, &forged))
+	require.Error(t, VerifyAttestation(publicKey, event.NewProof("chain1", "tx1", 11, 2, nil, nil), attestation))
+	require.Error(t, VerifyAttestation(publicKey, proof, nil))
+}
+
+func TestAttestationProver_Prove(t *testing.T) {
+	require.Panics(t, func() { NewAttestationProver("testdata/attestation/not_exist.yml", []string{"chain1"}) })
+	ap := NewAttestationProver(attestationFixture, []string{"chain1"})
+	require.Equal(t, AttestationProverType, ap.GetType())
+	require.Equal(t, []string{"chain1"}, ap.GetChainIDs())
+
+	proof, err := ap.ToProof("chain1", "tx1", 10, 2, nil, nil)
+	require.NoError(t, err)
+	// 未设置请求方法
+	ok, err := ap.Prove(proof)
+	require.Error(t, err)
+	require.False(t, ok)
+
+	// 2/3背书通过
+	ap.SetRequester(fixtureRequester(map[string]bool{"proxy0": true, "proxy2": true}))
+	ok, err = ap.Prove(proof)
+	require.NoError(t, err)
+	require.True(t, ok)
+	attestations := ap.GetAttestations(proof)
+	require.Len(t, attestations, 2)
+	for _, attestation := range attestations {
+		require.True(t, attestation.Result)
+	}
+	require.Nil(t, ap.GetAttestations(event.NewProof("chain1", "tx2", 10, 2, nil, nil)))
+
+	// 背书不足或结果不一致
+	for _, results := range []map[string]bool{
+		{"proxy0": true},
+		{"proxy0": true, "proxy1": false, "proxy2": false},
+		{},
+	} {
+		ap.SetRequester(fixtureRequester(results))
+		proof := event.NewProof("chain1", "tx3", 10, 2, nil, nil)
+		ok, err = ap.Prove(proof)
+		require.Error(t, err)
+		require.False(t, ok)
+		require.Nil(t, ap.GetAttestations(proof))
+	}
+
+	// 签名者与配置的公钥不一致
+	ap.SetRequester(func(peer string, proof *eventproto.Proof, timeout time.Duration) (*eventproto.Attestation, error) {
+		return SignAttestation(fixturePeerKey(0), proof, true)
+	})
+	ok, err = ap.Prove(event.NewProof("chain1", "tx4", 10, 2, nil, nil))
+	require.Error(t, err)
+	require.False(t, ok)
+	ok, err = ap.Prove(nil)
+	require.Error(t, err)
+	require.False(t, ok)
+}
+
+func TestNewAttestationProver_Config(t *testing.T) {
+	publicKey := hex.EncodeToString(fixturePeerKey(0).Public().(ed25519.PublicKey))
+	invalidConfigs := []*AttestationConfig{
+		{},
+		{Peers: []*AttestationPeerConfig{{Name: "", PublicKey: publicKey}}},
+		{Peers: []*AttestationPeerConfig{{Name: "proxy0", PublicKey: "abcd"}}},
+		{Peers: []*AttestationPeerConfig{{Name: "proxy0", PublicKey: publicKey}, {Name: "proxy0", PublicKey: publicKey}}},
+		{Threshold: 2, Peers: []*AttestationPeerConfig{{Name: "proxy0", PublicKey: publicKey}}},
+	}
+	for _, config := range invalidConfigs {
+		_, err := newAttestationProver(config, []string{"chain1"})
+		require.Error(t, err)
+	}
+	ap, err := newAttestationProver(&AttestationConfig{Peers: []*AttestationPeerConfig{{Name: "proxy0", PublicKey: publicKey}}}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, ap.threshold)
+	require.Equal(t, DefaultAttestationTimeout*time.Second, ap.timeout)
+}
@@ -0,0 +1,216 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package impl
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// BlockHeader the signed block header which is synchronized by light client,
+// the tx root is the merkle root of sha256(txKey) of all the txs in the block.
+// NOTE: the header format, its signing hash and the tx leaf are defined by this prover for the test fixtures only,
+// they do not match the block header of any supported chain, the headers of real chains can not be verified yet
+type BlockHeader struct {
+	ChainID      string             `json:"chain_id"`       // 链ID
+	Height       int64              `json:"height"`         // 区块高度
+	BlockHash    string             `json:"block_hash"`     // 区块哈希，hex编码
+	PreBlockHash string             `json:"pre_block_hash"` // 上一个区块哈希，hex编码
+	TxRoot       string             `json:"tx_root"`        // 交易默克尔根，hex编码
+	Timestamp    int64              `json:"timestamp"`      // 出块时间，单位：秒
+	Signatures   []*HeaderSignature `json:"signatures"`     // 共识节点对区块头的签名
+}
+
+// HeaderSignature the ed25519 signature of block header
+type HeaderSignature struct {
+	Signer    string `json:"signer"`    // 签名者的ed25519公钥，hex编码
+	Signature string `json:"signature"` // 对SigningHash的签名，hex编码
+}
+
+// InclusionProof the merkle path of tx which is carried by the extra of proof,
+// the siblings are ordered from leaf to root and the position of leaf is the index of proof
+type InclusionProof struct {
+	Path []string `json:"path"` // 各层兄弟节点的哈希，hex编码
+}
+
+// SigningHash return the hash of header which is signed by signers, the fields are length-prefixed to avoid ambiguity,
+// the hash is only produced by the fixture generator, no consensus node signs it
+func (h *BlockHeader) SigningHash() ([]byte, error) {
+	blockHash, err := hex.DecodeString(h.BlockHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block hash, %v", err)
+	}
+	preBlockHash, err := hex.DecodeString(h.PreBlockHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pre block hash, %v", err)
+	}
+	txRoot, err := hex.DecodeString(h.TxRoot)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tx root, %v", err)
+	}
+	var buf bytes.Buffer
+	for _, field := range [][]byte{[]byte(h.ChainID), blockHash, preBlockHash, txRoot} {
+		_ = binary.Write(&buf, binary.BigEndian, uint32(len(field)))
+		buf.Write(field)
+	}
+	_ = binary.Write(&buf, binary.BigEndian, h.Height)
+	_ = binary.Write(&buf, binary.BigEndian, h.Timestamp)
+	hash := sha256.Sum256(buf.Bytes())
+	return hash[:], nil
+}
+
+// Sign add the signature of the private key to the header
+func (h *BlockHeader) Sign(privateKey ed25519.PrivateKey) error {
+	hash, err := h.SigningHash()
+	if err != nil {
+		return err
+	}
+	publicKey, ok := privateKey.Public().(ed25519.PublicKey)
+	if !ok {
+		return errors.New("invalid ed25519 private key")
+	}
+	h.Signatures = append(h.Signatures, &HeaderSignature{
+		Signer:    hex.EncodeToString(publicKey),
+		Signature: hex.EncodeToString(ed25519.Sign(privateKey, hash)),
+	})
+	return nil
+}
+
+// VerifySignatures check that the header is signed by at least threshold of the trusted signers
+func (h *BlockHeader) VerifySignatures(trustedSigners map[string]ed25519.PublicKey, threshold int) error {
+	hash, err := h.SigningHash()
+	if err != nil {
+		return err
+	}
+	signed := make(map[string]struct{})
+	for _, sig := range h.Signatures {
+		publicKey, trusted := trustedSigners[sig.Signer]
+		if !trusted {
+			continue
+		}
+		signature, err := hex.DecodeString(sig.Signature)
+		if err != nil || !ed25519.Verify(publicKey, hash, signature) {
+			continue
+		}
+		signed[sig.Signer] = struct{}{}
+	}
+	if len(signed) < threshold {
+		return fmt.Errorf("header of chain[%s] at height[%d] is signed by %d trusted signers, less than %d",
+			h.ChainID, h.Height, len(signed), threshold)
+	}
+	return nil
+}
+
+// VerifyInclusion check that the tx is included in the header by the merkle path
+func (h *BlockHeader) VerifyInclusion(txKey string, index int32, inclusion *InclusionProof) error {
+	if index < 0 {
+		return fmt.Errorf("invalid tx index %d", index)
+	}
+	txRoot, err := hex.DecodeString(h.TxRoot)
+	if err != nil {
+		return fmt.Errorf("invalid tx root, %v", err)
+	}
+	path := make([][]byte, 0, len(inclusion.Path))
+	for _, node := range inclusion.Path {
+		sibling, err := hex.DecodeString(node)
+		if err != nil {
+			return fmt.Errorf("invalid merkle path, %v", err)
+		}
+		path = append(path, sibling)
+	}
+	// 路径长度决定了树高，index超出范围时无法通过校验
+	if len(path) < 63 && int64(index) >= int64(1)<<uint(len(path)) {
+		return fmt.Errorf("tx index %d is out of the merkle path", index)
+	}
+	root := MerkleRootFromPath(TxLeaf(txKey), int(index), path)
+	if !bytes.Equal(root, txRoot) {
+		return fmt.Errorf("tx[%s] is not included in block[%d] of chain[%s]", txKey, h.Height, h.ChainID)
+	}
+	return nil
+}
+
+// ParseInclusionProof parse the merkle path from the extra of proof
+func ParseInclusionProof(extra []byte) (*InclusionProof, error) {
+	if len(extra) == 0 {
+		return nil, errors.New("merkle path of proof is missing")
+	}
+	inclusion := &InclusionProof{}
+	if err := json.Unmarshal(extra, inclusion); err != nil {
+		return nil, fmt.Errorf("invalid merkle path of proof, %v", err)
+	}
+	return inclusion, nil
+}
+
+// TxLeaf return the merkle leaf of tx, it is the fixture format and differs from the tx merkle tree of real chains
+func TxLeaf(txKey string) []byte {
+	leaf := sha256.Sum256([]byte(txKey))
+	return leaf[:]
+}
+
+// MerkleRoot compute the merkle root of leaves, the last node is duplicated when the number of nodes is odd
+func MerkleRoot(leaves [][]byte) []byte {
+	if len(leaves) == 0 {
+		return nil
+	}
+	level := leaves
+	for len(level) > 1 {
+		level = nextMerkleLevel(level)
+	}
+	return level[0]
+}
+
+// MerklePath compute the merkle path of the leaf at index, which can be verified by MerkleRootFromPath
+func MerklePath(leaves [][]byte, index int) [][]byte {
+	path := make([][]byte, 0)
+	level := leaves
+	for len(level) > 1 {
+		sibling := index ^ 1
+		if sibling >= len(level) {
+			sibling = index
+		}
+		path = append(path, level[sibling])
+		level = nextMerkleLevel(level)
+		index /= 2
+	}
+	return path
+}
+
+// MerkleRootFromPath compute the merkle root from the leaf and its path
+func MerkleRootFromPath(leaf []byte, index int, path [][]byte) []byte {
+	node := leaf
+	for _, sibling := range path {
+		if index%2 == 0 {
+			node = hashMerkleNode(node, sibling)
+		} else {
+			node = hashMerkleNode(sibling, node)
+		}
+		index /= 2
+	}
+	return node
+}
+
+func nextMerkleLevel(level [][]byte) [][]byte {
+	next := make([][]byte, 0, (len(level)+1)/2)
+	for i := 0; i < len(level); i += 2 {
+		right := level[i]
+		if i+1 < len(level) {
+			right = level[i+1]
+		}
+		next = append(next, hashMerkleNode(level[i], right))
+	}
+	return next
+}
+
+func hashMerkleNode(left, right []byte) []byte {
+	hash := sha256.Sum256(append(append(make([]byte, 0, len(left)+len(right)), left...), right...))
+	return hash[:]
+}
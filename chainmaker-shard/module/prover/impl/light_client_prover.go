@@ -0,0 +1,302 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package impl
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"chainmaker.org/chainmaker-cross/event"
+	"chainmaker.org/chainmaker-cross/logger"
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// LightClientConfig the config of light client prover
+type LightClientConfig struct {
+	SyncInterval int                       `mapstructure:"sync_interval"` // 重新加载区块头文件的间隔，单位：秒，0表示只在启动时加载
+	Chains       []*LightClientChainConfig `mapstructure:"chains"`        // 各链的信任配置
+}
+
+// LightClientChainConfig the trusted signers and header files of one chain
+type LightClientChainConfig struct {
+	ChainID    string   `mapstructure:"chain_id"`    // 链ID
+	Signers    []string `mapstructure:"signers"`     // 可信的共识节点ed25519公钥，hex编码
+	Threshold  int      `mapstructure:"threshold"`   // 区块头至少需要的可信签名数，默认超过2/3
+	HeaderPath string   `mapstructure:"header_path"` // 区块头文件或目录，文件内容为BlockHeader的json数组，相对路径基于配置文件所在目录
+}
+
+// lightClientChain the trusted signers and verified headers of one chain
+type lightClientChain struct {
+	signers    map[string]ed25519.PublicKey // 可信签名者
+	threshold  int                          // 签名阈值
+	headerPath string                       // 区块头文件或目录
+	headers    map[int64]*BlockHeader       // 已验证的区块头，key为高度
+}
+
+// LightClientProver verify the proof by the merkle inclusion of tx in the signed block header at the block height,
+// the headers are verified by the trusted signers before stored
+type LightClientProver struct {
+	sync.RWMutex                              // lock of headers
+	chainIDs     []string                     // 支持的链
+	chains       map[string]*lightClientChain // 各链的区块头
+	stopC        chan struct{}                // 停止同步
+	log          *zap.SugaredLogger           // log
+}
+
+// NewLightClientProver create new instance of light client prover by the config file, the headers are loaded from
+// the header files, and reloaded periodically if sync interval is configured
+func NewLightClientProver(ymlFile string, chainIDs []string) *LightClientProver {
+	cmViper := viper.New()
+	cmViper.SetConfigFile(ymlFile)
+	if err := cmViper.ReadInConfig(); err != nil {
+		panic(fmt.Errorf("read light client prover config failed, %v", err))
+	}
+	config := &LightClientConfig{}
+	if err := cmViper.Unmarshal(config); err != nil {
+		panic(fmt.Errorf("unmarshal light client prover config failed, %v", err))
+	}
+	prover, err := newLightClientProver(config, filepath.Dir(ymlFile), chainIDs)
+	if err != nil {
+		panic(fmt.Errorf("create light client prover failed, %v", err))
+	}
+	if err = prover.SyncHeaders(); err != nil {
+		panic(fmt.Errorf("load headers of light client prover failed, %v", err))
+	}
+	if config.SyncInterval > 0 {
+		prover.startSync(time.Duration(config.SyncInterval) * time.Second)
+	}
+	return prover
+}
+
+// newLightClientProver create the prover without headers, every chain id must be configured
+func newLightClientProver(config *LightClientConfig, baseDir string, chainIDs []string) (*LightClientProver, error) {
+	chains := make(map[string]*lightClientChain, len(config.Chains))
+	for _, chainConfig := range config.Chains {
+		signers := make(map[string]ed25519.PublicKey, len(chainConfig.Signers))
+		for _, signer := range chainConfig.Signers {
+			signer = strings.ToLower(signer)
+			publicKey, err := hex.DecodeString(signer)
+			if err != nil || len(publicKey) != ed25519.PublicKeySize {
+				return nil, fmt.Errorf("invalid signer [%s] of chain[%s]", signer, chainConfig.ChainID)
+			}
+			signers[signer] = publicKey
+		}
+		if len(signers) == 0 {
+			return nil, fmt.Errorf("trusted signers of chain[%s] are missing", chainConfig.ChainID)
+		}
+		threshold := chainConfig.Threshold
+		if threshold <= 0 {
+			threshold = len(signers)*2/3 + 1
+		}
+		if threshold > len(signers) {
+			return nil, fmt.Errorf("threshold %d of chain[%s] is more than the number of signers", threshold, chainConfig.ChainID)
+		}
+		headerPath := chainConfig.HeaderPath
+		if headerPath != "" && !filepath.IsAbs(headerPath) {
+			headerPath = filepath.Join(baseDir, headerPath)
+		}
+		chains[chainConfig.ChainID] = &lightClientChain{
+			signers:    signers,
+			threshold:  threshold,
+			headerPath: headerPath,
+			headers:    make(map[int64]*BlockHeader),
+		}
+	}
+	for _, chainID := range chainIDs {
+		if _, exist := chains[chainID]; !exist {
+			return nil, fmt.Errorf("light client config of chain[%s] is missing", chainID)
+		}
+	}
+	return &LightClientProver{
+		chainIDs: chainIDs,
+		chains:   chains,
+		log:      logger.GetLogger(logger.ModuleProver),
+	}, nil
+}
+
+// GetType return type of prover
+func (l *LightClientProver) GetType() ProverType {
+	return LightClientProverType
+}
+
+// GetChainIDs return chain-ids
+func (l *LightClientProver) GetChainIDs() []string {
+	return l.chainIDs
+}
+
+// ToProof convert to Proof for the inputs, the extra should be the json of InclusionProof
+func (l *LightClientProver) ToProof(chainID, txKey string, blockHeight int64, index int32, contract *eventproto.ContractInfo, extra []byte) (*eventproto.Proof, error) {
+	return event.NewProof(chainID, txKey, blockHeight, index, contract, extra), nil
+}
+
+// Prove verify the merkle inclusion of tx against the stored header at the block height
+func (l *LightClientProver) Prove(proof *eventproto.Proof) (bool, error) {
+	if proof == nil {
+		return false, errors.New("proof is nil")
+	}
+	header, err := l.GetHeader(proof.GetChainID(), proof.GetBlockHeight())
+	if err != nil {
+		return false, err
+	}
+	inclusion, err := ParseInclusionProof(proof.GetExtra())
+	if err != nil {
+		return false, err
+	}
+	if err = header.VerifyInclusion(proof.GetTxKey(), proof.GetIndex(), inclusion); err != nil {
+		l.log.Warnf("prove tx[%s] of chain[%s] failed, %v", proof.GetTxKey(), proof.GetChainID(), err)
+		return false, err
+	}
+	return true, nil
+}
+
+// AddHeader verify the signatures of header and store it, the header which conflicts with the stored neighbours
+// or the stored header at the same height is rejected
+func (l *LightClientProver) AddHeader(header *BlockHeader) error {
+	l.Lock()
+	defer l.Unlock()
+	return l.addHeader(header)
+}
+
+// GetHeader return the verified header of chain at the height
+func (l *LightClientProver) GetHeader(chainID string, height int64) (*BlockHeader, error) {
+	l.RLock()
+	defer l.RUnlock()
+	chain, exist := l.chains[chainID]
+	if !exist {
+		return nil, fmt.Errorf("chain[%s] is not registered in light client prover", chainID)
+	}
+	header, exist := chain.headers[height]
+	if !exist {
+		return nil, fmt.Errorf("header of chain[%s] at height[%d] has not been synchronized", chainID, height)
+	}
+	return header, nil
+}
+
+// SyncHeaders load the headers from the header files of all chains, the loaded headers are skipped.
+// The invalid header is logged and skipped so that it does not block the other headers, the error is returned
+// only when the header files of some chain can not be read, after the other chains are synchronized
+func (l *LightClientProver) SyncHeaders() error {
+	l.Lock()
+	defer l.Unlock()
+	var unreadChains []string
+	for chainID, chain := range l.chains {
+		if chain.headerPath == "" {
+			continue
+		}
+		headers, err := readHeaderFiles(chain.headerPath)
+		if err != nil {
+			l.log.Errorf("read headers of chain[%s] error, %v", chainID, err)
+			unreadChains = append(unreadChains, chainID)
+			continue
+		}
+		for _, header := range headers {
+			if header.ChainID != chainID {
+				l.log.Warnf("header of chain[%s] at height[%d] is found in header files of chain[%s], skip it",
+					header.ChainID, header.Height, chainID)
+				continue
+			}
+			if err = l.addHeader(header); err != nil {
+				l.log.Warnf("skip header of chain[%s] at height[%d], %v", chainID, header.Height, err)
+			}
+		}
+	}
+	if len(unreadChains) > 0 {
+		sort.Strings(unreadChains)
+		return fmt.Errorf("read headers of chains %v error", unreadChains)
+	}
+	return nil
+}
+
+// Stop stop synchronizing headers
+func (l *LightClientProver) Stop() {
+	if l.stopC != nil {
+		close(l.stopC)
+		l.stopC = nil
+	}
+}
+
+// startSync reload the header files periodically
+func (l *LightClientProver) startSync(interval time.Duration) {
+	l.stopC = make(chan struct{})
+	go func(stopC chan struct{}) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := l.SyncHeaders(); err != nil {
+					l.log.Errorf("sync headers of light client prover failed, %v", err)
+				}
+			case <-stopC:
+				return
+			}
+		}
+	}(l.stopC)
+}
+
+func (l *LightClientProver) addHeader(header *BlockHeader) error {
+	chain, exist := l.chains[header.ChainID]
+	if !exist {
+		return fmt.Errorf("chain[%s] is not registered in light client prover", header.ChainID)
+	}
+	if stored, exist := chain.headers[header.Height]; exist {
+		if stored.BlockHash != header.BlockHash {
+			return fmt.Errorf("header of chain[%s] at height[%d] conflicts with the stored one", header.ChainID, header.Height)
+		}
+		return nil
+	}
+	if err := header.VerifySignatures(chain.signers, chain.threshold); err != nil {
+		return err
+	}
+	// 与相邻区块头的哈希链接需一致
+	if prev, exist := chain.headers[header.Height-1]; exist && prev.BlockHash != header.PreBlockHash {
+		return fmt.Errorf("header of chain[%s] at height[%d] does not link to the previous header", header.ChainID, header.Height)
+	}
+	if next, exist := chain.headers[header.Height+1]; exist && next.PreBlockHash != header.BlockHash {
+		return fmt.Errorf("header of chain[%s] at height[%d] does not link to the next header", header.ChainID, header.Height)
+	}
+	chain.headers[header.Height] = header
+	return nil
+}
+
+// readHeaderFiles read the headers from the json file, or all the json files in the directory
+func readHeaderFiles(headerPath string) ([]*BlockHeader, error) {
+	info, err := os.Stat(headerPath)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{headerPath}
+	if info.IsDir() {
+		if files, err = filepath.Glob(filepath.Join(headerPath, "*.json")); err != nil {
+			return nil, err
+		}
+	}
+	headers := make([]*BlockHeader, 0)
+	for _, file := range files {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		fileHeaders := make([]*BlockHeader, 0)
+		if err = json.Unmarshal(content, &fileHeaders); err != nil {
+			return nil, fmt.Errorf("unmarshal header file[%s] error, %v", file, err)
+		}
+		headers = append(headers, fileHeaders...)
+	}
+	return headers, nil
+}
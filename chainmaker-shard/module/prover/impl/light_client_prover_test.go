@@ -0,0 +1,185 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package impl
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"chainmaker.org/chainmaker-cross/event"
+	"github.com/stretchr/testify/require"
+)
+
+const lightClientFixture = "testdata/light_client/light_client.yml"
+
+// fixtureProofExtra return the merkle path of the tx in the fixture block, which has 5 txs named chain1-tx-{height}-{i}
+func fixtureProofExtra(t *testing.T, height int64, index int) []byte {
+	leaves := make([][]byte, 0, 5)
+	for i := 0; i < 5; i++ {
+		leaves = append(leaves, TxLeaf(fmt.Sprintf("chain1-tx-%d-%d", height, i)))
+	}
+	inclusion := &InclusionProof{}
+	for _, node := range MerklePath(leaves, index) {
+		inclusion.Path = append(inclusion.Path, hex.EncodeToString(node))
+	}
+	extra, err := json.Marshal(inclusion)
+	require.NoError(t, err)
+	return extra
+}
+
+func TestLightClientProver_Prove(t *testing.T) {
+	require.Panics(t, func() { NewLightClientProver(lightClientFixture, []string{"chain2"}) })
+	lp := NewLightClientProver(lightClientFixture, []string{"chain1"})
+	require.Equal(t, LightClientProverType, lp.GetType())
+	require.Equal(t, []string{"chain1"}, lp.GetChainIDs())
+
+	// 区块头中包含的交易
+	for i := 0; i < 5; i++ {
+		proof, err := lp.ToProof("chain1", fmt.Sprintf("chain1-tx-10-%d", i), 10, int32(i), nil, fixtureProofExtra(t, 10, i))
+		require.NoError(t, err)
+		ok, err := lp.Prove(proof)
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+	// 交易、位置或高度不匹配
+	invalidProofs := []struct {
+		txKey  string
+		height int64
+		index  int32
+		extra  []byte
+	}{
+		{"chain1-tx-10-9", 10, 2, fixtureProofExtra(t, 10, 2)},
+		{"chain1-tx-10-2", 10, 3, fixtureProofExtra(t, 10, 2)},
+		{"chain1-tx-10-2", 10, 8, fixtureProofExtra(t, 10, 2)},
+		{"chain1-tx-10-2", 11, 2, fixtureProofExtra(t, 10, 2)},
+		{"chain1-tx-10-2", 12, 2, fixtureProofExtra(t, 10, 2)},
+		{"chain1-tx-10-2", 10, 2, nil},
+		{"chain1-tx-10-2", 10, 2, []byte("not json")},
+	}
+	for _, p := range invalidProofs {
+		ok, err := lp.Prove(event.NewProof("chain1", p.txKey, p.height, p.index, nil, p.extra))
+		require.Error(t, err)
+		require.False(t, ok)
+	}
+	ok, err := lp.Prove(event.NewProof("chain2", "chain1-tx-10-2", 10, 2, nil, fixtureProofExtra(t, 10, 2)))
+	require.Error(t, err)
+	require.False(t, ok)
+	ok, err = lp.Prove(nil)
+	require.Error(t, err)
+	require.False(t, ok)
+}
+
+func TestLightClientProver_AddHeader(t *testing.T) {
+	lp := NewLightClientProver(lightClientFixture, []string{"chain1"})
+	stored, err := lp.GetHeader("chain1", 11)
+	require.NoError(t, err)
+
+	// 重复加载已存储的区块头
+	require.NoError(t, lp.SyncHeaders())
+	require.NoError(t, lp.AddHeader(stored))
+
+	// 不可信的签名者
+	_, untrusted, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	header := &BlockHeader{
+		ChainID:      "chain1",
+		Height:       12,
+		BlockHash:    hex.EncodeToString(TxLeaf("chain1-block-12")),
+		PreBlockHash: stored.BlockHash,
+		TxRoot:       hex.EncodeToString(MerkleRoot([][]byte{TxLeaf("chain1-tx-12-0")})),
+		Timestamp:    1700000012,
+	}
+	require.NoError(t, header.Sign(untrusted))
+	require.Error(t, lp.AddHeader(header))
+
+	// 同一高度的冲突区块头
+	conflict := *stored
+	conflict.BlockHash = header.BlockHash
+	require.Error(t, lp.AddHeader(&conflict))
+
+	// 篡改已签名的区块头
+	tampered := *stored
+	tampered.Height = 12
+	tampered.PreBlockHash = stored.BlockHash
+	require.Error(t, lp.AddHeader(&tampered))
+
+	// 未注册的链
+	header.ChainID = "chain2"
+	require.Error(t, lp.AddHeader(header))
+}
+
+// signedHeader create the header of chain at height which links to the previous header and is signed by the keys
+func signedHeader(t *testing.T, chainID string, height int64, prev *BlockHeader, keys ...ed25519.PrivateKey) *BlockHeader {
+	header := &BlockHeader{
+		ChainID:   chainID,
+		Height:    height,
+		BlockHash: hex.EncodeToString(TxLeaf(fmt.Sprintf("%s-block-%d", chainID, height))),
+		TxRoot:    hex.EncodeToString(MerkleRoot([][]byte{TxLeaf(fmt.Sprintf("%s-tx-%d-0", chainID, height))})),
+		Timestamp: 1700000000 + height,
+	}
+	if prev != nil {
+		header.PreBlockHash = prev.BlockHash
+	}
+	for _, key := range keys {
+		require.NoError(t, header.Sign(key))
+	}
+	return header
+}
+
+func writeHeaderFile(t *testing.T, file string, headers ...*BlockHeader) {
+	content, err := json.Marshal(headers)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(file, content, 0600))
+}
+
+func TestLightClientProver_SyncHeaders(t *testing.T) {
+	_, signer, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	_, untrusted, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	dir, err := ioutil.TempDir("", "light_client")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	signers := []string{hex.EncodeToString(signer.Public().(ed25519.PublicKey))}
+	config := &LightClientConfig{Chains: []*LightClientChainConfig{
+		{ChainID: "chain1", Signers: signers, HeaderPath: "chain1.json"},
+		{ChainID: "chain2", Signers: signers, HeaderPath: "chain2.json"},
+		{ChainID: "chain3", Signers: signers, HeaderPath: "not_exist.json"},
+	}}
+	lp, err := newLightClientProver(config, dir, []string{"chain1", "chain2", "chain3"})
+	require.NoError(t, err)
+
+	// chain1中未通过签名校验及属于其他链的区块头被跳过，不影响其后的区块头
+	header1 := signedHeader(t, "chain1", 1, nil, signer)
+	header2 := signedHeader(t, "chain1", 2, header1, untrusted)
+	header3 := signedHeader(t, "chain1", 3, header2, signer)
+	writeHeaderFile(t, filepath.Join(dir, "chain1.json"), header1, header2, signedHeader(t, "chain2", 2, nil, signer), header3)
+	writeHeaderFile(t, filepath.Join(dir, "chain2.json"), signedHeader(t, "chain2", 1, nil, signer))
+	// chain3的区块头文件无法读取，其他链仍然同步
+	require.Error(t, lp.SyncHeaders())
+	for _, height := range []int64{1, 3} {
+		_, err = lp.GetHeader("chain1", height)
+		require.NoError(t, err)
+	}
+	_, err = lp.GetHeader("chain1", 2)
+	require.Error(t, err)
+	_, err = lp.GetHeader("chain2", 1)
+	require.NoError(t, err)
+	_, err = lp.GetHeader("chain2", 2)
+	require.Error(t, err)
+
+	// 修复后重新同步
+	writeHeaderFile(t, filepath.Join(dir, "not_exist.json"), signedHeader(t, "chain3", 1, nil, signer))
+	require.NoError(t, lp.SyncHeaders())
+	_, err = lp.GetHeader("chain3", 1)
+	require.NoError(t, err)
+}
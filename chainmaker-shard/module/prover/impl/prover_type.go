@@ -13,4 +13,24 @@ const (
 	TrustProverType ProverType = iota
 	// spv验证证明
 	SpvProverType
+	// 轻客户端验证证明
+	LightClientProverType
+	// 多代理签名背书验证证明
+	AttestationProverType
 )
+
+// Name return the name of prover type, which is recorded in the audit of prove decision
+func (t ProverType) Name() string {
+	switch t {
+	case TrustProverType:
+		return "trust"
+	case SpvProverType:
+		return "spv"
+	case LightClientProverType:
+		return "light_client"
+	case AttestationProverType:
+		return "attestation"
+	default:
+		return "unknown"
+	}
+}
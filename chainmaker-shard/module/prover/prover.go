@@ -6,6 +6,8 @@
 package prover
 
 import (
+	"fmt"
+
 	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
 	"chainmaker.org/chainmaker-cross/prover/impl"
 )
@@ -25,3 +27,21 @@ type Prover interface {
 	// Prove load result of this proof
 	Prove(proof *eventproto.Proof) (bool, error)
 }
+
+// AttestedProver is the prover which collects the attestations of peer proxies when proving
+type AttestedProver interface {
+	Prover
+
+	// GetAttestations return the attestations of the proved proof, nil if not proved
+	GetAttestations(proof *eventproto.Proof) []*eventproto.Attestation
+}
+
+// NewVerifiedProof create the verified proof which is saved to chain by adapter,
+// the attestations are embedded when the proof is proved by attested prover
+func NewVerifiedProof(prover Prover, txProof *eventproto.Proof, verifyResult bool) *eventproto.VerifiedProof {
+	verifiedProof := eventproto.NewVerifiedProof(txProof, verifyResult, fmt.Sprintf("%v", prover.GetType()), "")
+	if attestedProver, ok := prover.(AttestedProver); ok {
+		verifiedProof.Attestations = attestedProver.GetAttestations(txProof)
+	}
+	return verifiedProof
+}
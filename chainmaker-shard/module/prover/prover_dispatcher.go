@@ -6,26 +6,46 @@
 package prover
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 
 	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
 
+	"chainmaker.org/chainmaker-cross/conf"
 	"chainmaker.org/chainmaker-cross/event"
+	"chainmaker.org/chainmaker-cross/monitor"
+	"chainmaker.org/chainmaker-cross/prover/impl"
 )
 
 var dispatcher *ProverDispatcher
 
 func init() {
 	dispatcher = &ProverDispatcher{
-		provers: make(map[string]Prover),
+		provers:  make(map[string]Prover),
+		policies: make(map[string]string),
 	}
 }
 
+// ProveDecision the decision of proving one proof, which is recorded for audit
+type ProveDecision struct {
+	ChainID    string // 证明所属的链
+	TxKey      string // 证明对应的交易，未携带证明时为空
+	ProverType string // 证明器类型，未配置证明器时为空
+	Policy     string // 证明策略
+	Result     bool   // 是否通过
+	Reason     string // 通过或拒绝的原因
+}
+
+// ProveAuditor record the prove decision of the cross transaction
+type ProveAuditor func(crossID string, decision *ProveDecision)
+
 // ProverDispatcher is dispatcher of prover
 type ProverDispatcher struct {
 	sync.RWMutex
-	provers map[string]Prover // Prover 的map， key 为chainID
+	provers  map[string]Prover // Prover 的map， key 为chainID
+	policies map[string]string // 证明策略，key 为chainID
+	auditor  ProveAuditor      // 证明结果的审计记录
 }
 
 // GetProverDispatcher return the instance of ProverDispatcher
@@ -33,18 +53,35 @@ func GetProverDispatcher() *ProverDispatcher {
 	return dispatcher
 }
 
-// Register put the prover to map
+// Register put the prover to map, the trust prover uses trust policy and the others use required policy
 func (pd *ProverDispatcher) Register(prover Prover) {
+	policy := conf.ProofPolicyRequired
+	if prover.GetType() == impl.TrustProverType {
+		policy = conf.ProofPolicyTrust
+	}
+	pd.RegisterWithPolicy(prover, policy)
+}
+
+// RegisterWithPolicy put the prover and the proof policy of its chains to map
+func (pd *ProverDispatcher) RegisterWithPolicy(prover Prover, policy string) {
 	pd.Lock()
 	defer pd.Unlock()
 	chainIDs := prover.GetChainIDs()
 	for _, chainID := range chainIDs {
 		if _, exist := pd.provers[chainID]; !exist {
 			pd.provers[chainID] = prover
+			pd.policies[chainID] = policy
 		}
 	}
 }
 
+// SetAuditor set the auditor which records the prove decisions of cross transactions
+func (pd *ProverDispatcher) SetAuditor(auditor ProveAuditor) {
+	pd.Lock()
+	defer pd.Unlock()
+	pd.auditor = auditor
+}
+
 // ToProof convert to Proof for the inputs
 func (pd *ProverDispatcher) ToProof(chainID, txKey string, blockHeight int64, index int32, contract *eventproto.ContractInfo, extra []byte) (*eventproto.Proof, error) {
 	if prover, exist := pd.GetProver(chainID); exist {
@@ -54,13 +91,66 @@ func (pd *ProverDispatcher) ToProof(chainID, txKey string, blockHeight int64, in
 	return event.NewProof(chainID, txKey, blockHeight, index, contract, extra), nil
 }
 
-// Prove load result of this proof
+// Prove load result of this proof, the decision is not audited because the cross transaction is unknown
 func (pd *ProverDispatcher) Prove(proof *eventproto.Proof) (bool, error) {
-	chainID := proof.GetChainID()
-	if prover, exist := pd.GetProver(chainID); exist {
-		return prover.Prove(proof)
+	decision := pd.Decide("", proof.GetChainID(), proof)
+	if !decision.Result {
+		return false, errors.New(decision.Reason)
+	}
+	return true, nil
+}
+
+// Decide prove the proof by the prover and policy of its chain, the chain id is only used when the proof is missing.
+// The decision is recorded by auditor if the cross id is not empty
+func (pd *ProverDispatcher) Decide(crossID, chainID string, proof *eventproto.Proof) *ProveDecision {
+	if proof != nil {
+		chainID = proof.GetChainID()
+	}
+	pd.RLock()
+	prover, exist := pd.provers[chainID]
+	policy, auditor := pd.policies[chainID], pd.auditor
+	pd.RUnlock()
+	decision := &ProveDecision{ChainID: chainID, TxKey: proof.GetTxKey(), Policy: policy}
+	switch {
+	case !exist:
+		// 未配置证明器的链一律拒绝
+		decision.Reason = fmt.Sprintf("can not find prover for chainID [%v]", chainID)
+	case policy == conf.ProofPolicyTrust:
+		decision.Result, decision.Reason = true, "trusted by policy without verification"
+	case proof == nil && policy == conf.ProofPolicyOptional:
+		decision.Result, decision.Reason = true, "proof is missing, accepted by optional policy"
+	case proof == nil:
+		decision.Reason = fmt.Sprintf("proof is missing, rejected by %s policy", policy)
+	default:
+		ok, err := prover.Prove(proof)
+		decision.Result = ok && err == nil
+		if err != nil {
+			decision.Reason = err.Error()
+		} else if ok {
+			decision.Reason = "verified by prover"
+		} else {
+			decision.Reason = "rejected by prover"
+		}
+	}
+	if exist {
+		decision.ProverType = prover.GetType().Name()
+	}
+	monitor.ObserveProve(chainID, decision.Result)
+	if crossID != "" && auditor != nil {
+		auditor(crossID, decision)
+	}
+	return decision
+}
+
+// SetAttestationRequester set the requester of all the attestation provers, which sends request to peer proxies
+func (pd *ProverDispatcher) SetAttestationRequester(requester impl.AttestationRequester) {
+	pd.RLock()
+	defer pd.RUnlock()
+	for _, prover := range pd.provers {
+		if attestationProver, ok := prover.(*impl.AttestationProver); ok {
+			attestationProver.SetRequester(requester)
+		}
 	}
-	return false, fmt.Errorf("can not find prover for chainID [%v]", chainID)
 }
 
 // GetProver return prover by chain id
@@ -6,6 +6,8 @@
 package prover
 
 import (
+	"fmt"
+
 	"chainmaker.org/chainmaker-cross/conf"
 	"chainmaker.org/chainmaker-cross/prover/impl"
 )
@@ -15,9 +17,13 @@ type Provider string
 const (
 	TrustProvider Provider = "trust"
 	SpvProvider   Provider = "spv"
+	// LightClientProvider 内置轻客户端，根据同步的区块头验证交易的默克尔证明
+	LightClientProvider Provider = "light_client"
+	// AttestationProvider 向多个对端代理请求签名背书，达到阈值时证明有效
+	AttestationProvider Provider = "attestation"
 )
 
-// InitProvers init all the provers
+// InitProvers init all the provers, unknown provider is rejected rather than trusted
 func InitProvers() *ProverDispatcher {
 	for _, proverCfg := range conf.Config.ProverConfigs {
 		var prov Prover
@@ -26,10 +32,14 @@ func InitProvers() *ProverDispatcher {
 			prov = impl.NewTrustProver(proverCfg.GetChainIDs())
 		case SpvProvider:
 			prov = impl.NewSpvProver(conf.FinalCfgPath(proverCfg.ConfigPath), proverCfg.GetChainIDs()) // TODO Unit Test
+		case LightClientProvider:
+			prov = impl.NewLightClientProver(conf.FinalCfgPath(proverCfg.ConfigPath), proverCfg.GetChainIDs())
+		case AttestationProvider:
+			prov = impl.NewAttestationProver(conf.FinalCfgPath(proverCfg.ConfigPath), proverCfg.GetChainIDs())
 		default:
-			prov = impl.NewTrustProver(proverCfg.GetChainIDs())
+			panic(fmt.Errorf("unsupported prover provider [%s] of chains %v", proverCfg.Provider, proverCfg.GetChainIDs()))
 		}
-		dispatcher.Register(prov)
+		dispatcher.RegisterWithPolicy(prov, proverCfg.GetPolicy())
 	}
 	return dispatcher
 }
@@ -6,9 +6,15 @@
 package prover
 
 import (
+	"crypto/ed25519"
+	"crypto/sha256"
 	"fmt"
+	"strings"
+	"time"
 
 	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/event"
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
 	"chainmaker.org/chainmaker-cross/prover/impl"
 	"github.com/stretchr/testify/require"
 
@@ -59,3 +65,83 @@ func TestProver(t *testing.T) {
 	require.Equal(t, ok, true)
 	require.NotNil(t, prover)
 }
+
+func TestNewVerifiedProof(t *testing.T) {
+	proof := event.NewProof("ChainID", "txKey", 10, 1, nil, nil)
+	verifiedProof := NewVerifiedProof(impl.NewTrustProver([]string{"ChainID"}), proof, true)
+	require.Equal(t, proof, verifiedProof.TxProof)
+	require.True(t, verifiedProof.VerifiedResult)
+	require.Equal(t, fmt.Sprintf("%v", impl.TrustProverType), verifiedProof.ProverType)
+	require.Nil(t, verifiedProof.Attestations)
+
+	// 背书证明器验证通过后，背书写入待保存的证明，测试配置中代理的公钥由种子sha256("attestation-fixture-peer-{i}")生成
+	ap := impl.NewAttestationProver("impl/testdata/attestation/attestation.yml", []string{"ChainID"})
+	ap.SetRequester(func(peer string, proof *eventproto.Proof, timeout time.Duration) (*eventproto.Attestation, error) {
+		seed := sha256.Sum256([]byte(strings.Replace(peer, "proxy", "attestation-fixture-peer-", 1)))
+		return impl.SignAttestation(ed25519.NewKeyFromSeed(seed[:]), proof, true)
+	})
+	ok, err := ap.Prove(proof)
+	require.NoError(t, err)
+	require.True(t, ok)
+	verifiedProof = NewVerifiedProof(ap, proof, ok)
+	require.Equal(t, fmt.Sprintf("%v", impl.AttestationProverType), verifiedProof.ProverType)
+	require.Len(t, verifiedProof.Attestations, 3)
+}
+
+type rejectProver struct {
+	*impl.TrustProver
+}
+
+func (r *rejectProver) GetType() impl.ProverType {
+	return impl.SpvProverType
+}
+
+func (r *rejectProver) Prove(proof *eventproto.Proof) (bool, error) {
+	return false, nil
+}
+
+func TestProverDispatcher_Decide(t *testing.T) {
+	pd := &ProverDispatcher{
+		provers:  make(map[string]Prover),
+		policies: make(map[string]string),
+	}
+	audits := make(map[string][]*ProveDecision)
+	pd.SetAuditor(func(crossID string, decision *ProveDecision) {
+		audits[crossID] = append(audits[crossID], decision)
+	})
+	pd.Register(impl.NewTrustProver([]string{"trustChain"}))
+	pd.Register(&rejectProver{impl.NewTrustProver([]string{"requiredChain"})})
+	pd.RegisterWithPolicy(&rejectProver{impl.NewTrustProver([]string{"optionalChain"})}, conf.ProofPolicyOptional)
+
+	// 信任策略不验证证明
+	decision := pd.Decide("cross1", "trustChain", nil)
+	require.True(t, decision.Result)
+	require.Equal(t, conf.ProofPolicyTrust, decision.Policy)
+	require.Equal(t, "trust", decision.ProverType)
+
+	// 必须策略下缺少证明或证明器拒绝时失败
+	decision = pd.Decide("cross1", "requiredChain", nil)
+	require.False(t, decision.Result)
+	require.Equal(t, conf.ProofPolicyRequired, decision.Policy)
+	decision = pd.Decide("cross1", "", event.NewProof("requiredChain", "txKey", 1, 0, nil, nil))
+	require.False(t, decision.Result)
+	require.Equal(t, "requiredChain", decision.ChainID)
+	require.Equal(t, "txKey", decision.TxKey)
+	require.Equal(t, "spv", decision.ProverType)
+
+	// 可选策略下缺少证明时通过，携带证明时仍需验证
+	require.True(t, pd.Decide("cross2", "optionalChain", nil).Result)
+	require.False(t, pd.Decide("cross2", "", event.NewProof("optionalChain", "txKey", 1, 0, nil, nil)).Result)
+
+	// 未配置证明器的链一律拒绝
+	decision = pd.Decide("cross2", "unknownChain", nil)
+	require.False(t, decision.Result)
+	require.Equal(t, "", decision.ProverType)
+
+	// 未指定跨链ID时不记录审计
+	ok, err := pd.Prove(event.NewProof("requiredChain", "txKey", 1, 0, nil, nil))
+	require.False(t, ok)
+	require.EqualError(t, err, "rejected by prover")
+	require.Len(t, audits["cross1"], 3)
+	require.Len(t, audits["cross2"], 3)
+}
@@ -0,0 +1,44 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package router
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+
+	"chainmaker.org/chainmaker-cross/event"
+)
+
+// RequestAttestation request the named peer proxy to attest the proof, the signed attestation is carried by
+// the extra of tx response, and it should be verified by the caller
+func (d *RouterDispatcher) RequestAttestation(peer string, proof *eventproto.Proof, timeout time.Duration) (*eventproto.Attestation, error) {
+	if proof == nil {
+		return nil, errors.New("proof to attest is nil")
+	}
+	resp, err := d.InvokePeer(peer, event.NewAttestTransactionEvent("", proof), timeout)
+	if err != nil {
+		return nil, err
+	}
+	if !resp.IsCompleted() {
+		return nil, fmt.Errorf("wait attestation from peer[%v] timeout", peer)
+	}
+	if !resp.IsSuccess() {
+		return nil, fmt.Errorf("peer[%v] refused to attest, %s", peer, resp.GetMsg())
+	}
+	extra := resp.GetExtra()
+	if len(extra) == 0 {
+		return nil, fmt.Errorf("attestation from peer[%v] is empty", peer)
+	}
+	attestation := &eventproto.Attestation{}
+	if err = json.Unmarshal(extra, attestation); err != nil {
+		return nil, fmt.Errorf("unmarshal attestation from peer[%v] error, %v", peer, err)
+	}
+	return attestation, nil
+}
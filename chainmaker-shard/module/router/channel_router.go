@@ -6,6 +6,7 @@
 package router
 
 import (
+	"sync"
 	"time"
 
 	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
@@ -16,7 +17,11 @@ import (
 
 // ChannelRouter is router which will communication with other cross chain proxy
 type ChannelRouter struct {
-	chainIDs []string                     // 转发代理支持的 chainID
+	sync.RWMutex
+	name     string                       // 对端代理名称，可为空
+	priority int                          // 路由优先级，值越小越优先
+	chainIDs []string                     // 转发代理支持的 chainID，开启代理发现时由对端通告更新
+	relays   map[string]int               // 对端代理经其他代理转发的 chainID => 对端之后还需经过的代理数
 	ch       *channel.NetChannel          // 跨链代理之间的连接
 	contexts *event.ProofResponseContexts // 交易验证数据
 }
@@ -30,6 +35,31 @@ func NewChannelRouter(chainIDs []string, netCh *channel.NetChannel) *ChannelRout
 	}
 }
 
+// SetName set the name of peer proxy, the named router can be addressed directly by dispatcher
+func (c *ChannelRouter) SetName(name string) {
+	c.name = name
+}
+
+// GetName return the name of peer proxy
+func (c *ChannelRouter) GetName() string {
+	return c.name
+}
+
+// SetPriority set the priority of router, the smaller value is preferred by the priority strategy
+func (c *ChannelRouter) SetPriority(priority int) {
+	c.priority = priority
+}
+
+// GetPriority return the priority of router
+func (c *ChannelRouter) GetPriority() int {
+	return c.priority
+}
+
+// Ping check whether the peer proxy is reachable, it is called by the heartbeat of dispatcher
+func (c *ChannelRouter) Ping(timeout time.Duration) error {
+	return c.ch.Ping(timeout)
+}
+
 // GetType return the type of router
 func (c *ChannelRouter) GetType() RouterType {
 	return ChannelRouterType
@@ -37,9 +67,34 @@ func (c *ChannelRouter) GetType() RouterType {
 
 // GetChainIDs return the chain ids
 func (c *ChannelRouter) GetChainIDs() []string {
+	c.RLock()
+	defer c.RUnlock()
 	return c.chainIDs
 }
 
+// SetChainIDs replace the chain ids by the announcement of peer proxy, it should be called by the dispatcher
+// so that the routing table is updated at the same time
+func (c *ChannelRouter) SetChainIDs(chainIDs []string) {
+	c.Lock()
+	defer c.Unlock()
+	c.chainIDs = chainIDs
+}
+
+// SetRelays set the chains which are reachable by the peer proxy through other proxies
+func (c *ChannelRouter) SetRelays(relays map[string]int) {
+	c.Lock()
+	defer c.Unlock()
+	c.relays = relays
+}
+
+// Hops return the count of proxies which the event passes through to reach the chain, 1 means the peer proxy
+// connects the chain directly
+func (c *ChannelRouter) Hops(chainID string) int {
+	c.RLock()
+	defer c.RUnlock()
+	return 1 + c.relays[chainID]
+}
+
 // Invoke put the event into channel, and wait response until completed
 func (c *ChannelRouter) Invoke(eve *eventproto.TransactionEvent, waitTime time.Duration) (*event.ProofResponse, error) {
 	// 创建返回对象
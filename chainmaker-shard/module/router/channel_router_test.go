@@ -74,7 +74,7 @@ func (c *ConnectionMock) WriteData(message net.Message) error {
 }
 
 func (c *ConnectionMock) GetProvider() net.ConnectionProvider {
-	return "mock"
+	return net.HttpConnection
 }
 
 func (c *ConnectionMock) Close() error {
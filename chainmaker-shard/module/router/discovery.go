@@ -0,0 +1,286 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package router
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+
+	"chainmaker.org/chainmaker-cross/channel"
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/event"
+	"chainmaker.org/chainmaker-cross/net"
+	"chainmaker.org/chainmaker-cross/net/net_libp2p"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+const (
+	DefaultAnnounceInterval = 60              // 默认向对端代理请求链通告的间隔，单位：秒
+	AnnounceTimeout         = 5 * time.Second // 单次链通告的超时时间
+)
+
+// dialPeer create the connection to the discovered proxy, it uses the libp2p channel config of listener,
+// since all the proxies in the same network are expected to listen on the same protocol and framing
+var dialPeer = func(address string) (net.Connection, error) {
+	listenerConfig := conf.Config.ListenerConfig
+	if listenerConfig == nil || listenerConfig.ChannelConfig == nil || listenerConfig.ChannelConfig.LibP2PChannel == nil {
+		return nil, errors.New("libp2p channel config of listener is required to connect the discovered proxy")
+	}
+	libp2pConfig := listenerConfig.ChannelConfig.LibP2PChannel
+	connection, err := net_libp2p.NewLibP2pConnection(address, protocol.ID(libp2pConfig.ProtocolID), libp2pConfig.GetDelimit(), 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	connection.SetFraming(libp2pConfig.Framing)
+	return connection, nil
+}
+
+// chainIDsSetter is the router whose chains can be updated by the announcement of peer proxy
+type chainIDsSetter interface {
+	SetChainIDs(chainIDs []string)
+}
+
+// SetDiscoveryConfig set the name, announce interval and bootstrap peers of discovery, the discovery is closed if not enabled
+func (d *RouterDispatcher) SetDiscoveryConfig(config *conf.DiscoveryConfig) {
+	proxyName := ""
+	if config != nil {
+		proxyName = config.Name
+	}
+	if proxyName == "" {
+		// 同一主机上的多个代理以进程号区分
+		hostname, _ := os.Hostname()
+		proxyName = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	}
+	d.Lock()
+	defer d.Unlock()
+	d.proxyName, d.announceInterval, d.bootstrap = proxyName, 0, nil
+	if config.IsEnabled() {
+		interval := config.AnnounceInterval
+		if interval == 0 {
+			interval = DefaultAnnounceInterval
+		}
+		d.announceInterval = time.Duration(interval) * time.Second
+		d.bootstrap = config.Bootstrap
+	}
+}
+
+// LocalAnnouncement return the announcement of current proxy to the peer proxy, which contains the chains connected
+// directly and the chains reachable through other proxies, the chains reachable through the peer itself are excluded
+func (d *RouterDispatcher) LocalAnnouncement(peer string) *eventproto.ChainAnnouncement {
+	d.RLock()
+	defer d.RUnlock()
+	chainIDs := make([]string, 0)
+	for chainID, routers := range d.routers {
+		if routers.InnerSupport() {
+			chainIDs = append(chainIDs, chainID)
+		}
+	}
+	sort.Strings(chainIDs)
+	return &eventproto.ChainAnnouncement{
+		ProxyName: d.proxyName,
+		ChainIDs:  chainIDs,
+		Relays:    d.localRelays(peer),
+		Timestamp: time.Now().Unix(),
+	}
+}
+
+// Announce request the peer proxy to announce its chains, and update the routing table by the announcement,
+// the router without name is named by the announced proxy name
+func (d *RouterDispatcher) Announce(router Router) (*eventproto.ChainAnnouncement, error) {
+	payload, err := json.Marshal(d.LocalAnnouncement(""))
+	if err != nil {
+		return nil, err
+	}
+	name := d.health.Name(router)
+	resp, err := router.Invoke(event.NewAnnounceTransactionEvent(payload), AnnounceTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if !resp.IsCompleted() {
+		return nil, fmt.Errorf("wait announcement from router[%v] timeout", name)
+	}
+	if !resp.IsSuccess() {
+		return nil, fmt.Errorf("router[%v] refused to announce, %s", name, resp.GetMsg())
+	}
+	announcement := &eventproto.ChainAnnouncement{}
+	if err = json.Unmarshal(resp.GetExtra(), announcement); err != nil {
+		return nil, fmt.Errorf("unmarshal announcement from router[%v] error, %v", name, err)
+	}
+	if channelRouter, ok := router.(*ChannelRouter); ok && channelRouter.GetName() == "" && announcement.ProxyName != "" {
+		d.Lock()
+		if _, exist := d.peers[announcement.ProxyName]; !exist {
+			channelRouter.SetName(announcement.ProxyName)
+			d.peers[announcement.ProxyName] = channelRouter
+		}
+		d.Unlock()
+	}
+	chainIDs, relays := d.relayChainIDs(announcement)
+	if relay, ok := router.(relayRouter); ok {
+		relay.SetRelays(relays)
+	}
+	if err = d.UpdateChainIDs(router, chainIDs); err != nil {
+		return nil, err
+	}
+	return announcement, nil
+}
+
+// UpdateChainIDs replace the chains of channel router, and move the router between the routers of chains
+func (d *RouterDispatcher) UpdateChainIDs(router Router, chainIDs []string) error {
+	setter, ok := router.(chainIDsSetter)
+	if !ok || router.GetType() != ChannelRouterType {
+		return errors.New("only the chains of channel router can be updated")
+	}
+	d.Lock()
+	defer d.Unlock()
+	for _, chainID := range router.GetChainIDs() {
+		if routers, exist := d.routers[chainID]; exist {
+			routers.Remove(router)
+			if !routers.Support() {
+				delete(d.routers, chainID)
+			}
+		}
+	}
+	setter.SetChainIDs(chainIDs)
+	d.addRoutes(router, chainIDs)
+	return nil
+}
+
+// AddPeer connect the proxy of libp2p address and learn its chains by announcement,
+// the proxy which has been connected is ignored
+func (d *RouterDispatcher) AddPeer(address string) error {
+	peerID, err := parsePeerID(address)
+	if err != nil {
+		return err
+	}
+	d.RLock()
+	_, exist := d.peerIDs[peerID]
+	d.RUnlock()
+	if exist {
+		return nil
+	}
+	connection, err := dialPeer(address)
+	if err != nil {
+		return fmt.Errorf("connect peer[%s] failed, %v", address, err)
+	}
+	netChannel := channel.NewNetChannel(connection)
+	if err = netChannel.Init(); err != nil {
+		_ = connection.Close()
+		return fmt.Errorf("init channel of peer[%s] failed, %v", address, err)
+	}
+	channelRouter := NewChannelRouter(nil, netChannel)
+	d.Lock()
+	if _, exist = d.peerIDs[peerID]; exist {
+		// 并发发现同一代理
+		d.Unlock()
+		_ = connection.Close()
+		return nil
+	}
+	d.peerIDs[peerID] = channelRouter
+	d.Unlock()
+	if err = d.Register(channelRouter); err != nil {
+		return err
+	}
+	announcement, err := d.Announce(channelRouter)
+	if err != nil {
+		// 对端代理暂不可用，等待下次通告
+		d.logger.Warnf("peer[%s] is added, but announcement failed, %v", address, err)
+		return nil
+	}
+	d.logger.Infof("peer[%s] named [%s] is added, chains %v", address, announcement.ProxyName, announcement.ChainIDs)
+	return nil
+}
+
+// BindPeer record the libp2p address of the configured router, so that the proxy is not connected again when discovered
+func (d *RouterDispatcher) BindPeer(address string, router Router) error {
+	peerID, err := parsePeerID(address)
+	if err != nil {
+		return err
+	}
+	d.Lock()
+	defer d.Unlock()
+	d.peerIDs[peerID] = router
+	return nil
+}
+
+// StartDiscovery connect the bootstrap peers and refresh the chains of all channel routers periodically
+func (d *RouterDispatcher) StartDiscovery() {
+	d.Lock()
+	if d.announceInterval <= 0 || d.discoveryStopC != nil {
+		d.Unlock()
+		return
+	}
+	stopC, interval, bootstrap := make(chan struct{}), d.announceInterval, d.bootstrap
+	d.discoveryStopC = stopC
+	d.Unlock()
+	d.discoveryWg.Add(1)
+	go func() {
+		defer d.discoveryWg.Done()
+		// 引导代理在添加时完成通告
+		d.AnnounceAll()
+		for _, address := range bootstrap {
+			if err := d.AddPeer(address); err != nil {
+				d.logger.Warnf("add bootstrap peer failed, %v", err)
+			}
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				d.AnnounceAll()
+			case <-stopC:
+				return
+			}
+		}
+	}()
+	d.logger.Infof("proxy discovery started, announce interval %v, bootstrap %v", interval, bootstrap)
+}
+
+// StopDiscovery stop refreshing the chains of channel routers
+func (d *RouterDispatcher) StopDiscovery() {
+	d.Lock()
+	stopC := d.discoveryStopC
+	d.discoveryStopC = nil
+	d.Unlock()
+	if stopC != nil {
+		close(stopC)
+		d.discoveryWg.Wait()
+	}
+}
+
+// AnnounceAll request all the channel routers to announce their chains, the chains of router are kept
+// if the announcement failed, so that the static chains still work with the proxy which does not support announcement
+func (d *RouterDispatcher) AnnounceAll() {
+	for _, router := range d.channelRouters() {
+		if _, ok := router.(chainIDsSetter); !ok {
+			continue
+		}
+		if _, err := d.Announce(router); err != nil {
+			d.logger.Warnf("announce by router[%s] failed, %v", d.health.Name(router), err)
+		}
+	}
+}
+
+// parsePeerID return the peer id of libp2p address
+func parsePeerID(address string) (string, error) {
+	maddr, err := ma.NewMultiaddr(address)
+	if err != nil {
+		return "", fmt.Errorf("invalid peer address[%s], %v", address, err)
+	}
+	info, err := peer.AddrInfoFromP2pAddr(maddr)
+	if err != nil {
+		return "", fmt.Errorf("invalid peer address[%s], %v", address, err)
+	}
+	return info.ID.Pretty(), nil
+}
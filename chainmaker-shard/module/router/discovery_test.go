@@ -0,0 +1,107 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package router
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/event"
+	"chainmaker.org/chainmaker-cross/net"
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+	"chainmaker.org/chainmaker-cross/utils"
+	"github.com/stretchr/testify/require"
+)
+
+const testPeerAddress = "/ip4/127.0.0.1/tcp/19528/p2p/QmeyNRs2DwWjcHTpcVHoUSaDAAif4VQZ2wQDQAUNDP33gH"
+
+func newAnnouncement(t *testing.T, name string, chainIDs ...string) []byte {
+	announcement, err := json.Marshal(&eventproto.ChainAnnouncement{ProxyName: name, ChainIDs: chainIDs})
+	require.Nil(t, err)
+	return announcement
+}
+
+func TestRouterDispatcher_Announce(t *testing.T) {
+	event.InitLog(getLogger())
+	inner := &InnerRouter{chainIDs: []string{"chain2", "chain1"}}
+	peer := &routerMock{chainIDs: []string{"chain3"}, deliverOK: true, respond: true, extra: newAnnouncement(t, "proxy2", "chain4", "chain5")}
+	d := newTestDispatcher(t, nil, inner, peer)
+	d.SetDiscoveryConfig(&conf.DiscoveryConfig{Name: "proxy1"})
+	local := d.LocalAnnouncement("")
+	require.Equal(t, "proxy1", local.ProxyName)
+	require.Equal(t, []string{"chain1", "chain2"}, local.ChainIDs)
+
+	// 通告的链替换静态配置的链
+	announcement, err := d.Announce(peer)
+	require.Nil(t, err)
+	require.Equal(t, "proxy2", announcement.ProxyName)
+	require.Equal(t, []string{"chain4", "chain5"}, peer.GetChainIDs())
+	routers, _ := d.selectRouters("chain4")
+	require.Equal(t, []Router{peer}, routers)
+	routers, _ = d.selectRouters("chain3")
+	require.Len(t, routers, 0)
+	eve := event.NewExecuteTransactionEvent(utils.NewUUID(), "chain5", []byte(""), "", nil)
+	resp, err := d.Invoke(eve, time.Second)
+	require.Nil(t, err)
+	require.True(t, resp.IsSuccess())
+
+	// 不支持链通告的代理保留原有的链
+	legacy := &routerMock{chainIDs: []string{"chain6"}, deliverOK: true}
+	require.Nil(t, d.Register(legacy))
+	d.AnnounceAll()
+	require.Equal(t, []string{"chain6"}, legacy.GetChainIDs())
+	routers, _ = d.selectRouters("chain6")
+	require.Equal(t, []Router{legacy}, routers)
+
+	// 直连路由的链不能被更新
+	require.NotNil(t, d.UpdateChainIDs(inner, []string{"chain7"}))
+}
+
+func TestRouterDispatcher_AddPeer(t *testing.T) {
+	event.InitLog(getLogger())
+	dialed := 0
+	defer func(origin func(string) (net.Connection, error)) { dialPeer = origin }(dialPeer)
+	dialPeer = func(string) (net.Connection, error) {
+		dialed++
+		return newConnectionMock(), nil
+	}
+	d := newTestDispatcher(t, nil)
+	require.NotNil(t, d.AddPeer("/ip4/127.0.0.1/tcp/19528"))
+	// 对端代理未响应时仍然添加，等待下次通告
+	require.Nil(t, d.AddPeer(testPeerAddress))
+	require.Nil(t, d.AddPeer(testPeerAddress))
+	require.Equal(t, 1, dialed)
+	require.Len(t, d.channelRouters(), 1)
+
+	// 已配置的代理不再重复连接
+	d = newTestDispatcher(t, nil)
+	require.Nil(t, d.BindPeer(testPeerAddress, &routerMock{}))
+	require.Nil(t, d.AddPeer(testPeerAddress))
+	require.Equal(t, 1, dialed)
+
+	dialPeer = func(string) (net.Connection, error) {
+		return nil, errors.New("connection refused")
+	}
+	require.NotNil(t, newTestDispatcher(t, nil).AddPeer(testPeerAddress))
+}
+
+func TestRouterDispatcher_Discovery(t *testing.T) {
+	peer := &routerMock{deliverOK: true, respond: true, extra: newAnnouncement(t, "proxy2", "chain1")}
+	d := newTestDispatcher(t, nil, peer)
+	// 未开启时不启动
+	d.StartDiscovery()
+	require.Nil(t, d.discoveryStopC)
+	d.SetDiscoveryConfig(&conf.DiscoveryConfig{Enable: true, AnnounceInterval: 1})
+	d.StartDiscovery()
+	defer d.StopDiscovery()
+	require.Eventually(t, func() bool {
+		routers, _ := d.selectRouters("chain1")
+		return len(routers) == 1
+	}, time.Second, 10*time.Millisecond)
+}
@@ -38,7 +38,7 @@ func TestInnerRouter_Invoke(t *testing.T) {
 	innerRouter := GetInnerRouter()
 	innerRouter.Init(chainIDs)
 	crossID := utils.NewUUID()
-	transactionEvent := event.NewExecuteTransactionEvent(crossID, "chain1", []byte(""), nil)
+	transactionEvent := event.NewExecuteTransactionEvent(crossID, "chain1", []byte(""), "", nil)
 	response, err := innerRouter.Invoke(transactionEvent, time.Second)
 	require.Nil(t, err)
 	require.NotNil(t, response)
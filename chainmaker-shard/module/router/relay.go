@@ -0,0 +1,123 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package router
+
+import (
+	"fmt"
+	"sort"
+
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+)
+
+const (
+	DefaultMaxHops = 3 // 默认事件最多经过的代理跳数
+)
+
+// relayRouter is the channel router which can reach some chains through other proxies
+type relayRouter interface {
+
+	// SetRelays set the chains reachable through other proxies and the count of proxies after the peer proxy
+	SetRelays(relays map[string]int)
+
+	// Hops return the count of proxies which the event passes through to reach the chain
+	Hops(chainID string) int
+}
+
+// hopsOf return the count of proxies which the event passes through to reach the chain by the router,
+// the router which can not relay connects the chain by the peer proxy directly
+func hopsOf(router Router, chainID string) int {
+	if relay, ok := router.(relayRouter); ok {
+		return relay.Hops(chainID)
+	}
+	return 1
+}
+
+// forward return the copy of event which is sent to the peer proxy, the event from client starts with the max hops,
+// and it is rejected if it has passed current proxy or its ttl is exhausted
+func (d *RouterDispatcher) forward(eve *eventproto.TransactionEvent) (*eventproto.TransactionEvent, error) {
+	d.RLock()
+	proxyName, maxHops := d.proxyName, d.maxHops
+	d.RUnlock()
+	if eve.HasHop(proxyName) {
+		return nil, fmt.Errorf("cross[%v]->chain[%v] has passed proxy[%v] by path %v, loop detected",
+			eve.GetCrossID(), eve.GetChainID(), proxyName, eve.GetHops())
+	}
+	if len(eve.GetHops()) == 0 && eve.GetTtl() == 0 {
+		// 客户端发起或不支持多跳转发的代理发送的事件
+		origin := *eve
+		origin.Ttl = int32(maxHops)
+		eve = &origin
+	}
+	if eve.GetTtl() <= 0 {
+		return nil, fmt.Errorf("cross[%v]->chain[%v] can not be forwarded, ttl is exhausted by path %v",
+			eve.GetCrossID(), eve.GetChainID(), eve.GetHops())
+	}
+	return eve.Forward(proxyName), nil
+}
+
+// skipVisited remove the routers of the proxies which the event has passed, the router without name is kept
+// and the loop is detected by the peer proxy itself
+func skipVisited(routers []Router, eve *eventproto.TransactionEvent) []Router {
+	unvisited := make([]Router, 0, len(routers))
+	for _, router := range routers {
+		if channelRouter, ok := router.(*ChannelRouter); ok && channelRouter.GetName() != "" && eve.HasHop(channelRouter.GetName()) {
+			continue
+		}
+		unvisited = append(unvisited, router)
+	}
+	return unvisited
+}
+
+// localRelays return the chains which are reachable by the channel routers except the peer proxy,
+// the chains connected directly are excluded, the caller should hold the lock
+func (d *RouterDispatcher) localRelays(peer string) map[string]int {
+	relays := make(map[string]int)
+	for _, router := range d.channels {
+		if channelRouter, ok := router.(*ChannelRouter); ok && peer != "" && channelRouter.GetName() == peer {
+			// 不向对端代理通告经其自身到达的链
+			continue
+		}
+		for _, chainID := range router.GetChainIDs() {
+			if routers, exist := d.routers[chainID]; exist && routers.InnerSupport() {
+				continue
+			}
+			// 请求方经当前代理转发，还需增加一跳
+			hops := hopsOf(router, chainID)
+			if hops >= d.maxHops {
+				continue
+			}
+			if known, exist := relays[chainID]; !exist || hops < known {
+				relays[chainID] = hops
+			}
+		}
+	}
+	return relays
+}
+
+// relayChainIDs merge the direct chains and the relay chains of announcement, the relay chains which exceed
+// the max hops or are connected by the peer directly are ignored
+func (d *RouterDispatcher) relayChainIDs(announcement *eventproto.ChainAnnouncement) ([]string, map[string]int) {
+	d.RLock()
+	maxHops := d.maxHops
+	d.RUnlock()
+	direct := make(map[string]struct{}, len(announcement.ChainIDs))
+	chainIDs := make([]string, 0, len(announcement.ChainIDs)+len(announcement.Relays))
+	for _, chainID := range announcement.ChainIDs {
+		direct[chainID] = struct{}{}
+		chainIDs = append(chainIDs, chainID)
+	}
+	relays := make(map[string]int)
+	relayIDs := make([]string, 0, len(announcement.Relays))
+	for chainID, hops := range announcement.Relays {
+		if _, exist := direct[chainID]; exist || hops <= 0 || 1+hops > maxHops {
+			continue
+		}
+		relays[chainID] = hops
+		relayIDs = append(relayIDs, chainID)
+	}
+	sort.Strings(relayIDs)
+	return append(chainIDs, relayIDs...), relays
+}
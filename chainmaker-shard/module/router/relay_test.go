@@ -0,0 +1,96 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package router
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/event"
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+	"chainmaker.org/chainmaker-cross/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouterDispatcher_Forward(t *testing.T) {
+	event.InitLog(getLogger())
+	visited := NewChannelRouter([]string{"chain2"}, nil)
+	visited.SetName("proxy2")
+	visited.SetPriority(1)
+	peer := &routerMock{chainIDs: []string{"chain2"}, deliverOK: true, respond: true}
+	d := newTestDispatcher(t, nil, visited, peer)
+	d.SetDiscoveryConfig(&conf.DiscoveryConfig{Name: "proxy1"})
+
+	// 客户端发起的事件按最大跳数设置ttl，原事件不变
+	origin := event.NewExecuteTransactionEvent(utils.NewUUID(), "chain2", []byte(""), "", nil)
+	resp, err := d.Invoke(origin, time.Second)
+	require.Nil(t, err)
+	require.True(t, resp.IsSuccess())
+	require.Equal(t, []string{"proxy1"}, peer.received.GetHops())
+	require.Equal(t, int32(DefaultMaxHops-1), peer.received.GetTtl())
+	require.Nil(t, origin.GetHops())
+
+	// 中间代理转发时追加路径，已经过的代理被跳过
+	eve := event.NewExecuteTransactionEvent(utils.NewUUID(), "chain2", []byte(""), "", nil)
+	eve.Hops, eve.Ttl = []string{"proxy0", "proxy2"}, 1
+	require.Equal(t, []Router{peer}, skipVisited([]Router{visited, peer}, eve))
+	_, err = d.Invoke(eve, time.Second)
+	require.Nil(t, err)
+	require.Equal(t, []string{"proxy0", "proxy2", "proxy1"}, peer.received.GetHops())
+	require.Equal(t, int32(0), peer.received.GetTtl())
+	require.Equal(t, 2, peer.invoked)
+
+	// ttl耗尽
+	eve.Ttl = 0
+	_, err = d.Invoke(eve, time.Second)
+	require.NotNil(t, err)
+	// 环路
+	eve.Hops, eve.Ttl = []string{"proxy0", "proxy1"}, 2
+	_, err = d.Invoke(eve, time.Second)
+	require.NotNil(t, err)
+	require.Equal(t, 2, peer.invoked)
+}
+
+func TestRouterDispatcher_Relay(t *testing.T) {
+	event.InitLog(getLogger())
+	inner := &InnerRouter{chainIDs: []string{"chain1"}}
+	proxy2 := NewChannelRouter([]string{"chain2", "chain3"}, nil)
+	proxy2.SetName("proxy2")
+	proxy2.SetRelays(map[string]int{"chain3": 1})
+	require.Equal(t, 2, proxy2.Hops("chain3"))
+	require.Equal(t, 1, proxy2.Hops("chain2"))
+	d := newTestDispatcher(t, &conf.RoutingConfig{MaxHops: 3}, inner, proxy2)
+
+	// 不向对端代理通告经其自身到达的链
+	announcement := d.LocalAnnouncement("proxy3")
+	require.Equal(t, []string{"chain1"}, announcement.ChainIDs)
+	require.Equal(t, map[string]int{"chain2": 1, "chain3": 2}, announcement.Relays)
+	require.Len(t, d.LocalAnnouncement("proxy2").Relays, 0)
+	d.SetRoutingConfig(&conf.RoutingConfig{MaxHops: 2})
+	require.Equal(t, map[string]int{"chain2": 1}, d.LocalAnnouncement("proxy3").Relays)
+
+	// 超过最大跳数的链不加入路由表
+	extra, err := json.Marshal(&eventproto.ChainAnnouncement{
+		ProxyName: "proxy4",
+		ChainIDs:  []string{"chain4"},
+		Relays:    map[string]int{"chain4": 1, "chain5": 1, "chain6": 2},
+	})
+	require.Nil(t, err)
+	relay := &routerMock{deliverOK: true, respond: true, extra: extra}
+	direct := &routerMock{chainIDs: []string{"chain5"}, deliverOK: true, respond: true}
+	require.Nil(t, d.Register(relay))
+	require.Nil(t, d.Register(direct))
+	_, err = d.Announce(relay)
+	require.Nil(t, err)
+	require.Equal(t, []string{"chain4", "chain5"}, relay.GetChainIDs())
+	require.Equal(t, map[string]int{"chain5": 1}, relay.relays)
+
+	// 跳数少的路由优先
+	routers, _ := d.selectRouters("chain5")
+	require.Equal(t, []Router{direct, relay}, routers)
+}
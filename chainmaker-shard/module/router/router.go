@@ -7,6 +7,7 @@ package router
 
 import (
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
@@ -22,6 +23,18 @@ const (
 	PreferenceIndex = 0
 )
 
+// routerTypeName return the name of router type, which is used as the label of metrics
+func routerTypeName(ty RouterType) string {
+	switch ty {
+	case InnerRouterType:
+		return "inner"
+	case ChannelRouterType:
+		return "channel"
+	default:
+		return "unknown"
+	}
+}
+
 // Router is interface for router
 type Router interface {
 
@@ -39,6 +52,7 @@ type Router interface {
 type Routers struct {
 	inner Router   // 直连消息通道
 	rs    []Router // 转发消息通道
+	next  uint32   // 轮询策略的下一个位置
 }
 
 // NewRouters create new routers
@@ -61,6 +75,19 @@ func (rs *Routers) Add(router Router) error {
 	return fmt.Errorf("can not support router type -> [%v]", ty)
 }
 
+// Remove remove the channel router from routers, return true if the router exists
+func (rs *Routers) Remove(router Router) bool {
+	for i, r := range rs.rs {
+		if r == router {
+			routers := make([]Router, 0, len(rs.rs)-1)
+			routers = append(routers, rs.rs[:i]...)
+			rs.rs = append(routers, rs.rs[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
 // Support return true if inner router is not nil or channel router is not empty
 func (rs *Routers) Support() bool {
 	return rs.InnerSupport() || rs.ChannelSupport()
@@ -73,7 +100,7 @@ func (rs *Routers) InnerSupport() bool {
 
 // ChannelSupport return true if channel router is not empty
 func (rs *Routers) ChannelSupport() bool {
-	return rs.rs != nil
+	return len(rs.rs) > 0
 }
 
 // GetInnerRouter return inner router
@@ -92,3 +119,15 @@ func (rs *Routers) GetChannelRouter() (Router, bool) {
 	}
 	return nil, false
 }
+
+// GetChannelRouters return the copy of all channel routers in the order of registration
+func (rs *Routers) GetChannelRouters() []Router {
+	routers := make([]Router, len(rs.rs))
+	copy(routers, rs.rs)
+	return routers
+}
+
+// Next return the sequence of round-robin strategy, it increases by every call
+func (rs *Routers) Next() uint32 {
+	return atomic.AddUint32(&rs.next, 1) - 1
+}
@@ -8,28 +8,57 @@ package router
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
 	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
 
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/conf/chaos"
 	"chainmaker.org/chainmaker-cross/event"
+	"chainmaker.org/chainmaker-cross/monitor"
 	"go.uber.org/zap"
 )
 
 var dispatcher *RouterDispatcher
 
 func init() {
-	dispatcher = &RouterDispatcher{
-		routers: make(map[string]*Routers),
-	}
+	dispatcher = newRouterDispatcher()
 }
 
 // RouterDispatcher dispatcher of router
 type RouterDispatcher struct {
-	sync.RWMutex                     // lock
-	routers      map[string]*Routers // 能够连接到指定 chainID 的节点路由
-	logger       *zap.SugaredLogger  // log
+	sync.RWMutex                                // lock
+	routers           map[string]*Routers       // 能够连接到指定 chainID 的节点路由
+	channels          []Router                  // 全部转发路由，按注册顺序
+	peers             map[string]*ChannelRouter // 按名称寻址的对端代理路由
+	peerIDs           map[string]Router         // 对端代理的libp2p网络身份 => 路由，用于发现时去重
+	proxyName         string                    // 当前代理名称，随链通告发送给对端代理
+	announceInterval  time.Duration             // 向对端代理请求链通告的间隔，0表示关闭代理发现
+	bootstrap         []string                  // 引导代理的libp2p地址
+	discoveryStopC    chan struct{}             // 停止代理发现信号
+	discoveryWg       sync.WaitGroup            // 等待代理发现任务退出
+	strategy          string                    // 多个转发路由的选择策略
+	maxFailover       int                       // 单次调用最多切换的路由次数，0表示尝试全部路由
+	maxHops           int                       // 事件最多经过的代理跳数
+	heartbeatInterval time.Duration             // 心跳检测间隔，0表示关闭
+	health            *HealthTracker            // 路由健康状态
+	stopC             chan struct{}             // 停止心跳信号
+	wg                sync.WaitGroup            // 等待心跳任务退出
+	logger            *zap.SugaredLogger        // log
+}
+
+// newRouterDispatcher create new instance of RouterDispatcher with the default routing config
+func newRouterDispatcher() *RouterDispatcher {
+	d := &RouterDispatcher{
+		routers: make(map[string]*Routers),
+		peers:   make(map[string]*ChannelRouter),
+		peerIDs: make(map[string]Router),
+	}
+	d.SetRoutingConfig(nil)
+	d.SetDiscoveryConfig(nil)
+	return d
 }
 
 // GetDispatcher return the instance of RouterDispatcher
@@ -42,14 +71,54 @@ func (d *RouterDispatcher) SetLogger(logger *zap.SugaredLogger) {
 	d.logger = logger
 }
 
-// Register add router to router dispatcher
+// SetRoutingConfig set the strategy, failover, max hops and heartbeat of routing, the default value is used if not configured
+func (d *RouterDispatcher) SetRoutingConfig(config *conf.RoutingConfig) {
+	if config == nil {
+		config = &conf.RoutingConfig{}
+	}
+	strategy := config.Strategy
+	if strategy == "" {
+		strategy = conf.RouteStrategyPriority
+	}
+	heartbeatInterval := config.HeartbeatInterval
+	if heartbeatInterval == 0 {
+		heartbeatInterval = DefaultHeartbeatInterval
+	} else if heartbeatInterval < 0 {
+		heartbeatInterval = 0
+	}
+	maxHops := config.MaxHops
+	if maxHops == 0 {
+		maxHops = DefaultMaxHops
+	}
+	d.Lock()
+	defer d.Unlock()
+	d.strategy, d.maxFailover, d.maxHops = strategy, config.MaxFailover, maxHops
+	d.heartbeatInterval = time.Duration(heartbeatInterval) * time.Second
+	d.health = NewHealthTracker(config.FailureThreshold, time.Duration(config.RecoverInterval)*time.Second)
+}
+
+// Register add router to router dispatcher, the chainIDs of channel router can be empty
+// when they are learned from the announcement of peer proxy
 func (d *RouterDispatcher) Register(router Router) error {
 	d.Lock()
 	defer d.Unlock()
+	if channelRouter, ok := router.(*ChannelRouter); ok && channelRouter.GetName() != "" {
+		d.peers[channelRouter.GetName()] = channelRouter
+	}
 	chainIDs := router.GetChainIDs()
-	if chainIDs == nil {
+	if router.GetType() == ChannelRouterType {
+		if !d.hasChannel(router) {
+			d.channels = append(d.channels, router)
+		}
+	} else if chainIDs == nil {
 		return errors.New("chainIDs is empty")
 	}
+	d.addRoutes(router, chainIDs)
+	return nil
+}
+
+// addRoutes add the router to the routers of chains, the caller should hold the lock
+func (d *RouterDispatcher) addRoutes(router Router, chainIDs []string) {
 	for _, chainID := range chainIDs {
 		if rs, exist := d.routers[chainID]; exist {
 			// 已经存在该chainID的处理
@@ -65,43 +134,237 @@ func (d *RouterDispatcher) Register(router Router) error {
 			}
 		}
 	}
-	return nil
 }
 
-// Invoke is entry for handle of transaction event
+// hasChannel return whether the channel router has been registered, the caller should hold the lock
+func (d *RouterDispatcher) hasChannel(router Router) bool {
+	for _, r := range d.channels {
+		if r == router {
+			return true
+		}
+	}
+	return false
+}
+
+// Invoke is entry for handle of transaction event, the inner router is preferred,
+// otherwise the event is invoked by the channel routers in the order of strategy until one of them responds
 func (d *RouterDispatcher) Invoke(eve *eventproto.TransactionEvent, waitTime time.Duration) (*event.ProofResponse, error) {
+	chainID := eve.GetChainID()
+	routers, inner := d.selectRouters(chainID)
+	if len(routers) == 0 {
+		d.logger.Errorf("can not find router to handle chain[%s]", chainID)
+		return nil, fmt.Errorf("can not find router to handle chain[%v]", chainID)
+	}
+	if inner {
+		d.logger.Infof("find inner router to handle event for chain[%s]", chainID)
+	} else {
+		d.logger.Infof("find %d channel routers to handle event for chain[%s] by %s strategy", len(routers), chainID, d.strategy)
+		// 经转发路由发送的事件记录路径，防止环路
+		forwarded, err := d.forward(eve)
+		if err != nil {
+			d.logger.Errorf("forward event failed, %v", err)
+			return nil, err
+		}
+		if routers = skipVisited(routers, eve); len(routers) == 0 {
+			return nil, fmt.Errorf("all the routers of chain[%v] have been passed by path %v", chainID, eve.GetHops())
+		}
+		eve = forwarded
+	}
+	// 故障注入未开启时不做任何处理
+	if err := chaos.Inject(chaos.BeforeInvoke, eve.GetCrossID(), chainID, eve.GetOpFunc().String()); err != nil {
+		return nil, err
+	}
 	var (
-		router Router
-		exist  bool
+		resp *event.ProofResponse
+		err  error
 	)
-	chainID := eve.GetChainID()
-	// 判断是否支持本地路由
-	if router, exist = d.getInnerRouter(chainID); !exist {
-		// 判断是否支持远端路由
-		if router, exist = d.getChannelRouter(chainID); !exist {
-			d.logger.Errorf("can not find router to handle chain[%s]", chainID)
-			return nil, fmt.Errorf("can not find router to handle chain[%v]", chainID)
-		} else {
-			d.logger.Infof("find channel router to handle event for chain[%s]", chainID)
+	for i, router := range routers {
+		if i > 0 {
+			d.logger.Warnf("cross[%s]->chain[%s] fail over to router[%s]", eve.GetCrossID(), chainID, d.health.Name(router))
+		}
+		start := time.Now()
+		resp, err = router.Invoke(eve, waitTime)
+		timeout := err == nil && !resp.IsCompleted()
+		monitor.ObserveRouterInvoke(routerTypeName(router.GetType()), chainID, start, timeout)
+		if inner {
+			break
+		}
+		if err == nil && !timeout {
+			// 对端代理已响应，无论交易成功与否路由均是健康的
+			d.health.Succeed(router, time.Since(start))
+			break
+		}
+		failErr := err
+		if timeout {
+			failErr = fmt.Errorf("wait response timeout after %v", waitTime)
+		}
+		d.logger.Warnf("cross[%s]->chain[%s] invoke by router[%s] failed, %v", eve.GetCrossID(), chainID, d.health.Name(router), failErr)
+		if d.health.Fail(router, failErr) {
+			d.logger.Warnf("router[%s] becomes unhealthy, %v", d.health.Name(router), failErr)
 		}
-	} else {
-		d.logger.Infof("find inner router to handle event for chain[%s]", chainID)
 	}
-	return router.Invoke(eve, waitTime)
+	if err == nil {
+		// 交易已发送但结果未返回
+		if err = chaos.Inject(chaos.AfterInvoke, eve.GetCrossID(), chainID, eve.GetOpFunc().String()); err != nil {
+			return nil, err
+		}
+	}
+	return resp, err
 }
 
-func (d *RouterDispatcher) getChannelRouter(chainID string) (*ChannelRouter, bool) {
+// InvokePeer invoke the transaction event by the router of the named peer proxy
+func (d *RouterDispatcher) InvokePeer(peer string, eve *eventproto.TransactionEvent, waitTime time.Duration) (*event.ProofResponse, error) {
 	d.RLock()
-	defer d.RUnlock()
-	if routers, exist := d.routers[chainID]; exist {
-		r, exi := routers.GetChannelRouter()
-		if exi {
-			if chr, ok := r.(*ChannelRouter); ok {
-				return chr, true
+	router, exist := d.peers[peer]
+	d.RUnlock()
+	if !exist {
+		return nil, fmt.Errorf("can not find router of peer[%v]", peer)
+	}
+	start := time.Now()
+	resp, err := router.Invoke(eve, waitTime)
+	monitor.ObserveRouterInvoke(routerTypeName(router.GetType()), eve.GetChainID(), start, err == nil && !resp.IsCompleted())
+	return resp, err
+}
+
+// GetRouterHealth return the health of channel routers which can reach the chain
+func (d *RouterDispatcher) GetRouterHealth(chainID string) []*RouterHealth {
+	d.RLock()
+	routers, exist := d.routers[chainID]
+	d.RUnlock()
+	if !exist {
+		return nil
+	}
+	return d.health.Snapshot(routers.GetChannelRouters())
+}
+
+// StartHeartbeat start probing the channel routers periodically, the unhealthy router recovers once the heartbeat succeeds
+func (d *RouterDispatcher) StartHeartbeat() {
+	d.Lock()
+	defer d.Unlock()
+	if d.heartbeatInterval <= 0 || d.stopC != nil {
+		return
+	}
+	stopC, interval := make(chan struct{}), d.heartbeatInterval
+	d.stopC = stopC
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				d.Heartbeat()
+			case <-stopC:
+				return
 			}
 		}
+	}()
+	d.logger.Infof("router heartbeat started, interval %v", interval)
+}
+
+// StopHeartbeat stop probing the channel routers
+func (d *RouterDispatcher) StopHeartbeat() {
+	d.Lock()
+	stopC := d.stopC
+	d.stopC = nil
+	d.Unlock()
+	if stopC != nil {
+		close(stopC)
+		d.wg.Wait()
 	}
-	return nil, false
+}
+
+// Heartbeat probe all the channel routers once
+func (d *RouterDispatcher) Heartbeat() {
+	for _, router := range d.channelRouters() {
+		checker, ok := router.(HealthChecker)
+		if !ok {
+			continue
+		}
+		if err := checker.Ping(HeartbeatTimeout); err != nil {
+			if d.health.Fail(router, err) {
+				d.logger.Warnf("router[%s] becomes unhealthy, heartbeat failed, %v", d.health.Name(router), err)
+			}
+			continue
+		}
+		if !d.health.IsHealthy(router) {
+			d.logger.Infof("router[%s] recovers by heartbeat", d.health.Name(router))
+		}
+		// 心跳时延不计入平均时延，仅重置失败次数
+		d.health.Succeed(router, 0)
+	}
+}
+
+// selectRouters return the inner router if exist, otherwise return the channel routers ordered by the strategy,
+// the unhealthy routers are put at the end so that they are only tried when all healthy routers failed
+func (d *RouterDispatcher) selectRouters(chainID string) ([]Router, bool) {
+	d.RLock()
+	routers, exist := d.routers[chainID]
+	if !exist {
+		d.RUnlock()
+		return nil, false
+	}
+	if inner, ok := routers.GetInnerRouter(); ok {
+		d.RUnlock()
+		return []Router{inner}, true
+	}
+	candidates, strategy, maxFailover := routers.GetChannelRouters(), d.strategy, d.maxFailover
+	d.RUnlock()
+	if len(candidates) == 0 {
+		return nil, false
+	}
+	switch strategy {
+	case conf.RouteStrategyRoundRobin:
+		offset := int(routers.Next() % uint32(len(candidates)))
+		rotated := make([]Router, 0, len(candidates))
+		rotated = append(rotated, candidates[offset:]...)
+		candidates = append(rotated, candidates[:offset]...)
+	case conf.RouteStrategyLeastLatency:
+		// 时延未知的路由优先尝试
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return d.health.Latency(candidates[i]) < d.health.Latency(candidates[j])
+		})
+	default:
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return priorityOf(candidates[i]) < priorityOf(candidates[j])
+		})
+	}
+	// 跳数少的路由优先，跳数相同时保持策略的顺序
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return hopsOf(candidates[i], chainID) < hopsOf(candidates[j], chainID)
+	})
+	selected := make([]Router, 0, len(candidates))
+	unhealthy := make([]Router, 0)
+	for _, router := range candidates {
+		if d.health.IsHealthy(router) {
+			selected = append(selected, router)
+		} else {
+			unhealthy = append(unhealthy, router)
+		}
+	}
+	selected = append(selected, unhealthy...)
+	if maxFailover > 0 && len(selected) > maxFailover+1 {
+		selected = selected[:maxFailover+1]
+	}
+	return selected, false
+}
+
+// channelRouters return all the channel routers in the order of registration
+func (d *RouterDispatcher) channelRouters() []Router {
+	d.RLock()
+	defer d.RUnlock()
+	channelRouters := make([]Router, len(d.channels))
+	copy(channelRouters, d.channels)
+	return channelRouters
+}
+
+// priorityOf return the priority of router, the router without priority is 0
+func priorityOf(router Router) int {
+	if channelRouter, ok := router.(*ChannelRouter); ok {
+		return channelRouter.GetPriority()
+	}
+	return 0
 }
 
 func (d *RouterDispatcher) getInnerRouter(chainID string) (*InnerRouter, bool) {
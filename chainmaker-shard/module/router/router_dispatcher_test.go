@@ -9,6 +9,8 @@ import (
 	"testing"
 	"time"
 
+	"chainmaker.org/chainmaker-cross/channel"
+	"chainmaker.org/chainmaker-cross/conf/chaos"
 	"chainmaker.org/chainmaker-cross/event"
 	"chainmaker.org/chainmaker-cross/utils"
 	"github.com/stretchr/testify/require"
@@ -35,7 +37,7 @@ func TestRouterDispatcher_Invoke(t *testing.T) {
 	err := routerDispatcher.Register(innerRouter)
 	require.Nil(t, err)
 	crossID := utils.NewUUID()
-	transactionEvent := event.NewExecuteTransactionEvent(crossID, "chain1", []byte(""), nil)
+	transactionEvent := event.NewExecuteTransactionEvent(crossID, "chain1", []byte(""), "", nil)
 	response, err := routerDispatcher.Invoke(transactionEvent, time.Second)
 	require.Nil(t, err)
 	require.NotNil(t, response)
@@ -44,8 +46,65 @@ func TestRouterDispatcher_Invoke(t *testing.T) {
 	require.Equal(t, "chain1", response.GetChainID())
 
 	crossID = utils.NewUUID()
-	transactionEvent = event.NewExecuteTransactionEvent(crossID, "chain3", []byte(""), nil)
+	transactionEvent = event.NewExecuteTransactionEvent(crossID, "chain3", []byte(""), "", nil)
 	response, err = routerDispatcher.Invoke(transactionEvent, time.Second)
 	require.NotNil(t, err)
 	require.Nil(t, response)
 }
+
+func TestRouterDispatcher_InvokePeer(t *testing.T) {
+	routerDispatcher := GetDispatcher()
+	testLogger := getLogger()
+	event.InitLog(testLogger)
+	routerDispatcher.SetLogger(testLogger)
+	proof := event.NewProof("chain5", "txKey", 10, 1, nil, nil)
+	// 未注册的代理
+	_, err := routerDispatcher.InvokePeer("proxy-unknown", event.NewAttestTransactionEvent("", proof), time.Second)
+	require.NotNil(t, err)
+	_, err = routerDispatcher.RequestAttestation("proxy-unknown", proof, time.Second)
+	require.NotNil(t, err)
+
+	connection := newConnectionMock()
+	defer connection.Close()
+	netChannel := channel.NewNetChannel(connection)
+	require.Nil(t, netChannel.Init())
+	channelRouter := NewChannelRouter([]string{"chain5"}, netChannel)
+	channelRouter.SetName("proxy-attest")
+	require.Equal(t, "proxy-attest", channelRouter.GetName())
+	require.Nil(t, routerDispatcher.Register(channelRouter))
+	response, err := routerDispatcher.InvokePeer("proxy-attest", event.NewAttestTransactionEvent("", proof), time.Second)
+	require.Nil(t, err)
+	require.Equal(t, false, response.IsCompleted())
+	// 对端代理未响应
+	attestation, err := routerDispatcher.RequestAttestation("proxy-attest", proof, time.Second)
+	require.NotNil(t, err)
+	require.Nil(t, attestation)
+}
+
+func TestRouterDispatcher_InvokeChaos(t *testing.T) {
+	routerDispatcher := GetDispatcher()
+	testLogger := getLogger()
+	event.InitLog(testLogger)
+	routerDispatcher.SetLogger(testLogger)
+	innerRouter := GetInnerRouter()
+	innerRouter.Init([]string{"chain1"})
+	require.Nil(t, routerDispatcher.Register(innerRouter))
+
+	injector := chaos.GetInjector()
+	injector.SetLogger(testLogger)
+	require.Nil(t, injector.Enable(&chaos.Scenario{Name: "router", Rules: []*chaos.Rule{
+		{Name: "before", Point: chaos.BeforeInvoke, Action: chaos.ActionError, Op: "CommitOpFunc", Times: 1},
+	}}, ""))
+	defer injector.Disable()
+	crossID := utils.NewUUID()
+	// 非提交操作不受影响
+	response, err := routerDispatcher.Invoke(event.NewExecuteTransactionEvent(crossID, "chain1", []byte(""), "", nil), time.Second)
+	require.Nil(t, err)
+	require.NotNil(t, response)
+	// 提交操作仅注入一次
+	_, err = routerDispatcher.Invoke(event.NewCommitTransactionEvent(crossID, "chain1", []byte("")), time.Second)
+	require.True(t, chaos.IsInjected(err))
+	_, err = routerDispatcher.Invoke(event.NewCommitTransactionEvent(crossID, "chain1", []byte("")), time.Second)
+	require.Nil(t, err)
+	require.Equal(t, 1, injector.Hits("before"))
+}
@@ -12,6 +12,7 @@ import (
 	"chainmaker.org/chainmaker-cross/net"
 	"chainmaker.org/chainmaker-cross/net/net_http"
 	"chainmaker.org/chainmaker-cross/net/net_libp2p"
+	"chainmaker.org/chainmaker-cross/utils"
 	"github.com/libp2p/go-libp2p-core/protocol"
 )
 
@@ -22,6 +23,8 @@ func InitRouters(innerChainIDs []string) *RouterDispatcher {
 	// 初始化innerRouter
 	innerRouter.Init(innerChainIDs)
 	dispatcher.SetLogger(log)
+	dispatcher.SetRoutingConfig(conf.Config.RoutingConfig)
+	dispatcher.SetDiscoveryConfig(conf.Config.Discovery)
 	_ = dispatcher.Register(innerRouter)
 	// 开始处理所有的Router
 	for _, routerConfig := range conf.Config.RouterConfigs {
@@ -30,7 +33,8 @@ func InitRouters(innerChainIDs []string) *RouterDispatcher {
 		var err error
 		if routerProvider == net.LibP2PConnection {
 			if routerConfig.LibP2PRouter != nil {
-				connection, err = net_libp2p.NewLibP2pConnection(
+				var libp2pConnection *net_libp2p.LibP2pConnection
+				libp2pConnection, err = net_libp2p.NewLibP2pConnection(
 					routerConfig.LibP2PRouter.Address,
 					protocol.ID(routerConfig.LibP2PRouter.ProtocolID),
 					routerConfig.LibP2PRouter.GetDelimit(),
@@ -40,6 +44,9 @@ func InitRouters(innerChainIDs []string) *RouterDispatcher {
 				if err != nil {
 					log.Errorf("connect [%s] failed", routerConfig.LibP2PRouter.Address)
 				} else {
+					// 对端代理支持分帧时使用分帧协议
+					libp2pConnection.SetFraming(routerConfig.LibP2PRouter.Framing)
+					connection = libp2pConnection
 					log.Infof("connect [%s] established", routerConfig.LibP2PRouter.Address)
 				}
 			}
@@ -51,14 +58,28 @@ func InitRouters(innerChainIDs []string) *RouterDispatcher {
 		if err == nil {
 			// 将connection加入router
 			netChannel := channel.NewNetChannel(connection)
+			// 只接受对端代理公钥签名的响应，未配置时使用全局信任列表
+			if trusted, err := utils.ParsePublicKeys(routerConfig.PublicKeys); err == nil {
+				netChannel.SetTrustedKeys(trusted)
+			} else {
+				log.Warn("parse public keys of channel router failed, ", err)
+			}
 			err := netChannel.Init()
 			if err == nil {
 				channelRouter := NewChannelRouter(routerConfig.GetChainIDs(), netChannel)
+				channelRouter.SetName(routerConfig.Name)
+				channelRouter.SetPriority(routerConfig.Priority)
 				err := GetDispatcher().Register(channelRouter)
 				if err != nil {
 					// 打印，但不处理
 					log.Warn("register channel router failed, ", err)
 				}
+				if routerProvider == net.LibP2PConnection {
+					// 已配置的代理被发现时不再重复连接
+					if err := GetDispatcher().BindPeer(routerConfig.LibP2PRouter.Address, channelRouter); err != nil {
+						log.Warn("bind peer of channel router failed, ", err)
+					}
+				}
 			} else {
 				// 打印信息
 				log.Warn("init channel router failed, ", err)
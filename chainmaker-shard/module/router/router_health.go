@@ -0,0 +1,166 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package router
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultHeartbeatInterval = 10              // 默认心跳检测间隔，单位：秒
+	DefaultFailureThreshold  = 3               // 默认连续失败次数阈值
+	DefaultRecoverInterval   = 30              // 默认不健康路由重新尝试的间隔，单位：秒
+	HeartbeatTimeout         = 3 * time.Second // 单次心跳检测的超时时间
+	latencyWeight            = 0.3             // 平均时延中最新一次时延的权重
+)
+
+// HealthChecker is the router which can be probed by the heartbeat
+type HealthChecker interface {
+
+	// Ping return error if the router can not reach the peer in the timeout
+	Ping(timeout time.Duration) error
+}
+
+// RouterHealth the health snapshot of router
+type RouterHealth struct {
+	Name        string        `json:"name"`         // 路由名称
+	Healthy     bool          `json:"healthy"`      // 是否健康
+	Failures    int           `json:"failures"`     // 连续失败次数
+	Latency     time.Duration `json:"latency"`      // 平均时延
+	LastError   string        `json:"last_error"`   // 最后一次失败的原因
+	LastFailure time.Time     `json:"last_failure"` // 最后一次失败的时间
+}
+
+// routerHealth the health state of router
+type routerHealth struct {
+	name        string        // 路由名称，用于日志
+	failures    int           // 连续失败次数
+	latency     time.Duration // 平均时延，0表示未知
+	lastError   string        // 最后一次失败的原因
+	lastFailure time.Time     // 最后一次失败的时间
+}
+
+// HealthTracker track the health of routers by the results of invoking and heartbeat,
+// the router is unhealthy after failing continuously, and it will be tried again after the recover interval
+type HealthTracker struct {
+	sync.RWMutex
+	states           map[Router]*routerHealth // 路由 => 健康状态
+	failureThreshold int                      // 连续失败次数阈值
+	recoverInterval  time.Duration            // 不健康路由重新尝试的间隔
+}
+
+// NewHealthTracker create new instance of health tracker
+func NewHealthTracker(failureThreshold int, recoverInterval time.Duration) *HealthTracker {
+	if failureThreshold <= 0 {
+		failureThreshold = DefaultFailureThreshold
+	}
+	if recoverInterval <= 0 {
+		recoverInterval = DefaultRecoverInterval * time.Second
+	}
+	return &HealthTracker{
+		states:           make(map[Router]*routerHealth),
+		failureThreshold: failureThreshold,
+		recoverInterval:  recoverInterval,
+	}
+}
+
+// Succeed record the success of router, the continuous failures are reset,
+// and the latency is ignored if it is not measured (zero)
+func (h *HealthTracker) Succeed(router Router, latency time.Duration) {
+	h.Lock()
+	defer h.Unlock()
+	state := h.state(router)
+	state.failures = 0
+	if latency <= 0 {
+		return
+	}
+	if state.latency == 0 {
+		state.latency = latency
+	} else {
+		state.latency = time.Duration(latencyWeight*float64(latency) + (1-latencyWeight)*float64(state.latency))
+	}
+}
+
+// Fail record the failure of router, return true if the router becomes unhealthy by this failure
+func (h *HealthTracker) Fail(router Router, err error) bool {
+	h.Lock()
+	defer h.Unlock()
+	state := h.state(router)
+	healthy := h.isHealthy(state)
+	state.failures++
+	state.lastFailure = time.Now()
+	if err != nil {
+		state.lastError = err.Error()
+	}
+	return healthy && !h.isHealthy(state)
+}
+
+// IsHealthy return whether the router is healthy, the unknown router is healthy
+func (h *HealthTracker) IsHealthy(router Router) bool {
+	h.RLock()
+	defer h.RUnlock()
+	if state, exist := h.states[router]; exist {
+		return h.isHealthy(state)
+	}
+	return true
+}
+
+// Latency return the average latency of router, 0 will be returned if it is unknown
+func (h *HealthTracker) Latency(router Router) time.Duration {
+	h.RLock()
+	defer h.RUnlock()
+	if state, exist := h.states[router]; exist {
+		return state.latency
+	}
+	return 0
+}
+
+// Name return the name of router which is used in log
+func (h *HealthTracker) Name(router Router) string {
+	h.Lock()
+	defer h.Unlock()
+	return h.state(router).name
+}
+
+// Snapshot return the health of routers
+func (h *HealthTracker) Snapshot(routers []Router) []*RouterHealth {
+	h.Lock()
+	defer h.Unlock()
+	snapshot := make([]*RouterHealth, 0, len(routers))
+	for _, router := range routers {
+		state := h.state(router)
+		snapshot = append(snapshot, &RouterHealth{
+			Name:        state.name,
+			Healthy:     h.isHealthy(state),
+			Failures:    state.failures,
+			Latency:     state.latency,
+			LastError:   state.lastError,
+			LastFailure: state.lastFailure,
+		})
+	}
+	return snapshot
+}
+
+// state return the health state of router, it is created if not exist, the caller should hold the lock
+func (h *HealthTracker) state(router Router) *routerHealth {
+	state, exist := h.states[router]
+	if !exist {
+		name := fmt.Sprintf("%s-router-%d", routerTypeName(router.GetType()), len(h.states))
+		if channelRouter, ok := router.(*ChannelRouter); ok && channelRouter.GetName() != "" {
+			name = channelRouter.GetName()
+		}
+		state = &routerHealth{name: name}
+		h.states[router] = state
+	}
+	return state
+}
+
+// isHealthy return whether the state is healthy, the unhealthy router is half-open after the recover interval
+func (h *HealthTracker) isHealthy(state *routerHealth) bool {
+	return state.failures < h.failureThreshold || time.Since(state.lastFailure) >= h.recoverInterval
+}
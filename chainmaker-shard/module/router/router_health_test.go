@@ -0,0 +1,189 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package router
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/event"
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+	"chainmaker.org/chainmaker-cross/utils"
+	"github.com/stretchr/testify/require"
+)
+
+// routerMock channel router whose delivery, response and heartbeat can be controlled
+type routerMock struct {
+	chainIDs  []string
+	deliverOK bool                         // 是否投递成功
+	respond   bool                         // 投递成功后是否返回结果
+	delay     time.Duration                // 返回结果前的时延
+	pingErr   error                        // 心跳结果
+	extra     []byte                       // 返回结果的附加数据
+	relays    map[string]int               // 经其他代理转发的链
+	received  *eventproto.TransactionEvent // 最后一次调用的事件
+	invoked   int                          // 调用次数
+}
+
+func (r *routerMock) GetType() RouterType {
+	return ChannelRouterType
+}
+
+func (r *routerMock) GetChainIDs() []string {
+	return r.chainIDs
+}
+
+func (r *routerMock) Invoke(eve *eventproto.TransactionEvent, waitTime time.Duration) (*event.ProofResponse, error) {
+	r.invoked++
+	r.received = eve
+	resp := event.NewProofResponse(eve.GetCrossID(), eve.GetChainID(), eve.GetOpFunc())
+	if !r.deliverOK {
+		return resp, errors.New("peer is unreachable")
+	}
+	if r.respond {
+		time.Sleep(r.delay)
+		resp.Done(eve.GetChainID(), "txKey", 1, 0, nil, r.extra)
+	}
+	resp.Wait(waitTime)
+	return resp, nil
+}
+
+func (r *routerMock) Ping(time.Duration) error {
+	return r.pingErr
+}
+
+func (r *routerMock) SetChainIDs(chainIDs []string) {
+	r.chainIDs = chainIDs
+}
+
+func (r *routerMock) SetRelays(relays map[string]int) {
+	r.relays = relays
+}
+
+func (r *routerMock) Hops(chainID string) int {
+	return 1 + r.relays[chainID]
+}
+
+func newTestDispatcher(t *testing.T, config *conf.RoutingConfig, routers ...Router) *RouterDispatcher {
+	d := newRouterDispatcher()
+	d.SetLogger(getLogger())
+	d.SetRoutingConfig(config)
+	for _, r := range routers {
+		require.Nil(t, d.Register(r))
+	}
+	return d
+}
+
+func TestHealthTracker(t *testing.T) {
+	healthy := &routerMock{chainIDs: []string{"chain1"}}
+	tracker := NewHealthTracker(2, 50*time.Millisecond)
+	require.True(t, tracker.IsHealthy(healthy))
+	require.False(t, tracker.Fail(healthy, errors.New("timeout")))
+	require.True(t, tracker.IsHealthy(healthy))
+	// 连续失败达到阈值后不健康
+	require.True(t, tracker.Fail(healthy, errors.New("timeout")))
+	require.False(t, tracker.IsHealthy(healthy))
+	snapshot := tracker.Snapshot([]Router{healthy})
+	require.Equal(t, 2, snapshot[0].Failures)
+	require.Equal(t, "timeout", snapshot[0].LastError)
+	require.Equal(t, "channel-router-0", snapshot[0].Name)
+	// 超过恢复间隔后重新尝试
+	time.Sleep(60 * time.Millisecond)
+	require.True(t, tracker.IsHealthy(healthy))
+	tracker.Succeed(healthy, 100*time.Millisecond)
+	require.Equal(t, 100*time.Millisecond, tracker.Latency(healthy))
+	tracker.Succeed(healthy, 0)
+	require.Equal(t, 100*time.Millisecond, tracker.Latency(healthy))
+	require.Equal(t, 0, tracker.Snapshot([]Router{healthy})[0].Failures)
+}
+
+func TestRouterDispatcher_Failover(t *testing.T) {
+	event.InitLog(getLogger())
+	unreachable := &routerMock{chainIDs: []string{"chain1"}}
+	silent := &routerMock{chainIDs: []string{"chain1"}, deliverOK: true}
+	healthy := &routerMock{chainIDs: []string{"chain1"}, deliverOK: true, respond: true}
+	d := newTestDispatcher(t, &conf.RoutingConfig{FailureThreshold: 1}, unreachable, silent, healthy)
+
+	// 投递失败及等待超时均切换到下一个路由
+	eve := event.NewExecuteTransactionEvent(utils.NewUUID(), "chain1", []byte(""), "", nil)
+	resp, err := d.Invoke(eve, 50*time.Millisecond)
+	require.Nil(t, err)
+	require.True(t, resp.IsSuccess())
+	require.Equal(t, []int{1, 1, 1}, []int{unreachable.invoked, silent.invoked, healthy.invoked})
+	health := d.GetRouterHealth("chain1")
+	require.Len(t, health, 3)
+	require.False(t, health[0].Healthy)
+	require.False(t, health[1].Healthy)
+	require.True(t, health[2].Healthy)
+
+	// 不健康的路由排到最后
+	resp, err = d.Invoke(eve, 50*time.Millisecond)
+	require.Nil(t, err)
+	require.True(t, resp.IsSuccess())
+	require.Equal(t, []int{1, 1, 2}, []int{unreachable.invoked, silent.invoked, healthy.invoked})
+
+	// 心跳成功后恢复
+	d.Heartbeat()
+	require.True(t, d.GetRouterHealth("chain1")[0].Healthy)
+
+	// 全部失败时返回最后一个路由的结果
+	d = newTestDispatcher(t, &conf.RoutingConfig{MaxFailover: 1}, unreachable, silent, healthy)
+	resp, err = d.Invoke(eve, 50*time.Millisecond)
+	require.Nil(t, err)
+	require.False(t, resp.IsCompleted())
+	require.Equal(t, 2, healthy.invoked)
+}
+
+func TestRouterDispatcher_Strategy(t *testing.T) {
+	event.InitLog(getLogger())
+	slow := &routerMock{chainIDs: []string{"chain1"}, deliverOK: true, respond: true, delay: 30 * time.Millisecond}
+	fast := &routerMock{chainIDs: []string{"chain1"}, deliverOK: true, respond: true}
+	eve := event.NewExecuteTransactionEvent(utils.NewUUID(), "chain1", []byte(""), "", nil)
+
+	// 轮询
+	d := newTestDispatcher(t, &conf.RoutingConfig{Strategy: conf.RouteStrategyRoundRobin}, slow, fast)
+	for i := 0; i < 4; i++ {
+		_, err := d.Invoke(eve, time.Second)
+		require.Nil(t, err)
+	}
+	require.Equal(t, 2, slow.invoked)
+	require.Equal(t, 2, fast.invoked)
+
+	// 平均时延最低
+	d = newTestDispatcher(t, &conf.RoutingConfig{Strategy: conf.RouteStrategyLeastLatency}, slow, fast)
+	d.health.Succeed(slow, 30*time.Millisecond)
+	d.health.Succeed(fast, time.Millisecond)
+	_, err := d.Invoke(eve, time.Second)
+	require.Nil(t, err)
+	require.Equal(t, 3, fast.invoked)
+
+	// 优先级
+	low := NewChannelRouter([]string{"chain1"}, nil)
+	high := NewChannelRouter([]string{"chain1"}, nil)
+	high.SetPriority(-1)
+	d = newTestDispatcher(t, nil, low, high)
+	routers, inner := d.selectRouters("chain1")
+	require.False(t, inner)
+	require.Equal(t, []Router{high, low}, routers)
+}
+
+func TestRouterDispatcher_Heartbeat(t *testing.T) {
+	down := &routerMock{chainIDs: []string{"chain1", "chain2"}, pingErr: errors.New("connection refused")}
+	d := newTestDispatcher(t, &conf.RoutingConfig{HeartbeatInterval: -1, FailureThreshold: 2}, down)
+	d.StartHeartbeat()
+	d.StopHeartbeat()
+	d.Heartbeat()
+	require.True(t, d.GetRouterHealth("chain1")[0].Healthy)
+	d.Heartbeat()
+	require.False(t, d.GetRouterHealth("chain2")[0].Healthy)
+	require.Equal(t, "connection refused", d.GetRouterHealth("chain2")[0].LastError)
+	down.pingErr = nil
+	d.Heartbeat()
+	require.True(t, d.GetRouterHealth("chain1")[0].Healthy)
+	require.Nil(t, d.GetRouterHealth("chain3"))
+}
@@ -4,10 +4,13 @@ go 1.16
 
 require (
 	chainmaker.org/chainmaker-cross/adapter v0.0.0
+	chainmaker.org/chainmaker-cross/channel v0.0.0
+	chainmaker.org/chainmaker-cross/conf v0.0.0
 	chainmaker.org/chainmaker-cross/event v0.0.0
 	chainmaker.org/chainmaker-cross/handler v0.0.0
 	chainmaker.org/chainmaker-cross/listener v0.0.0
 	chainmaker.org/chainmaker-cross/logger v0.0.0
+	chainmaker.org/chainmaker-cross/monitor v0.0.0
 	chainmaker.org/chainmaker-cross/pb/protogo v0.0.0
 	chainmaker.org/chainmaker-cross/prover v0.0.0
 	chainmaker.org/chainmaker-cross/router v0.0.0
@@ -25,6 +28,7 @@ replace (
 	chainmaker.org/chainmaker-cross/handler => ../handler
 	chainmaker.org/chainmaker-cross/listener => ../listener
 	chainmaker.org/chainmaker-cross/logger => ../logger
+	chainmaker.org/chainmaker-cross/monitor => ../monitor
 	chainmaker.org/chainmaker-cross/net => ./../net
 	chainmaker.org/chainmaker-cross/pb/protogo => ../pb/protogo
 	chainmaker.org/chainmaker-cross/prover => ../prover
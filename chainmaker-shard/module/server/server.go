@@ -7,16 +7,23 @@ package server
 
 import (
 	"errors"
+	"fmt"
 	"sync"
 
 	"chainmaker.org/chainmaker-cross/adapter"
+	"chainmaker.org/chainmaker-cross/channel/auth"
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/conf/chaos"
 	"chainmaker.org/chainmaker-cross/event"
 	"chainmaker.org/chainmaker-cross/handler"
 	"chainmaker.org/chainmaker-cross/listener"
+	"chainmaker.org/chainmaker-cross/listener/webhook"
 	"chainmaker.org/chainmaker-cross/logger"
+	"chainmaker.org/chainmaker-cross/monitor"
 	"chainmaker.org/chainmaker-cross/prover"
 	"chainmaker.org/chainmaker-cross/router"
 	"chainmaker.org/chainmaker-cross/store"
+	storetypes "chainmaker.org/chainmaker-cross/store/types"
 	"chainmaker.org/chainmaker-cross/transaction"
 	"go.uber.org/zap"
 )
@@ -33,25 +40,80 @@ type Server struct {
 	proverDispatcher  *prover.ProverDispatcher        // 验证管理服务
 	adapterDispatcher *adapter.ChainAdapterDispatcher // 转接器管理服务
 	eventHandlers     *handler.EventHandlerTools      // 跨链事件消息处理函数
+	monitorServer     *monitor.MonitorServer          // 监控服务，未开启时为nil
+	retentionWorker   *store.RetentionWorker          // 归档服务，未配置时为nil
+	leaderElector     *store.LeaderElector            // 高可用选举，未开启时为nil
 }
 
 // NewServer create new cross chain server
 func NewServer() *Server {
+	// 故障注入需在恢复流程开始前开启
+	enableChaos()
+	// 代理间消息的签名需在路由及监听启动前开启
+	if err := auth.GetAuthenticator().Init(conf.Config.AuthConfig, conf.Config.RouterConfigs); err != nil {
+		panic(fmt.Sprintf("init authenticator failed, %v", err))
+	}
 	stateDB := store.InitStateDB()
 	transactionMgr := transaction.InitManager(stateDB)
 	adapterDispatcher := adapter.InitAdapters()
 	event.InitLog(logger.GetLogger(logger.ModuleDefault))
-	return &Server{
+	// 回调需要从存储中加载跨链事件的回调地址
+	webhook.GetDispatcher().SetStateDB(stateDB)
+	eventHandlers := handler.InitEventHandlers(stateDB, transactionMgr.GetEventChan())
+	// 死信集合中的跨链事务由事务模块重新驱动
+	handler.GetDeadLetterHandler().SetRedriver(transactionMgr.Redrive)
+	// 运维人员对单条链的重试由事务模块执行
+	handler.GetAdminHandler().SetRetrier(transactionMgr.RetryChain)
+	// 人工处理只允许失败或进入死信的跨链事务，处理中的跨链事务由事务模块拒绝
+	handler.GetAdminHandler().SetResolver(transactionMgr.Resolve)
+	leaderElector := store.NewLeaderElector(stateDB, conf.Config.HAConfig)
+	if leaderElector != nil {
+		leaderChecker := func() (bool, string) {
+			return leaderElector.IsLeader(), leaderElector.GetLeader()
+		}
+		// 高可用模式下只有leader接收跨链事件，运维的重试、手动处理及重新驱动也只能在leader上执行
+		handler.GetCrossProcessHandler().SetLeaderChecker(leaderChecker)
+		handler.GetAdminHandler().SetLeaderChecker(leaderChecker)
+		handler.GetDeadLetterHandler().SetLeaderChecker(leaderChecker)
+		// 失去租约后旧leader仍在处理的跨链事务不能再写入状态
+		transactionMgr.SetLeaseChecker(leaderElector.HoldsLease)
+	}
+	listenerMgr := listener.InitListener()
+	routerDispatcher := router.InitRouters(adapterDispatcher.GetChainIDs())
+	proverDispatcher := prover.InitProvers()
+	// 背书证明器通过路由向对端代理请求签名
+	proverDispatcher.SetAttestationRequester(routerDispatcher.RequestAttestation)
+	// 每次证明的结果随跨链事务一起存储，查询跨链结果时返回
+	proverDispatcher.SetAuditor(func(crossID string, decision *prover.ProveDecision) {
+		if err := stateDB.AppendProveAudit(crossID, &storetypes.ProveAudit{
+			ChainID:    decision.ChainID,
+			TxKey:      decision.TxKey,
+			ProverType: decision.ProverType,
+			Policy:     decision.Policy,
+			Result:     decision.Result,
+			Reason:     decision.Reason,
+		}); err != nil {
+			logger.GetLogger(logger.ModuleServer).Errorf("append prove audit of cross[%s] failed, %v", crossID, err)
+		}
+	})
+	server := &Server{
 		started:           false,
 		logger:            logger.GetLogger(logger.ModuleServer),
 		stateDB:           stateDB,
 		transactionMgr:    transactionMgr,
-		listenerMgr:       listener.InitListener(),
-		routerDispatcher:  router.InitRouters(adapterDispatcher.GetChainIDs()),
-		proverDispatcher:  prover.InitProvers(),
+		listenerMgr:       listenerMgr,
+		routerDispatcher:  routerDispatcher,
+		proverDispatcher:  proverDispatcher,
 		adapterDispatcher: adapterDispatcher,
-		eventHandlers:     handler.InitEventHandlers(stateDB, transactionMgr.GetEventChan()),
+		eventHandlers:     eventHandlers,
+		monitorServer:     monitor.NewMonitorServer(conf.Config.MonitorConfig),
+		retentionWorker:   store.NewRetentionWorker(stateDB, conf.Config.StorageConfig.Retention),
+		leaderElector:     leaderElector,
 	}
+	if leaderElector != nil {
+		leaderElector.SetCallbacks(server.onElected, server.onRevoked)
+	}
+	return server
 }
 
 // GetTransactionMgr return TransactionMgr
@@ -98,13 +160,22 @@ func (s *Server) Start() error {
 		log.Error("this server has been started")
 		return errors.New("this server has been started")
 	}
-	log.Info("--- start transaction manager ---")
-	err := s.transactionMgr.Start()
-	if err != nil {
-		log.Error("start transaction manager error:", err)
-		return err
+	var err error
+	if s.leaderElector != nil {
+		// 高可用模式下由选举结果启停事务模块
+		log.Infof("--- start leader elector of node[%s] ---", s.leaderElector.GetNodeID())
+		s.leaderElector.Start()
+	} else {
+		log.Info("--- start transaction manager ---")
+		if err = s.startLeaderServices(); err != nil {
+			return err
+		}
+		log.Info("--- start transaction manager over ---")
 	}
-	log.Info("--- start transaction manager over ---")
+	// 心跳检测转发路由的健康状态
+	s.routerDispatcher.StartHeartbeat()
+	// 向对端代理请求链通告，动态更新路由表
+	s.routerDispatcher.StartDiscovery()
 	log.Info("--- start listener manager ---")
 	err = s.listenerMgr.Start()
 	if err != nil {
@@ -112,6 +183,13 @@ func (s *Server) Start() error {
 		return err
 	}
 	log.Info("--- start listener manager over ---")
+	if s.monitorServer != nil {
+		log.Info("--- start monitor server ---")
+		if err = s.monitorServer.Start(); err != nil {
+			log.Error("start monitor server error:", err)
+			return err
+		}
+	}
 	s.beenStarted()
 	return nil
 }
@@ -123,16 +201,60 @@ func (s *Server) Stop() error {
 	if !s.started {
 		return errors.New("this server has not been started")
 	}
-	s.transactionMgr.Stop()
+	if s.leaderElector != nil {
+		// 释放租约，其他节点立即接管
+		s.leaderElector.Stop()
+	} else {
+		s.stopLeaderServices()
+	}
+	s.routerDispatcher.StopDiscovery()
+	s.routerDispatcher.StopHeartbeat()
 	s.stateDB.Close()
 	if err := s.listenerMgr.Stop(); err != nil {
 		// 打印err
 		s.logger.Errorf("stop proxy server error", err)
 	}
+	if s.monitorServer != nil {
+		_ = s.monitorServer.Stop()
+	}
 	s.beenStopped()
 	return nil
 }
 
+// startLeaderServices start the services which can only run on one proxy, such as the transaction manager
+func (s *Server) startLeaderServices() error {
+	if err := s.transactionMgr.Start(); err != nil {
+		s.logger.Error("start transaction manager error:", err)
+		return err
+	}
+	if s.retentionWorker != nil {
+		s.logger.Info("--- start retention worker ---")
+		s.retentionWorker.Start()
+	}
+	return nil
+}
+
+// stopLeaderServices stop the services which are started by startLeaderServices
+func (s *Server) stopLeaderServices() {
+	s.transactionMgr.Stop()
+	if s.retentionWorker != nil {
+		s.retentionWorker.Stop()
+	}
+}
+
+// onElected start the leader services, the unfinished cross transactions are taken over by recovery
+func (s *Server) onElected() error {
+	s.logger.Info("--- this proxy is elected as leader, start transaction manager ---")
+	return s.startLeaderServices()
+}
+
+// onRevoked stop the leader services when the leadership is lost,
+// it returns after the cross transactions which are being handled have exited
+func (s *Server) onRevoked() {
+	s.logger.Info("--- this proxy becomes follower, stop transaction manager ---")
+	s.stopLeaderServices()
+}
+
 func (s *Server) beenStarted() {
 	s.started = true
 }
@@ -140,3 +262,19 @@ func (s *Server) beenStarted() {
 func (s *Server) beenStopped() {
 	s.started = false
 }
+
+// enableChaos enable the chaos injector by the config, it is only used to verify the recovery
+func enableChaos() {
+	chaosConfig := conf.Config.ChaosConfig
+	if !chaosConfig.IsEnabled() {
+		return
+	}
+	stateFile := chaosConfig.StateFile
+	if stateFile != "" {
+		stateFile = conf.FinalCfgPath(stateFile)
+	}
+	chaos.GetInjector().SetLogger(logger.GetLogger(logger.ModuleServer))
+	if err := chaos.GetInjector().Enable(chaosConfig.Scenario, stateFile); err != nil {
+		panic(fmt.Sprintf("enable chaos scenario failed, %v", err))
+	}
+}
@@ -0,0 +1,79 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package store
+
+import (
+	"chainmaker.org/chainmaker-cross/conf/chaos"
+	storetypes "chainmaker.org/chainmaker-cross/store/types"
+)
+
+var _ StateDB = (*ChaosStateDB)(nil)
+
+// ChaosStateDB is decorator of StateDB, which injects the faults before and after the states are written,
+// the op of rule is the name of target state, such as StateCommitSuccess
+type ChaosStateDB struct {
+	StateDB // 实际存储
+}
+
+// NewChaosStateDB create new instance of ChaosStateDB
+func NewChaosStateDB(stateDB StateDB) *ChaosStateDB {
+	return &ChaosStateDB{
+		StateDB: stateDB,
+	}
+}
+
+// StartCross start the cross transaction with the faults injected
+func (c *ChaosStateDB) StartCross(crossID string, content []byte) error {
+	return c.write(crossID, "", storetypes.StateInit, func() error {
+		return c.StateDB.StartCross(crossID, content)
+	})
+}
+
+// FinishCross finish the cross transaction with the faults injected
+func (c *ChaosStateDB) FinishCross(crossID string, result []byte, state storetypes.State) error {
+	return c.write(crossID, "", state, func() error {
+		return c.StateDB.FinishCross(crossID, result, state)
+	})
+}
+
+// WriteCrossState write the total state with the faults injected
+func (c *ChaosStateDB) WriteCrossState(crossID string, state storetypes.State) error {
+	return c.write(crossID, "", state, func() error {
+		return c.StateDB.WriteCrossState(crossID, state)
+	})
+}
+
+// WriteChainCrossState write the state of chain with the faults injected
+func (c *ChaosStateDB) WriteChainCrossState(crossID, chainID string, state storetypes.State, content []byte) error {
+	return c.write(crossID, chainID, state, func() error {
+		return c.StateDB.WriteChainCrossState(crossID, chainID, state, content)
+	})
+}
+
+// FinishChainCrossState finish the cross transaction for chain with the faults injected
+func (c *ChaosStateDB) FinishChainCrossState(crossID, chainID string, result []byte, state storetypes.State) error {
+	return c.write(crossID, chainID, state, func() error {
+		return c.StateDB.FinishChainCrossState(crossID, chainID, result, state)
+	})
+}
+
+// ApplyTransition change the state atomically with the faults injected
+func (c *ChaosStateDB) ApplyTransition(transition *storetypes.Transition) error {
+	return c.write(transition.CrossID, transition.ChainID, transition.To, func() error {
+		return c.StateDB.ApplyTransition(transition)
+	})
+}
+
+// write inject the faults before and after the write, the fault after write is skipped if the write fails
+func (c *ChaosStateDB) write(crossID, chainID string, state storetypes.State, fn func() error) error {
+	if err := chaos.Inject(chaos.BeforeStateWrite, crossID, chainID, state.String()); err != nil {
+		return err
+	}
+	if err := fn(); err != nil {
+		return err
+	}
+	return chaos.Inject(chaos.AfterStateWrite, crossID, chainID, state.String())
+}
@@ -0,0 +1,48 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package store
+
+import (
+	"testing"
+
+	"chainmaker.org/chainmaker-cross/conf/chaos"
+	"chainmaker.org/chainmaker-cross/store/kvdb"
+	"chainmaker.org/chainmaker-cross/store/kvdb/factory"
+	storetypes "chainmaker.org/chainmaker-cross/store/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChaosStateDB(t *testing.T) {
+	provider, err := factory.NewKvDBProvider(storetypes.Memory, nil)
+	require.NoError(t, err)
+	stateDB := NewChaosStateDB(kvdb.NewKvStateDB(provider))
+	defer stateDB.Close()
+
+	injector := chaos.GetInjector()
+	require.NoError(t, injector.Enable(&chaos.Scenario{Rules: []*chaos.Rule{
+		{Name: "before", Point: chaos.BeforeStateWrite, Action: chaos.ActionError, ChainID: "chain1", Op: "StateCommitSuccess"},
+		{Name: "after", Point: chaos.AfterStateWrite, Action: chaos.ActionError, ChainID: "chain2", Op: "StateCommitSuccess"},
+	}}, ""))
+	defer injector.Disable()
+
+	require.NoError(t, stateDB.StartCross("cross-1", []byte("content")))
+	require.NoError(t, stateDB.WriteChainCrossState("cross-1", "chain1", storetypes.StateExecuteSuccess, nil))
+
+	// 写入前注入的错误，状态未写入
+	err = stateDB.ApplyTransition(&storetypes.Transition{CrossID: "cross-1", ChainID: "chain1",
+		From: storetypes.StateExecuteSuccess, To: storetypes.StateCommitSuccess})
+	require.True(t, chaos.IsInjected(err))
+	state, _, _ := stateDB.ReadChainCrossState("cross-1", "chain1")
+	require.Equal(t, storetypes.StateExecuteSuccess, state)
+
+	// 写入后注入的错误，状态已写入
+	err = stateDB.WriteChainCrossState("cross-1", "chain2", storetypes.StateCommitSuccess, []byte("result"))
+	require.True(t, chaos.IsInjected(err))
+	state, content, exist := stateDB.ReadChainCrossState("cross-1", "chain2")
+	require.True(t, exist)
+	require.Equal(t, storetypes.StateCommitSuccess, state)
+	require.Equal(t, []byte("result"), content)
+}
@@ -0,0 +1,193 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package store
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	LeaderLeaseName      = "leader" // leader租约名称
+	DefaultLeaseTimeout  = 15       // 默认租约时长，单位：秒
+	DefaultRenewInterval = 5        // 默认续约间隔，单位：秒
+)
+
+// LeaderElector elect the leader of proxies by the lease in the shared state database,
+// the leader renews the lease periodically and the followers take over when it expires
+type LeaderElector struct {
+	sync.RWMutex                     // lock of leader
+	stateDB       StateDB            // 共享存储
+	nodeID        string             // 节点ID
+	leaseTimeout  time.Duration      // 租约时长
+	renewInterval time.Duration      // 续约间隔
+	leader        bool               // 是否为leader
+	renewedAt     time.Time          // 最后一次续约成功的时间
+	onElected     func() error       // 成为leader时的回调，返回错误时放弃leader
+	onRevoked     func()             // 失去leader时的回调
+	stopC         chan struct{}      // 停止信号
+	wg            sync.WaitGroup     // 等待选举任务退出
+	logger        *zap.SugaredLogger // log
+}
+
+// NewLeaderElector create new instance of leader elector, nil will be returned when high-availability is disabled
+func NewLeaderElector(stateDB StateDB, config *conf.HAConfig) *LeaderElector {
+	if config == nil || !config.Enable {
+		return nil
+	}
+	nodeID, leaseTimeout, renewInterval := config.NodeID, config.LeaseTimeout, config.RenewInterval
+	if nodeID == "" {
+		hostname, _ := os.Hostname()
+		nodeID = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	}
+	if leaseTimeout <= 0 {
+		leaseTimeout = DefaultLeaseTimeout
+	}
+	if renewInterval <= 0 || renewInterval >= leaseTimeout {
+		renewInterval = leaseTimeout / 3
+		if renewInterval <= 0 {
+			renewInterval = 1
+		}
+	}
+	return &LeaderElector{
+		stateDB:       stateDB,
+		nodeID:        nodeID,
+		leaseTimeout:  time.Duration(leaseTimeout) * time.Second,
+		renewInterval: time.Duration(renewInterval) * time.Second,
+		stopC:         make(chan struct{}),
+		logger:        logger.GetLogger(logger.ModuleStorage),
+	}
+}
+
+// SetCallbacks set the callbacks which are called when the leadership is gained or lost
+func (e *LeaderElector) SetCallbacks(onElected func() error, onRevoked func()) {
+	e.onElected, e.onRevoked = onElected, onRevoked
+}
+
+// GetNodeID return the id of this node
+func (e *LeaderElector) GetNodeID() string {
+	return e.nodeID
+}
+
+// IsLeader return whether this node is the leader
+func (e *LeaderElector) IsLeader() bool {
+	e.RLock()
+	defer e.RUnlock()
+	return e.leader
+}
+
+// GetLeader return the node id of the current leader, empty string will be returned if there is no valid leader
+func (e *LeaderElector) GetLeader() string {
+	lease, exist := e.stateDB.ReadLease(LeaderLeaseName)
+	if !exist || lease.ExpireAt <= time.Now().UnixNano()/int64(time.Millisecond) {
+		return ""
+	}
+	return lease.Owner
+}
+
+// HoldsLease return whether this node holds the unexpired leader lease, which fences the state writes of the old leader
+func (e *LeaderElector) HoldsLease() bool {
+	return e.GetLeader() == e.nodeID
+}
+
+// Start start electing periodically
+func (e *LeaderElector) Start() {
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		ticker := time.NewTicker(e.renewInterval)
+		defer ticker.Stop()
+		for {
+			e.RunOnce()
+			select {
+			case <-ticker.C:
+			case <-e.stopC:
+				return
+			}
+		}
+	}()
+	e.logger.Infof("leader elector of node[%s] started, lease timeout %v, renew interval %v",
+		e.nodeID, e.leaseTimeout, e.renewInterval)
+}
+
+// Stop stop electing, the leadership is given up and the lease is released after the revoked callback returns,
+// so the followers take over immediately
+func (e *LeaderElector) Stop() {
+	close(e.stopC)
+	e.wg.Wait()
+	if e.IsLeader() {
+		e.revoke()
+		if err := e.stateDB.ReleaseLease(LeaderLeaseName, e.nodeID); err != nil {
+			e.logger.Warnf("release leader lease of node[%s] error, %v", e.nodeID, err)
+		}
+	}
+	e.logger.Info("leader elector stopped")
+}
+
+// RunOnce acquire or renew the lease once, return whether this node is the leader after that
+func (e *LeaderElector) RunOnce() bool {
+	acquired, err := e.stateDB.AcquireLease(LeaderLeaseName, e.nodeID, e.leaseTimeout)
+	isLeader := e.IsLeader()
+	if err != nil {
+		// 存储异常时，在租约确定有效期内保持leader，避免短暂异常导致切换
+		if isLeader && time.Since(e.renewedAt) < e.leaseTimeout-e.renewInterval {
+			e.logger.Warnf("renew leader lease of node[%s] error, %v", e.nodeID, err)
+			return true
+		}
+		e.logger.Errorf("acquire leader lease of node[%s] error, %v", e.nodeID, err)
+		acquired = false
+	}
+	switch {
+	case acquired && !isLeader:
+		e.elect()
+	case acquired:
+		e.setLeader(true)
+	case isLeader:
+		e.logger.Warnf("node[%s] lost the leader lease, leader is [%s] now", e.nodeID, e.GetLeader())
+		e.revoke()
+	}
+	return e.IsLeader()
+}
+
+// elect become the leader, the lease is released if the callback fails, so other node can take over
+func (e *LeaderElector) elect() {
+	e.logger.Infof("node[%s] is elected as leader", e.nodeID)
+	e.setLeader(true)
+	if e.onElected == nil {
+		return
+	}
+	if err := e.onElected(); err != nil {
+		e.logger.Errorf("node[%s] gives up leader, %v", e.nodeID, err)
+		e.setLeader(false)
+		if err = e.stateDB.ReleaseLease(LeaderLeaseName, e.nodeID); err != nil {
+			e.logger.Warnf("release leader lease of node[%s] error, %v", e.nodeID, err)
+		}
+	}
+}
+
+// revoke become the follower
+func (e *LeaderElector) revoke() {
+	e.setLeader(false)
+	if e.onRevoked != nil {
+		e.onRevoked()
+	}
+	e.logger.Infof("node[%s] becomes follower", e.nodeID)
+}
+
+func (e *LeaderElector) setLeader(leader bool) {
+	e.Lock()
+	defer e.Unlock()
+	e.leader = leader
+	if leader {
+		e.renewedAt = time.Now()
+	}
+}
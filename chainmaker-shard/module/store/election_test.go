@@ -0,0 +1,78 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package store
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/store/kvdb"
+	"chainmaker.org/chainmaker-cross/store/kvdb/memory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLeaderElector(t *testing.T) {
+	stateDB := kvdb.NewKvStateDB(memory.NewMemProvider())
+	require.Nil(t, NewLeaderElector(stateDB, nil))
+	require.Nil(t, NewLeaderElector(stateDB, &conf.HAConfig{}))
+	elector := NewLeaderElector(stateDB, &conf.HAConfig{Enable: true})
+	require.NotNil(t, elector)
+	require.NotEmpty(t, elector.GetNodeID())
+	require.Equal(t, DefaultLeaseTimeout*time.Second, elector.leaseTimeout)
+	require.Equal(t, DefaultRenewInterval*time.Second, elector.renewInterval)
+}
+
+func TestLeaderElector_Failover(t *testing.T) {
+	// 两个节点共享同一个存储
+	stateDB := kvdb.NewKvStateDB(memory.NewMemProvider())
+	defer stateDB.Close()
+	haConfig := &conf.HAConfig{Enable: true, NodeID: "node1", LeaseTimeout: 1, RenewInterval: 1}
+	node1 := NewLeaderElector(stateDB, haConfig)
+	haConfig.NodeID = "node2"
+	node2 := NewLeaderElector(stateDB, haConfig)
+	var (
+		elected, revoked  []string
+		leaseHeldOnRevoke bool
+	)
+	node1.SetCallbacks(func() error { elected = append(elected, "node1"); return nil },
+		func() { revoked = append(revoked, "node1") })
+	node2.SetCallbacks(func() error { elected = append(elected, "node2"); return nil },
+		func() { revoked, leaseHeldOnRevoke = append(revoked, "node2"), node2.HoldsLease() })
+
+	require.True(t, node1.RunOnce())
+	require.False(t, node2.RunOnce())
+	require.True(t, node1.RunOnce())
+	require.Equal(t, "node1", node2.GetLeader())
+	require.Equal(t, []string{"node1"}, elected)
+	require.True(t, node1.HoldsLease())
+	require.False(t, node2.HoldsLease())
+
+	// node1失效，租约过期后node2接管
+	time.Sleep(node1.leaseTimeout)
+	require.True(t, node2.RunOnce())
+	require.False(t, node1.RunOnce())
+	require.Equal(t, []string{"node1", "node2"}, elected)
+	require.Equal(t, []string{"node1"}, revoked)
+	// 旧leader的写入被租约拦截
+	require.False(t, node1.HoldsLease())
+	require.True(t, node2.HoldsLease())
+
+	// 正常退出时，停止回调返回后才释放租约，node1立即接管
+	node2.Stop()
+	require.Equal(t, []string{"node1", "node2"}, revoked)
+	require.True(t, leaseHeldOnRevoke)
+	require.False(t, node2.HoldsLease())
+	require.True(t, node1.RunOnce())
+
+	// 成为leader时回调失败则放弃并释放租约
+	node1.setLeader(false)
+	require.Nil(t, stateDB.ReleaseLease(LeaderLeaseName, "node1"))
+	node1.SetCallbacks(func() error { return errors.New("start failed") }, nil)
+	require.False(t, node1.RunOnce())
+	require.Equal(t, "", node2.GetLeader())
+}
@@ -0,0 +1,215 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package badgerdb
+
+import (
+	"fmt"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/logger"
+	types "chainmaker.org/chainmaker-cross/store/kvdb/types"
+	"github.com/dgraph-io/badger/v2"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const valueLogGCDiscardRatio = 0.5
+
+// BadgerProvider provides handle to the embedded badger database
+type BadgerProvider struct {
+	db     *badger.DB         // badger
+	logger *zap.SugaredLogger // log
+}
+
+// NewBadgerProvider create new instance of badger provider by config
+func NewBadgerProvider(badgerConf *conf.BadgerConfig) *BadgerProvider {
+	if badgerConf == nil {
+		panic("can not create badger because it's config is nil")
+	}
+	log := logger.GetLogger(logger.ModuleStorage)
+	dbOpts := badger.DefaultOptions(conf.FinalCfgPath(badgerConf.StorePath)).
+		WithSyncWrites(badgerConf.SyncWrites).
+		WithLogger(&badgerLogger{log})
+	db, err := badger.Open(dbOpts)
+	if err != nil {
+		panic(fmt.Sprintf("Error opening badgerprovider: %s", err))
+	}
+	return &BadgerProvider{
+		db:     db,
+		logger: log,
+	}
+}
+
+// Get return value by key
+func (b *BadgerProvider) Get(key string) ([]byte, bool) {
+	var value []byte
+	err := b.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte(key))
+		if err != nil {
+			return err
+		}
+		value, err = item.ValueCopy(nil)
+		return err
+	})
+	if err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+// Put put key and value
+func (b *BadgerProvider) Put(key string, value []byte) error {
+	if key == "" {
+		return errors.New("error writing badger with nil key")
+	}
+	if value == nil {
+		return errors.New("error writing badger with nil value")
+	}
+	return b.db.Update(func(txn *badger.Txn) error {
+		return txn.Set([]byte(key), value)
+	})
+}
+
+// Has return true if this key been existed
+func (b *BadgerProvider) Has(key string) (bool, error) {
+	err := b.db.View(func(txn *badger.Txn) error {
+		_, err := txn.Get([]byte(key))
+		return err
+	})
+	if err == badger.ErrKeyNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Delete delete key from data base
+func (b *BadgerProvider) Delete(key string) error {
+	return b.db.Update(func(txn *badger.Txn) error {
+		return txn.Delete([]byte(key))
+	})
+}
+
+// WriteBatch write batch into database in one transaction
+func (b *BadgerProvider) WriteBatch(batch *types.KvDBBatcher) error {
+	if batch.Len() == 0 {
+		return errors.New("error writing with nil batch")
+	}
+	return b.db.Update(func(txn *badger.Txn) error {
+		for _, kv := range batch.GetKvs() {
+			dbKey, dbValue := []byte(kv.GetKey()), kv.GetValue()
+			var err error
+			if dbValue == nil {
+				// 表示删除
+				err = txn.Delete(dbKey)
+			} else {
+				err = txn.Set(dbKey, dbValue)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// NewIterator return the iterator of key-values in range [start, limit)
+func (b *BadgerProvider) NewIterator(start, limit string) types.Iterator {
+	txn := b.db.NewTransaction(false)
+	iter := txn.NewIterator(badger.DefaultIteratorOptions)
+	iter.Seek([]byte(start))
+	return &badgerIterator{
+		txn:   txn,
+		iter:  iter,
+		limit: limit,
+	}
+}
+
+// Compact flatten the LSM tree and rewrite the value log files to reclaim the space of deleted keys
+func (b *BadgerProvider) Compact() error {
+	if err := b.db.Flatten(1); err != nil {
+		return err
+	}
+	for {
+		// 每次回收一个value log文件，无可回收文件时返回ErrNoRewrite
+		if err := b.db.RunValueLogGC(valueLogGCDiscardRatio); err != nil {
+			if err == badger.ErrNoRewrite {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// Close close the badger
+func (b *BadgerProvider) Close() {
+	if err := b.db.Close(); err != nil {
+		b.logger.Error("close badger failed", err)
+	}
+	b.logger.Info("Module storage stopped")
+}
+
+// badgerIterator wrapper of badger iterator, the read-only transaction is discarded when released
+type badgerIterator struct {
+	txn     *badger.Txn      // 只读事务
+	iter    *badger.Iterator // badger iterator
+	limit   string           // 上限，不包含
+	started bool             // 是否已移动到第一个key
+	value   []byte           // 当前的值
+	err     error            // 读取值的错误
+}
+
+// Next move to the next key-value
+func (it *badgerIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if it.started {
+		it.iter.Next()
+	}
+	it.started = true
+	if !it.iter.Valid() {
+		return false
+	}
+	if it.limit != "" && it.Key() >= it.limit {
+		return false
+	}
+	it.value, it.err = it.iter.Item().ValueCopy(nil)
+	return it.err == nil
+}
+
+// Key return key of current key-value
+func (it *badgerIterator) Key() string {
+	return string(it.iter.Item().Key())
+}
+
+// Value return copy of value of current key-value
+func (it *badgerIterator) Value() []byte {
+	return it.value
+}
+
+// Error return the error of iterator
+func (it *badgerIterator) Error() error {
+	return it.err
+}
+
+// Release close the iterator and discard the transaction
+func (it *badgerIterator) Release() {
+	it.iter.Close()
+	it.txn.Discard()
+}
+
+// badgerLogger adapt zap logger to the logger of badger
+type badgerLogger struct {
+	*zap.SugaredLogger
+}
+
+// Warningf log the warning of badger
+func (l *badgerLogger) Warningf(template string, args ...interface{}) {
+	l.Warnf(template, args...)
+}
@@ -0,0 +1,73 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package badgerdb
+
+import (
+	"io/ioutil"
+	"os"
+	"strconv"
+	"testing"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	kvdbtypes "chainmaker.org/chainmaker-cross/store/kvdb/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBadgerProvider(t *testing.T) {
+	dbProvider, clean := newBadgerProvider(t)
+	defer clean()
+	// put, get, has and delete
+	require.Nil(t, dbProvider.Put("key", []byte("value")))
+	require.NotNil(t, dbProvider.Put("key", nil))
+	value, exist := dbProvider.Get("key")
+	require.True(t, exist)
+	require.Equal(t, "value", string(value))
+	has, err := dbProvider.Has("key")
+	require.Nil(t, err)
+	require.True(t, has)
+	require.Nil(t, dbProvider.Delete("key"))
+	_, exist = dbProvider.Get("key")
+	require.False(t, exist)
+	has, err = dbProvider.Has("key")
+	require.Nil(t, err)
+	require.False(t, has)
+
+	// write batch, nil value means delete
+	batch := kvdbtypes.NewKvDBBatcher()
+	for i := 0; i < 5; i++ {
+		batch.Add("iter/"+strconv.Itoa(i), []byte("value"+strconv.Itoa(i)))
+	}
+	require.Nil(t, dbProvider.WriteBatch(batch))
+	require.NotNil(t, dbProvider.WriteBatch(kvdbtypes.NewKvDBBatcher()))
+	batch = kvdbtypes.NewKvDBBatcher()
+	batch.Add("iter/2", nil)
+	require.Nil(t, dbProvider.WriteBatch(batch))
+	_, exist = dbProvider.Get("iter/2")
+	require.False(t, exist)
+
+	// iterator
+	iter := dbProvider.NewIterator("iter/1", "iter/4")
+	keys := make([]string, 0)
+	for iter.Next() {
+		keys = append(keys, iter.Key())
+		require.Equal(t, "value"+iter.Key()[len("iter/"):], string(iter.Value()))
+	}
+	require.Nil(t, iter.Error())
+	iter.Release()
+	require.Equal(t, []string{"iter/1", "iter/3"}, keys)
+
+	require.Nil(t, dbProvider.Compact())
+}
+
+func newBadgerProvider(t *testing.T) (*BadgerProvider, func()) {
+	storePath, err := ioutil.TempDir("", "badger")
+	require.Nil(t, err)
+	dbProvider := NewBadgerProvider(&conf.BadgerConfig{StorePath: storePath})
+	return dbProvider, func() {
+		dbProvider.Close()
+		_ = os.RemoveAll(storePath)
+	}
+}
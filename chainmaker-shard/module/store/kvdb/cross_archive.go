@@ -0,0 +1,247 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvdb
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	kvdbtypes "chainmaker.org/chainmaker-cross/store/kvdb/types"
+	storetypes "chainmaker.org/chainmaker-cross/store/types"
+)
+
+const (
+	DefaultArchiveBatchSize = 1000                // 默认每个归档文件的最大跨链事务数
+	ArchiveFileFormat       = "cross-%d.jsonl.gz" // 归档文件名，{纳秒时间戳}
+)
+
+var archivePathError = errors.New("archive path of state database is not configured")
+
+// archiveRecord all the key-values of one archived cross, which is a line of the archive file
+type archiveRecord struct {
+	CrossID string            `json:"cross_id"` // 跨链ID
+	Kvs     map[string][]byte `json:"kvs"`      // 该跨链事务的所有数据
+}
+
+// SetArchivePath set the directory of archive files
+func (k *KvStateDB) SetArchivePath(archivePath string) {
+	k.archivePath = archivePath
+}
+
+// ArchiveCrosses write the finished crosses whose start time and last update are before the time (ms)
+// into a new archive file and delete them from database, at most limit crosses will be archived once.
+// The failed crosses waiting for operator and the crosses without index are skipped
+func (k *KvStateDB) ArchiveCrosses(before int64, limit int) ([]string, error) {
+	if k.archivePath == "" {
+		return nil, archivePathError
+	}
+	if limit <= 0 {
+		limit = DefaultArchiveBatchSize
+	}
+	k.Lock()
+	defer k.Unlock()
+	records, err := k.collectArchiveRecords(before, limit)
+	if err != nil || len(records) == 0 {
+		return nil, err
+	}
+	fileName, err := k.writeArchiveFile(records)
+	if err != nil {
+		return nil, err
+	}
+	// 归档文件写入成功后再删除，删除失败时下次归档会重复写入，恢复时以最新的归档文件为准
+	batch := kvdbtypes.NewKvDBBatcher()
+	crossIDs := make([]string, 0, len(records))
+	for _, record := range records {
+		for key := range record.Kvs {
+			batch.Add(key, nil)
+		}
+		batch.Add(crossArchiveKey(record.CrossID), []byte(fileName))
+		crossIDs = append(crossIDs, record.CrossID)
+	}
+	if err = k.provider.WriteBatch(batch); err != nil {
+		return nil, err
+	}
+	k.logger.Infof("archive %d crosses into file[%s]", len(crossIDs), fileName)
+	return crossIDs, nil
+}
+
+// RestoreCross load the archived cross from archive file and write it back to database,
+// the restored cross can be searched again and will not be archived until the retention passes again
+func (k *KvStateDB) RestoreCross(crossID string) error {
+	if k.archivePath == "" {
+		return archivePathError
+	}
+	k.Lock()
+	defer k.Unlock()
+	fileName, exist := k.provider.Get(crossArchiveKey(crossID))
+	if !exist || len(fileName) == 0 {
+		return fmt.Errorf("cross[%s] is not archived", crossID)
+	}
+	record, err := readArchiveFile(filepath.Join(k.archivePath, string(fileName)), crossID)
+	if err != nil {
+		return err
+	}
+	batch := kvdbtypes.NewKvDBBatcher()
+	for key, value := range record.Kvs {
+		batch.Add(key, value)
+	}
+	if content, ok := record.Kvs[crossIndexKey(crossID)]; ok {
+		// 刷新更新时间，避免恢复后立即被再次归档
+		index := &storetypes.CrossIndex{}
+		if err = json.Unmarshal(content, index); err != nil {
+			return err
+		}
+		index.UpdateTime = currentMillis()
+		if content, err = json.Marshal(index); err != nil {
+			return err
+		}
+		batch.Add(crossIndexKey(crossID), content)
+	}
+	batch.Add(crossArchiveKey(crossID), nil)
+	if err = k.provider.WriteBatch(batch); err != nil {
+		return err
+	}
+	k.logger.Infof("restore cross[%s] from archive file[%s]", crossID, fileName)
+	return nil
+}
+
+// Compact compact the database to reclaim the space of archived crosses
+func (k *KvStateDB) Compact() error {
+	return k.provider.Compact()
+}
+
+// collectArchiveRecords scan the time index and collect the crosses which should be archived
+func (k *KvStateDB) collectArchiveRecords(before int64, limit int) ([]*archiveRecord, error) {
+	// 失败待处理和死信中的跨链事务不归档
+	excluded := make(map[string]struct{})
+	for _, crossID := range append(k.readIDSet(FailedCrossSetKey), k.readIDSet(DeadLetterCrossSetKey)...) {
+		excluded[crossID] = struct{}{}
+	}
+	iter := k.provider.NewIterator(TimeIndexPrefix, TimeIndexPrefix+fmt.Sprintf("%020d", before))
+	defer iter.Release()
+	records := make([]*archiveRecord, 0)
+	for len(records) < limit && iter.Next() {
+		crossID := string(iter.Value())
+		if _, exist := excluded[crossID]; exist {
+			continue
+		}
+		index := k.readIndex(crossID)
+		if index == nil || index.UpdateTime >= before || !k.readCrossState(crossID).IsFinal() {
+			continue
+		}
+		records = append(records, k.readArchiveRecord(index))
+	}
+	return records, iter.Error()
+}
+
+// readArchiveRecord read all the key-values of the cross
+func (k *KvStateDB) readArchiveRecord(index *storetypes.CrossIndex) *archiveRecord {
+	crossID := index.CrossID
+	keys := append(indexKeys(index),
+		crossKey(crossID),
+		crossResultKey(crossID),
+		crossChainsKey(crossID),
+		crossStateKey(crossID),
+		crossHistoryKey(crossID),
+		proveAuditKey(crossID),
+		deadLetterKey(crossID),
+		crossIndexKey(crossID),
+	)
+	chainIDs := index.ChainIDs
+	if ids, exist := k.ReadChainIDs(crossID); exist {
+		chainIDs = append(chainIDs, ids...)
+	}
+	for _, chainID := range chainIDs {
+		keys = append(keys, chainCrossStateKey(crossID, chainID), chainCrossResultKey(crossID, chainID))
+	}
+	record := &archiveRecord{
+		CrossID: crossID,
+		Kvs:     make(map[string][]byte, len(keys)),
+	}
+	for _, key := range keys {
+		if value, exist := k.provider.Get(key); exist {
+			record.Kvs[key] = value
+		}
+	}
+	return record
+}
+
+// writeArchiveFile write the records into a new gzip compressed json-lines file, return the name of file
+func (k *KvStateDB) writeArchiveFile(records []*archiveRecord) (string, error) {
+	if err := os.MkdirAll(k.archivePath, 0755); err != nil {
+		return "", err
+	}
+	fileName := fmt.Sprintf(ArchiveFileFormat, time.Now().UnixNano())
+	filePath := filepath.Join(k.archivePath, fileName)
+	// 先写入临时文件，完成后重命名，避免留下不完整的归档文件
+	tmpPath := filePath + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmpPath)
+	gzipWriter := gzip.NewWriter(file)
+	encoder := json.NewEncoder(gzipWriter)
+	for _, record := range records {
+		if err = encoder.Encode(record); err != nil {
+			_ = file.Close()
+			return "", err
+		}
+	}
+	if err = gzipWriter.Close(); err != nil {
+		_ = file.Close()
+		return "", err
+	}
+	if err = file.Sync(); err != nil {
+		_ = file.Close()
+		return "", err
+	}
+	if err = file.Close(); err != nil {
+		return "", err
+	}
+	return fileName, os.Rename(tmpPath, filePath)
+}
+
+// readArchiveFile find the record of cross in the archive file, the last one is used if archived repeatedly
+func readArchiveFile(filePath, crossID string) (*archiveRecord, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, err
+	}
+	defer gzipReader.Close()
+	decoder := json.NewDecoder(gzipReader)
+	var found *archiveRecord
+	for {
+		record := &archiveRecord{}
+		if err = decoder.Decode(record); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if record.CrossID == crossID {
+			found = record
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("can not find cross[%s] in archive file[%s]", crossID, filePath)
+	}
+	return found, nil
+}
+
+func crossArchiveKey(crossID string) string {
+	return fmt.Sprintf(CrossArchiveFormat, crossID)
+}
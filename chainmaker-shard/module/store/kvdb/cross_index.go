@@ -0,0 +1,215 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	kvdbtypes "chainmaker.org/chainmaker-cross/store/kvdb/types"
+	storetypes "chainmaker.org/chainmaker-cross/store/types"
+)
+
+const (
+	DefaultQueryLimit = 20   // 默认每页数量
+	MaxQueryLimit     = 1000 // 最大每页数量
+)
+
+// IndexCross write the index record and secondary indexes of the cross transaction,
+// the old secondary indexes will be replaced if the cross has been indexed
+func (k *KvStateDB) IndexCross(index *storetypes.CrossIndex) error {
+	if index == nil || index.CrossID == "" {
+		return fmt.Errorf("index of cross is invalid")
+	}
+	k.Lock()
+	defer k.Unlock()
+	now := currentMillis()
+	record := *index
+	if record.StartTime <= 0 {
+		record.StartTime = now
+	}
+	record.State = k.readCrossState(record.CrossID)
+	record.StateName = record.State.String()
+	record.UpdateTime = now
+	batch := kvdbtypes.NewKvDBBatcher()
+	newKeys := make(map[string]struct{})
+	for _, key := range indexKeys(&record) {
+		newKeys[key] = struct{}{}
+	}
+	if old := k.readIndex(record.CrossID); old != nil {
+		// 删除旧的二级索引
+		for _, key := range indexKeys(old) {
+			if _, exist := newKeys[key]; !exist {
+				batch.Add(key, nil)
+			}
+		}
+	}
+	content, err := json.Marshal(&record)
+	if err != nil {
+		return err
+	}
+	batch.Add(crossIndexKey(record.CrossID), content)
+	for key := range newKeys {
+		batch.Add(key, []byte(record.CrossID))
+	}
+	return k.provider.WriteBatch(batch)
+}
+
+// QueryCross search the indexed cross transactions by conditions, the most selective secondary index is used
+// to scan the range of start time, and the other conditions are checked by the index record
+func (k *KvStateDB) QueryCross(query *storetypes.CrossQuery) (*storetypes.CrossQueryResult, error) {
+	if query == nil {
+		query = &storetypes.CrossQuery{}
+	}
+	if query.EndTime > 0 && query.EndTime < query.StartTime {
+		return nil, fmt.Errorf("end time[%d] is before start time[%d]", query.EndTime, query.StartTime)
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = DefaultQueryLimit
+	} else if limit > MaxQueryLimit {
+		limit = MaxQueryLimit
+	}
+	prefix := queryIndexPrefix(query)
+	start := prefix + fmt.Sprintf("%020d", query.StartTime)
+	end := prefix + IndexTimeLimit
+	if query.EndTime > 0 {
+		end = prefix + fmt.Sprintf("%020d", query.EndTime+1)
+	}
+	if query.Cursor != "" {
+		if !strings.HasPrefix(query.Cursor, prefix) {
+			return nil, fmt.Errorf("cursor[%s] does not match the query", query.Cursor)
+		}
+		// 从游标的下一个key开始
+		if cursorStart := query.Cursor + "\x00"; cursorStart > start {
+			start = cursorStart
+		}
+	}
+	iter := k.provider.NewIterator(start, end)
+	defer iter.Release()
+	result := &storetypes.CrossQueryResult{
+		Records: make([]*storetypes.CrossIndex, 0),
+	}
+	var lastKey string
+	for iter.Next() {
+		if len(result.Records) >= limit {
+			// 仍有数据，返回下一页的游标
+			result.NextCursor = lastKey
+			break
+		}
+		lastKey = iter.Key()
+		index := k.readIndex(string(iter.Value()))
+		if index == nil || !matchQuery(index, query) {
+			continue
+		}
+		result.Records = append(result.Records, index)
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// updateIndexState add the new state of the indexed cross to the batch, nothing will be done if not indexed
+func (k *KvStateDB) updateIndexState(batch *kvdbtypes.KvDBBatcher, crossID string, state storetypes.State) {
+	index := k.readIndex(crossID)
+	if index == nil {
+		return
+	}
+	if index.State != state {
+		batch.Add(stateIndexKey(index.State, index.StartTime, crossID), nil)
+		batch.Add(stateIndexKey(state, index.StartTime, crossID), []byte(crossID))
+	}
+	index.State = state
+	index.StateName = state.String()
+	index.UpdateTime = currentMillis()
+	content, err := json.Marshal(index)
+	if err != nil {
+		k.logger.Errorf("marshal index of cross[%s] error, %v", crossID, err)
+		return
+	}
+	batch.Add(crossIndexKey(crossID), content)
+}
+
+// readIndex read the index record of cross, nil will be returned if not indexed
+func (k *KvStateDB) readIndex(crossID string) *storetypes.CrossIndex {
+	content, exist := k.provider.Get(crossIndexKey(crossID))
+	if !exist || len(content) == 0 {
+		return nil
+	}
+	index := &storetypes.CrossIndex{}
+	if err := json.Unmarshal(content, index); err != nil {
+		k.logger.Errorf("unmarshal index of cross[%s] error, %v", crossID, err)
+		return nil
+	}
+	return index
+}
+
+// indexKeys return all the secondary index keys of the index record
+func indexKeys(index *storetypes.CrossIndex) []string {
+	suffix := fmt.Sprintf(IndexTimeFormat, index.StartTime, index.CrossID)
+	keys := []string{
+		TimeIndexPrefix + suffix,
+		stateIndexKey(index.State, index.StartTime, index.CrossID),
+	}
+	for _, chainID := range index.ChainIDs {
+		keys = append(keys, fmt.Sprintf(ChainIndexFormat, chainID)+suffix)
+	}
+	if index.Initiator != "" {
+		keys = append(keys, fmt.Sprintf(InitiatorIndexFormat, index.Initiator)+suffix)
+	}
+	return keys
+}
+
+// queryIndexPrefix return the prefix of the secondary index which is used by the query
+func queryIndexPrefix(query *storetypes.CrossQuery) string {
+	if query.ChainID != "" {
+		return fmt.Sprintf(ChainIndexFormat, query.ChainID)
+	}
+	if query.Initiator != "" {
+		return fmt.Sprintf(InitiatorIndexFormat, query.Initiator)
+	}
+	if query.State != storetypes.StateUnknown {
+		return fmt.Sprintf(StateIndexFormat, query.State)
+	}
+	return TimeIndexPrefix
+}
+
+// matchQuery check whether the index record matches all the conditions of query
+func matchQuery(index *storetypes.CrossIndex, query *storetypes.CrossQuery) bool {
+	if query.State != storetypes.StateUnknown && index.State != query.State {
+		return false
+	}
+	if query.Initiator != "" && index.Initiator != query.Initiator {
+		return false
+	}
+	if index.StartTime < query.StartTime || (query.EndTime > 0 && index.StartTime > query.EndTime) {
+		return false
+	}
+	if query.ChainID == "" {
+		return true
+	}
+	for _, chainID := range index.ChainIDs {
+		if chainID == query.ChainID {
+			return true
+		}
+	}
+	return false
+}
+
+func crossIndexKey(crossID string) string {
+	return fmt.Sprintf(CrossIndexFormat, crossID)
+}
+
+func stateIndexKey(state storetypes.State, startTime int64, crossID string) string {
+	return fmt.Sprintf(StateIndexFormat, state) + fmt.Sprintf(IndexTimeFormat, startTime, crossID)
+}
+
+func currentMillis() int64 {
+	return time.Now().UnixNano() / int64(time.Millisecond)
+}
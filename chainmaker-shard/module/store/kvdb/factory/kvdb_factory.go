@@ -9,27 +9,42 @@ import (
 	"errors"
 
 	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/store/kvdb/badgerdb"
 	"chainmaker.org/chainmaker-cross/store/kvdb/leveldb"
 	"chainmaker.org/chainmaker-cross/store/kvdb/memory"
+	"chainmaker.org/chainmaker-cross/store/kvdb/sqldb"
 	"chainmaker.org/chainmaker-cross/store/kvdb/types"
 	storetypes "chainmaker.org/chainmaker-cross/store/types"
 )
 
 var (
 	levelDBConfigError       = errors.New("create leveldb error by config")
+	badgerConfigError        = errors.New("create badger error by config")
+	sqlConfigError           = errors.New("create sql provider error by config")
 	unsupportedProviderError = errors.New("can not support this provider")
 )
 
 // NewKvDBProvider create new kvdb provider instance by provider type
 func NewKvDBProvider(provider storetypes.StateDBProvider, config interface{}) (types.KvDBProvider, error) {
-	if provider == storetypes.LevelDB {
-		if dbConf, ok := config.(*conf.LevelDBConfig); ok {
+	switch provider {
+	case storetypes.LevelDB:
+		if dbConf, ok := config.(*conf.LevelDBConfig); ok && dbConf != nil {
 			return leveldb.NewLevelDBProvider(dbConf), nil
-		} else {
-			return nil, levelDBConfigError
 		}
-	} else if provider == storetypes.Memory {
+		return nil, levelDBConfigError
+	case storetypes.Badger:
+		if dbConf, ok := config.(*conf.BadgerConfig); ok && dbConf != nil {
+			return badgerdb.NewBadgerProvider(dbConf), nil
+		}
+		return nil, badgerConfigError
+	case storetypes.SQL:
+		if dbConf, ok := config.(*conf.SQLConfig); ok && dbConf != nil {
+			return sqldb.NewSQLProvider(dbConf), nil
+		}
+		return nil, sqlConfigError
+	case storetypes.Memory:
 		return memory.NewMemProvider(), nil
+	default:
+		return nil, unsupportedProviderError
 	}
-	return nil, unsupportedProviderError
 }
@@ -15,7 +15,9 @@ import (
 	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/filter"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
 	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
 	"go.uber.org/zap"
 )
 
@@ -124,6 +126,20 @@ func (l *LevelDBProvider) WriteBatch(batch *types.KvDBBatcher) error {
 	return nil
 }
 
+// NewIterator return the iterator of key-values in range [start, limit)
+func (l *LevelDBProvider) NewIterator(start, limit string) types.Iterator {
+	keyRange := &util.Range{Start: []byte(start)}
+	if limit != "" {
+		keyRange.Limit = []byte(limit)
+	}
+	return &levelDBIterator{iter: l.db.NewIterator(keyRange, nil)}
+}
+
+// Compact compact the whole key range of leveldb, the space of deleted keys will be reclaimed
+func (l *LevelDBProvider) Compact() error {
+	return l.db.CompactRange(util.Range{})
+}
+
 // Close close the leveldb
 func (l *LevelDBProvider) Close() {
 	if err := l.db.Close(); err != nil {
@@ -131,3 +147,37 @@ func (l *LevelDBProvider) Close() {
 	}
 	l.logger.Info("Module storage stopped")
 }
+
+// levelDBIterator wrapper of leveldb iterator
+type levelDBIterator struct {
+	iter iterator.Iterator
+}
+
+// Next move to the next key-value
+func (it *levelDBIterator) Next() bool {
+	return it.iter.Next()
+}
+
+// Key return key of current key-value
+func (it *levelDBIterator) Key() string {
+	return string(it.iter.Key())
+}
+
+// Value return copy of value of current key-value, the buffer of leveldb may be reused
+func (it *levelDBIterator) Value() []byte {
+	value := it.iter.Value()
+	if value == nil {
+		return nil
+	}
+	return append([]byte{}, value...)
+}
+
+// Error return the error of iterator
+func (it *levelDBIterator) Error() error {
+	return it.iter.Error()
+}
+
+// Release release the iterator
+func (it *levelDBIterator) Release() {
+	it.iter.Release()
+}
@@ -117,6 +117,32 @@ func TestLevelDBProvider_WriteBatch(t *testing.T) {
 	//dbProvider.Close()
 }
 
+func TestLevelDBProvider_Iterator(t *testing.T) {
+	levelDBConfig := newLevelDBConfig()
+	dbProvider := NewLevelDBProvider(levelDBConfig)
+	defer dbProvider.Close()
+	for i := 0; i < 5; i++ {
+		if err := dbProvider.Put("iter/"+strconv.Itoa(i), []byte("value"+strconv.Itoa(i))); err != nil {
+			t.Errorf("put iter/%d failed", i)
+		}
+	}
+	iter := dbProvider.NewIterator("iter/1", "iter/4")
+	defer iter.Release()
+	keys := make([]string, 0)
+	for iter.Next() {
+		keys = append(keys, iter.Key())
+		if string(iter.Value()) != "value"+iter.Key()[len("iter/"):] {
+			t.Errorf("%s 's value is not right", iter.Key())
+		}
+	}
+	if iter.Error() != nil {
+		t.Errorf("iterate error %s", iter.Error())
+	}
+	if len(keys) != 3 || keys[0] != "iter/1" || keys[2] != "iter/3" {
+		t.Errorf("iterate keys %v are not right", keys)
+	}
+}
+
 func TestLevelDBProvider_Close(t *testing.T) {
 	levelDBConfig := newLevelDBConfig()
 	dbProvider := NewLevelDBProvider(levelDBConfig)
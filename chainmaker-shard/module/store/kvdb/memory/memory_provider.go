@@ -6,6 +6,7 @@
 package memory
 
 import (
+	"sort"
 	"sync"
 
 	kvdbtypes "chainmaker.org/chainmaker-cross/store/kvdb/types"
@@ -14,13 +15,21 @@ import (
 // MemProvider storage of memory
 type MemProvider struct {
 	sync.RWMutex
-	cache map[string][]byte
+	cache  map[string][]byte
+	leases map[string]*memLease
+}
+
+// memLease lease in memory, which can only be shared by the proxies in the same process
+type memLease struct {
+	owner    string
+	expireAt int64
 }
 
 // NewMemProvider create new memory provider
 func NewMemProvider() *MemProvider {
 	return &MemProvider{
-		cache: make(map[string][]byte),
+		cache:  make(map[string][]byte),
+		leases: make(map[string]*memLease),
 	}
 }
 
@@ -63,12 +72,112 @@ func (m *MemProvider) WriteBatch(batch *kvdbtypes.KvDBBatcher) error {
 	defer m.Unlock()
 	for i := 0; i < len(kvs); i++ {
 		kv := kvs[i]
+		if kv.GetValue() == nil {
+			// 表示删除
+			delete(m.cache, kv.GetKey())
+			continue
+		}
 		m.cache[kv.GetKey()] = kv.GetValue()
 	}
 	return nil
 }
 
+// NewIterator return the iterator of the snapshot of key-values in range [start, limit)
+func (m *MemProvider) NewIterator(start, limit string) kvdbtypes.Iterator {
+	m.RLock()
+	defer m.RUnlock()
+	kvs := make([]*kvdbtypes.Kv, 0)
+	for key, value := range m.cache {
+		if key >= start && (limit == "" || key < limit) {
+			kvs = append(kvs, kvdbtypes.NewKv(key, value))
+		}
+	}
+	sort.Slice(kvs, func(i, j int) bool {
+		return kvs[i].GetKey() < kvs[j].GetKey()
+	})
+	return &memIterator{kvs: kvs, index: -1}
+}
+
+// Compact nothing to do for memory
+func (m *MemProvider) Compact() error {
+	return nil
+}
+
+// AcquireLease acquire or renew the lease for owner if it is not held by others
+func (m *MemProvider) AcquireLease(name, owner string, now, expireAt int64) (bool, error) {
+	m.Lock()
+	defer m.Unlock()
+	if lease, exist := m.leases[name]; exist && lease.owner != owner && lease.expireAt > now {
+		return false, nil
+	}
+	m.leases[name] = &memLease{owner: owner, expireAt: expireAt}
+	return true, nil
+}
+
+// ReleaseLease expire the lease if it is held by owner
+func (m *MemProvider) ReleaseLease(name, owner string) error {
+	m.Lock()
+	defer m.Unlock()
+	if lease, exist := m.leases[name]; exist && lease.owner == owner {
+		lease.expireAt = 0
+	}
+	return nil
+}
+
+// ReadLease read the owner and expire time of the lease
+func (m *MemProvider) ReadLease(name string) (string, int64, bool) {
+	m.RLock()
+	defer m.RUnlock()
+	lease, exist := m.leases[name]
+	if !exist {
+		return "", 0, false
+	}
+	return lease.owner, lease.expireAt, true
+}
+
 // Close clear the memory
 func (m *MemProvider) Close() {
 	m.cache = nil
 }
+
+// memIterator iterator of the snapshot of memory
+type memIterator struct {
+	kvs   []*kvdbtypes.Kv
+	index int
+}
+
+// Next move to the next key-value
+func (it *memIterator) Next() bool {
+	if it.index+1 >= len(it.kvs) {
+		it.index = len(it.kvs)
+		return false
+	}
+	it.index++
+	return true
+}
+
+// Key return key of current key-value
+func (it *memIterator) Key() string {
+	if it.index < 0 || it.index >= len(it.kvs) {
+		return ""
+	}
+	return it.kvs[it.index].GetKey()
+}
+
+// Value return value of current key-value
+func (it *memIterator) Value() []byte {
+	if it.index < 0 || it.index >= len(it.kvs) {
+		return nil
+	}
+	return it.kvs[it.index].GetValue()
+}
+
+// Error always return nil
+func (it *memIterator) Error() error {
+	return nil
+}
+
+// Release release the snapshot
+func (it *memIterator) Release() {
+	it.kvs = nil
+}
@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"testing"
 
+	kvdbtypes "chainmaker.org/chainmaker-cross/store/kvdb/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -79,3 +80,36 @@ func TestMemProvider(t *testing.T) {
 	// 完成后关闭
 	mp.Close()
 }
+
+func TestMemProvider_Iterator(t *testing.T) {
+	mp := NewMemProvider()
+	defer mp.Close()
+	batch := kvdbtypes.NewKvDBBatcher()
+	for i := 0; i < 5; i++ {
+		batch.Add("iter/"+strconv.Itoa(i), []byte("value"+strconv.Itoa(i)))
+	}
+	require.Nil(t, mp.WriteBatch(batch))
+	// nil value in batch means delete
+	batch = kvdbtypes.NewKvDBBatcher()
+	batch.Add("iter/2", nil)
+	require.Nil(t, mp.WriteBatch(batch))
+
+	iter := mp.NewIterator("iter/1", "iter/4")
+	defer iter.Release()
+	keys := make([]string, 0)
+	for iter.Next() {
+		keys = append(keys, iter.Key())
+		require.Equal(t, "value"+iter.Key()[len("iter/"):], string(iter.Value()))
+	}
+	require.Nil(t, iter.Error())
+	require.Equal(t, []string{"iter/1", "iter/3"}, keys)
+
+	// empty limit means no upper bound
+	iter = mp.NewIterator("iter/3", "")
+	defer iter.Release()
+	keys = keys[:0]
+	for iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	require.Equal(t, []string{"iter/3", "iter/4"}, keys)
+}
@@ -0,0 +1,87 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package sqldb
+
+import (
+	"database/sql"
+	"fmt"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	types "chainmaker.org/chainmaker-cross/store/kvdb/types"
+)
+
+// LeaseTableSuffix the lease table is named as {table}_lease
+const LeaseTableSuffix = "_lease"
+
+var _ types.LeaseProvider = (*SQLProvider)(nil)
+
+// createLeaseTableSQL the lease is stored in a separate table, so it can be updated conditionally in one statement
+var createLeaseTableSQL = map[string]string{
+	conf.SQLDriverMySQL:  "CREATE TABLE IF NOT EXISTS %s (name VARCHAR(128) NOT NULL PRIMARY KEY, owner VARCHAR(255) NOT NULL, expire_at BIGINT NOT NULL)",
+	conf.SQLDriverSQLite: "CREATE TABLE IF NOT EXISTS %s (name TEXT NOT NULL PRIMARY KEY, owner TEXT NOT NULL, expire_at INTEGER NOT NULL)",
+}
+
+// insertLeaseSQL insert the lease only when it does not exist
+var insertLeaseSQL = map[string]string{
+	conf.SQLDriverMySQL:  "INSERT IGNORE INTO %s (name, owner, expire_at) VALUES (?, ?, ?)",
+	conf.SQLDriverSQLite: "INSERT OR IGNORE INTO %s (name, owner, expire_at) VALUES (?, ?, ?)",
+}
+
+// AcquireLease acquire or renew the lease by conditional update, the database guarantees only one owner succeeds
+func (s *SQLProvider) AcquireLease(name, owner string, now, expireAt int64) (bool, error) {
+	table := leaseTable(s.table)
+	result, err := s.db.Exec(fmt.Sprintf("UPDATE %s SET owner = ?, expire_at = ? WHERE name = ? AND (owner = ? OR expire_at <= ?)",
+		table), owner, expireAt, name, owner, now)
+	if err != nil {
+		return false, err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return false, err
+	} else if affected > 0 {
+		return true, nil
+	}
+	// 租约不存在时插入
+	result, err = s.db.Exec(fmt.Sprintf(insertLeaseSQL[s.driver], table), name, owner, expireAt)
+	if err != nil {
+		return false, err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return false, err
+	} else if affected > 0 {
+		return true, nil
+	}
+	// mysql在值未变化时返回的影响行数为0，以当前持有者为准
+	currentOwner, _, exist := s.ReadLease(name)
+	return exist && currentOwner == owner, nil
+}
+
+// ReleaseLease expire the lease immediately if it is held by owner
+func (s *SQLProvider) ReleaseLease(name, owner string) error {
+	_, err := s.db.Exec(fmt.Sprintf("UPDATE %s SET expire_at = 0 WHERE name = ? AND owner = ?", leaseTable(s.table)),
+		name, owner)
+	return err
+}
+
+// ReadLease read the owner and expire time of the lease
+func (s *SQLProvider) ReadLease(name string) (string, int64, bool) {
+	var (
+		owner    string
+		expireAt int64
+	)
+	err := s.db.QueryRow(fmt.Sprintf("SELECT owner, expire_at FROM %s WHERE name = ?", leaseTable(s.table)), name).
+		Scan(&owner, &expireAt)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			s.logger.Errorf("read lease[%s] error, %v", name, err)
+		}
+		return "", 0, false
+	}
+	return owner, expireAt, true
+}
+
+func leaseTable(table string) string {
+	return table + LeaseTableSuffix
+}
@@ -0,0 +1,59 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package sqldb
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLProvider_Lease(t *testing.T) {
+	storePath, err := ioutil.TempDir("", "sqlite")
+	require.Nil(t, err)
+	defer os.RemoveAll(storePath)
+	// 两个节点共享同一个数据库文件
+	sqlConf := &conf.SQLConfig{
+		Driver: conf.SQLDriverSQLite,
+		DSN:    filepath.Join(storePath, "cross.db"),
+	}
+	dbProvider, other := NewSQLProvider(sqlConf), NewSQLProvider(sqlConf)
+	defer dbProvider.Close()
+	defer other.Close()
+
+	_, _, exist := dbProvider.ReadLease("leader")
+	require.False(t, exist)
+	acquired, err := dbProvider.AcquireLease("leader", "node1", 1000, 2000)
+	require.Nil(t, err)
+	require.True(t, acquired)
+	// 未过期时其他节点无法抢占，持有者可以续约
+	acquired, err = other.AcquireLease("leader", "node2", 1500, 2500)
+	require.Nil(t, err)
+	require.False(t, acquired)
+	acquired, err = dbProvider.AcquireLease("leader", "node1", 1500, 2500)
+	require.Nil(t, err)
+	require.True(t, acquired)
+	owner, expireAt, exist := other.ReadLease("leader")
+	require.True(t, exist)
+	require.Equal(t, "node1", owner)
+	require.Equal(t, int64(2500), expireAt)
+	// 过期后其他节点接管
+	acquired, err = other.AcquireLease("leader", "node2", 2500, 3500)
+	require.Nil(t, err)
+	require.True(t, acquired)
+	// 释放后立即可被抢占
+	require.Nil(t, dbProvider.ReleaseLease("leader", "node1"))
+	owner, _, _ = dbProvider.ReadLease("leader")
+	require.Equal(t, "node2", owner)
+	require.Nil(t, other.ReleaseLease("leader", "node2"))
+	acquired, err = dbProvider.AcquireLease("leader", "node1", 3000, 4000)
+	require.Nil(t, err)
+	require.True(t, acquired)
+}
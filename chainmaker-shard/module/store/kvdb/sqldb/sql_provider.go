@@ -0,0 +1,278 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package sqldb
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/logger"
+	types "chainmaker.org/chainmaker-cross/store/kvdb/types"
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultTable     = "cross_state" // 默认表名
+	IteratorPageSize = 256           // 迭代器每次加载的数量
+)
+
+var tableNamePattern = regexp.MustCompile("^[A-Za-z_][A-Za-z0-9_]*$")
+
+// createTableSQL key使用二进制比较，保证与leveldb相同的key顺序
+var createTableSQL = map[string]string{
+	conf.SQLDriverMySQL:  "CREATE TABLE IF NOT EXISTS %s (k VARBINARY(512) NOT NULL PRIMARY KEY, v LONGBLOB NOT NULL)",
+	conf.SQLDriverSQLite: "CREATE TABLE IF NOT EXISTS %s (k TEXT NOT NULL PRIMARY KEY, v BLOB NOT NULL)",
+}
+
+// compactSQL reclaim the space of deleted rows, sqlite vacuums the whole database rather than one table
+var compactSQL = map[string]string{
+	conf.SQLDriverMySQL:  "OPTIMIZE TABLE %s",
+	conf.SQLDriverSQLite: "VACUUM",
+}
+
+// SQLProvider provides handle to sql database, which stores the key-values in one table,
+// so multiple operators can inspect the state by sql client
+type SQLProvider struct {
+	db     *sql.DB            // 数据库
+	driver string             // 数据库驱动
+	table  string             // 表名
+	logger *zap.SugaredLogger // log
+}
+
+// NewSQLProvider create new instance of sql provider by config, the table will be created if not exist
+func NewSQLProvider(sqlConf *conf.SQLConfig) *SQLProvider {
+	if sqlConf == nil {
+		panic("can not create sql provider because it's config is nil")
+	}
+	createSQL, ok := createTableSQL[sqlConf.Driver]
+	if !ok {
+		panic(fmt.Sprintf("unsupported sql driver: %s", sqlConf.Driver))
+	}
+	table := sqlConf.Table
+	if table == "" {
+		table = DefaultTable
+	}
+	if !tableNamePattern.MatchString(table) {
+		panic(fmt.Sprintf("invalid table name of sql provider: %s", table))
+	}
+	db, err := sql.Open(sqlConf.Driver, sqlConf.DSN)
+	if err != nil {
+		panic(fmt.Sprintf("Error opening sqlprovider: %s", err))
+	}
+	if sqlConf.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(sqlConf.MaxOpenConns)
+	}
+	if _, err = db.Exec(fmt.Sprintf(createSQL, table)); err != nil {
+		_ = db.Close()
+		panic(fmt.Sprintf("Error creating table of sqlprovider: %s", err))
+	}
+	if _, err = db.Exec(fmt.Sprintf(createLeaseTableSQL[sqlConf.Driver], leaseTable(table))); err != nil {
+		_ = db.Close()
+		panic(fmt.Sprintf("Error creating lease table of sqlprovider: %s", err))
+	}
+	return &SQLProvider{
+		db:     db,
+		driver: sqlConf.Driver,
+		table:  table,
+		logger: logger.GetLogger(logger.ModuleStorage),
+	}
+}
+
+// Get return value by key
+func (s *SQLProvider) Get(key string) ([]byte, bool) {
+	var value []byte
+	err := s.db.QueryRow(fmt.Sprintf("SELECT v FROM %s WHERE k = ?", s.table), key).Scan(&value)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			s.logger.Errorf("get value of key[%s] error, %v", key, err)
+		}
+		return nil, false
+	}
+	return value, true
+}
+
+// Put put key and value
+func (s *SQLProvider) Put(key string, value []byte) error {
+	if key == "" {
+		return errors.New("error writing sql with nil key")
+	}
+	if value == nil {
+		return errors.New("error writing sql with nil value")
+	}
+	_, err := s.db.Exec(s.replaceSQL(), key, value)
+	return err
+}
+
+// Has return true if this key been existed
+func (s *SQLProvider) Has(key string) (bool, error) {
+	var count int
+	err := s.db.QueryRow(fmt.Sprintf("SELECT COUNT(1) FROM %s WHERE k = ?", s.table), key).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Delete delete key from data base
+func (s *SQLProvider) Delete(key string) error {
+	_, err := s.db.Exec(s.deleteSQL(), key)
+	return err
+}
+
+// WriteBatch write batch into database in one transaction
+func (s *SQLProvider) WriteBatch(batch *types.KvDBBatcher) error {
+	if batch.Len() == 0 {
+		return errors.New("error writing with nil batch")
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, kv := range batch.GetKvs() {
+		if kv.GetValue() == nil {
+			// 表示删除
+			_, err = tx.Exec(s.deleteSQL(), kv.GetKey())
+		} else {
+			_, err = tx.Exec(s.replaceSQL(), kv.GetKey(), kv.GetValue())
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// NewIterator return the iterator of key-values in range [start, limit), the key-values are loaded page by page,
+// so the connection will not be held while iterating
+func (s *SQLProvider) NewIterator(start, limit string) types.Iterator {
+	return &sqlIterator{
+		provider: s,
+		start:    start,
+		limit:    limit,
+		index:    -1,
+	}
+}
+
+// Compact reclaim the space of deleted rows
+func (s *SQLProvider) Compact() error {
+	query := compactSQL[s.driver]
+	if strings.Contains(query, "%s") {
+		query = fmt.Sprintf(query, s.table)
+	}
+	_, err := s.db.Exec(query)
+	return err
+}
+
+// Close close the database
+func (s *SQLProvider) Close() {
+	if err := s.db.Close(); err != nil {
+		s.logger.Error("close sql database failed", err)
+	}
+	s.logger.Info("Module storage stopped")
+}
+
+func (s *SQLProvider) replaceSQL() string {
+	return fmt.Sprintf("REPLACE INTO %s (k, v) VALUES (?, ?)", s.table)
+}
+
+func (s *SQLProvider) deleteSQL() string {
+	return fmt.Sprintf("DELETE FROM %s WHERE k = ?", s.table)
+}
+
+// loadPage load the key-values after the key, the key is included if inclusive
+func (s *SQLProvider) loadPage(key, limit string, inclusive bool) ([]*types.Kv, error) {
+	query := fmt.Sprintf("SELECT k, v FROM %s WHERE k > ?", s.table)
+	if inclusive {
+		query = fmt.Sprintf("SELECT k, v FROM %s WHERE k >= ?", s.table)
+	}
+	args := []interface{}{key}
+	if limit != "" {
+		query += " AND k < ?"
+		args = append(args, limit)
+	}
+	query += fmt.Sprintf(" ORDER BY k LIMIT %d", IteratorPageSize)
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	kvs := make([]*types.Kv, 0, IteratorPageSize)
+	for rows.Next() {
+		var (
+			k string
+			v []byte
+		)
+		if err = rows.Scan(&k, &v); err != nil {
+			return nil, err
+		}
+		kvs = append(kvs, types.NewKv(k, v))
+	}
+	return kvs, rows.Err()
+}
+
+// sqlIterator iterator of sql provider which loads key-values page by page
+type sqlIterator struct {
+	provider *SQLProvider // sql provider
+	start    string       // 下限，包含
+	limit    string       // 上限，不包含
+	kvs      []*types.Kv  // 当前页
+	index    int          // 当前页的位置
+	loaded   bool         // 是否已加载第一页
+	finished bool         // 是否已加载最后一页
+	err      error        // 加载错误
+}
+
+// Next move to the next key-value, the next page will be loaded when the current page is exhausted
+func (it *sqlIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	it.index++
+	if it.index < len(it.kvs) {
+		return true
+	}
+	if it.finished {
+		return false
+	}
+	key, inclusive := it.start, true
+	if it.loaded {
+		key, inclusive = it.kvs[len(it.kvs)-1].GetKey(), false
+	}
+	it.kvs, it.err = it.provider.loadPage(key, it.limit, inclusive)
+	it.loaded, it.index = true, 0
+	if it.err != nil {
+		return false
+	}
+	it.finished = len(it.kvs) < IteratorPageSize
+	return len(it.kvs) > 0
+}
+
+// Key return key of current key-value
+func (it *sqlIterator) Key() string {
+	return it.kvs[it.index].GetKey()
+}
+
+// Value return value of current key-value
+func (it *sqlIterator) Value() []byte {
+	return it.kvs[it.index].GetValue()
+}
+
+// Error return the error of loading
+func (it *sqlIterator) Error() error {
+	return it.err
+}
+
+// Release nothing to release, the rows are closed after loaded
+func (it *sqlIterator) Release() {
+	it.kvs = nil
+}
@@ -0,0 +1,93 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package sqldb
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	kvdbtypes "chainmaker.org/chainmaker-cross/store/kvdb/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSQLProvider(t *testing.T) {
+	require.Panics(t, func() { NewSQLProvider(nil) })
+	require.Panics(t, func() { NewSQLProvider(&conf.SQLConfig{Driver: "postgres"}) })
+	require.Panics(t, func() {
+		NewSQLProvider(&conf.SQLConfig{Driver: conf.SQLDriverSQLite, DSN: "cross.db", Table: "cross;drop"})
+	})
+}
+
+func TestSQLProvider(t *testing.T) {
+	dbProvider, clean := newSQLProvider(t)
+	defer clean()
+	// put, get, has and delete
+	require.Nil(t, dbProvider.Put("key", []byte("value")))
+	require.Nil(t, dbProvider.Put("key", []byte("new value")))
+	require.NotNil(t, dbProvider.Put("key", nil))
+	value, exist := dbProvider.Get("key")
+	require.True(t, exist)
+	require.Equal(t, "new value", string(value))
+	has, err := dbProvider.Has("key")
+	require.Nil(t, err)
+	require.True(t, has)
+	require.Nil(t, dbProvider.Delete("key"))
+	_, exist = dbProvider.Get("key")
+	require.False(t, exist)
+
+	// write batch, nil value means delete
+	batch := kvdbtypes.NewKvDBBatcher()
+	total := IteratorPageSize + 10
+	for i := 0; i < total; i++ {
+		batch.Add(fmt.Sprintf("iter/%04d", i), []byte(fmt.Sprintf("value%d", i)))
+	}
+	require.Nil(t, dbProvider.WriteBatch(batch))
+	require.NotNil(t, dbProvider.WriteBatch(kvdbtypes.NewKvDBBatcher()))
+	batch = kvdbtypes.NewKvDBBatcher()
+	batch.Add("iter/0002", nil)
+	require.Nil(t, dbProvider.WriteBatch(batch))
+	_, exist = dbProvider.Get("iter/0002")
+	require.False(t, exist)
+
+	// iterator across pages
+	iter := dbProvider.NewIterator("iter/0001", "")
+	keys := make([]string, 0)
+	for iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	require.Nil(t, iter.Error())
+	iter.Release()
+	require.Equal(t, total-2, len(keys))
+	require.Equal(t, "iter/0001", keys[0])
+	require.Equal(t, "iter/0003", keys[1])
+	iter = dbProvider.NewIterator("iter/0001", "iter/0004")
+	keys = keys[:0]
+	for iter.Next() {
+		keys = append(keys, iter.Key())
+		require.Equal(t, fmt.Sprintf("value%d", len(keys)*2-1), string(iter.Value()))
+	}
+	iter.Release()
+	require.Equal(t, []string{"iter/0001", "iter/0003"}, keys)
+
+	require.Nil(t, dbProvider.Compact())
+}
+
+func newSQLProvider(t *testing.T) (*SQLProvider, func()) {
+	storePath, err := ioutil.TempDir("", "sqlite")
+	require.Nil(t, err)
+	dbProvider := NewSQLProvider(&conf.SQLConfig{
+		Driver: conf.SQLDriverSQLite,
+		DSN:    filepath.Join(storePath, "cross.db"),
+	})
+	return dbProvider, func() {
+		dbProvider.Close()
+		_ = os.RemoveAll(storePath)
+	}
+}
@@ -6,9 +6,11 @@
 package kvdb
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"chainmaker.org/chainmaker-cross/logger"
 	kvdbtypes "chainmaker.org/chainmaker-cross/store/kvdb/types"
@@ -26,13 +28,29 @@ const (
 	ChainCrossStateFormat  string = "S/%s/%s"  // k:S/{CrossID}/{ChainID}	v:int			跨链消息某条链的状态
 	ChainCrossResultFormat string = "C/%s/%s"  // k:C/{CrossID}/{ChainID}	v:int			跨链消息某条链的执行结果
 	UnfinishedCrossSetKey  string = "UF/CROSS" // k:S/{CrossID}			v:int			未完成的跨链交易
+	DeadLetterCrossSetKey  string = "DL/CROSS" // k:DL/CROSS				v:[]{CrossIDs}	重试耗尽的跨链交易
+	DeadLetterFormat       string = "DLR/%s"   // k:DLR/{CrossID}			v:json			死信记录
+	FailedCrossSetKey      string = "FL/CROSS" // k:FL/CROSS				v:[]{CrossIDs}	失败的跨链交易
+	CrossHistoryFormat     string = "H/%s"     // k:H/{CrossID}			v:json			跨链消息的状态历史
+	CrossIndexFormat       string = "IX/%s"    // k:IX/{CrossID}			v:json			跨链消息的索引记录
+	CrossArchiveFormat     string = "AR/%s"    // k:AR/{CrossID}			v:string		已归档跨链消息所在的归档文件
+	ProveAuditFormat       string = "PA/%s"    // k:PA/{CrossID}			v:json			跨链消息的证明审计记录
+
+	// 二级索引，{StartTime}为20位毫秒时间戳，按时间有序，v:{CrossID}
+	TimeIndexPrefix      string = "IT/"      // k:IT/{StartTime}/{CrossID}
+	ChainIndexFormat     string = "IC/%s/"   // k:IC/{ChainID}/{StartTime}/{CrossID}
+	StateIndexFormat     string = "IS/%03d/" // k:IS/{State}/{StartTime}/{CrossID}
+	InitiatorIndexFormat string = "II/%s/"   // k:II/{Initiator}/{StartTime}/{CrossID}
+	IndexTimeFormat      string = "%020d/%s" // {StartTime}/{CrossID}
+	IndexTimeLimit       string = "~"        // 大于所有时间戳的字符，作为范围查询的上限
 )
 
 // KvStateDB is the struct which will be call by other module
 type KvStateDB struct {
-	sync.Mutex                        // lock
-	provider   kvdbtypes.KvDBProvider // DataBase提供者
-	logger     *zap.SugaredLogger     // log
+	sync.Mutex                         // lock
+	provider    kvdbtypes.KvDBProvider // DataBase提供者
+	archivePath string                 // 归档文件目录
+	logger      *zap.SugaredLogger     // log
 }
 
 // NewKvStateDB create new instance of KvStateDB
@@ -53,6 +71,7 @@ func (k *KvStateDB) StartCross(crossID string, content []byte) error {
 	batch.Add(crossKey(crossID), content)                                 // 内容
 	batch.Add(crossStateKey(crossID), []byte{byte(storetypes.StateInit)}) // 初始化
 	batch.Add(unfinishedSetKey, unfinishedSetValue)                       // 添加到未完成集合
+	k.appendHistory(batch, crossID, "", storetypes.StateInit, "")
 	return k.provider.WriteBatch(batch)
 }
 
@@ -67,6 +86,15 @@ func (k *KvStateDB) FinishCross(crossID string, result []byte, state storetypes.
 		batch.Add(unfinishedSetKey, unfinishedSetValue) // 重置未完成集合
 	}
 	batch.Add(crossResultKey(crossID), result)
+	if state == storetypes.StateFailed {
+		if failedSetValue, added := k.addToIDSet(FailedCrossSetKey, crossID); added {
+			batch.Add(FailedCrossSetKey, failedSetValue) // 添加到失败集合
+		}
+	} else if failedSetValue, exist := k.removeFromIDSet(FailedCrossSetKey, crossID); exist {
+		batch.Add(FailedCrossSetKey, failedSetValue)
+	}
+	k.appendHistory(batch, crossID, "", state, "")
+	k.updateIndexState(batch, crossID, state)
 	return k.provider.WriteBatch(batch)
 }
 
@@ -108,8 +136,13 @@ func (k *KvStateDB) ReadChainIDs(crossID string) ([]string, bool) {
 
 // WriteCrossState write cross state for crossID
 func (k *KvStateDB) WriteCrossState(crossID string, state storetypes.State) error {
-	stateKey := crossStateKey(crossID)
-	return k.provider.Put(stateKey, []byte{byte(state)})
+	k.Lock()
+	defer k.Unlock()
+	batch := kvdbtypes.NewKvDBBatcher()
+	batch.Add(crossStateKey(crossID), []byte{byte(state)})
+	k.appendHistory(batch, crossID, "", state, "")
+	k.updateIndexState(batch, crossID, state)
+	return k.provider.WriteBatch(batch)
 }
 
 // ReadCrossState read cross state for crossID
@@ -133,22 +166,28 @@ func (k *KvStateDB) ReadCrossState(crossID string) (storetypes.State, []byte, bo
 
 // FinishChainCrossState finish cross transaction for crossID and chainID
 func (k *KvStateDB) FinishChainCrossState(crossID, chainID string, result []byte, state storetypes.State) error {
+	k.Lock()
+	defer k.Unlock()
 	batch := kvdbtypes.NewKvDBBatcher()
 	batch.Add(chainCrossStateKey(crossID, chainID), []byte{byte(state)})
 	if state == storetypes.StateSuccess {
 		// 表明当前链处理成功
 		batch.Add(chainCrossResultKey(crossID, chainID), result)
 	}
+	k.appendHistory(batch, crossID, chainID, state, "")
 	return k.provider.WriteBatch(batch)
 }
 
 // WriteChainCrossState write the state for crossID and chainID
 func (k *KvStateDB) WriteChainCrossState(crossID, chainID string, state storetypes.State, content []byte) error {
+	k.Lock()
+	defer k.Unlock()
 	batch := kvdbtypes.NewKvDBBatcher()
 	batch.Add(chainCrossStateKey(crossID, chainID), []byte{byte(state)})
 	if content != nil {
 		batch.Add(chainCrossResultKey(crossID, chainID), content)
 	}
+	k.appendHistory(batch, crossID, chainID, state, "")
 	return k.provider.WriteBatch(batch)
 }
 
@@ -194,6 +233,120 @@ func (k *KvStateDB) DeleteCrossIDFromUnfinished(crossID string) error {
 	return k.provider.WriteBatch(batch)
 }
 
+// AddDeadLetter move crossID from UnfinishedCrossIDs to the dead-letter set
+func (k *KvStateDB) AddDeadLetter(deadLetter *storetypes.DeadLetter) error {
+	content, err := json.Marshal(deadLetter)
+	if err != nil {
+		return err
+	}
+	crossID := deadLetter.CrossID
+	k.Lock()
+	defer k.Unlock()
+	batch := kvdbtypes.NewKvDBBatcher()
+	if unfinishedSetValue, exist := k.removeFromIDSet(UnfinishedCrossSetKey, crossID); exist {
+		batch.Add(UnfinishedCrossSetKey, unfinishedSetValue) // 从未完成集合中移除
+	}
+	if deadLetterSetValue, added := k.addToIDSet(DeadLetterCrossSetKey, crossID); added {
+		batch.Add(DeadLetterCrossSetKey, deadLetterSetValue) // 添加到死信集合
+	}
+	batch.Add(deadLetterKey(crossID), content)
+	k.appendHistory(batch, crossID, deadLetter.ChainID, deadLetter.State,
+		fmt.Sprintf("moved to dead-letter set in %s phase, %s", deadLetter.Phase, deadLetter.Reason))
+	return k.provider.WriteBatch(batch)
+}
+
+// ReadDeadLetterCrossIDs load all the crossID in the dead-letter set
+func (k *KvStateDB) ReadDeadLetterCrossIDs() []string {
+	k.Lock()
+	defer k.Unlock()
+	return k.readIDSet(DeadLetterCrossSetKey)
+}
+
+// ReadDeadLetter load the dead-letter record for crossID
+func (k *KvStateDB) ReadDeadLetter(crossID string) (*storetypes.DeadLetter, bool) {
+	content, exist := k.provider.Get(deadLetterKey(crossID))
+	if !exist || content == nil {
+		return nil, false
+	}
+	deadLetter := &storetypes.DeadLetter{}
+	if err := json.Unmarshal(content, deadLetter); err != nil {
+		k.logger.Errorf("unmarshal dead letter of cross[%s] error, %v", crossID, err)
+		return nil, false
+	}
+	return deadLetter, true
+}
+
+// RedriveDeadLetter move crossID from the dead-letter set back to UnfinishedCrossIDs
+func (k *KvStateDB) RedriveDeadLetter(crossID string) error {
+	k.Lock()
+	defer k.Unlock()
+	deadLetterSetValue, exist := k.removeFromIDSet(DeadLetterCrossSetKey, crossID)
+	if !exist {
+		return fmt.Errorf("cross[%s] is not in the dead-letter set", crossID)
+	}
+	batch := kvdbtypes.NewKvDBBatcher()
+	batch.Add(DeadLetterCrossSetKey, deadLetterSetValue)
+	batch.Add(deadLetterKey(crossID), nil)
+	if unfinishedSetValue, added := k.addToIDSet(UnfinishedCrossSetKey, crossID); added {
+		batch.Add(UnfinishedCrossSetKey, unfinishedSetValue) // 重新添加到未完成集合
+	}
+	k.appendHistory(batch, crossID, "", k.readCrossState(crossID), "redriven from dead-letter set")
+	return k.provider.WriteBatch(batch)
+}
+
+// ReadFailedCrossIDs load all the crossID of failed cross transactions
+func (k *KvStateDB) ReadFailedCrossIDs() []string {
+	k.Lock()
+	defer k.Unlock()
+	return k.readIDSet(FailedCrossSetKey)
+}
+
+// ResolveCross mark the failed or dead-lettered cross transaction as resolved by the transition table,
+// it will be removed from unfinished, failed and dead-letter set
+func (k *KvStateDB) ResolveCross(crossID, note string) error {
+	k.Lock()
+	defer k.Unlock()
+	current := k.readCrossState(crossID)
+	if current == storetypes.StateUnknown {
+		return fmt.Errorf("can not find state of cross[%s]", crossID)
+	}
+	return k.applyTransition(&storetypes.Transition{
+		CrossID: crossID,
+		From:    current,
+		To:      storetypes.StateResolved,
+		Payload: []byte(note),
+		Note:    note,
+	})
+}
+
+// ReadCrossHistory load the state history of the cross transaction
+func (k *KvStateDB) ReadCrossHistory(crossID string) []*storetypes.StateRecord {
+	k.Lock()
+	defer k.Unlock()
+	return k.readHistory(crossID)
+}
+
+// AppendProveAudit add the audit record of prove decision to the cross transaction
+func (k *KvStateDB) AppendProveAudit(crossID string, audit *storetypes.ProveAudit) error {
+	k.Lock()
+	defer k.Unlock()
+	if audit.Timestamp == 0 {
+		audit.Timestamp = time.Now().UnixNano() / int64(time.Millisecond)
+	}
+	content, err := json.Marshal(append(k.readProveAudits(crossID), audit))
+	if err != nil {
+		return err
+	}
+	return k.provider.Put(proveAuditKey(crossID), content)
+}
+
+// ReadProveAudits load the audit records of prove decisions of the cross transaction
+func (k *KvStateDB) ReadProveAudits(crossID string) []*storetypes.ProveAudit {
+	k.Lock()
+	defer k.Unlock()
+	return k.readProveAudits(crossID)
+}
+
 // Close close the database
 func (k *KvStateDB) Close() {
 	k.provider.Close()
@@ -238,6 +391,99 @@ func (k *KvStateDB) redefineRemoveFromUnfinishedSet(crossID string) (string, []b
 	return UnfinishedCrossSetKey, []byte(value), isExist
 }
 
+// readCrossState read the total state of cross, StateUnknown will be returned if not exist
+func (k *KvStateDB) readCrossState(crossID string) storetypes.State {
+	stateValBytes, exist := k.provider.Get(crossStateKey(crossID))
+	if !exist || len(stateValBytes) == 0 {
+		return storetypes.StateUnknown
+	}
+	return storetypes.State(stateValBytes[StateBytesIndex])
+}
+
+// readHistory read the state history of cross
+func (k *KvStateDB) readHistory(crossID string) []*storetypes.StateRecord {
+	content, exist := k.provider.Get(crossHistoryKey(crossID))
+	if !exist || len(content) == 0 {
+		return nil
+	}
+	records := make([]*storetypes.StateRecord, 0)
+	if err := json.Unmarshal(content, &records); err != nil {
+		k.logger.Errorf("unmarshal state history of cross[%s] error, %v", crossID, err)
+		return nil
+	}
+	return records
+}
+
+// appendHistory add the state record to the batch, only one record can be added for the same cross in one batch
+func (k *KvStateDB) appendHistory(batch *kvdbtypes.KvDBBatcher, crossID, chainID string, state storetypes.State, note string) {
+	records := append(k.readHistory(crossID), &storetypes.StateRecord{
+		ChainID:   chainID,
+		State:     state,
+		StateName: state.String(),
+		Note:      note,
+		Timestamp: time.Now().UnixNano() / int64(time.Millisecond),
+	})
+	content, err := json.Marshal(records)
+	if err != nil {
+		k.logger.Errorf("marshal state history of cross[%s] error, %v", crossID, err)
+		return
+	}
+	batch.Add(crossHistoryKey(crossID), content)
+}
+
+// readProveAudits read the audit records of prove decisions of cross
+func (k *KvStateDB) readProveAudits(crossID string) []*storetypes.ProveAudit {
+	content, exist := k.provider.Get(proveAuditKey(crossID))
+	if !exist || len(content) == 0 {
+		return nil
+	}
+	audits := make([]*storetypes.ProveAudit, 0)
+	if err := json.Unmarshal(content, &audits); err != nil {
+		k.logger.Errorf("unmarshal prove audits of cross[%s] error, %v", crossID, err)
+		return nil
+	}
+	return audits
+}
+
+// readIDSet read the ids of the set
+func (k *KvStateDB) readIDSet(setKey string) []string {
+	idsBytes, exist := k.provider.Get(setKey)
+	if !exist || len(idsBytes) == 0 {
+		return nil
+	}
+	return strings.Split(string(idsBytes), IDSep)
+}
+
+// addToIDSet return the new value of the set after adding id, false will be returned if id has been in the set
+func (k *KvStateDB) addToIDSet(setKey, id string) ([]byte, bool) {
+	ids := k.readIDSet(setKey)
+	for _, existID := range ids {
+		if existID == id {
+			return nil, false
+		}
+	}
+	return []byte(strings.Join(append(ids, id), IDSep)), true
+}
+
+// removeFromIDSet return the new value of the set after removing id, false will be returned if id is not in the set,
+// nil value means the set is empty and should be deleted
+func (k *KvStateDB) removeFromIDSet(setKey, id string) ([]byte, bool) {
+	ids := k.readIDSet(setKey)
+	newIDs := make([]string, 0, len(ids))
+	var isExist = false
+	for _, existID := range ids {
+		if existID != id {
+			newIDs = append(newIDs, existID)
+		} else {
+			isExist = true
+		}
+	}
+	if len(newIDs) == 0 {
+		return nil, isExist
+	}
+	return []byte(strings.Join(newIDs, IDSep)), isExist
+}
+
 func crossKey(crossID string) string {
 	return fmt.Sprintf(CrossKeyFormat, crossID)
 }
@@ -261,3 +507,15 @@ func chainCrossStateKey(crossID, chainID string) string {
 func chainCrossResultKey(crossID, chainID string) string {
 	return fmt.Sprintf(ChainCrossResultFormat, crossID, chainID)
 }
+
+func deadLetterKey(crossID string) string {
+	return fmt.Sprintf(DeadLetterFormat, crossID)
+}
+
+func crossHistoryKey(crossID string) string {
+	return fmt.Sprintf(CrossHistoryFormat, crossID)
+}
+
+func proveAuditKey(crossID string) string {
+	return fmt.Sprintf(ProveAuditFormat, crossID)
+}
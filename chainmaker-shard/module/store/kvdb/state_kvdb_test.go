@@ -96,6 +96,279 @@ func TestKvStateDB_ChainIDs(t *testing.T) {
 	}
 }
 
+func TestKvStateDB_DeadLetter(t *testing.T) {
+	stateDB := newKvStateDB(t)
+	defer stateDB.Close()
+	crossID := strconv.Itoa(time.Now().Nanosecond())
+	if err := stateDB.StartCross(crossID, []byte("this is cross event")); err != nil {
+		t.Errorf("start cross %s error: %s", crossID, err.Error())
+	}
+	deadLetter := &storetypes.DeadLetter{
+		CrossID:   crossID,
+		ChainID:   "chain1",
+		Phase:     "commit",
+		State:     storetypes.StateCommitFailed,
+		Reason:    "retries exhausted",
+		Timestamp: time.Now().Unix(),
+	}
+	// 重复添加只记录一次
+	for i := 0; i < 2; i++ {
+		if err := stateDB.AddDeadLetter(deadLetter); err != nil {
+			t.Errorf("add cross %s to dead letter error: %s", crossID, err.Error())
+		}
+	}
+	if containsID(stateDB.ReadUnfinishedCrossIDs(), crossID) {
+		t.Errorf("cross %s should be removed from unfinished set", crossID)
+	}
+	if count := countID(stateDB.ReadDeadLetterCrossIDs(), crossID); count != 1 {
+		t.Errorf("cross %s should be in dead letter set once, but %d", crossID, count)
+	}
+	dbDeadLetter, exist := stateDB.ReadDeadLetter(crossID)
+	if !exist {
+		t.Errorf("can not find dead letter for %s", crossID)
+		t.FailNow()
+	}
+	if *dbDeadLetter != *deadLetter {
+		t.Errorf("read dead letter is not equal, %v", dbDeadLetter)
+	}
+	// 重新驱动
+	if err := stateDB.RedriveDeadLetter(crossID); err != nil {
+		t.Errorf("redrive cross %s error: %s", crossID, err.Error())
+	}
+	if containsID(stateDB.ReadDeadLetterCrossIDs(), crossID) {
+		t.Errorf("cross %s should be removed from dead letter set", crossID)
+	}
+	if _, exist = stateDB.ReadDeadLetter(crossID); exist {
+		t.Errorf("dead letter of cross %s should be deleted", crossID)
+	}
+	if !containsID(stateDB.ReadUnfinishedCrossIDs(), crossID) {
+		t.Errorf("cross %s should be added to unfinished set", crossID)
+	}
+	if err := stateDB.RedriveDeadLetter(crossID); err == nil {
+		t.Errorf("redrive cross %s which is not in dead letter set should fail", crossID)
+	}
+	_ = stateDB.DeleteCrossIDFromUnfinished(crossID)
+}
+
+func TestKvStateDB_ResolveAndHistory(t *testing.T) {
+	stateDB := newKvStateDB(t)
+	defer stateDB.Close()
+	crossID := strconv.Itoa(time.Now().Nanosecond())
+	if err := stateDB.StartCross(crossID, []byte("this is cross event")); err != nil {
+		t.Errorf("start cross %s error: %s", crossID, err.Error())
+	}
+	if err := stateDB.WriteChainCrossState(crossID, "chain1", storetypes.StateCommitFailed, nil); err != nil {
+		t.Errorf("write chain cross state %s error: %s", crossID, err.Error())
+	}
+	if err := stateDB.FinishCross(crossID, []byte("commit failed"), storetypes.StateFailed); err != nil {
+		t.Errorf("finish cross %s error: %s", crossID, err.Error())
+	}
+	if !containsID(stateDB.ReadFailedCrossIDs(), crossID) {
+		t.Errorf("cross %s should be added to failed set", crossID)
+	}
+	note := "fixed by operator"
+	if err := stateDB.ResolveCross(crossID, note); err != nil {
+		t.Errorf("resolve cross %s error: %s", crossID, err.Error())
+	}
+	if containsID(stateDB.ReadFailedCrossIDs(), crossID) {
+		t.Errorf("cross %s should be removed from failed set", crossID)
+	}
+	state, result, exist := stateDB.ReadCrossState(crossID)
+	if !exist || state != storetypes.StateResolved || string(result) != note {
+		t.Errorf("cross %s should be resolved, but state = %s, result = %s", crossID, state, result)
+	}
+	history := stateDB.ReadCrossHistory(crossID)
+	expected := []storetypes.State{storetypes.StateInit, storetypes.StateCommitFailed, storetypes.StateFailed, storetypes.StateResolved}
+	if len(history) != len(expected) {
+		t.Errorf("history of cross %s should have %d records, but %d", crossID, len(expected), len(history))
+		t.FailNow()
+	}
+	for i, record := range history {
+		if record.State != expected[i] {
+			t.Errorf("record %d of cross %s should be %s, but %s", i, crossID, expected[i], record.State)
+		}
+	}
+	if history[1].ChainID != "chain1" || history[3].Note != note {
+		t.Errorf("history of cross %s is not correct, %v", crossID, history)
+	}
+	if err := stateDB.ResolveCross("not-exist-"+crossID, note); err == nil {
+		t.Errorf("resolve cross which not exist should fail")
+	}
+}
+
+func TestKvStateDB_ResolveDeadLetter(t *testing.T) {
+	stateDB := newKvStateDB(t)
+	defer stateDB.Close()
+	crossID := strconv.Itoa(time.Now().Nanosecond())
+	if err := stateDB.StartCross(crossID, []byte("this is cross event")); err != nil {
+		t.Errorf("start cross %s error: %s", crossID, err.Error())
+	}
+	// 未完成且未进入死信的跨链交易不能被人工处理
+	if err := stateDB.ResolveCross(crossID, "fixed by operator"); err == nil {
+		t.Errorf("resolve unfinished cross %s should fail", crossID)
+	}
+	if state, _, _ := stateDB.ReadCrossState(crossID); state != storetypes.StateInit {
+		t.Errorf("cross %s should still be init, but %s", crossID, state)
+	}
+	if !containsID(stateDB.ReadUnfinishedCrossIDs(), crossID) {
+		t.Errorf("cross %s should still be in unfinished set", crossID)
+	}
+	err := stateDB.AddDeadLetter(&storetypes.DeadLetter{
+		CrossID:   crossID,
+		ChainID:   "chain1",
+		Phase:     "commit",
+		State:     storetypes.StateCommitFailed,
+		Reason:    "retries exhausted",
+		Timestamp: time.Now().Unix(),
+	})
+	if err != nil {
+		t.Errorf("add cross %s to dead letter error: %s", crossID, err.Error())
+	}
+	if err = stateDB.ResolveCross(crossID, "fixed by operator"); err != nil {
+		t.Errorf("resolve dead letter cross %s error: %s", crossID, err.Error())
+	}
+	if state, _, _ := stateDB.ReadCrossState(crossID); state != storetypes.StateResolved {
+		t.Errorf("cross %s should be resolved, but %s", crossID, state)
+	}
+	if containsID(stateDB.ReadDeadLetterCrossIDs(), crossID) {
+		t.Errorf("cross %s should be removed from dead letter set", crossID)
+	}
+	if _, exist := stateDB.ReadDeadLetter(crossID); exist {
+		t.Errorf("dead letter of cross %s should be deleted", crossID)
+	}
+	// 已处理的跨链交易不能重复处理
+	if err = stateDB.ResolveCross(crossID, "fixed again"); err == nil {
+		t.Errorf("resolve resolved cross %s should fail", crossID)
+	}
+}
+
+func TestKvStateDB_ProveAudits(t *testing.T) {
+	stateDB := newKvStateDB(t)
+	defer stateDB.Close()
+	crossID := strconv.Itoa(time.Now().Nanosecond())
+	if audits := stateDB.ReadProveAudits(crossID); len(audits) != 0 {
+		t.Errorf("cross %s should have no prove audits, but %d", crossID, len(audits))
+	}
+	audits := []*storetypes.ProveAudit{
+		{ChainID: "chain1", TxKey: "tx1", ProverType: "spv", Policy: "required", Result: true, Reason: "verified by prover"},
+		{ChainID: "chain2", Policy: "required", Reason: "proof is missing, rejected by required policy"},
+	}
+	for _, audit := range audits {
+		if err := stateDB.AppendProveAudit(crossID, audit); err != nil {
+			t.Errorf("append prove audit of cross %s error: %s", crossID, err.Error())
+		}
+	}
+	loaded := stateDB.ReadProveAudits(crossID)
+	if len(loaded) != len(audits) {
+		t.Errorf("cross %s should have %d prove audits, but %d", crossID, len(audits), len(loaded))
+		t.FailNow()
+	}
+	for i, audit := range loaded {
+		if audit.ChainID != audits[i].ChainID || audit.Result != audits[i].Result || audit.Reason != audits[i].Reason {
+			t.Errorf("prove audit %d of cross %s is not correct, %v", i, crossID, audit)
+		}
+		if audit.Timestamp == 0 {
+			t.Errorf("timestamp of prove audit %d of cross %s should be set", i, crossID)
+		}
+	}
+}
+
+func TestKvStateDB_QueryCross(t *testing.T) {
+	stateDB := newKvStateDB(t)
+	defer stateDB.Close()
+	prefix := strconv.Itoa(time.Now().Nanosecond())
+	chain := "query-chain-" + prefix
+	startTime := int64(1600000000000)
+	for i := 0; i < 5; i++ {
+		crossID := prefix + "-" + strconv.Itoa(i)
+		if err := stateDB.StartCross(crossID, []byte("this is cross event")); err != nil {
+			t.Errorf("start cross %s error: %s", crossID, err.Error())
+		}
+		index := &storetypes.CrossIndex{
+			CrossID:   crossID,
+			ChainIDs:  []string{chain, "other-chain"},
+			Initiator: "user" + strconv.Itoa(i%2),
+			StartTime: startTime + int64(i),
+		}
+		if err := stateDB.IndexCross(index); err != nil {
+			t.Errorf("index cross %s error: %s", crossID, err.Error())
+		}
+		state := storetypes.StateSuccess
+		if i%2 == 0 {
+			state = storetypes.StateFailed
+		}
+		if err := stateDB.FinishCross(crossID, nil, state); err != nil {
+			t.Errorf("finish cross %s error: %s", crossID, err.Error())
+		}
+	}
+	// 按链和状态查询
+	result, err := stateDB.QueryCross(&storetypes.CrossQuery{ChainID: chain, State: storetypes.StateFailed})
+	if err != nil {
+		t.Errorf("query cross error: %s", err.Error())
+		t.FailNow()
+	}
+	if len(result.Records) != 3 || result.NextCursor != "" {
+		t.Errorf("query should return 3 failed crosses, but %d", len(result.Records))
+	}
+	for _, record := range result.Records {
+		if record.State != storetypes.StateFailed || record.StateName != storetypes.StateFailed.String() {
+			t.Errorf("state of cross %s should be failed, but %s", record.CrossID, record.State)
+		}
+	}
+	// 按时间范围和发起者查询
+	result, err = stateDB.QueryCross(&storetypes.CrossQuery{
+		ChainID:   chain,
+		Initiator: "user1",
+		StartTime: startTime + 2,
+		EndTime:   startTime + 4,
+	})
+	if err != nil || len(result.Records) != 1 || result.Records[0].CrossID != prefix+"-3" {
+		t.Errorf("query should return cross %s-3, but %v, %v", prefix, result, err)
+	}
+	// 分页查询
+	var crossIDs []string
+	query := &storetypes.CrossQuery{ChainID: chain, Limit: 2}
+	for page := 0; page < 5; page++ {
+		result, err = stateDB.QueryCross(query)
+		if err != nil {
+			t.Errorf("query cross error: %s", err.Error())
+			t.FailNow()
+		}
+		for _, record := range result.Records {
+			crossIDs = append(crossIDs, record.CrossID)
+		}
+		if result.NextCursor == "" {
+			break
+		}
+		query.Cursor = result.NextCursor
+	}
+	if len(crossIDs) != 5 {
+		t.Errorf("paging query should return 5 crosses, but %v", crossIDs)
+	}
+	for i, crossID := range crossIDs {
+		if crossID != prefix+"-"+strconv.Itoa(i) {
+			t.Errorf("crosses should be ordered by start time, but %v", crossIDs)
+		}
+	}
+	if _, err = stateDB.QueryCross(&storetypes.CrossQuery{Cursor: "IC/not-match/"}); err == nil {
+		t.Errorf("query with invalid cursor should fail")
+	}
+}
+
+func containsID(ids []string, id string) bool {
+	return countID(ids, id) > 0
+}
+
+func countID(ids []string, id string) int {
+	count := 0
+	for _, existID := range ids {
+		if existID == id {
+			count++
+		}
+	}
+	return count
+}
+
 func newKvStateDB(t *testing.T) *KvStateDB {
 	levelDBConfig := newLevelDBConfig()
 	dbProvider, err := factory.NewKvDBProvider(storetypes.LevelDB, levelDBConfig)
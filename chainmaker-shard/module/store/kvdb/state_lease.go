@@ -0,0 +1,53 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvdb
+
+import (
+	"errors"
+	"time"
+
+	kvdbtypes "chainmaker.org/chainmaker-cross/store/kvdb/types"
+	storetypes "chainmaker.org/chainmaker-cross/store/types"
+)
+
+var leaseUnsupportedError = errors.New("the provider of state database can not be shared, lease is unsupported")
+
+// AcquireLease acquire or renew the lease for owner with ttl, true will be returned if the owner holds the lease.
+// The lease is stored by the provider rather than the key-values, so it is not locked by the state database
+func (k *KvStateDB) AcquireLease(name, owner string, ttl time.Duration) (bool, error) {
+	provider, ok := k.provider.(kvdbtypes.LeaseProvider)
+	if !ok {
+		return false, leaseUnsupportedError
+	}
+	now := currentMillis()
+	return provider.AcquireLease(name, owner, now, now+int64(ttl/time.Millisecond))
+}
+
+// ReleaseLease release the lease if it is held by owner
+func (k *KvStateDB) ReleaseLease(name, owner string) error {
+	provider, ok := k.provider.(kvdbtypes.LeaseProvider)
+	if !ok {
+		return leaseUnsupportedError
+	}
+	return provider.ReleaseLease(name, owner)
+}
+
+// ReadLease read the current holder of the lease, false will be returned if never acquired or unsupported
+func (k *KvStateDB) ReadLease(name string) (*storetypes.Lease, bool) {
+	provider, ok := k.provider.(kvdbtypes.LeaseProvider)
+	if !ok {
+		return nil, false
+	}
+	owner, expireAt, exist := provider.ReadLease(name)
+	if !exist {
+		return nil, false
+	}
+	return &storetypes.Lease{
+		Name:     name,
+		Owner:    owner,
+		ExpireAt: expireAt,
+	}, true
+}
@@ -0,0 +1,121 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvdb
+
+import (
+	"strings"
+
+	kvdbtypes "chainmaker.org/chainmaker-cross/store/kvdb/types"
+	storetypes "chainmaker.org/chainmaker-cross/store/types"
+)
+
+// ApplyTransition change the state of the cross transaction or one chain of it, the state, payload, id sets,
+// history and index are written in one batch. The transition is rejected if the stored state is not the from state
+// or the transition is illegal, so the concurrent writers can not overwrite each other
+func (k *KvStateDB) ApplyTransition(transition *storetypes.Transition) error {
+	k.Lock()
+	defer k.Unlock()
+	return k.applyTransition(transition)
+}
+
+// applyTransition apply the transition with the lock held
+func (k *KvStateDB) applyTransition(transition *storetypes.Transition) error {
+	crossID, chainID := transition.CrossID, transition.ChainID
+	var current storetypes.State
+	if transition.IsChain() {
+		current = k.readChainCrossState(crossID, chainID)
+	} else {
+		current = k.readCrossState(crossID)
+	}
+	if current != transition.From || !storetypes.CanTransit(transition.From, transition.To, transition.IsChain()) ||
+		!k.canResolve(transition) {
+		return &storetypes.IllegalTransitionError{
+			CrossID: crossID,
+			ChainID: chainID,
+			Current: current,
+			From:    transition.From,
+			To:      transition.To,
+		}
+	}
+	batch := kvdbtypes.NewKvDBBatcher()
+	if transition.IsChain() {
+		k.addChainTransition(batch, transition)
+	} else if transition.To == storetypes.StateInit {
+		k.addStartTransition(batch, transition)
+	} else {
+		k.addFinishTransition(batch, transition)
+	}
+	return k.provider.WriteBatch(batch)
+}
+
+// addStartTransition add the content, state, chain ids and history of the new cross transaction to the batch
+func (k *KvStateDB) addStartTransition(batch *kvdbtypes.KvDBBatcher, transition *storetypes.Transition) {
+	crossID := transition.CrossID
+	batch.Add(crossKey(crossID), transition.Payload)
+	batch.Add(crossStateKey(crossID), []byte{byte(storetypes.StateInit)})
+	if unfinishedSetValue, added := k.addToIDSet(UnfinishedCrossSetKey, crossID); added {
+		batch.Add(UnfinishedCrossSetKey, unfinishedSetValue) // 添加到未完成集合
+	}
+	if len(transition.ChainIDs) > 0 {
+		batch.Add(crossChainsKey(crossID), []byte(strings.Join(transition.ChainIDs, IDSep)))
+	}
+	k.appendHistory(batch, crossID, "", storetypes.StateInit, "")
+}
+
+// addFinishTransition add the final state, result, id sets, history and index of the cross transaction to the batch
+func (k *KvStateDB) addFinishTransition(batch *kvdbtypes.KvDBBatcher, transition *storetypes.Transition) {
+	crossID, state := transition.CrossID, transition.To
+	batch.Add(crossStateKey(crossID), []byte{byte(state)})
+	if transition.Payload != nil {
+		batch.Add(crossResultKey(crossID), transition.Payload)
+	}
+	if unfinishedSetValue, exist := k.removeFromIDSet(UnfinishedCrossSetKey, crossID); exist {
+		batch.Add(UnfinishedCrossSetKey, unfinishedSetValue) // 从未完成集合中移除
+	}
+	if state == storetypes.StateFailed {
+		if failedSetValue, added := k.addToIDSet(FailedCrossSetKey, crossID); added {
+			batch.Add(FailedCrossSetKey, failedSetValue) // 添加到失败集合
+		}
+	} else if failedSetValue, exist := k.removeFromIDSet(FailedCrossSetKey, crossID); exist {
+		batch.Add(FailedCrossSetKey, failedSetValue)
+	}
+	if state == storetypes.StateResolved {
+		if deadLetterSetValue, exist := k.removeFromIDSet(DeadLetterCrossSetKey, crossID); exist {
+			batch.Add(DeadLetterCrossSetKey, deadLetterSetValue)
+		}
+		batch.Add(deadLetterKey(crossID), nil)
+	}
+	k.appendHistory(batch, crossID, "", state, transition.Note)
+	k.updateIndexState(batch, crossID, state)
+}
+
+// canResolve return whether the cross can be resolved, the unfinished cross can only be resolved in the dead-letter set
+func (k *KvStateDB) canResolve(transition *storetypes.Transition) bool {
+	if transition.IsChain() || transition.To != storetypes.StateResolved || transition.From != storetypes.StateInit {
+		return true
+	}
+	deadLetter, exist := k.provider.Get(deadLetterKey(transition.CrossID))
+	return exist && deadLetter != nil
+}
+
+// addChainTransition add the state, result and history of one chain to the batch
+func (k *KvStateDB) addChainTransition(batch *kvdbtypes.KvDBBatcher, transition *storetypes.Transition) {
+	crossID, chainID := transition.CrossID, transition.ChainID
+	batch.Add(chainCrossStateKey(crossID, chainID), []byte{byte(transition.To)})
+	if transition.Payload != nil {
+		batch.Add(chainCrossResultKey(crossID, chainID), transition.Payload)
+	}
+	k.appendHistory(batch, crossID, chainID, transition.To, transition.Note)
+}
+
+// readChainCrossState read the state of one chain, StateUnknown will be returned if not exist
+func (k *KvStateDB) readChainCrossState(crossID, chainID string) storetypes.State {
+	stateValBytes, exist := k.provider.Get(chainCrossStateKey(crossID, chainID))
+	if !exist || len(stateValBytes) == 0 {
+		return storetypes.StateUnknown
+	}
+	return storetypes.State(stateValBytes[StateBytesIndex])
+}
@@ -0,0 +1,106 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvdb
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+	"time"
+
+	storetypes "chainmaker.org/chainmaker-cross/store/types"
+)
+
+func TestKvStateDB_ApplyTransition(t *testing.T) {
+	stateDB := newKvStateDB(t)
+	defer stateDB.Close()
+	crossID := strconv.Itoa(time.Now().Nanosecond())
+	chainIDs := []string{"chain1", "chain2"}
+	start := &storetypes.Transition{
+		CrossID:  crossID,
+		From:     storetypes.StateUnknown,
+		To:       storetypes.StateInit,
+		Payload:  []byte("this is cross event"),
+		ChainIDs: chainIDs,
+	}
+	if err := stateDB.ApplyTransition(start); err != nil {
+		t.Fatalf("start cross %s error: %v", crossID, err)
+	}
+	// 开始时内容、状态、涉及的链及未完成集合一并写入
+	if content, err := stateDB.ReadCross(crossID); err != nil || !bytes.Equal(content, start.Payload) {
+		t.Errorf("content of cross %s is not written", crossID)
+	}
+	if ids, exist := stateDB.ReadChainIDs(crossID); !exist || len(ids) != len(chainIDs) {
+		t.Errorf("chain ids of cross %s is not written", crossID)
+	}
+	if !containsID(stateDB.ReadUnfinishedCrossIDs(), crossID) {
+		t.Errorf("cross %s is not in unfinished set", crossID)
+	}
+	// 重复开始会被拒绝
+	if err := stateDB.ApplyTransition(start); !storetypes.IsIllegalTransition(err) {
+		t.Errorf("duplicate start of cross %s should be rejected, %v", crossID, err)
+	}
+
+	// 链状态：已回滚的链不能提交
+	chainTransitions := []struct {
+		from, to storetypes.State
+		legal    bool
+	}{
+		{storetypes.StateUnknown, storetypes.StateExecuteSuccess, true},
+		{storetypes.StateExecuteSuccess, storetypes.StateProofSuccess, true},
+		{storetypes.StateExecuteSuccess, storetypes.StateRollbackSuccess, false},
+		{storetypes.StateProofSuccess, storetypes.StateRollbackSuccess, true},
+		{storetypes.StateRollbackSuccess, storetypes.StateCommitSuccess, false},
+	}
+	for _, c := range chainTransitions {
+		err := stateDB.ApplyTransition(&storetypes.Transition{
+			CrossID: crossID,
+			ChainID: chainIDs[0],
+			From:    c.from,
+			To:      c.to,
+			Payload: []byte(c.to.String()),
+		})
+		if c.legal != (err == nil) {
+			t.Errorf("transition of chain from %v to %v, expect legal %v, error %v", c.from, c.to, c.legal, err)
+		}
+	}
+	state, result, _ := stateDB.ReadChainCrossState(crossID, chainIDs[0])
+	if state != storetypes.StateRollbackSuccess || string(result) != storetypes.StateRollbackSuccess.String() {
+		t.Errorf("state of chain is %v, result is %s", state, result)
+	}
+
+	// 结束时状态、结果、集合一并写入，结束后不能再改为成功
+	finish := &storetypes.Transition{
+		CrossID: crossID,
+		From:    storetypes.StateInit,
+		To:      storetypes.StateFailed,
+		Payload: []byte("rolled back"),
+	}
+	if err := stateDB.ApplyTransition(finish); err != nil {
+		t.Fatalf("finish cross %s error: %v", crossID, err)
+	}
+	if state, result, _ := stateDB.ReadCrossState(crossID); state != storetypes.StateFailed || !bytes.Equal(result, finish.Payload) {
+		t.Errorf("state of cross %s is %v, result is %s", crossID, state, result)
+	}
+	if containsID(stateDB.ReadUnfinishedCrossIDs(), crossID) || !containsID(stateDB.ReadFailedCrossIDs(), crossID) {
+		t.Errorf("cross %s is not moved from unfinished set to failed set", crossID)
+	}
+	finish.To = storetypes.StateSuccess
+	if err := stateDB.ApplyTransition(finish); !storetypes.IsIllegalTransition(err) {
+		t.Errorf("finished cross %s should not be changed, %v", crossID, err)
+	}
+	finish.From, finish.To = storetypes.StateFailed, storetypes.StateResolved
+	if err := stateDB.ApplyTransition(finish); err != nil {
+		t.Errorf("resolve cross %s error: %v", crossID, err)
+	}
+	if containsID(stateDB.ReadFailedCrossIDs(), crossID) {
+		t.Errorf("resolved cross %s is still in failed set", crossID)
+	}
+	// 每次合法的状态变更均记录历史：开始、3次链状态、失败、处理完成
+	if records := stateDB.ReadCrossHistory(crossID); len(records) != 6 {
+		t.Errorf("expect 6 history records, but %d", len(records))
+	}
+}
@@ -23,10 +23,50 @@ type KvDBProvider interface {
 	// WriteBatch writes a batch in an atomic operation
 	WriteBatch(batch *KvDBBatcher) error
 
+	// NewIterator return the iterator of key-values whose key is in range [start, limit), ordered by key,
+	// empty limit means no upper bound
+	NewIterator(start, limit string) Iterator
+
+	// Compact compact the underlying storage to reclaim the space of deleted keys
+	Compact() error
+
 	// Close close the database
 	Close()
 }
 
+// LeaseProvider the provider which can be shared by multiple proxies, the lease is used as the distributed lock
+type LeaseProvider interface {
+
+	// AcquireLease acquire or renew the lease for owner until expireAt, true will be returned if acquired,
+	// the lease can be taken only when it is held by the owner or has expired at now, time is in millisecond
+	AcquireLease(name, owner string, now, expireAt int64) (bool, error)
+
+	// ReleaseLease release the lease if it is held by owner, so the others can acquire it immediately
+	ReleaseLease(name, owner string) error
+
+	// ReadLease read the owner and expire time of the lease, false will be returned if never acquired
+	ReadLease(name string) (string, int64, bool)
+}
+
+// Iterator iterates over key-values of database in order of key, it must be released after used
+type Iterator interface {
+
+	// Next move the iterator to the next key-value, false will be returned if exhausted
+	Next() bool
+
+	// Key return the key of current key-value
+	Key() string
+
+	// Value return the value of current key-value
+	Value() []byte
+
+	// Error return the error occurred when iterating
+	Error() error
+
+	// Release release the iterator
+	Release()
+}
+
 // KvDBBatcher the struct for batch key-values
 type KvDBBatcher struct {
 	// 不加锁，由调用方处理
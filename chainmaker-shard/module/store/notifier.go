@@ -0,0 +1,219 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package store
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"chainmaker.org/chainmaker-cross/logger"
+	storetypes "chainmaker.org/chainmaker-cross/store/types"
+)
+
+const (
+	SubscriberChanSize = 64 // 订阅者通道缓存大小
+	AllCrossIDs        = "" // 订阅全部跨链事务的状态变更
+)
+
+var stateNotifier *StateNotifier
+
+func init() {
+	stateNotifier = NewStateNotifier()
+}
+
+// GetStateNotifier return the global state notifier
+func GetStateNotifier() *StateNotifier {
+	return stateNotifier
+}
+
+// StateChange state transition of cross transaction, ChainID is empty when it is the total state
+type StateChange struct {
+	Seq       uint64           `json:"seq"`                // 全局递增序号，用于接收方排序
+	CrossID   string           `json:"cross_id"`           // 跨链ID
+	ChainID   string           `json:"chain_id,omitempty"` // 链ID
+	State     storetypes.State `json:"state"`              // 状态值
+	StateName string           `json:"state_name"`         // 状态名称
+	Timestamp int64            `json:"timestamp"`          // 状态变更时间(ms)
+}
+
+// IsFinal return whether the cross transaction has been finished
+func (s *StateChange) IsFinal() bool {
+	return s.ChainID == "" && s.State.IsFinal()
+}
+
+// Subscription subscriber of state change
+type Subscription struct {
+	id      uint64
+	crossID string
+	ch      chan *StateChange
+	queue   *changeQueue // 排队投递队列，为nil时通道已满的状态变更被丢弃
+	dropped uint64       // 丢弃的状态变更数量
+}
+
+// C return the channel which will receive state changes
+func (s *Subscription) C() <-chan *StateChange {
+	return s.ch
+}
+
+// Dropped return the number of state changes dropped because the subscriber is too slow
+func (s *Subscription) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// changeQueue unbounded queue of state changes, which are moved to the channel of subscriber by one goroutine,
+// so the publisher is never blocked and no change is dropped
+type changeQueue struct {
+	sync.Mutex
+	changes []*StateChange // 待投递的状态变更
+	signal  chan struct{}  // 有新的状态变更
+	closeCh chan struct{}  // 订阅已取消
+}
+
+func newChangeQueue() *changeQueue {
+	return &changeQueue{
+		signal:  make(chan struct{}, 1),
+		closeCh: make(chan struct{}),
+	}
+}
+
+func (q *changeQueue) push(change *StateChange) {
+	q.Lock()
+	q.changes = append(q.changes, change)
+	q.Unlock()
+	select {
+	case q.signal <- struct{}{}:
+	default:
+	}
+}
+
+// pump move the queued changes to ch in order, ch is closed after the subscription is canceled
+func (q *changeQueue) pump(ch chan *StateChange) {
+	defer close(ch)
+	for {
+		select {
+		case <-q.closeCh:
+			return
+		case <-q.signal:
+		}
+		q.Lock()
+		changes := q.changes
+		q.changes = nil
+		q.Unlock()
+		for _, change := range changes {
+			select {
+			case ch <- change:
+			case <-q.closeCh:
+				return
+			}
+		}
+	}
+}
+
+// StateNotifier publish the state changes of cross transactions to subscribers
+type StateNotifier struct {
+	sync.RWMutex
+	seq         uint64                              // 状态变更序号
+	subID       uint64                              // 订阅者ID
+	subscribers map[string]map[uint64]*Subscription // crossID -> 订阅者
+}
+
+// NewStateNotifier create new instance of StateNotifier
+func NewStateNotifier() *StateNotifier {
+	return &StateNotifier{
+		subscribers: make(map[string]map[uint64]*Subscription),
+	}
+}
+
+// Subscribe subscribe state changes of crossID, AllCrossIDs means all the cross transactions,
+// the changes will be dropped when the channel is full
+func (n *StateNotifier) Subscribe(crossID string) *Subscription {
+	return n.subscribe(crossID, false)
+}
+
+// SubscribeQueued subscribe state changes of crossID like Subscribe, but the changes are queued without limit
+// when the subscriber is slow, it is used by the subscriber which can not lose any change, such as webhook
+func (n *StateNotifier) SubscribeQueued(crossID string) *Subscription {
+	return n.subscribe(crossID, true)
+}
+
+func (n *StateNotifier) subscribe(crossID string, queued bool) *Subscription {
+	n.Lock()
+	defer n.Unlock()
+	n.subID++
+	sub := &Subscription{
+		id:      n.subID,
+		crossID: crossID,
+		ch:      make(chan *StateChange, SubscriberChanSize),
+	}
+	if queued {
+		sub.queue = newChangeQueue()
+		go sub.queue.pump(sub.ch)
+	}
+	subs, exist := n.subscribers[crossID]
+	if !exist {
+		subs = make(map[uint64]*Subscription)
+		n.subscribers[crossID] = subs
+	}
+	subs[sub.id] = sub
+	return sub
+}
+
+// Unsubscribe cancel the subscription and close its channel
+func (n *StateNotifier) Unsubscribe(sub *Subscription) {
+	if sub == nil {
+		return
+	}
+	n.Lock()
+	defer n.Unlock()
+	subs, exist := n.subscribers[sub.crossID]
+	if !exist {
+		return
+	}
+	if _, exist = subs[sub.id]; !exist {
+		return
+	}
+	delete(subs, sub.id)
+	if len(subs) == 0 {
+		delete(n.subscribers, sub.crossID)
+	}
+	if sub.queue != nil {
+		// 通道由投递goroutine关闭
+		close(sub.queue.closeCh)
+		return
+	}
+	close(sub.ch)
+}
+
+// Publish publish the state change to subscribers, change will be dropped if the subscriber is too slow
+// unless it is subscribed by SubscribeQueued
+func (n *StateNotifier) Publish(crossID, chainID string, state storetypes.State) {
+	change := &StateChange{
+		Seq:       atomic.AddUint64(&n.seq, 1),
+		CrossID:   crossID,
+		ChainID:   chainID,
+		State:     state,
+		StateName: state.String(),
+		Timestamp: time.Now().UnixNano() / int64(time.Millisecond),
+	}
+	n.RLock()
+	defer n.RUnlock()
+	for _, key := range []string{crossID, AllCrossIDs} {
+		for _, sub := range n.subscribers[key] {
+			if sub.queue != nil {
+				sub.queue.push(change)
+				continue
+			}
+			select {
+			case sub.ch <- change:
+			default:
+				dropped := atomic.AddUint64(&sub.dropped, 1)
+				logger.GetLogger(logger.ModuleStorage).Warnf("subscriber[%d] of cross[%s] is too slow, drop state change[%d] of cross[%s], %d dropped in total",
+					sub.id, sub.crossID, change.Seq, crossID, dropped)
+			}
+		}
+	}
+}
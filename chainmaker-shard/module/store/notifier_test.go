@@ -0,0 +1,83 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package store
+
+import (
+	"testing"
+
+	"chainmaker.org/chainmaker-cross/store/kvdb"
+	"chainmaker.org/chainmaker-cross/store/kvdb/factory"
+	storetypes "chainmaker.org/chainmaker-cross/store/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifyStateDB(t *testing.T) {
+	provider, err := factory.NewKvDBProvider(storetypes.Memory, nil)
+	require.NoError(t, err)
+	notifier := NewStateNotifier()
+	stateDB := NewNotifyStateDB(kvdb.NewKvStateDB(provider), notifier)
+	defer stateDB.Close()
+
+	sub := notifier.Subscribe("cross-1")
+	all := notifier.Subscribe(AllCrossIDs)
+	other := notifier.Subscribe("cross-2")
+
+	require.NoError(t, stateDB.StartCross("cross-1", []byte("content")))
+	require.NoError(t, stateDB.WriteChainCrossState("cross-1", "chain1", storetypes.StateExecuteSuccess, nil))
+	require.NoError(t, stateDB.FinishCross("cross-1", []byte("result"), storetypes.StateSuccess))
+
+	expects := []struct {
+		chainID string
+		state   storetypes.State
+	}{
+		{"", storetypes.StateInit},
+		{"chain1", storetypes.StateExecuteSuccess},
+		{"", storetypes.StateSuccess},
+	}
+	var lastSeq uint64
+	for _, expect := range expects {
+		change := <-sub.C()
+		require.Equal(t, "cross-1", change.CrossID)
+		require.Equal(t, expect.chainID, change.ChainID)
+		require.Equal(t, expect.state, change.State)
+		require.Equal(t, expect.state.String(), change.StateName)
+		require.True(t, change.Seq > lastSeq)
+		lastSeq = change.Seq
+		require.Equal(t, change, <-all.C())
+	}
+	require.True(t, (&StateChange{State: storetypes.StateSuccess}).IsFinal())
+	require.Len(t, other.C(), 0)
+
+	notifier.Unsubscribe(sub)
+	_, ok := <-sub.C()
+	require.False(t, ok)
+	// 重复取消订阅无影响
+	notifier.Unsubscribe(sub)
+}
+
+func TestStateNotifier_Queued(t *testing.T) {
+	notifier := NewStateNotifier()
+	lossy := notifier.Subscribe(AllCrossIDs)
+	queued := notifier.SubscribeQueued(AllCrossIDs)
+	// 订阅者未及时读取，超过通道缓存的状态变更
+	total := SubscriberChanSize * 2
+	for i := 0; i < total; i++ {
+		notifier.Publish("cross-1", "", storetypes.StateInit)
+	}
+	require.Equal(t, uint64(total-SubscriberChanSize), lossy.Dropped())
+	var lastSeq uint64
+	for i := 0; i < total; i++ {
+		change := <-queued.C()
+		require.True(t, change.Seq > lastSeq)
+		lastSeq = change.Seq
+	}
+	require.Equal(t, uint64(0), queued.Dropped())
+
+	notifier.Unsubscribe(queued)
+	_, ok := <-queued.C()
+	require.False(t, ok)
+	notifier.Unsubscribe(queued)
+}
@@ -0,0 +1,89 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package store
+
+import (
+	storetypes "chainmaker.org/chainmaker-cross/store/types"
+)
+
+var _ StateDB = (*NotifyStateDB)(nil)
+
+// NotifyStateDB is decorator of StateDB, which publish the state changes after written successfully
+type NotifyStateDB struct {
+	StateDB                 // 实际存储
+	notifier *StateNotifier // 状态变更通知
+}
+
+// NewNotifyStateDB create new instance of NotifyStateDB
+func NewNotifyStateDB(stateDB StateDB, notifier *StateNotifier) *NotifyStateDB {
+	return &NotifyStateDB{
+		StateDB:  stateDB,
+		notifier: notifier,
+	}
+}
+
+// StartCross start the cross transaction and publish StateInit
+func (n *NotifyStateDB) StartCross(crossID string, content []byte) error {
+	if err := n.StateDB.StartCross(crossID, content); err != nil {
+		return err
+	}
+	n.notifier.Publish(crossID, "", storetypes.StateInit)
+	return nil
+}
+
+// FinishCross finish the cross transaction and publish the final state
+func (n *NotifyStateDB) FinishCross(crossID string, result []byte, state storetypes.State) error {
+	if err := n.StateDB.FinishCross(crossID, result, state); err != nil {
+		return err
+	}
+	n.notifier.Publish(crossID, "", state)
+	return nil
+}
+
+// WriteCrossState write the total state and publish it
+func (n *NotifyStateDB) WriteCrossState(crossID string, state storetypes.State) error {
+	if err := n.StateDB.WriteCrossState(crossID, state); err != nil {
+		return err
+	}
+	n.notifier.Publish(crossID, "", state)
+	return nil
+}
+
+// WriteChainCrossState write the state of chain and publish it
+func (n *NotifyStateDB) WriteChainCrossState(crossID, chainID string, state storetypes.State, content []byte) error {
+	if err := n.StateDB.WriteChainCrossState(crossID, chainID, state, content); err != nil {
+		return err
+	}
+	n.notifier.Publish(crossID, chainID, state)
+	return nil
+}
+
+// FinishChainCrossState finish the cross transaction for chain and publish the state
+func (n *NotifyStateDB) FinishChainCrossState(crossID, chainID string, result []byte, state storetypes.State) error {
+	if err := n.StateDB.FinishChainCrossState(crossID, chainID, result, state); err != nil {
+		return err
+	}
+	n.notifier.Publish(crossID, chainID, state)
+	return nil
+}
+
+// ApplyTransition change the state atomically and publish the target state
+func (n *NotifyStateDB) ApplyTransition(transition *storetypes.Transition) error {
+	if err := n.StateDB.ApplyTransition(transition); err != nil {
+		return err
+	}
+	n.notifier.Publish(transition.CrossID, transition.ChainID, transition.To)
+	return nil
+}
+
+// ResolveCross mark the cross transaction as resolved and publish StateResolved
+func (n *NotifyStateDB) ResolveCross(crossID, note string) error {
+	if err := n.StateDB.ResolveCross(crossID, note); err != nil {
+		return err
+	}
+	n.notifier.Publish(crossID, "", storetypes.StateResolved)
+	return nil
+}
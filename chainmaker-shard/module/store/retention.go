@@ -0,0 +1,109 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package store
+
+import (
+	"sync"
+	"time"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultCheckInterval = 60   // 默认检查间隔，单位：分钟
+	DefaultBatchSize     = 1000 // 默认每个归档文件的最大跨链事务数
+)
+
+// RetentionWorker archive the finished cross transactions which exceed the retention periodically
+type RetentionWorker struct {
+	stateDB   StateDB            // 存储
+	retention time.Duration      // 保留时长
+	interval  time.Duration      // 检查间隔
+	batchSize int                // 每批归档数量
+	stopC     chan struct{}      // 停止信号
+	wg        sync.WaitGroup     // 等待归档任务退出
+	logger    *zap.SugaredLogger // log
+}
+
+// NewRetentionWorker create new instance of retention worker, nil will be returned when retention is disabled
+func NewRetentionWorker(stateDB StateDB, config *conf.RetentionConfig) *RetentionWorker {
+	if config == nil || config.RetentionDays <= 0 {
+		return nil
+	}
+	interval, batchSize := config.CheckInterval, config.BatchSize
+	if interval <= 0 {
+		interval = DefaultCheckInterval
+	}
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	return &RetentionWorker{
+		stateDB:   stateDB,
+		retention: time.Duration(config.RetentionDays) * 24 * time.Hour,
+		interval:  time.Duration(interval) * time.Minute,
+		batchSize: batchSize,
+		stopC:     make(chan struct{}),
+		logger:    logger.GetLogger(logger.ModuleStorage),
+	}
+}
+
+// Start start archiving periodically, the worker can be started again after stopped
+func (r *RetentionWorker) Start() {
+	r.stopC = make(chan struct{})
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+		for {
+			if _, err := r.RunOnce(); err != nil {
+				r.logger.Errorf("archive finished crosses error, %v", err)
+			}
+			select {
+			case <-ticker.C:
+			case <-r.stopC:
+				return
+			}
+		}
+	}()
+	r.logger.Infof("retention worker started, crosses finished more than %v ago will be archived", r.retention)
+}
+
+// Stop stop archiving and wait for the running task
+func (r *RetentionWorker) Stop() {
+	close(r.stopC)
+	r.wg.Wait()
+	r.logger.Info("retention worker stopped")
+}
+
+// RunOnce archive all the crosses which exceed the retention, and compact the state database after archived,
+// the number of archived crosses will be returned
+func (r *RetentionWorker) RunOnce() (int, error) {
+	before := time.Now().Add(-r.retention).UnixNano() / int64(time.Millisecond)
+	total := 0
+	for {
+		select {
+		case <-r.stopC:
+			return total, nil
+		default:
+		}
+		crossIDs, err := r.stateDB.ArchiveCrosses(before, r.batchSize)
+		total += len(crossIDs)
+		if err != nil {
+			return total, err
+		}
+		if len(crossIDs) < r.batchSize {
+			break
+		}
+	}
+	if total > 0 {
+		r.logger.Infof("archive %d crosses finished before %v", total, time.Unix(0, before*int64(time.Millisecond)))
+		return total, r.stateDB.Compact()
+	}
+	return total, nil
+}
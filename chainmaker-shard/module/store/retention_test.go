@@ -0,0 +1,93 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package store
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/store/kvdb"
+	"chainmaker.org/chainmaker-cross/store/kvdb/memory"
+	storetypes "chainmaker.org/chainmaker-cross/store/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRetentionWorker(t *testing.T) {
+	stateDB := kvdb.NewKvStateDB(memory.NewMemProvider())
+	require.Nil(t, NewRetentionWorker(stateDB, nil))
+	require.Nil(t, NewRetentionWorker(stateDB, &conf.RetentionConfig{}))
+	worker := NewRetentionWorker(stateDB, &conf.RetentionConfig{RetentionDays: 7})
+	require.NotNil(t, worker)
+	require.Equal(t, 7*24*time.Hour, worker.retention)
+	require.Equal(t, DefaultCheckInterval*time.Minute, worker.interval)
+	require.Equal(t, DefaultBatchSize, worker.batchSize)
+}
+
+func TestRetentionWorker_RunOnce(t *testing.T) {
+	archivePath, err := ioutil.TempDir("", "cross-archive")
+	require.NoError(t, err)
+	defer os.RemoveAll(archivePath)
+	kvStateDB := kvdb.NewKvStateDB(memory.NewMemProvider())
+	kvStateDB.SetArchivePath(archivePath)
+	stateDB := NewNotifyStateDB(kvStateDB, NewStateNotifier())
+	defer stateDB.Close()
+
+	finishCross := func(crossID string, state storetypes.State) {
+		require.NoError(t, stateDB.StartCross(crossID, []byte("content")))
+		require.NoError(t, stateDB.IndexCross(&storetypes.CrossIndex{CrossID: crossID, ChainIDs: []string{"chain1"}}))
+		require.NoError(t, stateDB.WriteChainCrossState(crossID, "chain1", storetypes.StateCommitSuccess, []byte("proof")))
+		if state != storetypes.StateInit {
+			require.NoError(t, stateDB.FinishCross(crossID, []byte("result"), state))
+		}
+	}
+	finishCross("success-cross", storetypes.StateSuccess)
+	finishCross("failed-cross", storetypes.StateFailed)
+	finishCross("unfinished-cross", storetypes.StateInit)
+	time.Sleep(5 * time.Millisecond)
+
+	// 保留时间为0，已完成且非失败待处理的跨链事务都会被归档
+	worker := &RetentionWorker{
+		stateDB:   stateDB,
+		batchSize: 1,
+		stopC:     make(chan struct{}),
+		logger:    kvStateDB.GetLogger(),
+	}
+	total, err := worker.RunOnce()
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	_, _, exist := stateDB.ReadCrossState("success-cross")
+	require.False(t, exist)
+	_, _, exist = stateDB.ReadCrossState("failed-cross")
+	require.True(t, exist)
+	_, _, exist = stateDB.ReadCrossState("unfinished-cross")
+	require.True(t, exist)
+	result, err := stateDB.QueryCross(&storetypes.CrossQuery{ChainID: "chain1", State: storetypes.StateSuccess})
+	require.NoError(t, err)
+	require.Empty(t, result.Records)
+
+	// 恢复后可再次查询
+	require.NoError(t, stateDB.RestoreCross("success-cross"))
+	state, content, exist := stateDB.ReadCrossState("success-cross")
+	require.True(t, exist)
+	require.Equal(t, storetypes.StateSuccess, state)
+	require.Equal(t, "result", string(content))
+	_, content, exist = stateDB.ReadChainCrossState("success-cross", "chain1")
+	require.True(t, exist)
+	require.Equal(t, "proof", string(content))
+	result, err = stateDB.QueryCross(&storetypes.CrossQuery{ChainID: "chain1", State: storetypes.StateSuccess})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(result.Records))
+	require.Error(t, stateDB.RestoreCross("success-cross"))
+
+	// 恢复后更新时间刷新，保留期内不会被再次归档
+	worker.retention = time.Minute
+	total, err = worker.RunOnce()
+	require.NoError(t, err)
+	require.Equal(t, 0, total)
+}
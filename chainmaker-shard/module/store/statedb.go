@@ -6,6 +6,8 @@
 package store
 
 import (
+	"time"
+
 	storetypes "chainmaker.org/chainmaker-cross/store/types"
 )
 
@@ -45,12 +47,60 @@ type StateDB interface {
 	// ReadChainCrossState read the state for crossID and chain
 	ReadChainCrossState(crossID, chainID string) (storetypes.State, []byte, bool)
 
+	// ApplyTransition change the state of the cross transaction or chain atomically,
+	// *storetypes.IllegalTransitionError will be returned if the transition is rejected
+	ApplyTransition(transition *storetypes.Transition) error
+
 	// ReadUnfinishedCrossIDs read unfinished crossID array
 	ReadUnfinishedCrossIDs() []string
 
 	// DeleteCrossIDFromUnfinished delete crossID from the unfinished crossID array
 	DeleteCrossIDFromUnfinished(crossID string) error
 
+	// AddDeadLetter move the crossID from the unfinished crossID array to the dead-letter set
+	AddDeadLetter(deadLetter *storetypes.DeadLetter) error
+
+	// ReadDeadLetterCrossIDs read crossID array of the dead-letter set
+	ReadDeadLetterCrossIDs() []string
+
+	// ReadDeadLetter read the dead-letter record for the crossID
+	ReadDeadLetter(crossID string) (*storetypes.DeadLetter, bool)
+
+	// RedriveDeadLetter move the crossID from the dead-letter set back to the unfinished crossID array
+	RedriveDeadLetter(crossID string) error
+
+	// ReadFailedCrossIDs read crossID array of the failed cross transactions
+	ReadFailedCrossIDs() []string
+
+	// ResolveCross mark the cross transaction as resolved by operator with the note
+	ResolveCross(crossID, note string) error
+
+	// IndexCross write the index record of the cross transaction, which supports searching by QueryCross
+	IndexCross(index *storetypes.CrossIndex) error
+
+	// QueryCross search the indexed cross transactions by conditions, ordered by start time
+	QueryCross(query *storetypes.CrossQuery) (*storetypes.CrossQueryResult, error)
+
+	// ArchiveCrosses archive the finished cross transactions which are not updated since the time (ms)
+	// and delete them from the state database, the archived crossIDs will be returned
+	ArchiveCrosses(before int64, limit int) ([]string, error)
+	// RestoreCross restore the archived cross transaction into the state database
+	RestoreCross(crossID string) error
+	// Compact compact the state database to reclaim the space of deleted data
+	Compact() error
+	// AcquireLease acquire or renew the lease shared by the proxies, true will be returned if the owner holds it
+	AcquireLease(name, owner string, ttl time.Duration) (bool, error)
+	// ReleaseLease release the lease if it is held by the owner
+	ReleaseLease(name, owner string) error
+	// ReadLease read the current holder of the lease
+	ReadLease(name string) (*storetypes.Lease, bool)
+	// ReadCrossHistory read the state history of the cross transaction
+	ReadCrossHistory(crossID string) []*storetypes.StateRecord
+	// AppendProveAudit add the audit record of prove decision to the cross transaction
+	AppendProveAudit(crossID string, audit *storetypes.ProveAudit) error
+	// ReadProveAudits read the audit records of prove decisions of the cross transaction
+	ReadProveAudits(crossID string) []*storetypes.ProveAudit
+
 	// Close close the state database
 	Close()
 }
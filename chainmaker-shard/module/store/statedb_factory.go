@@ -9,37 +9,44 @@ import (
 	"fmt"
 
 	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/logger"
 	"chainmaker.org/chainmaker-cross/store/kvdb"
 	"chainmaker.org/chainmaker-cross/store/kvdb/factory"
 	storetypes "chainmaker.org/chainmaker-cross/store/types"
 )
 
-// InitStateDB init state database
+// InitStateDB init state database, it panics when the storage config is invalid,
+// the unknown provider will not fall back to memory to avoid losing the crosses on restart
 func InitStateDB() StateDB {
-	var stateDB StateDB
-	dbProvider := storetypes.StateDBProvider(conf.Config.StorageConfig.Provider)
+	storageConfig := conf.Config.StorageConfig
+	if err := storageConfig.Validate(); err != nil {
+		panic(fmt.Sprintf("init statedb config failed, %v", err))
+	}
+	var dbConfig interface{}
+	dbProvider := storetypes.StateDBProvider(storageConfig.Provider)
 	switch dbProvider {
 	case storetypes.LevelDB:
-		dbProvider, err := factory.NewKvDBProvider(dbProvider, conf.Config.StorageConfig.LevelDB)
-		if err == nil {
-			stateDB = kvdb.NewKvStateDB(dbProvider)
-		} else {
-			panic(fmt.Sprintf("init statedb config failed, %v", err))
-		}
+		dbConfig = storageConfig.LevelDB
+	case storetypes.Badger:
+		dbConfig = storageConfig.Badger
+	case storetypes.SQL:
+		dbConfig = storageConfig.SQL
 	case storetypes.Memory:
-		dbProvider, err := factory.NewKvDBProvider(dbProvider, nil)
-		if err == nil {
-			stateDB = kvdb.NewKvStateDB(dbProvider)
-		} else {
-			panic(fmt.Sprintf("init statedb config failed, %v", err))
-		}
-	default:
-		dbProvider, err := factory.NewKvDBProvider(storetypes.Memory, nil)
-		if err == nil {
-			stateDB = kvdb.NewKvStateDB(dbProvider)
-		} else {
-			panic(fmt.Sprintf("init statedb config failed, %v", err))
-		}
+		logger.GetLogger(logger.ModuleStorage).Warn("statedb is stored in memory, all the crosses will be lost on restart")
+	}
+	provider, err := factory.NewKvDBProvider(dbProvider, dbConfig)
+	if err != nil {
+		panic(fmt.Sprintf("init statedb config failed, %v", err))
+	}
+	stateDB := kvdb.NewKvStateDB(provider)
+	if retention := storageConfig.Retention; retention != nil {
+		stateDB.SetArchivePath(retention.ArchivePath)
+	}
+	// 状态写入后通知订阅者
+	notifyStateDB := NewNotifyStateDB(stateDB, GetStateNotifier())
+	if conf.Config.ChaosConfig.IsEnabled() {
+		// 写入状态前后注入故障，仅用于测试恢复逻辑
+		return NewChaosStateDB(notifyStateDB)
 	}
-	return stateDB
+	return notifyStateDB
 }
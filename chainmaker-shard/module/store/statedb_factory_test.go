@@ -0,0 +1,30 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package store
+
+import (
+	"testing"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitStateDB(t *testing.T) {
+	defer func(storageConfig *conf.StorageConfig) {
+		conf.Config.StorageConfig = storageConfig
+	}(conf.Config.StorageConfig)
+
+	// 未知的存储类型拒绝启动，不再回退到内存存储
+	conf.Config.StorageConfig = &conf.StorageConfig{Provider: "unknown"}
+	require.Panics(t, func() { InitStateDB() })
+	conf.Config.StorageConfig = &conf.StorageConfig{Provider: conf.StorageLevelDB}
+	require.Panics(t, func() { InitStateDB() })
+
+	conf.Config.StorageConfig = &conf.StorageConfig{Provider: conf.StorageMemory}
+	stateDB := InitStateDB()
+	require.NotNil(t, stateDB)
+	stateDB.Close()
+}
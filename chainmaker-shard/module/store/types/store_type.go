@@ -5,6 +5,8 @@
 
 package types
 
+import "strings"
+
 // State event status
 type State byte
 
@@ -24,12 +26,121 @@ const (
 	StateCommitFailed
 	StateSuccess
 	StateFailed
+	StateResolved // 运维人员手动处理完成
 )
 
-// StateDBProvider state db type, contains leveldb temporary
+var stateNames = map[State]string{
+	StateUnknown:            "StateUnknown",
+	StateInit:               "StateInit",
+	StateReceived:           "StateReceived",
+	StateProofConvertFailed: "StateProofConvertFailed",
+	StateProofFailed:        "StateProofFailed",
+	StateProofSuccess:       "StateProofSuccess",
+	StateExecuteSuccess:     "StateExecuteSuccess",
+	StateExecuteFailed:      "StateExecuteFailed",
+	StateRollbackSuccess:    "StateRollbackSuccess",
+	StateRollbackFailed:     "StateRollbackFailed",
+	StateCommitSuccess:      "StateCommitSuccess",
+	StateCommitFailed:       "StateCommitFailed",
+	StateSuccess:            "StateSuccess",
+	StateFailed:             "StateFailed",
+	StateResolved:           "StateResolved",
+}
+
+// String return name of the state
+func (s State) String() string {
+	if name, exist := stateNames[s]; exist {
+		return name
+	}
+	return stateNames[StateUnknown]
+}
+
+// ParseState parse the state from its name, the prefix "State" and case are ignored, such as StateFailed or failed
+func ParseState(name string) (State, bool) {
+	for state, stateName := range stateNames {
+		if strings.EqualFold(name, stateName) || strings.EqualFold(name, strings.TrimPrefix(stateName, "State")) {
+			return state, true
+		}
+	}
+	return StateUnknown, false
+}
+
+// IsFinal return whether the cross transaction has been finished
+func (s State) IsFinal() bool {
+	return s == StateSuccess || s == StateFailed || s == StateResolved
+}
+
+// StateDBProvider state db type, contains leveldb, badger, sql and memory
 type StateDBProvider string
 
 const (
 	LevelDB StateDBProvider = "leveldb"
+	Badger  StateDBProvider = "badger"
+	SQL     StateDBProvider = "sql"
 	Memory  StateDBProvider = "memory"
 )
+
+// DeadLetter record of the cross transaction whose retries are exhausted
+type DeadLetter struct {
+	CrossID   string `json:"cross_id"`  // 跨链ID
+	ChainID   string `json:"chain_id"`  // 重试耗尽的链
+	Phase     string `json:"phase"`     // 重试耗尽的阶段，如commit、rollback
+	State     State  `json:"state"`     // 该链最后的状态
+	Reason    string `json:"reason"`    // 最后一次失败的原因
+	Timestamp int64  `json:"timestamp"` // 进入死信集合的时间
+}
+
+// StateRecord one record of the state history of cross transaction
+type StateRecord struct {
+	ChainID   string `json:"chain_id,omitempty"` // 链ID，为空表示跨链事务的整体状态
+	State     State  `json:"state"`              // 状态
+	StateName string `json:"state_name"`         // 状态名称
+	Note      string `json:"note,omitempty"`     // 备注，如死信原因、运维人员的处理说明
+	Timestamp int64  `json:"timestamp"`          // 记录时间，单位：毫秒
+}
+
+// ProveAudit audit record of one prove decision of the cross transaction
+type ProveAudit struct {
+	ChainID    string `json:"chain_id"`              // 证明所属的链
+	TxKey      string `json:"tx_key,omitempty"`      // 证明对应的交易，未携带证明时为空
+	ProverType string `json:"prover_type,omitempty"` // 证明器类型，未配置证明器时为空
+	Policy     string `json:"policy,omitempty"`      // 证明策略
+	Result     bool   `json:"result"`                // 是否通过
+	Reason     string `json:"reason"`                // 通过或拒绝的原因
+	Timestamp  int64  `json:"timestamp"`             // 记录时间，单位：毫秒
+}
+
+// CrossIndex index record of cross transaction, which supports searching the history of cross transactions
+type CrossIndex struct {
+	CrossID    string   `json:"cross_id"`            // 跨链ID
+	ChainIDs   []string `json:"chain_ids"`           // 涉及的链
+	Initiator  string   `json:"initiator,omitempty"` // 发起方
+	State      State    `json:"state"`               // 当前状态
+	StateName  string   `json:"state_name"`          // 状态名称
+	StartTime  int64    `json:"start_time"`          // 开始时间，单位：毫秒
+	UpdateTime int64    `json:"update_time"`         // 状态更新时间，单位：毫秒
+}
+
+// CrossQuery conditions of searching cross transactions, zero value of each field means no limit
+type CrossQuery struct {
+	ChainID   string // 涉及的链
+	State     State  // 当前状态，StateUnknown表示不限制
+	Initiator string // 发起方
+	StartTime int64  // 开始时间的下限(包含)，单位：毫秒
+	EndTime   int64  // 开始时间的上限(包含)，单位：毫秒
+	Cursor    string // 上一页返回的游标，为空表示第一页
+	Limit     int    // 每页数量
+}
+
+// CrossQueryResult one page of the cross transactions which are ordered by start time
+type CrossQueryResult struct {
+	Records    []*CrossIndex `json:"records"`               // 跨链事务索引记录
+	NextCursor string        `json:"next_cursor,omitempty"` // 下一页的游标，为空表示没有更多数据
+}
+
+// Lease the lease shared by the proxies, the holder is the leader until the lease expires
+type Lease struct {
+	Name     string `json:"name"`      // 租约名称
+	Owner    string `json:"owner"`     // 持有者的节点ID
+	ExpireAt int64  `json:"expire_at"` // 过期时间，单位：毫秒
+}
@@ -0,0 +1,98 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package types
+
+import "fmt"
+
+// Transition one state change of the cross transaction or one chain of it, all the related keys are written atomically
+type Transition struct {
+	CrossID  string   // 跨链ID
+	ChainID  string   // 链ID，为空表示跨链事务的整体状态
+	From     State    // 当前状态，必须与存储中的状态一致，StateUnknown表示不存在
+	To       State    // 目标状态
+	Payload  []byte   // 整体状态：开始时为跨链消息，结束时为结果；链状态：执行结果或证明，nil表示不写入
+	ChainIDs []string // 跨链消息涉及的链，仅在开始跨链事务时写入
+	Note     string   // 状态变更的备注，记录到状态历史中
+}
+
+// IsChain return whether the transition is for one chain of the cross transaction
+func (t *Transition) IsChain() bool {
+	return t.ChainID != ""
+}
+
+// crossTransitions the legal transitions of the total state of cross transaction,
+// the final state can only be changed to resolved by operator when failed, and the unfinished cross
+// can only be resolved after it is moved to the dead-letter set, which is checked by the state database
+var crossTransitions = map[State][]State{
+	StateUnknown: {StateInit, StateFailed},
+	StateInit:    {StateSuccess, StateFailed, StateResolved},
+	StateFailed:  {StateResolved},
+}
+
+// chainTransitions the legal transitions of the state of one chain, StateReceived is allowed from any state because
+// the transaction event may be resent, and the committed or rolled back chain can not be changed to the other side
+var chainTransitions = map[State][]State{
+	StateUnknown: {StateReceived, StateExecuteSuccess, StateExecuteFailed, StateProofSuccess, StateProofFailed,
+		StateProofConvertFailed, StateCommitSuccess, StateCommitFailed, StateRollbackSuccess, StateRollbackFailed, StateFailed},
+	StateReceived: {StateReceived, StateExecuteSuccess, StateExecuteFailed, StateProofSuccess, StateProofFailed,
+		StateProofConvertFailed, StateCommitSuccess, StateCommitFailed, StateRollbackSuccess, StateRollbackFailed, StateFailed},
+	StateExecuteSuccess: {StateReceived, StateProofSuccess, StateProofFailed, StateProofConvertFailed,
+		StateCommitSuccess, StateCommitFailed, StateRollbackSuccess, StateRollbackFailed, StateFailed},
+	StateExecuteFailed: {StateReceived, StateRollbackSuccess, StateRollbackFailed, StateFailed},
+	StateProofSuccess: {StateReceived, StateCommitSuccess, StateCommitFailed, StateRollbackSuccess, StateRollbackFailed,
+		StateFailed},
+	StateProofFailed: {StateReceived, StateProofSuccess, StateProofFailed, StateProofConvertFailed,
+		StateRollbackSuccess, StateRollbackFailed, StateFailed},
+	StateProofConvertFailed: {StateReceived, StateProofSuccess, StateProofFailed, StateProofConvertFailed,
+		StateRollbackSuccess, StateRollbackFailed, StateFailed},
+	StateCommitSuccess:   {StateReceived, StateCommitSuccess},
+	StateCommitFailed:    {StateReceived, StateCommitSuccess, StateCommitFailed},
+	StateRollbackSuccess: {StateReceived, StateRollbackSuccess},
+	StateRollbackFailed:  {StateReceived, StateRollbackSuccess, StateRollbackFailed},
+	StateFailed:          {StateReceived, StateRollbackSuccess, StateRollbackFailed, StateFailed},
+}
+
+// CanTransit return whether the state can be changed from one to the other,
+// isChain means the state of one chain rather than the total state of cross transaction
+func CanTransit(from, to State, isChain bool) bool {
+	transitions := crossTransitions
+	if isChain {
+		transitions = chainTransitions
+	}
+	for _, state := range transitions[from] {
+		if state == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IllegalTransitionError the error of transition which is rejected by state database
+type IllegalTransitionError struct {
+	CrossID string // 跨链ID
+	ChainID string // 链ID
+	Current State  // 存储中的状态
+	From    State  // 期望的当前状态
+	To      State  // 目标状态
+}
+
+// Error return the description of the illegal transition
+func (e *IllegalTransitionError) Error() string {
+	target := fmt.Sprintf("cross[%s]", e.CrossID)
+	if e.ChainID != "" {
+		target = fmt.Sprintf("cross[%s]->chain[%s]", e.CrossID, e.ChainID)
+	}
+	if e.Current != e.From {
+		return fmt.Sprintf("%s's state is %v rather than %v, can not change to %v", target, e.Current, e.From, e.To)
+	}
+	return fmt.Sprintf("%s's state can not change from %v to %v", target, e.From, e.To)
+}
+
+// IsIllegalTransition return whether the error is caused by illegal transition
+func IsIllegalTransition(err error) bool {
+	_, ok := err.(*IllegalTransitionError)
+	return ok
+}
@@ -0,0 +1,46 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package types
+
+import "testing"
+
+func TestCanTransit(t *testing.T) {
+	cases := []struct {
+		from, to State
+		isChain  bool
+		legal    bool
+	}{
+		{StateUnknown, StateInit, false, true},
+		{StateInit, StateInit, false, false},
+		{StateInit, StateSuccess, false, true},
+		{StateSuccess, StateFailed, false, false},
+		{StateFailed, StateResolved, false, true},
+		{StateInit, StateResolved, false, true},
+		{StateSuccess, StateResolved, false, false},
+		{StateResolved, StateInit, false, false},
+		{StateReceived, StateCommitSuccess, true, true},
+		{StateExecuteFailed, StateCommitSuccess, true, false},
+		{StateCommitSuccess, StateRollbackSuccess, true, false},
+		{StateCommitFailed, StateCommitSuccess, true, true},
+		{StateRollbackSuccess, StateReceived, true, true},
+	}
+	for _, c := range cases {
+		if CanTransit(c.from, c.to, c.isChain) != c.legal {
+			t.Errorf("transition from %v to %v (chain: %v) should be legal: %v", c.from, c.to, c.isChain, c.legal)
+		}
+	}
+}
+
+func TestIllegalTransitionError(t *testing.T) {
+	var err error = &IllegalTransitionError{CrossID: "cross", Current: StateSuccess, From: StateInit, To: StateFailed}
+	if !IsIllegalTransition(err) {
+		t.Error("error should be illegal transition")
+	}
+	expected := "cross[cross]'s state is StateSuccess rather than StateInit, can not change to StateFailed"
+	if err.Error() != expected {
+		t.Errorf("unexpected error message: %s", err.Error())
+	}
+}
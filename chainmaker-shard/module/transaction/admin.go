@@ -0,0 +1,123 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package transaction
+
+import (
+	"fmt"
+
+	"chainmaker.org/chainmaker-cross/event"
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+	storetype "chainmaker.org/chainmaker-cross/store/types"
+)
+
+// RetryChain force a retry of commit or rollback of the cross for one chain, which is requested by operator.
+// The cross will be finished when all the chains have been committed or rolled back successfully.
+func (tm *Manager) RetryChain(crossID, chainID string, opFunc eventproto.OpFuncType) error {
+	var successState, failedState storetype.State
+	switch opFunc {
+	case event.CommitOpFunc:
+		successState, failedState = storetype.StateCommitSuccess, storetype.StateCommitFailed
+	case event.RollbackOpFunc:
+		successState, failedState = storetype.StateRollbackSuccess, storetype.StateRollbackFailed
+	default:
+		return fmt.Errorf("can not support operate func [%v]", opFunc)
+	}
+	ctx, running := tm.runContext()
+	if !running {
+		return notRunningError
+	}
+	// 处理中的跨链事务由其处理流程负责重试，避免与其并发操作
+	if !tm.beginHandling(ctx, crossID) {
+		return fmt.Errorf("cross[%s] is being handled, retry it later", crossID)
+	}
+	defer tm.endHandling(crossID)
+	crossEvent, err := tm.loadCrossEvent(crossID)
+	if err != nil {
+		return err
+	}
+	var crossTx *eventproto.CrossTx
+	crossTxs := crossEvent.GetPkgTxEvents().GetCrossTxs()
+	for _, tx := range crossTxs {
+		if tx.GetChainID() == chainID {
+			crossTx = tx
+			break
+		}
+	}
+	if crossTx == nil {
+		return fmt.Errorf("chain[%s] is not involved in cross[%s]", chainID, crossID)
+	}
+	// 已提交的链不能回滚，已回滚的链不能提交
+	if state, _, _ := tm.db.ReadChainCrossState(crossID, chainID); !storetype.CanTransit(state, successState, true) {
+		return fmt.Errorf("cross[%s]->chain[%s] can not be retried to %v from %v", crossID, chainID, successState, state)
+	}
+	if tm.bindRetryPolicy(crossEvent) {
+		defer tm.unbindRetryPolicy(crossID)
+	}
+	tm.logger.Infof("cross[%v]->chain[%v] %v is retried by operator", crossID, chainID, opFunc)
+	re, err := tm.secondPhaseHandle(crossID, crossTx, opFunc)
+	if err == nil && !re.IsSuccess() {
+		err = fmt.Errorf("%v failed, %s", opFunc, re.Msg)
+	}
+	if err != nil {
+		tm.recordChainState(crossID, chainID, failedState)
+		return err
+	}
+	tm.recordChainState(crossID, chainID, successState)
+	// 所有链均已处理成功时结束该跨链事务
+	for _, tx := range crossTxs {
+		if state, _, _ := tm.db.ReadChainCrossState(crossID, tx.GetChainID()); state != successState {
+			return nil
+		}
+	}
+	if !tm.holdsLease(crossID) {
+		return notLeaseHolderError
+	}
+	if _, exist := tm.db.ReadDeadLetter(crossID); exist {
+		if err = tm.db.RedriveDeadLetter(crossID); err != nil {
+			return err
+		}
+	}
+	tm.finishCrossEvent(crossID)
+	return nil
+}
+
+// Resolve mark the failed or dead-lettered cross as resolved, which is requested by operator.
+// The cross which is being handled can not be resolved, it should be resolved after its handling stopped.
+func (tm *Manager) Resolve(crossID, note string) error {
+	ctx, running := tm.runContext()
+	if !running {
+		return notRunningError
+	}
+	if !tm.beginHandling(ctx, crossID) {
+		return fmt.Errorf("cross[%s] is being handled, resolve it later", crossID)
+	}
+	defer tm.endHandling(crossID)
+	if !tm.holdsLease(crossID) {
+		return notLeaseHolderError
+	}
+	if err := tm.db.ResolveCross(crossID, note); err != nil {
+		return err
+	}
+	tm.logger.Infof("cross[%v] is resolved by operator", crossID)
+	return nil
+}
+
+// loadCrossEvent load the cross event from db
+func (tm *Manager) loadCrossEvent(crossID string) (*eventproto.CrossEvent, error) {
+	content, err := tm.db.ReadCross(crossID)
+	if err != nil {
+		return nil, err
+	}
+	eve, err := tm.crossEventCoder.UnmarshalFromBinary(content)
+	if err != nil {
+		return nil, err
+	}
+	crossEvent, ok := eve.(*eventproto.CrossEvent)
+	if !ok {
+		return nil, fmt.Errorf("content of cross[%s] can not convert to event.CrossEvent", crossID)
+	}
+	return crossEvent, nil
+}
@@ -0,0 +1,89 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package transaction
+
+import (
+	"context"
+	"testing"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/event"
+	"chainmaker.org/chainmaker-cross/event/coder"
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+	"chainmaker.org/chainmaker-cross/store"
+	storetype "chainmaker.org/chainmaker-cross/store/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryChain_Invalid(t *testing.T) {
+	conf.Config.StorageConfig = &conf.StorageConfig{
+		Provider: "memory",
+	}
+	stateDB := store.InitStateDB()
+	defer stateDB.Close()
+	manager := &Manager{
+		db:              stateDB,
+		crossEventCoder: coder.GetCrossEventCoder(),
+		retryPolicies:   newRetryPolicies(),
+		inflight:        newInflightCrosses(),
+		logger:          getLogger(),
+		ctx:             context.Background(),
+	}
+	crossEvent := event.NewCrossEvent([]*eventproto.CrossTx{initCrossTxs(chain1, 0), initCrossTxs(chain2, 1)})
+	crossID := crossEvent.GetCrossID()
+	content, err := manager.crossEventCoder.MarshalToBinary(crossEvent)
+	require.Nil(t, err)
+	require.Nil(t, stateDB.StartCross(crossID, content))
+
+	loaded, err := manager.loadCrossEvent(crossID)
+	require.Nil(t, err)
+	require.Equal(t, crossID, loaded.GetCrossID())
+
+	require.NotNil(t, manager.RetryChain(crossID, chain1, eventproto.OpFuncType_ExecuteOpFunc))
+	require.NotNil(t, manager.RetryChain("not-exist", chain1, eventproto.OpFuncType_CommitOpFunc))
+	require.NotNil(t, manager.RetryChain(crossID, "chain3", eventproto.OpFuncType_CommitOpFunc))
+	// 处理中的跨链事务不能由运维重试
+	require.True(t, manager.beginHandling(context.Background(), crossID))
+	require.NotNil(t, manager.RetryChain(crossID, chain1, eventproto.OpFuncType_CommitOpFunc))
+	manager.endHandling(crossID)
+}
+
+func TestResolve(t *testing.T) {
+	conf.Config.StorageConfig = &conf.StorageConfig{
+		Provider: "memory",
+	}
+	stateDB := store.InitStateDB()
+	defer stateDB.Close()
+	manager := &Manager{
+		db:       stateDB,
+		inflight: newInflightCrosses(),
+		logger:   getLogger(),
+		ctx:      context.Background(),
+	}
+	crossID := "resolve-cross"
+	require.Nil(t, stateDB.StartCross(crossID, []byte("content")))
+	require.NotNil(t, manager.Resolve("not-exist", "fixed"))
+	// 未完成且未进入死信的跨链事务不能手动处理
+	require.NotNil(t, manager.Resolve(crossID, "fixed"))
+	require.Nil(t, stateDB.AddDeadLetter(&storetype.DeadLetter{CrossID: crossID, ChainID: chain1,
+		Phase: "commit", State: storetype.StateCommitFailed, Reason: "retries exhausted"}))
+	// 处理中的跨链事务不能手动处理
+	require.True(t, manager.beginHandling(context.Background(), crossID))
+	require.NotNil(t, manager.Resolve(crossID, "fixed"))
+	manager.endHandling(crossID)
+	// 失去租约后不能手动处理
+	manager.SetLeaseChecker(func() bool { return false })
+	require.Equal(t, notLeaseHolderError, manager.Resolve(crossID, "fixed"))
+	manager.SetLeaseChecker(nil)
+	require.Nil(t, manager.Resolve(crossID, "fixed"))
+	state, result, _ := stateDB.ReadCrossState(crossID)
+	require.Equal(t, storetype.StateResolved, state)
+	require.Equal(t, "fixed", string(result))
+	require.False(t, manager.isHandling(crossID))
+	// 停止后拒绝手动处理
+	manager.ctx = nil
+	require.Equal(t, notRunningError, manager.Resolve(crossID, "fixed again"))
+}
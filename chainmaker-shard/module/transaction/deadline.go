@@ -0,0 +1,144 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package transaction
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+)
+
+// crossDeadlines deadlines of the cross events which are being handled
+type crossDeadlines struct {
+	sync.RWMutex
+	deadlines map[string]time.Time
+}
+
+// newCrossDeadlines create new instance of crossDeadlines
+func newCrossDeadlines() *crossDeadlines {
+	return &crossDeadlines{
+		deadlines: make(map[string]time.Time),
+	}
+}
+
+// resolveDeadline return the deadline of cross event, cross_timeout of proxy will be used when the event has no deadline,
+// zero time means no deadline
+func resolveDeadline(eve *eventproto.CrossEvent) time.Time {
+	if eve.GetDeadline() > 0 {
+		return time.Unix(eve.GetDeadline(), 0)
+	}
+	if conf.Config.CrossTimeout > 0 && eve.GetTimestamp() > 0 {
+		return time.Unix(eve.GetTimestamp(), 0).Add(time.Duration(conf.Config.CrossTimeout) * time.Millisecond)
+	}
+	return time.Time{}
+}
+
+// deadlineExceededError return the reason of the cross which is aborted for timeout
+func deadlineExceededError(crossID string) error {
+	return fmt.Errorf("cross[%s] deadline exceeded", crossID)
+}
+
+// bindDeadline bind the deadline for the cross event
+func (tm *Manager) bindDeadline(eve *eventproto.CrossEvent) {
+	deadline := resolveDeadline(eve)
+	if deadline.IsZero() {
+		return
+	}
+	tm.deadlines.Lock()
+	defer tm.deadlines.Unlock()
+	tm.deadlines.deadlines[eve.GetCrossID()] = deadline
+}
+
+// unbindDeadline remove the deadline of the cross event after handled
+func (tm *Manager) unbindDeadline(crossID string) {
+	tm.deadlines.Lock()
+	defer tm.deadlines.Unlock()
+	delete(tm.deadlines.deadlines, crossID)
+}
+
+// getDeadline return the deadline of the cross event, zero time means no deadline
+func (tm *Manager) getDeadline(crossID string) time.Time {
+	tm.deadlines.RLock()
+	defer tm.deadlines.RUnlock()
+	return tm.deadlines.deadlines[crossID]
+}
+
+// isExpired return whether the deadline of the cross event has passed
+func (tm *Manager) isExpired(crossID string) bool {
+	deadline := tm.getDeadline(crossID)
+	return !deadline.IsZero() && !time.Now().Before(deadline)
+}
+
+// hasCommitMargin return whether there is enough time before the deadline to commit the cross,
+// the transaction contracts which check the deadline reject the late commits, so the commit must not be started near the deadline
+func (tm *Manager) hasCommitMargin(crossID string) bool {
+	deadline := tm.getDeadline(crossID)
+	if deadline.IsZero() {
+		return true
+	}
+	margin := time.Duration(conf.Config.CommitMargin) * time.Millisecond
+	return time.Until(deadline) > margin
+}
+
+// getCommitTimeout return the deadline duration of commit retries, which is limited by the deadline of cross,
+// the commit will not be retried after the deadline because it may be rejected by the transaction contracts
+func (tm *Manager) getCommitTimeout(crossID string) time.Duration {
+	timeout := tm.getRetryPolicy(crossID).GetCommitTimeout()
+	if deadline := tm.getDeadline(crossID); !deadline.IsZero() {
+		remain := time.Until(deadline)
+		if remain <= 0 {
+			// 0表示不限制，已超过截止时间时使用最小值
+			remain = time.Nanosecond
+		}
+		if timeout <= 0 || remain < timeout {
+			timeout = remain
+		}
+	}
+	return timeout
+}
+
+// getExecuteTimeout return the duration of waiting for the execute result, which is limited by the deadline of cross
+func (tm *Manager) getExecuteTimeout(crossID string) time.Duration {
+	timeout := tm.getRetryPolicy(crossID).GetExecuteTimeout()
+	if deadline := tm.getDeadline(crossID); !deadline.IsZero() {
+		if remain := time.Until(deadline); remain < timeout {
+			timeout = remain
+		}
+	}
+	return timeout
+}
+
+// abortIfExpired rollback the executed cross txs and finish the cross as failed when its deadline has passed,
+// true will be returned if the cross is aborted
+func (tm *Manager) abortIfExpired(crossID string, executedCrossTxs []*eventproto.CrossTx) bool {
+	if !tm.isExpired(crossID) {
+		return false
+	}
+	tm.abortForDeadline(crossID, executedCrossTxs)
+	return true
+}
+
+// abortIfNoCommitMargin rollback the executed cross txs and finish the cross as failed when there is not enough time
+// before the deadline to commit, true will be returned if the cross is aborted
+func (tm *Manager) abortIfNoCommitMargin(crossID string, executedCrossTxs []*eventproto.CrossTx) bool {
+	if tm.hasCommitMargin(crossID) {
+		return false
+	}
+	tm.logger.Warnf("cross[%v] has no enough time to commit before deadline[%v]", crossID, tm.getDeadline(crossID))
+	tm.abortForDeadline(crossID, executedCrossTxs)
+	return true
+}
+
+// abortForDeadline rollback the executed cross txs and finish the cross as failed for timeout
+func (tm *Manager) abortForDeadline(crossID string, executedCrossTxs []*eventproto.CrossTx) {
+	err := deadlineExceededError(crossID)
+	tm.logger.Warnf("cross[%v] will be aborted, %v", crossID, err)
+	tm.rollbackHandledEvents(crossID, executedCrossTxs, err)
+	tm.recordInterruptedState(crossID, []byte(err.Error()))
+}
@@ -0,0 +1,119 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package transaction
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/event"
+	"chainmaker.org/chainmaker-cross/store"
+	storetype "chainmaker.org/chainmaker-cross/store/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadline_Bind(t *testing.T) {
+	manager := &Manager{
+		retryPolicies: newRetryPolicies(),
+		deadlines:     newCrossDeadlines(),
+	}
+	crossID := "deadline-cross"
+	now := time.Now().Unix()
+	defer func(timeout int64) {
+		conf.Config.CrossTimeout = timeout
+	}(conf.Config.CrossTimeout)
+
+	// 未配置截止时间
+	conf.Config.CrossTimeout = 0
+	manager.bindDeadline(&eventproto.CrossEvent{CrossId: crossID, Timestamp: now})
+	require.True(t, manager.getDeadline(crossID).IsZero())
+	require.False(t, manager.isExpired(crossID))
+	require.Equal(t, manager.getRetryPolicy(crossID).GetExecuteTimeout(), manager.getExecuteTimeout(crossID))
+
+	// 使用代理配置的默认超时时间
+	conf.Config.CrossTimeout = 60 * 1000
+	manager.bindDeadline(&eventproto.CrossEvent{CrossId: crossID, Timestamp: now})
+	require.Equal(t, time.Unix(now+60, 0), manager.getDeadline(crossID))
+
+	// 跨链事件的截止时间优先
+	manager.bindDeadline(&eventproto.CrossEvent{CrossId: crossID, Timestamp: now, Deadline: now + 2})
+	require.Equal(t, time.Unix(now+2, 0), manager.getDeadline(crossID))
+	require.False(t, manager.isExpired(crossID))
+	require.True(t, manager.getExecuteTimeout(crossID) <= 2*time.Second)
+
+	manager.unbindDeadline(crossID)
+	require.True(t, manager.getDeadline(crossID).IsZero())
+}
+
+func TestDeadline_CommitMargin(t *testing.T) {
+	manager := &Manager{
+		retryPolicies: newRetryPolicies(),
+		deadlines:     newCrossDeadlines(),
+	}
+	crossID := "margin-cross"
+	defer func(margin int64) {
+		conf.Config.CommitMargin = margin
+	}(conf.Config.CommitMargin)
+	conf.Config.CommitMargin = 10 * 1000
+
+	// 未设置截止时间，提交不受限制
+	require.True(t, manager.hasCommitMargin(crossID))
+	require.Equal(t, manager.getRetryPolicy(crossID).GetCommitTimeout(), manager.getCommitTimeout(crossID))
+
+	// 剩余时间充足
+	manager.bindDeadline(&eventproto.CrossEvent{CrossId: crossID, Deadline: time.Now().Unix() + 60})
+	defer manager.unbindDeadline(crossID)
+	require.True(t, manager.hasCommitMargin(crossID))
+	timeout := manager.getCommitTimeout(crossID)
+	require.True(t, timeout > 0 && timeout <= 60*time.Second)
+
+	// 未超过截止时间，但剩余时间不足以提交
+	manager.bindDeadline(&eventproto.CrossEvent{CrossId: crossID, Deadline: time.Now().Unix() + 5})
+	require.False(t, manager.isExpired(crossID))
+	require.False(t, manager.hasCommitMargin(crossID))
+
+	// 超过截止时间后不再重试提交
+	manager.bindDeadline(&eventproto.CrossEvent{CrossId: crossID, Deadline: time.Now().Unix() - 1})
+	require.Equal(t, time.Nanosecond, manager.getCommitTimeout(crossID))
+	err := manager.retryPhase(context.Background(), crossID, "chain1", "commit", manager.getCommitTimeout(crossID),
+		func() (*event.ProofResponse, error) {
+			t.Fatal("commit should not be retried after deadline")
+			return nil, nil
+		})
+	require.Error(t, err)
+}
+
+func TestDeadline_AbortIfExpired(t *testing.T) {
+	conf.Config.StorageConfig = &conf.StorageConfig{
+		Provider: "memory",
+	}
+	stateDB := store.InitStateDB()
+	defer stateDB.Close()
+	manager := &Manager{
+		db:            stateDB,
+		retryPolicies: newRetryPolicies(),
+		deadlines:     newCrossDeadlines(),
+		inflight:      newInflightCrosses(),
+		logger:        getLogger(),
+	}
+	crossID := "expired-cross"
+	require.Nil(t, stateDB.StartCross(crossID, []byte("content")))
+	manager.bindDeadline(&eventproto.CrossEvent{CrossId: crossID, Deadline: time.Now().Unix() + 3600})
+	require.False(t, manager.abortIfExpired(crossID, nil))
+
+	manager.bindDeadline(&eventproto.CrossEvent{CrossId: crossID, Deadline: time.Now().Unix() - 1})
+	defer manager.unbindDeadline(crossID)
+	require.True(t, manager.abortIfExpired(crossID, nil))
+	state, result, exist := stateDB.ReadCrossState(crossID)
+	require.True(t, exist)
+	require.Equal(t, storetype.StateFailed, state)
+	require.Equal(t, deadlineExceededError(crossID).Error(), string(result))
+	require.NotContains(t, stateDB.ReadUnfinishedCrossIDs(), crossID)
+}
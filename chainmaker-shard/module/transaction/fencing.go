@@ -0,0 +1,24 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package transaction
+
+// LeaseChecker return whether this proxy still holds the leader lease in the shared state database
+type LeaseChecker func() bool
+
+// SetLeaseChecker set the function which fences the state writes in high-availability mode
+func (tm *Manager) SetLeaseChecker(checker LeaseChecker) {
+	tm.leaseChecker = checker
+}
+
+// holdsLease return whether the state of cross can be written by this proxy, the old leader may still be handling
+// the cross after the lease is taken over, its writes are dropped and the cross is recovered by the new leader
+func (tm *Manager) holdsLease(crossID string) bool {
+	if tm.leaseChecker == nil || tm.leaseChecker() {
+		return true
+	}
+	tm.logger.Warnf("cross[%v]'s state will not be written, this proxy does not hold the leader lease", crossID)
+	return false
+}
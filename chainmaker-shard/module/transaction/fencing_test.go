@@ -0,0 +1,110 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package transaction
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/event"
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+	"chainmaker.org/chainmaker-cross/store"
+	storetype "chainmaker.org/chainmaker-cross/store/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_StopWaitsHandlers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	manager := &Manager{
+		retryPolicies: newRetryPolicies(),
+		inflight:      newInflightCrosses(),
+		logger:        getLogger(),
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+	crossID := "stop-cross"
+	manager.bindRetryPolicy(&eventproto.CrossEvent{
+		CrossId: crossID,
+		RetryPolicy: &eventproto.RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: int64(time.Hour / time.Millisecond),
+			MaxBackoff:     int64(time.Hour / time.Millisecond),
+		},
+	})
+	defer manager.unbindRetryPolicy(crossID)
+	runCtx, running := manager.runContext()
+	require.True(t, running)
+
+	var retryErr error
+	require.True(t, manager.goHandle(runCtx, func() {
+		require.True(t, manager.beginHandling(runCtx, crossID))
+		defer manager.endHandling(crossID)
+		retryErr = manager.retryPhase(manager.crossContext(crossID), crossID, chain1, "commit", 0,
+			func() (*event.ProofResponse, error) {
+				return nil, errors.New("should not be called")
+			})
+	}))
+	// 失去leader后，重试立即退出，Stop等待处理者退出后返回
+	stopped := make(chan struct{})
+	go func() {
+		manager.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("stop should wait the handler which exits on cancel")
+	}
+	require.Error(t, retryErr)
+	require.False(t, manager.isHandling(crossID))
+	require.False(t, manager.interrupted(crossID))
+	// 停止后不再启动新的处理者
+	_, running = manager.runContext()
+	require.False(t, running)
+	require.False(t, manager.goHandle(runCtx, func() {}))
+	require.Equal(t, notRunningError, manager.Redrive(crossID))
+}
+
+func TestManager_LeaseFence(t *testing.T) {
+	conf.Config.StorageConfig = &conf.StorageConfig{
+		Provider: "memory",
+	}
+	stateDB := store.InitStateDB()
+	defer stateDB.Close()
+	manager := &Manager{
+		db:     stateDB,
+		logger: getLogger(),
+	}
+	crossID := "fence-cross"
+	require.Nil(t, stateDB.StartCross(crossID, []byte("content")))
+
+	// 租约已被其他代理接管，旧leader的写入被丢弃
+	holds := false
+	manager.SetLeaseChecker(func() bool { return holds })
+	manager.recordChainState(crossID, chain1, storetype.StateExecuteSuccess)
+	manager.moveToDeadLetter(crossID, chain1, "commit", storetype.StateCommitFailed, errors.New("commit failed"))
+	manager.finishCross(crossID, storetype.StateInit, storetype.StateFailed, nil)
+	manager.finishCrossEvent(crossID)
+	state, _, _ := stateDB.ReadChainCrossState(crossID, chain1)
+	require.Equal(t, storetype.StateUnknown, state)
+	_, exist := stateDB.ReadDeadLetter(crossID)
+	require.False(t, exist)
+	state, _, _ = stateDB.ReadCrossState(crossID)
+	require.Equal(t, storetype.StateInit, state)
+	require.Contains(t, stateDB.ReadUnfinishedCrossIDs(), crossID)
+
+	// 持有租约时正常写入
+	holds = true
+	manager.recordChainState(crossID, chain1, storetype.StateExecuteSuccess)
+	state, _, _ = stateDB.ReadChainCrossState(crossID, chain1)
+	require.Equal(t, storetype.StateExecuteSuccess, state)
+	manager.finishCross(crossID, storetype.StateInit, storetype.StateFailed, nil)
+	state, _, _ = stateDB.ReadCrossState(crossID)
+	require.Equal(t, storetype.StateFailed, state)
+}
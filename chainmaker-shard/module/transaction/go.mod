@@ -7,6 +7,7 @@ require (
 	chainmaker.org/chainmaker-cross/conf v0.0.0
 	chainmaker.org/chainmaker-cross/event v0.0.0
 	chainmaker.org/chainmaker-cross/logger v0.0.0
+	chainmaker.org/chainmaker-cross/monitor v0.0.0
 	chainmaker.org/chainmaker-cross/prover v0.0.0
 	chainmaker.org/chainmaker-cross/router v0.0.0
 	chainmaker.org/chainmaker-cross/store v0.0.0
@@ -22,6 +23,7 @@ replace (
 	chainmaker.org/chainmaker-cross/conf => ../conf
 	chainmaker.org/chainmaker-cross/event => ../event
 	chainmaker.org/chainmaker-cross/logger => ../logger
+	chainmaker.org/chainmaker-cross/monitor => ../monitor
 	chainmaker.org/chainmaker-cross/net => ../net
 	chainmaker.org/chainmaker-cross/prover => ../prover
 	chainmaker.org/chainmaker-cross/handler => ../handler
@@ -0,0 +1,60 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package transaction
+
+import (
+	"context"
+	"sync"
+)
+
+// inflightCrosses the cross events which are being handled, only one goroutine can handle a cross event at the same time
+type inflightCrosses struct {
+	sync.Mutex
+	crossIDs map[string]context.Context // 跨链ID到处理者上下文的映射
+}
+
+// newInflightCrosses create new instance of inflightCrosses
+func newInflightCrosses() *inflightCrosses {
+	return &inflightCrosses{
+		crossIDs: make(map[string]context.Context),
+	}
+}
+
+// beginHandling mark the cross event as being handled by the handler with ctx, false is returned if it is being handled by others
+func (tm *Manager) beginHandling(ctx context.Context, crossID string) bool {
+	tm.inflight.Lock()
+	defer tm.inflight.Unlock()
+	if _, exist := tm.inflight.crossIDs[crossID]; exist {
+		return false
+	}
+	tm.inflight.crossIDs[crossID] = ctx
+	return true
+}
+
+// endHandling remove the mark after the cross event is handled
+func (tm *Manager) endHandling(crossID string) {
+	tm.inflight.Lock()
+	defer tm.inflight.Unlock()
+	delete(tm.inflight.crossIDs, crossID)
+}
+
+// isHandling return whether the cross event is being handled
+func (tm *Manager) isHandling(crossID string) bool {
+	tm.inflight.Lock()
+	defer tm.inflight.Unlock()
+	_, exist := tm.inflight.crossIDs[crossID]
+	return exist
+}
+
+// crossContext return the context of the handler which is handling the cross event, background is returned if not handled
+func (tm *Manager) crossContext(crossID string) context.Context {
+	tm.inflight.Lock()
+	defer tm.inflight.Unlock()
+	if ctx, exist := tm.inflight.crossIDs[crossID]; exist {
+		return ctx
+	}
+	return context.Background()
+}
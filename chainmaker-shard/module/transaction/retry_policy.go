@@ -0,0 +1,160 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package transaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/event"
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+	storetype "chainmaker.org/chainmaker-cross/store/types"
+)
+
+// retryPolicies retry policies of the cross events which are being handled
+type retryPolicies struct {
+	sync.RWMutex
+	policies map[string]*conf.RetryPolicy
+}
+
+// newRetryPolicies create new instance of retryPolicies
+func newRetryPolicies() *retryPolicies {
+	return &retryPolicies{
+		policies: make(map[string]*conf.RetryPolicy),
+	}
+}
+
+// toRetryPolicy convert the retry policy of cross event to the config struct
+func toRetryPolicy(policy *eventproto.RetryPolicy) *conf.RetryPolicy {
+	if policy == nil {
+		return nil
+	}
+	return &conf.RetryPolicy{
+		MaxAttempts:     int(policy.GetMaxAttempts()),
+		InitialBackoff:  policy.GetInitialBackoff(),
+		MaxBackoff:      policy.GetMaxBackoff(),
+		Multiplier:      policy.GetMultiplier(),
+		Jitter:          policy.GetJitter(),
+		ExecuteTimeout:  policy.GetExecuteTimeout(),
+		CommitTimeout:   policy.GetCommitTimeout(),
+		RollbackTimeout: policy.GetRollbackTimeout(),
+	}
+}
+
+// bindRetryPolicy bind the retry policy for the cross event, the policy of cross event overrides the config of proxy,
+// the policy which has been bound is kept, and true is returned only if the policy is bound by this call
+func (tm *Manager) bindRetryPolicy(eve *eventproto.CrossEvent) bool {
+	policy := conf.Config.GetRetryPolicy().Merge(toRetryPolicy(eve.GetRetryPolicy()))
+	tm.retryPolicies.Lock()
+	defer tm.retryPolicies.Unlock()
+	if _, exist := tm.retryPolicies.policies[eve.GetCrossID()]; exist {
+		return false
+	}
+	tm.retryPolicies.policies[eve.GetCrossID()] = policy
+	return true
+}
+
+// unbindRetryPolicy remove the retry policy of the cross event after handled
+func (tm *Manager) unbindRetryPolicy(crossID string) {
+	tm.retryPolicies.Lock()
+	defer tm.retryPolicies.Unlock()
+	delete(tm.retryPolicies.policies, crossID)
+}
+
+// getRetryPolicy return the retry policy of the cross event, the config of proxy will be returned if not bound
+func (tm *Manager) getRetryPolicy(crossID string) *conf.RetryPolicy {
+	tm.retryPolicies.RLock()
+	policy, exist := tm.retryPolicies.policies[crossID]
+	tm.retryPolicies.RUnlock()
+	if exist {
+		return policy
+	}
+	return conf.Config.GetRetryPolicy()
+}
+
+// retryPhase retry the operation of phase by the retry policy of cross until it succeeds,
+// error will be returned when the attempts are exhausted, the deadline of phase is exceeded or ctx is canceled
+func (tm *Manager) retryPhase(ctx context.Context, crossID, chainID, phase string, timeout time.Duration,
+	op func() (*event.ProofResponse, error)) error {
+	var (
+		policy   = tm.getRetryPolicy(crossID)
+		deadline time.Time
+		lastErr  error
+	)
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		wait := policy.Backoff(uint(attempt))
+		if !deadline.IsZero() && time.Now().Add(wait).After(deadline) {
+			return fmt.Errorf("%s deadline[%v] exceeded after %v retries, last error: %v", phase, timeout, attempt-1, lastErr)
+		}
+		// 先进行休眠，失去leader时立即退出
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s interrupted after %v retries, %v", phase, attempt-1, ctx.Err())
+		case <-time.After(wait):
+		}
+		re, err := op()
+		if err == nil && re.IsSuccess() {
+			return nil
+		}
+		if err != nil {
+			lastErr = err
+		} else {
+			lastErr = errors.New(re.Msg)
+		}
+		tm.logger.Warnf("cross[%v]->chain[%v]->[%v] %s failed, %v", crossID, chainID, attempt, phase, lastErr)
+	}
+	return fmt.Errorf("%s retries exhausted after %v attempts, last error: %v", phase, policy.MaxAttempts, lastErr)
+}
+
+// moveToDeadLetter move the cross into the dead-letter set, which can be re-driven by operator
+func (tm *Manager) moveToDeadLetter(crossID, chainID, phase string, state storetype.State, err error) {
+	if !tm.holdsLease(crossID) {
+		return
+	}
+	tm.logger.Errorf("cross[%v]->chain[%v] will be moved to dead-letter set, %v", crossID, chainID, err)
+	deadLetter := &storetype.DeadLetter{
+		CrossID:   crossID,
+		ChainID:   chainID,
+		Phase:     phase,
+		State:     state,
+		Reason:    err.Error(),
+		Timestamp: time.Now().Unix(),
+	}
+	if err := tm.db.AddDeadLetter(deadLetter); err != nil {
+		tm.logger.Errorf("add cross[%v] to dead-letter set error, %v", crossID, err)
+	}
+}
+
+// Redrive move the cross from the dead-letter set back to the unfinished, and handle it from the interrupted phase
+func (tm *Manager) Redrive(crossID string) error {
+	ctx, running := tm.runContext()
+	if !running {
+		return notRunningError
+	}
+	if _, exist := tm.db.ReadDeadLetter(crossID); !exist {
+		return fmt.Errorf("cross[%s] is not in the dead-letter set", crossID)
+	}
+	crossEvent, err := tm.loadCrossEvent(crossID)
+	if err != nil {
+		return err
+	}
+	if !tm.holdsLease(crossID) {
+		return notLeaseHolderError
+	}
+	if err = tm.db.RedriveDeadLetter(crossID); err != nil {
+		return err
+	}
+	tm.logger.Infof("cross[%s] is redriven from dead-letter set", crossID)
+	tm.handleRecovery(ctx, crossEvent)
+	return nil
+}
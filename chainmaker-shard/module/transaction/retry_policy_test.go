@@ -0,0 +1,120 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package transaction
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/event"
+	"chainmaker.org/chainmaker-cross/monitor"
+	"chainmaker.org/chainmaker-cross/store"
+	storetype "chainmaker.org/chainmaker-cross/store/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicy_Bind(t *testing.T) {
+	manager := &Manager{retryPolicies: newRetryPolicies()}
+	crossID := "retry-policy-cross"
+	// 未绑定时使用代理的配置
+	require.Equal(t, conf.Config.GetRetryPolicy(), manager.getRetryPolicy(crossID))
+	require.True(t, manager.bindRetryPolicy(&eventproto.CrossEvent{
+		CrossId: crossID,
+		RetryPolicy: &eventproto.RetryPolicy{
+			MaxAttempts:    3,
+			ExecuteTimeout: 1000,
+		},
+	}))
+	policy := manager.getRetryPolicy(crossID)
+	require.Equal(t, 3, policy.MaxAttempts)
+	require.Equal(t, time.Second, policy.GetExecuteTimeout())
+	require.Equal(t, conf.Config.GetRetryPolicy().InitialBackoff, policy.InitialBackoff)
+	// 已绑定的策略保持不变，由绑定者负责移除
+	require.False(t, manager.bindRetryPolicy(&eventproto.CrossEvent{CrossId: crossID}))
+	require.Equal(t, policy, manager.getRetryPolicy(crossID))
+	manager.unbindRetryPolicy(crossID)
+	require.Equal(t, conf.Config.GetRetryPolicy(), manager.getRetryPolicy(crossID))
+}
+
+func TestInflightCrosses(t *testing.T) {
+	manager := &Manager{inflight: newInflightCrosses()}
+	crossID := "inflight-cross"
+	require.False(t, manager.isHandling(crossID))
+	require.True(t, manager.beginHandling(context.Background(), crossID))
+	require.True(t, manager.isHandling(crossID))
+	require.False(t, manager.beginHandling(context.Background(), crossID))
+	manager.endHandling(crossID)
+	require.False(t, manager.isHandling(crossID))
+}
+
+func TestRetryPolicy_RetryPhase(t *testing.T) {
+	conf.Config.StorageConfig = &conf.StorageConfig{
+		Provider: "memory",
+	}
+	stateDB := store.InitStateDB()
+	defer stateDB.Close()
+	manager := &Manager{
+		db:            stateDB,
+		retryPolicies: newRetryPolicies(),
+		logger:        getLogger(),
+	}
+	crossID := "retry-phase-cross"
+	require.Nil(t, stateDB.StartCross(crossID, []byte("content")))
+	manager.bindRetryPolicy(&eventproto.CrossEvent{
+		CrossId: crossID,
+		RetryPolicy: &eventproto.RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: 1,
+			Multiplier:     2,
+		},
+	})
+	defer manager.unbindRetryPolicy(crossID)
+
+	// 重试成功
+	attempts := 0
+	err := manager.retryPhase(context.Background(), crossID, chain1, monitor.PhaseCommit, 0, func() (*event.ProofResponse, error) {
+		attempts++
+		if attempts < 2 {
+			return nil, errors.New("commit error")
+		}
+		return newProofResponse(crossID, event.SuccessResp, ""), nil
+	})
+	require.Nil(t, err)
+	require.Equal(t, 2, attempts)
+
+	// 重试耗尽
+	attempts = 0
+	err = manager.retryPhase(context.Background(), crossID, chain1, monitor.PhaseCommit, 0, func() (*event.ProofResponse, error) {
+		attempts++
+		return newProofResponse(crossID, event.FailureResp, "commit failed"), nil
+	})
+	require.NotNil(t, err)
+	require.Equal(t, 3, attempts)
+
+	// 超过截止时间
+	err = manager.retryPhase(context.Background(), crossID, chain1, monitor.PhaseRollback, time.Nanosecond, func() (*event.ProofResponse, error) {
+		return nil, errors.New("should not be called")
+	})
+	require.NotNil(t, err)
+
+	manager.moveToDeadLetter(crossID, chain1, monitor.PhaseCommit, storetype.StateCommitFailed, err)
+	deadLetter, exist := stateDB.ReadDeadLetter(crossID)
+	require.True(t, exist)
+	require.Equal(t, storetype.StateCommitFailed, deadLetter.State)
+	require.Equal(t, []string{crossID}, stateDB.ReadDeadLetterCrossIDs())
+	require.NotContains(t, stateDB.ReadUnfinishedCrossIDs(), crossID)
+}
+
+func newProofResponse(crossID string, code int32, msg string) *event.ProofResponse {
+	resp := event.NewProofResponse(crossID, chain1, event.CommitOpFunc)
+	resp.Code, resp.Msg = code, msg
+	return resp
+}
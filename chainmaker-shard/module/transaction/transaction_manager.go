@@ -18,9 +18,11 @@ import (
 
 	"chainmaker.org/chainmaker-cross/adapter"
 	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/conf/chaos"
 	"chainmaker.org/chainmaker-cross/event"
 	"chainmaker.org/chainmaker-cross/event/coder"
 	"chainmaker.org/chainmaker-cross/logger"
+	"chainmaker.org/chainmaker-cross/monitor"
 	"chainmaker.org/chainmaker-cross/prover"
 	"chainmaker.org/chainmaker-cross/router"
 	"chainmaker.org/chainmaker-cross/store"
@@ -29,15 +31,13 @@ import (
 )
 
 const (
-	RetryCount                     = 5000
 	EventChannelLength             = 1024 * 64
-	RetryPeriod                    = time.Second * 15
-	SupportedChainCount            = 2
-	ChainFirstIdx                  = 0
-	ChainSecondIdx                 = 1
+	MinSupportedChainCount         = 2
 	CrossChainProofSaveErrorFormat = "save chain[%s] cross[%s] proof to chain error"
 	DBCrossStateErrorFormat        = "save cross[%s] state[%v] error"
 	DBCrossChainStateErrorFormat   = "save chain[%s] cross[%s] state[%v] error"
+	EventChannelMetricName         = "transaction_manager"
+	ChainTransitionRetries         = 3 // 链状态被并发修改时的重试次数
 )
 
 var (
@@ -45,6 +45,9 @@ var (
 	crossEventCoderInitError = errors.New("can not find event coder to handle cross-event")
 	crossRespCoderInitError  = errors.New("can not find event coder to handle cross-resp")
 	txProofCoderInitError    = errors.New("can not find event coder to handle tx-proof")
+	proofConvertError        = errors.New("can not convert to tx-proof")
+	notRunningError          = errors.New("transaction manager is not running, this proxy may not be the leader")
+	notLeaseHolderError      = errors.New("this proxy does not hold the leader lease")
 	crossChainStateSuccess   = "cross chain success"
 )
 
@@ -59,9 +62,20 @@ func init() {
 		crossEventCoder:   coder.GetCrossEventCoder(),
 		crossRespCoder:    coder.GetCrossRespEventCoder(),
 		txProofCoder:      coder.GetTransactionProofCoder(),
+		retryPolicies:     newRetryPolicies(),
+		deadlines:         newCrossDeadlines(),
+		inflight:          newInflightCrosses(),
 	}
 }
 
+// chainCrossState state of one chain in cross which is loaded from database
+type chainCrossState struct {
+	crossTx *eventproto.CrossTx // 该链对应的跨链交易
+	state   storetype.State     // 该链的状态
+	result  []byte              // 该链的结果，如交易证明
+	exist   bool                // 状态是否存在
+}
+
 // Manager is module for transaction
 type Manager struct {
 	db                store.StateDB                   // 存储
@@ -72,8 +86,15 @@ type Manager struct {
 	crossEventCoder   event.EventCoder                // 跨链事件编解码器
 	crossRespCoder    event.EventCoder                // 跨链返回编解码器
 	txProofCoder      event.EventCoder                // 交易证明编解码器
+	retryPolicies     *retryPolicies                  // 处理中的跨链事件的重试策略
+	deadlines         *crossDeadlines                 // 处理中的跨链事件的截止时间
+	inflight          *inflightCrosses                // 处理中的跨链事件
+	leaseChecker      LeaseChecker                    // 高可用模式下写入状态前检查租约，未开启时为nil
 	logger            *zap.SugaredLogger              // log
+	runLock           sync.Mutex                      // 启停锁
+	ctx               context.Context                 // 本次运行的上下文，停止时取消
 	cancel            context.CancelFunc              // 退出函数
+	wg                sync.WaitGroup                  // 等待处理中的跨链事件退出
 }
 
 // GetTransactionManager return the instance of transaction manager
@@ -126,22 +147,72 @@ func (tm *Manager) Start() error {
 			return txProofCoderInitError
 		}
 	}
+	// 监控事件通道积压情况，重复启动时已注册
+	if err := monitor.RegisterEventChanDepth(EventChannelMetricName, func() int { return len(tm.eventCh) }); err != nil {
+		tm.logger.Warn("register event channel depth metric failed, ", err)
+	}
 	ctx, cancelFunc := context.WithCancel(context.Background())
-	tm.cancel = cancelFunc
-	tm.handleDBCrossEventsStart()
+	tm.runLock.Lock()
+	tm.ctx, tm.cancel = ctx, cancelFunc
+	tm.runLock.Unlock()
+	tm.handleDBCrossEventsStart(ctx)
 	tm.handleChanCrossEventsStart(ctx)
 	return nil
 }
 
-// Stop stop the transaction manager
+// Stop stop the transaction manager and wait for the cross events which are being handled to exit,
+// the manager can be started again, such as when it is elected as leader again
 func (tm *Manager) Stop() {
-	tm.cancel()
+	tm.runLock.Lock()
+	if tm.cancel != nil {
+		tm.cancel()
+		tm.cancel = nil
+	}
+	tm.runLock.Unlock()
+	// 处理中的跨链事务退出后才能释放租约，由其他代理接管
+	tm.wg.Wait()
+}
+
+// runContext return the context of current run, false will be returned if the manager is not running
+func (tm *Manager) runContext() (context.Context, bool) {
+	tm.runLock.Lock()
+	defer tm.runLock.Unlock()
+	if tm.ctx == nil || tm.ctx.Err() != nil {
+		return nil, false
+	}
+	return tm.ctx, true
+}
+
+// goHandle run the handler of cross event in a goroutine which is waited by Stop,
+// false will be returned if the context has been canceled
+func (tm *Manager) goHandle(ctx context.Context, handler func()) bool {
+	tm.runLock.Lock()
+	if ctx.Err() != nil {
+		tm.runLock.Unlock()
+		return false
+	}
+	tm.wg.Add(1)
+	tm.runLock.Unlock()
+	go func() {
+		defer tm.wg.Done()
+		handler()
+	}()
+	return true
+}
+
+// interrupted return whether the handler of cross has been stopped, the cross is left to the recovery of leader
+func (tm *Manager) interrupted(crossID string) bool {
+	if tm.crossContext(crossID).Err() == nil {
+		return false
+	}
+	tm.logger.Warnf("cross[%v] is interrupted since transaction manager stopped, it will be recovered by the leader", crossID)
+	return true
 }
 
 // handleDBCrossEventsStart
 //- 处理服务重启后，事物模块中缓存的所有跨链事物
-func (tm *Manager) handleDBCrossEventsStart() {
-	go func() {
+func (tm *Manager) handleDBCrossEventsStart(ctx context.Context) {
+	tm.goHandle(ctx, func() {
 		crossEventBytesArray, err := tm.recoverUnfinishedCrossEvents()
 		if err != nil {
 			tm.logger.Error("Module transaction-manager recover unfinished cross error")
@@ -151,6 +222,10 @@ func (tm *Manager) handleDBCrossEventsStart() {
 			tm.logger.Infof("Module transaction-manager recover unfinished cross: %s", crossEventBytesArray)
 		}
 		for _, crossEventBytes := range crossEventBytesArray {
+			if ctx.Err() != nil {
+				tm.logger.Info("Module transaction-manager stopped, recovery is interrupted")
+				return
+			}
 			eve, err := tm.crossEventCoder.UnmarshalFromBinary(crossEventBytes)
 			if err != nil {
 				tm.logger.Error("Module transaction-manager recover unfinished cross error")
@@ -161,7 +236,7 @@ func (tm *Manager) handleDBCrossEventsStart() {
 				// 跨链事件
 				tm.logger.Info("I recover cross event, will handle it!")
 				if crossEvent, ok := eve.(*eventproto.CrossEvent); ok {
-					tm.handleRecovery(crossEvent)
+					tm.handleRecovery(ctx, crossEvent)
 				} else {
 					tm.logger.Warn("This recovery event can not convert to event.CrossEvent")
 				}
@@ -169,13 +244,13 @@ func (tm *Manager) handleDBCrossEventsStart() {
 				tm.logger.Errorf("Recovery event type is [%v], so I will not handle it!", eventType)
 			}
 		}
-	}()
+	})
 }
 
 // handleDBCrossEventsStart
 //- 处理跨链事物
-func (tm Manager) handleChanCrossEventsStart(ctx context.Context) {
-	go func(ctx context.Context) {
+func (tm *Manager) handleChanCrossEventsStart(ctx context.Context) {
+	tm.goHandle(ctx, func() {
 		for {
 			select {
 			case <-ctx.Done():
@@ -195,7 +270,7 @@ func (tm Manager) handleChanCrossEventsStart(ctx context.Context) {
 							tm.logger.Errorf("This event's crossTxs not match to it's index, so I will not handle it!")
 							continue
 						}
-						tm.handle(crossEvent, false)
+						tm.handle(ctx, crossEvent, false)
 					} else {
 						tm.logger.Warn("This event can not convert to CrossEvent")
 					}
@@ -204,458 +279,495 @@ func (tm Manager) handleChanCrossEventsStart(ctx context.Context) {
 				}
 			}
 		}
-	}(ctx)
+	})
 }
 
-// handle the cross event
-func (tm *Manager) handle(eve *eventproto.CrossEvent, isSync bool) {
+// handle the cross event, the handler exits when ctx is canceled
+func (tm *Manager) handle(ctx context.Context, eve *eventproto.CrossEvent, isSync bool) {
 	if isSync {
-		tm.innerHandle(eve)
+		tm.innerHandle(ctx, eve)
 	} else {
-		go func(eve *eventproto.CrossEvent) {
-			tm.innerHandle(eve)
-		}(eve)
+		tm.goHandle(ctx, func() {
+			tm.innerHandle(ctx, eve)
+		})
 	}
 }
 
 // innerHandle which is inner handle for sync
-func (tm *Manager) innerHandle(eve *eventproto.CrossEvent) {
+func (tm *Manager) innerHandle(ctx context.Context, eve *eventproto.CrossEvent) {
 	// 开始该事务处理
 	crossID := eve.GetCrossID()
-	// 目前只支持两条链的跨链操作
-	chainIDs := eve.GetChainIDs()
-	if len(chainIDs) != SupportedChainCount {
-		tm.logger.Errorf("just support %v chains for cross-event %s", SupportedChainCount, crossID)
+	// 跨链操作至少需要两条链，且每条链只能出现一次
+	if err := checkChainIDs(eve.GetChainIDs()); err != nil {
+		tm.logger.Errorf("cross-event %s is invalid, %v", crossID, err)
 		return
 	}
+	monitor.ObserveCrossEvent(eve.GetChainIDs(), monitor.CrossReceived)
 	content, err := tm.crossEventCoder.MarshalToBinary(eve)
 	if err != nil {
 		// 记录错误，无需处理其他
-		_ = tm.db.FinishCross(crossID, []byte(err.Error()), storetype.StateFailed)
+		tm.finishCross(crossID, storetype.StateUnknown, storetype.StateFailed, []byte(err.Error()))
+		return
+	}
+	if !tm.holdsLease(crossID) {
 		return
 	}
-	if err = tm.db.StartCross(crossID, content); err != nil {
+	// 跨链消息、状态、涉及的链及未完成集合原子写入，重复的跨链ID会被拒绝
+	if err = tm.db.ApplyTransition(&storetype.Transition{
+		CrossID:  crossID,
+		From:     storetype.StateUnknown,
+		To:       storetype.StateInit,
+		Payload:  content,
+		ChainIDs: eve.GetChainIDs(),
+	}); err != nil {
 		tm.logger.Error("save event cross start error", err)
 		return
 	}
+	if err = tm.db.IndexCross(&storetype.CrossIndex{
+		CrossID:   crossID,
+		ChainIDs:  eve.GetChainIDs(),
+		Initiator: eve.GetInitiator(),
+	}); err != nil {
+		tm.logger.Warnf("index cross[%s] error, %v", crossID, err)
+	}
+	if !tm.beginHandling(ctx, crossID) {
+		tm.logger.Errorf("cross[%v] is being handled", crossID)
+		return
+	}
+	defer tm.endHandling(crossID)
+	if tm.bindRetryPolicy(eve) {
+		defer tm.unbindRetryPolicy(crossID)
+	}
+	tm.bindDeadline(eve)
+	defer tm.unbindDeadline(crossID)
 	txEvents := eve.GetPkgTxEvents()
 	// sort by index
 	sort.Sort(txEvents)
+	if eve.IsParallel() {
+		// 各交易之间无数据依赖，并发执行
+		tm.handleParallelTxEvents(crossID, txEvents.GetCrossTxs())
+		return
+	}
 	tm.handleTxEvents(crossID, txEvents.GetCrossTxs())
 }
 
-// Handle handle the cross event which is unfinished last time
-func (tm *Manager) handleRecovery(eve *eventproto.CrossEvent) {
-	go func(eve *eventproto.CrossEvent) {
+// Handle handle the cross event which is unfinished last time, the handler exits when ctx is canceled
+func (tm *Manager) handleRecovery(ctx context.Context, eve *eventproto.CrossEvent) {
+	tm.goHandle(ctx, func() {
 		// 开始该跨链事务处理
 		crossID := eve.GetCrossID()
-		chainIDs := eve.GetChainIDs()
-		if len(chainIDs) != SupportedChainCount {
-			tm.logger.Errorf("just support %v chains for cross-event %s", SupportedChainCount, crossID)
+		if err := checkChainIDs(eve.GetChainIDs()); err != nil {
+			tm.logger.Errorf("cross-event %s is invalid, %v", crossID, err)
 			return
 		}
+		// 重新成为leader时，仍在处理中的跨链事务无需恢复
+		if !tm.beginHandling(ctx, crossID) {
+			tm.logger.Infof("cross[%v] is being handled, skip recovery", crossID)
+			return
+		}
+		defer tm.endHandling(crossID)
+		if tm.bindRetryPolicy(eve) {
+			defer tm.unbindRetryPolicy(crossID)
+		}
+		tm.bindDeadline(eve)
+		defer tm.unbindDeadline(crossID)
 		txEvents := eve.GetPkgTxEvents()
 		sort.Sort(txEvents)
-		crossTxs := txEvents.Events //GetCrossTxs()
-		firstEventTx, secondEventTx := crossTxs[ChainFirstIdx], crossTxs[ChainSecondIdx]
-		// 检查第一笔交易的状态
-		firstTxState, result, exist := tm.db.ReadChainCrossState(crossID, firstEventTx.GetChainID())
-		if !exist {
+		crossTxs := txEvents.GetCrossTxs()
+		// 读取每条链的状态
+		chainStates := make([]*chainCrossState, 0, len(crossTxs))
+		for _, crossTx := range crossTxs {
+			state, result, exist := tm.db.ReadChainCrossState(crossID, crossTx.GetChainID())
+			chainStates = append(chainStates, &chainCrossState{
+				crossTx: crossTx,
+				state:   state,
+				result:  result,
+				exist:   exist,
+			})
+		}
+		if hasAnyState(chainStates, storetype.StateCommitSuccess, storetype.StateCommitFailed) {
+			// 已进入提交阶段，将尚未提交成功的交易全部提交
+			tm.commitUnfinishedTxs(crossID, chainStates)
+			return
+		}
+		if hasAnyState(chainStates, storetype.StateRollbackSuccess, storetype.StateRollbackFailed) {
+			// 已进入回滚阶段，将尚未回滚成功的交易全部回滚
+			tm.rollbackUnfinishedTxs(crossID, chainStates)
+			return
+		}
+		// 仍处于执行阶段但已超过截止时间，回滚所有交易
+		if tm.abortIfExpired(crossID, crossTxs) {
+			return
+		}
+		if eve.IsParallel() {
+			// 并发执行的交易无法确定中断位置，只有全部证明成功才能提交
+			tm.recoverParallelExecution(crossID, crossTxs, chainStates)
+			return
+		}
+		// 仍处于执行阶段，从中断处继续执行
+		tm.recoverExecution(crossID, crossTxs, chainStates)
+	})
+}
+
+// recoverExecution continue execute the cross txs from the first tx which has not been handled
+func (tm *Manager) recoverExecution(crossID string, crossTxs []*eventproto.CrossTx, chainStates []*chainCrossState) {
+	handledPkgTxEvents := make([]*eventproto.CrossTx, 0, len(crossTxs))
+	allResponse := make([]*event.ProofResponse, 0, len(crossTxs))
+	var prevProof *eventproto.Proof
+	for idx, chainState := range chainStates {
+		if tm.interrupted(crossID) {
+			return
+		}
+		crossTx := chainState.crossTx
+		chainID := crossTx.GetChainID()
+		if !chainState.exist {
+			if idx > 0 {
+				// 当前交易没有状态，使用上一笔交易的证明从当前交易开始重新执行
+				tm.executeTxEvents(crossID, crossTxs, idx, handledPkgTxEvents, allResponse, prevProof)
+				return
+			}
 			// 第一笔交易不存在，存在两种情况：
-			// 1、该交易尚未发送就宕机，则需要两笔都重新发一下
-			// 2、该交易已经发送，则需要判断其状态，然后再判断第二笔是否需要发送
-			txResponse, err := tm.adapterDispatcher.Query(firstEventTx.GetChainID(), firstEventTx.ExecutePayload)
+			// 1、该交易尚未发送就宕机，则需要全部重新发一下
+			// 2、该交易已经发送，则需要判断其状态，然后再判断后续交易是否需要发送
+			txResponse, err := tm.adapterDispatcher.Query(chainID, crossTx.ExecutePayload)
 			if err != nil || txResponse == nil {
 				// 表示出现错误、或没有应答，则重新执行
 				tm.handleTxEvents(crossID, crossTxs)
-			} else {
-				// 判断当前交易的状态
-				if txResponse.IsSuccess() {
-					// 执行下一笔交易
-					// 生成第一笔交易的证明
-					proof := event.NewProof(txResponse.GetChainID(), txResponse.TxKey, txResponse.BlockHeight,
-						txResponse.Index, txResponse.Contract, txResponse.Extra)
-					if proofBytes, err := tm.txProofCoder.MarshalToBinary(proof); err != nil {
-						tm.logger.Errorf("cross[%v]->chain[%v]'s tx-proof marshal error", crossID, proof.GetChainID(), err)
-					} else {
-						if err := tm.db.WriteChainCrossState(crossID, proof.GetChainID(), storetype.StateExecuteSuccess, proofBytes); err != nil {
-							tm.logger.Errorf(DBCrossChainStateErrorFormat, proof.GetChainID(), crossID, storetype.StateExecuteSuccess)
-						}
-					}
-					// 重新执行第二笔交易
-					tm.retrySecondTx(crossID, firstEventTx, secondEventTx, proof)
+				return
+			}
+			if !txResponse.IsSuccess() {
+				tm.logger.Errorf("cross[%v]->chain[%v]'s execute failed, %s", crossID, chainID, txResponse.Msg)
+				tm.recordChainState(crossID, chainID, storetype.StateFailed)
+				// 当前交易是否回滚由事务合约控制
+				tm.rollbackHandledEvents(crossID, []*eventproto.CrossTx{crossTx}, fmt.Errorf("chain[%v]'s execute failed for %s", chainID, txResponse.Msg))
+				tm.recordInterruptedState(crossID, []byte(fmt.Sprintf("chain[%v]'s execute failed for %s", chainID, txResponse.Msg)))
+				return
+			}
+			// 生成第一笔交易的证明
+			proof := event.NewProof(txResponse.GetChainID(), txResponse.TxKey, txResponse.BlockHeight,
+				txResponse.Index, txResponse.Contract, txResponse.Extra)
+			tm.recordChainProof(crossID, chainID, storetype.StateExecuteSuccess, proof)
+			handledPkgTxEvents = append(handledPkgTxEvents, crossTx)
+			allResponse = append(allResponse, event.NewProofResponseByProof(crossID, chainID, crossChainStateSuccess, event.SuccessResp, event.ExecuteOpFunc, proof))
+			prevProof = proof
+			continue
+		}
+		switch chainState.state {
+		case storetype.StateExecuteSuccess, storetype.StateProofSuccess:
+			proof, err := tm.unmarshalProof(chainState.result)
+			if err != nil {
+				tm.logger.Errorf("cross[%v]->chain[%v]'s proof can not be convert, ", crossID, chainID, err)
+				// 记录该链处理错误
+				tm.recordChainState(crossID, chainID, storetype.StateFailed)
+				// 此时有错误，回滚已执行的交易
+				tm.rollbackHandledEvents(crossID, append(handledPkgTxEvents, crossTx), err)
+				tm.recordInterruptedState(crossID, []byte(fmt.Sprintf("execute chain[%v] error", chainID)))
+				return
+			}
+			if idx > 0 {
+				// 重新将证明写到上一条链上，允许重复保存
+				if err := tm.saveProof(crossTxs[idx-1].GetChainID(), crossID, crossTx.ProofKey, proof, true); err != nil {
+					tm.logger.Errorf(CrossChainProofSaveErrorFormat, chainID, crossID)
 				}
 			}
-			return
-		} else {
-			// 获取第二笔交易的状态
-			secondTxState, secondResult, secondStateExist := tm.db.ReadChainCrossState(crossID, secondEventTx.GetChainID())
-			if firstTxState == storetype.StateExecuteSuccess {
-				if !secondStateExist {
-					// 第二笔交易没有状态，那么需要重新进行提交
-					// 对证明进行转换
-					proofEvent, err := tm.txProofCoder.UnmarshalFromBinary(result)
-					if err != nil {
-						tm.logger.Errorf("unmarshal cross[%s]->chain[%s] failed", crossID, secondEventTx.GetChainID())
-						// 处理本地回退
-						if ok := tm.rollbackCrossTx(crossID, firstEventTx); ok {
-							// 删除 unfinished
-							tm.finishCrossEvent(crossID)
-						}
-						return
-					}
-					if proof, ok := proofEvent.(*eventproto.Proof); ok {
-						// 重新执行第二笔交易
-						tm.retrySecondTx(crossID, firstEventTx, secondEventTx, proof)
-						return
-					} else {
-						// 打印日志，本地节点回滚
-						tm.logger.Errorf("cross[%v]->chain[%v]'s proof can not be convert", crossID, firstEventTx.GetChainID())
-						// 记录该链处理错误
-						if err := tm.db.WriteChainCrossState(crossID, firstEventTx.GetChainID(), storetype.StateFailed, nil); err != nil {
-							tm.logger.Errorf(DBCrossChainStateErrorFormat, firstEventTx.GetChainID(), crossID, storetype.StateFailed)
-						}
-						// 此时有错误，回滚第一笔交易
-						tm.rollbackCrossTx(crossID, firstEventTx)
-						// 记录整体状态，结束该事务
-						tm.recordInterruptedState(crossID, []byte(fmt.Sprintf("execute chain[%v] error", firstEventTx.GetChainID())))
-						return
-					}
+			handledPkgTxEvents = append(handledPkgTxEvents, crossTx)
+			allResponse = append(allResponse, event.NewProofResponseByProof(crossID, chainID, crossChainStateSuccess, event.SuccessResp, event.ExecuteOpFunc, proof))
+			prevProof = proof
+		case storetype.StateProofFailed, storetype.StateProofConvertFailed:
+			if idx > 0 && chainState.state == storetype.StateProofFailed {
+				// 重新将证明写到上一条链上
+				if proof, err := tm.unmarshalProof(chainState.result); err != nil {
+					tm.logger.Errorf("cross[%v]->chain[%v]'s proof can not be convert", crossID, chainID)
 				} else {
-					// 判断其状态，若提交成功，则只需要提交第一笔交易
-					if secondTxState == storetype.StateCommitSuccess {
-						// 另外一笔交易已经提交成功，当前交易提交
-						tm.commitDesignativeTx(crossID, firstEventTx)
-						return
-					}
-					// 若操作失败，则需要重新提交两笔
-					if secondTxState == storetype.StateCommitFailed {
-						tm.commitTwoTxs(crossID, firstEventTx, secondEventTx)
-						return
-					}
-					// 若操作失败，则需要回退两笔交易
-					if secondTxState == storetype.StateProofConvertFailed || secondTxState == storetype.StateProofFailed {
-						// 记录证明到本地
-						secondProofEvent, err := tm.txProofCoder.UnmarshalFromBinary(secondResult)
-						if err != nil {
-							tm.logger.Errorf("cross[%v]->chain[%v]'s proof can not be convert", crossID, secondEventTx.GetChainID())
-						} else {
-							// 保存证明信息，写到链上
-							if proof, ok := secondProofEvent.(*eventproto.Proof); ok {
-								err = tm.saveProof(firstEventTx.GetChainID(), crossID, firstEventTx.ProofKey, proof, false)
-								if err != nil {
-									tm.logger.Errorf("cross[%v]->chain[%v]'s save chain[%s]'s proof error, ", crossID, firstEventTx.GetChainID(), secondEventTx.GetChainID(), err)
-								}
-							}
-						}
-						// 两个都进行回退操作
-						tm.rollbackTwoTxs(crossID, firstEventTx, secondEventTx)
-						return
-					}
-					if secondTxState == storetype.StateProofSuccess {
-						// 记录证明到本地
-						secondProofEvent, err := tm.txProofCoder.UnmarshalFromBinary(secondResult)
-						if err != nil {
-							tm.logger.Errorf("cross[%v]->chain[%v]'s proof can not be convert", crossID, secondEventTx.GetChainID())
-						} else {
-							// 保存证明信息，写到链上
-							if proof, ok := secondProofEvent.(*eventproto.Proof); ok {
-								err = tm.saveProof(firstEventTx.GetChainID(), crossID, firstEventTx.ProofKey, proof, true)
-								if err != nil {
-									tm.logger.Errorf("cross[%v]->chain[%v]'s save chain[%s]'s proof error, ", crossID, firstEventTx.GetChainID(), secondEventTx.GetChainID(), err)
-								}
-							}
-						}
-						// 另外一笔已经成功执行，需要提交两笔交易
-						tm.commitTwoTxs(crossID, firstEventTx, secondEventTx)
-						return
+					if err := tm.saveProof(crossTxs[idx-1].GetChainID(), crossID, crossTx.ProofKey, proof, false); err != nil {
+						tm.logger.Errorf(CrossChainProofSaveErrorFormat, chainID, crossID)
 					}
 				}
-			} else if firstTxState == storetype.StateCommitSuccess {
-				// 提交成功，则判断第二笔是否提交成功
-				if secondTxState == storetype.StateCommitSuccess {
-					// 两笔都提交成功的话，只需要删除即可
-					tm.finishCrossEvent(crossID)
-					return
-				} else {
-					// 提交第二笔
-					tm.commitDesignativeTx(crossID, secondEventTx)
-					return
-				}
-			} else if firstTxState == storetype.StateCommitFailed {
-				// 提交失败的话，判断第二笔是否成功
-				if secondTxState == storetype.StateCommitSuccess {
-					// 只需要提交第一笔即可
-					tm.commitDesignativeTx(crossID, firstEventTx)
-					return
-				} else {
-					tm.commitTwoTxs(crossID, firstEventTx, secondEventTx)
-					return
-				}
-			} else if firstTxState == storetype.StateRollbackSuccess {
-				// 判断第二笔是否回滚成功
-				if secondTxState == storetype.StateRollbackSuccess {
-					tm.finishCrossEvent(crossID)
-					return
-				} else {
-					tm.rollbackDesignativeTx(crossID, secondEventTx)
-					return
-				}
-			} else if firstTxState == storetype.StateRollbackFailed {
-				if secondTxState == storetype.StateRollbackSuccess {
-					tm.rollbackDesignativeTx(crossID, firstEventTx)
-					return
-				} else {
-					tm.rollbackTwoTxs(crossID, firstEventTx, secondEventTx)
-					return
-				}
 			}
+			// 证明失败，回滚所有已执行的交易
+			tm.rollbackHandledEvents(crossID, append(handledPkgTxEvents, crossTx), fmt.Errorf("can not prove chain[%v]'s proof", chainID))
+			tm.recordInterruptedState(crossID, []byte(fmt.Sprintf("can not prove chain[%v]'s proof", chainID)))
+			return
+		case storetype.StateFailed:
+			// 执行失败，回滚已执行的交易及当前交易
+			tm.rollbackHandledEvents(crossID, append(handledPkgTxEvents, crossTx), fmt.Errorf("execute chain[%v] error", chainID))
+			tm.recordInterruptedState(crossID, []byte(fmt.Sprintf("execute chain[%v] error", chainID)))
+			return
+		default:
+			tm.logger.Errorf("cross[%v]->chain[%v]'s state[%v] can not be recovered", crossID, chainID, chainState.state)
+			return
+		}
+	}
+	// 所有交易均已执行成功，距截止时间有足够的剩余时间则进行提交
+	if tm.abortIfNoCommitMargin(crossID, handledPkgTxEvents) {
+		return
+	}
+	tm.commitAll(crossID, handledPkgTxEvents, allResponse)
+}
+
+// recoverParallelExecution commit the cross txs if all of them are proved, otherwise rollback all of them
+func (tm *Manager) recoverParallelExecution(crossID string, crossTxs []*eventproto.CrossTx, chainStates []*chainCrossState) {
+	allResponse := make([]*event.ProofResponse, 0, len(crossTxs))
+	for _, chainState := range chainStates {
+		chainID := chainState.crossTx.GetChainID()
+		if chainState.exist && chainState.state == storetype.StateProofSuccess {
+			if proof, err := tm.unmarshalProof(chainState.result); err == nil {
+				allResponse = append(allResponse, event.NewProofResponseByProof(crossID, chainID, crossChainStateSuccess, event.SuccessResp, event.ExecuteOpFunc, proof))
+				continue
+			}
+			tm.logger.Errorf("cross[%v]->chain[%v]'s proof can not be convert", crossID, chainID)
+		}
+		// 存在未完成或失败的交易，全部回滚
+		err := fmt.Errorf("chain[%v] is not executed successfully before interrupted", chainID)
+		tm.rollbackHandledEvents(crossID, crossTxs, err)
+		tm.recordInterruptedState(crossID, []byte(err.Error()))
+		return
+	}
+	if tm.abortIfNoCommitMargin(crossID, crossTxs) {
+		return
+	}
+	tm.commitAll(crossID, crossTxs, allResponse)
+}
+
+// handleParallelTxEvents execute all the cross txs concurrently, and then commit or rollback all of them
+func (tm *Manager) handleParallelTxEvents(crossID string, crossTxs []*eventproto.CrossTx) {
+	var (
+		wg          sync.WaitGroup
+		allResponse = make([]*event.ProofResponse, len(crossTxs))
+		allErrors   = make([]error, len(crossTxs))
+	)
+	if tm.abortIfExpired(crossID, nil) {
+		return
+	}
+	wg.Add(len(crossTxs))
+	for idx, crossTx := range crossTxs {
+		go func(idx int, crossTx *eventproto.CrossTx) {
+			defer wg.Done()
+			allResponse[idx], allErrors[idx] = tm.executeAndProve(crossID, crossTx)
+		}(idx, crossTx)
+	}
+	wg.Wait()
+	// 超过截止时间，所有交易均需要回滚
+	if tm.abortIfExpired(crossID, crossTxs) {
+		return
+	}
+	for _, err := range allErrors {
+		if err != nil {
+			// 存在失败的交易，所有交易均需要回滚，当前交易是否回滚由事务合约控制
+			tm.rollbackHandledEvents(crossID, crossTxs, err)
+			tm.recordInterruptedState(crossID, []byte(err.Error()))
+			return
 		}
-	}(eve)
+	}
+	// 所有交易均执行并证明成功，距截止时间有足够的剩余时间则并发进行commit操作
+	if tm.abortIfNoCommitMargin(crossID, crossTxs) {
+		return
+	}
+	tm.commitAll(crossID, crossTxs, allResponse)
+}
+
+// executeAndProve execute the cross tx without proof, prove its result and save the proof to the same chain
+func (tm *Manager) executeAndProve(crossID string, crossTx *eventproto.CrossTx) (*event.ProofResponse, error) {
+	chainID := crossTx.GetChainID()
+	resp, err := tm.execute(crossID, crossTx, nil)
+	if err != nil {
+		tm.logger.Errorf("cross[%v]->chain[%v]'s execute payload error, ", crossID, chainID, err)
+		tm.recordChainState(crossID, chainID, storetype.StateFailed)
+		return nil, fmt.Errorf("execute chain[%v] error", chainID)
+	}
+	if !resp.IsSuccess() {
+		tm.logger.Errorf("cross[%v]->chain[%v]'s execute failed, %s", crossID, chainID, resp.Msg)
+		tm.recordChainState(crossID, chainID, storetype.StateFailed)
+		return nil, fmt.Errorf("chain[%v]'s execute failed for %s", chainID, resp.Msg)
+	}
+	tm.logger.Infof("cross[%v]->chain[%v]'s execute payload success", crossID, chainID)
+	proof, err := tm.toProof(chainID, resp)
+	if err != nil {
+		tm.logger.Errorf("cross[%v]->chain[%v]'s response convert to proof error", crossID, chainID, err)
+		tm.recordChainState(crossID, chainID, storetype.StateProofConvertFailed)
+		return nil, fmt.Errorf("convert chain[%v]'s response to proof error", chainID)
+	}
+	decision := tm.proverDispatcher.Decide(crossID, chainID, proof)
+	// 并发模式下没有上一条链，证明及其验证结果写入到本链上
+	if err := tm.saveProof(chainID, crossID, crossTx.ProofKey, proof, decision.Result); err != nil {
+		tm.logger.Errorf(CrossChainProofSaveErrorFormat, chainID, crossID)
+	}
+	if !decision.Result {
+		tm.logger.Errorf("cross[%v]->chain[%v]'s proof check error, %s", crossID, chainID, decision.Reason)
+		tm.recordChainProof(crossID, chainID, storetype.StateProofFailed, proof)
+		return nil, fmt.Errorf("can not prove chain[%v]'s proof", chainID)
+	}
+	tm.logger.Infof("cross[%v]->chain[%v]'s proof check success", crossID, chainID)
+	tm.recordChainProof(crossID, chainID, storetype.StateProofSuccess, proof)
+	return resp, nil
 }
 
 // handleTxEvents
 func (tm *Manager) handleTxEvents(crossID string, crossTxs []*eventproto.CrossTx) {
-	handledPkgTxEvents := make([]*eventproto.CrossTx, 0)
-	allResponse := make([]*event.ProofResponse, 0)
-	var majorProof *eventproto.Proof
-	// 仅支持两条链操作，A-B，A的结果由B来验证，B的结果由A来验证
-	for _, pkgTxEve := range crossTxs {
-		pkgTxEvent := pkgTxEve
+	tm.executeTxEvents(crossID, crossTxs, 0, make([]*eventproto.CrossTx, 0), make([]*event.ProofResponse, 0), nil)
+}
+
+// executeTxEvents execute cross txs one by one from startIdx
+// 链式处理：第i笔交易携带第i-1笔交易的证明执行，其结果证明验证后保存到第i-1条链上
+func (tm *Manager) executeTxEvents(crossID string, crossTxs []*eventproto.CrossTx, startIdx int,
+	handledPkgTxEvents []*eventproto.CrossTx, allResponse []*event.ProofResponse, prevProof *eventproto.Proof) {
+	for idx := startIdx; idx < len(crossTxs); idx++ {
+		pkgTxEvent := crossTxs[idx]
 		chainID := pkgTxEvent.GetChainID()
-		resp, err := tm.execute(crossID, pkgTxEvent, majorProof)
+		// 已失去leader，后续交易由新的leader恢复执行
+		if tm.interrupted(crossID) {
+			return
+		}
+		// 超过截止时间，不再执行后续交易，回滚已执行的交易
+		if tm.abortIfExpired(crossID, handledPkgTxEvents) {
+			return
+		}
+		resp, err := tm.execute(crossID, pkgTxEvent, prevProof)
 		if err != nil {
 			tm.logger.Errorf("cross[%v]->chain[%v]'s execute payload error, ", crossID, chainID, err)
 			// 记录该链处理错误
-			if err := tm.db.WriteChainCrossState(crossID, chainID, storetype.StateFailed, nil); err != nil {
-				tm.logger.Errorf(DBCrossChainStateErrorFormat, chainID, crossID, storetype.StateFailed)
+			tm.recordChainState(crossID, chainID, storetype.StateFailed)
+			// 等待结果时超过截止时间，当前交易可能已执行，需要一并回滚
+			if tm.abortIfExpired(crossID, append(handledPkgTxEvents, pkgTxEvent)) {
+				return
 			}
 			// 此时有错误，需要回滚之前已经完成的提交
 			tm.rollbackHandledEvents(crossID, handledPkgTxEvents, err)
 			// 记录整体状态，结束该事务
 			tm.recordInterruptedState(crossID, []byte(fmt.Sprintf("execute chain[%v] error", chainID)))
 			return
-		} else {
-			if resp.IsSuccess() {
-				tm.logger.Infof("cross[%v]->chain[%v]'s execute payload success", crossID, chainID)
-				allResponse = append(allResponse, resp)
-				// 成功，则进行下一个处理
-				handledPkgTxEvents = append(handledPkgTxEvents, pkgTxEvent)
-				if majorProof == nil {
-					// 表示为第一条链的操作
-					majorProof, err = tm.toProof(chainID, resp)
-					tm.logger.Infof("cross[%v]->chain[%v]'s response convert to proof success", crossID, chainID)
-					if err != nil {
-						tm.logger.Errorf("cross[%v]->chain[%v]'s response convert to proof error", crossID, chainID, err)
-						if err := tm.db.WriteChainCrossState(crossID, chainID, storetype.StateProofConvertFailed, nil); err != nil {
-							tm.logger.Errorf(DBCrossChainStateErrorFormat, chainID, crossID, storetype.StateProofConvertFailed)
-						}
-						// 表示无法转换，需要进行回滚
-						tm.rollbackHandledEvents(crossID, handledPkgTxEvents, err)
-						tm.recordInterruptedState(crossID, []byte(fmt.Sprintf("convert chain[%v]'s response to proof error", chainID)))
-						return
-					}
-					// 表示交易执行成功，并且证明OK
-					if proofBytes, err := tm.txProofCoder.MarshalToBinary(majorProof); err != nil {
-						tm.logger.Errorf("cross[%v]->chain[%v]'s tx-proof marshal error", crossID, chainID, err)
-					} else {
-						if err := tm.db.WriteChainCrossState(crossID, chainID, storetype.StateExecuteSuccess, proofBytes); err != nil {
-							tm.logger.Errorf(DBCrossChainStateErrorFormat, chainID, crossID, storetype.StateExecuteSuccess)
-						}
-					}
-				} else {
-					// 表示非第一个操作，那么需要对其进行证明
-					// 表示为第二条链上的操作
-					var (
-						remoteProof      *eventproto.Proof
-						remoteProofBytes []byte
-					)
-					remoteProof, err = tm.toProof(chainID, resp)
-					tm.logger.Infof("cross[%v]->chain[%v]'s response convert to proof success", crossID, chainID)
-					if err != nil {
-						tm.logger.Errorf("cross[%v]->chain[%v]'s response convert to proof error", crossID, chainID, err)
-						if err := tm.db.WriteChainCrossState(crossID, chainID, storetype.StateProofConvertFailed, nil); err != nil {
-							tm.logger.Errorf(DBCrossChainStateErrorFormat, chainID, crossID, storetype.StateProofConvertFailed)
-						}
-						// 表示无法转换，需要进行回滚
-						tm.rollbackHandledEvents(crossID, handledPkgTxEvents, err)
-						tm.recordInterruptedState(crossID, []byte(fmt.Sprintf("convert chain[%v]'s response to proof error", chainID)))
-						return
-					}
-					remoteProofBytes, err = tm.txProofCoder.MarshalToBinary(remoteProof)
-					// TODO 后续采用更优雅的方式
-					firstCrossTx := crossTxs[0]
-					if ok, _ := tm.proverDispatcher.Prove(remoteProof); !ok {
-						// 证明失败
-						tm.logger.Errorf("cross[%v]->chain[%v]'s proof check error", crossID, chainID)
-						if err := tm.db.WriteChainCrossState(crossID, chainID, storetype.StateProofFailed, remoteProofBytes); err != nil {
-							tm.logger.Errorf(DBCrossChainStateErrorFormat, chainID, crossID, storetype.StateProofFailed)
-						}
-						// 证明完成后需要将该证据写入到链上
-						if err := tm.saveProof(firstCrossTx.GetChainID(), crossID, firstCrossTx.ProofKey, remoteProof, ok); err != nil {
-							tm.logger.Errorf(CrossChainProofSaveErrorFormat, chainID, crossID)
-						}
-						// 证明失败，则需要回滚
-						tm.rollbackHandledEvents(crossID, handledPkgTxEvents, fmt.Errorf("can not prove chain[%v]'s proof", chainID))
-						tm.recordInterruptedState(crossID, []byte(fmt.Sprintf("can not prove chain[%v]'s proof", chainID)))
-						return
-					} else {
-						// 证明成功
-						tm.logger.Infof("cross[%v]->chain[%v]'s proof check success", crossID, chainID)
-						if err := tm.db.WriteChainCrossState(crossID, chainID, storetype.StateProofSuccess, remoteProofBytes); err != nil {
-							tm.logger.Errorf(DBCrossChainStateErrorFormat, chainID, crossID, storetype.StateProofSuccess)
-						}
-						// 证明完成后需要将该证据写入到链上
-						if err := tm.saveProof(firstCrossTx.GetChainID(), crossID, firstCrossTx.ProofKey, remoteProof, ok); err != nil {
-							tm.logger.Errorf(CrossChainProofSaveErrorFormat, chainID, crossID)
-						}
-					}
-				}
-			} else {
-				tm.logger.Errorf("cross[%v]->chain[%v]'s execute failed, %s", crossID, chainID, resp.Msg)
-				if err := tm.db.WriteChainCrossState(crossID, chainID, storetype.StateFailed, nil); err != nil {
-					tm.logger.Errorf(DBCrossChainStateErrorFormat, chainID, crossID, storetype.StateFailed)
-				}
-				// 重新生成需要回滚的交易对象列表
-				rollbackEvents := make([]*eventproto.CrossTx, 0)
-				rollbackEvents = append(rollbackEvents, handledPkgTxEvents...)
-				// 也需要回滚当前的交易，当前交易是否回滚由事务合约控制
-				rollbackEvents = append(rollbackEvents, pkgTxEvent)
-				// 失败的情况下需要回滚之前已完成的提交
-				tm.rollbackHandledEvents(crossID, rollbackEvents, fmt.Errorf("chain[%v]'s execute failed for %s", chainID, resp.Msg))
-				// 记录整体状态，结束该事务
-				tm.recordInterruptedState(crossID, []byte(fmt.Sprintf("chain[%v]'s execute failed for %s", chainID, resp.Msg)))
-				return
-			}
 		}
+		if !resp.IsSuccess() {
+			tm.logger.Errorf("cross[%v]->chain[%v]'s execute failed, %s", crossID, chainID, resp.Msg)
+			tm.recordChainState(crossID, chainID, storetype.StateFailed)
+			// 重新生成需要回滚的交易对象列表
+			rollbackEvents := make([]*eventproto.CrossTx, 0, len(handledPkgTxEvents)+1)
+			rollbackEvents = append(rollbackEvents, handledPkgTxEvents...)
+			// 也需要回滚当前的交易，当前交易是否回滚由事务合约控制
+			rollbackEvents = append(rollbackEvents, pkgTxEvent)
+			// 失败的情况下需要回滚之前已完成的提交
+			tm.rollbackHandledEvents(crossID, rollbackEvents, fmt.Errorf("chain[%v]'s execute failed for %s", chainID, resp.Msg))
+			// 记录整体状态，结束该事务
+			tm.recordInterruptedState(crossID, []byte(fmt.Sprintf("chain[%v]'s execute failed for %s", chainID, resp.Msg)))
+			return
+		}
+		tm.logger.Infof("cross[%v]->chain[%v]'s execute payload success", crossID, chainID)
+		allResponse = append(allResponse, resp)
+		// 成功，则进行下一个处理
+		handledPkgTxEvents = append(handledPkgTxEvents, pkgTxEvent)
+		proof, err := tm.toProof(chainID, resp)
+		if err != nil {
+			tm.logger.Errorf("cross[%v]->chain[%v]'s response convert to proof error", crossID, chainID, err)
+			tm.recordChainState(crossID, chainID, storetype.StateProofConvertFailed)
+			// 表示无法转换，需要进行回滚
+			tm.rollbackHandledEvents(crossID, handledPkgTxEvents, err)
+			tm.recordInterruptedState(crossID, []byte(fmt.Sprintf("convert chain[%v]'s response to proof error", chainID)))
+			return
+		}
+		tm.logger.Infof("cross[%v]->chain[%v]'s response convert to proof success", crossID, chainID)
+		if idx == 0 {
+			// 表示为第一条链的操作，交易执行成功，记录其证明
+			tm.recordChainProof(crossID, chainID, storetype.StateExecuteSuccess, proof)
+		} else if !tm.proveAndSaveProof(crossID, crossTxs[idx-1].GetChainID(), pkgTxEvent.ProofKey, proof) {
+			// 证明失败，则需要回滚
+			tm.rollbackHandledEvents(crossID, handledPkgTxEvents, fmt.Errorf("can not prove chain[%v]'s proof", chainID))
+			tm.recordInterruptedState(crossID, []byte(fmt.Sprintf("can not prove chain[%v]'s proof", chainID)))
+			return
+		}
+		// 当前交易的证明由下一笔交易携带
+		prevProof = proof
+	}
+	// 执行到此处表示prepare阶段已全部处理完成，距截止时间有足够的剩余时间则并发进行commit操作
+	if tm.abortIfNoCommitMargin(crossID, handledPkgTxEvents) {
+		return
 	}
-	// 执行到此处表示prepare阶段已全部处理完成，下面需要并发进行commit操作
 	tm.commitAll(crossID, handledPkgTxEvents, allResponse)
 }
 
-// retrySecondTx
-func (tm *Manager) retrySecondTx(crossID string, firstEventTx, secondEventTx *eventproto.CrossTx, proof *eventproto.Proof) {
-	// 重新执行第二笔交易, sync execute
-	proofResponse, err := tm.execute(crossID, secondEventTx, proof)
-	if err != nil {
-		tm.logger.Errorf("cross[%v]->chain[%v]'s execute payload error, ", crossID, secondEventTx.GetChainID(), err)
-		// 记录该链处理错误
-		if err := tm.db.WriteChainCrossState(crossID, secondEventTx.GetChainID(), storetype.StateFailed, nil); err != nil {
-			tm.logger.Errorf(DBCrossChainStateErrorFormat, secondEventTx.GetChainID(), crossID, storetype.StateFailed)
-		}
-		// 此时有错误，回滚第一笔交易
-		tm.rollbackCrossTx(crossID, firstEventTx)
-		// 记录整体状态，结束该事务
-		tm.recordInterruptedState(crossID, []byte(fmt.Sprintf("execute chain[%v] error", secondEventTx.GetChainID())))
-		return
+// proveAndSaveProof prove the proof of current chain and save it to the previous chain with the proofKey of current tx,
+// the proofKey of the previous tx is already used by the proof which the previous chain received when executing
+func (tm *Manager) proveAndSaveProof(crossID, prevChainID, proofKey string, proof *eventproto.Proof) bool {
+	chainID := proof.GetChainID()
+	ok := tm.proverDispatcher.Decide(crossID, chainID, proof).Result
+	if ok {
+		tm.logger.Infof("cross[%v]->chain[%v]'s proof check success", crossID, chainID)
+		tm.recordChainProof(crossID, chainID, storetype.StateProofSuccess, proof)
 	} else {
-		// 判断结果
-		chainID := secondEventTx.GetChainID()
-		if proofResponse.IsSuccess() {
-			var (
-				remoteProof      *eventproto.Proof
-				remoteProofBytes []byte
-			)
-			remoteProof, err = tm.toProof(chainID, proofResponse)
-			tm.logger.Infof("cross[%v]->chain[%v]'s response convert to proof success", crossID, chainID)
-			if err != nil {
-				tm.logger.Errorf("cross[%v]->chain[%v]'s response convert to proof error", crossID, chainID, err)
-				if err := tm.db.WriteChainCrossState(crossID, chainID, storetype.StateProofConvertFailed, nil); err != nil {
-					tm.logger.Errorf(DBCrossChainStateErrorFormat, chainID, crossID, storetype.StateProofConvertFailed)
-				}
-				// 表示无法转换，需要进行回滚
-				tm.rollbackHandledEvents(crossID, []*eventproto.CrossTx{firstEventTx, secondEventTx}, err)
-				tm.recordInterruptedState(crossID, []byte(fmt.Sprintf("convert chain[%v]'s response to proof error", chainID)))
-				return
-			}
-			remoteProofBytes, err = tm.txProofCoder.MarshalToBinary(remoteProof)
-			if ok, _ := tm.proverDispatcher.Prove(remoteProof); !ok {
-				tm.logger.Errorf("cross[%v]->chain[%v]'s proof check error", crossID, chainID)
-				if err := tm.db.WriteChainCrossState(crossID, chainID, storetype.StateProofFailed, remoteProofBytes); err != nil {
-					tm.logger.Errorf(DBCrossChainStateErrorFormat, chainID, crossID, storetype.StateProofFailed)
-				}
-				// 证明完成后需要将该证据写入到链上，此时是写到当前链（即链1）
-				if err := tm.saveProof(firstEventTx.GetChainID(), crossID, firstEventTx.ProofKey, remoteProof, ok); err != nil {
-					tm.logger.Errorf(CrossChainProofSaveErrorFormat, chainID, crossID)
-				}
-				// 证明异常，则需要回滚
-				tm.rollbackHandledEvents(crossID, []*eventproto.CrossTx{firstEventTx, secondEventTx}, fmt.Errorf("can not prove chain[%v]'s proof", chainID))
-				tm.recordInterruptedState(crossID, []byte(fmt.Sprintf("can not prove chain[%v]'s proof", chainID)))
-				return
-			} else {
-				// 证明成功
-				tm.logger.Infof("cross[%v]->chain[%v]'s proof check success", crossID, chainID)
-				if err := tm.db.WriteChainCrossState(crossID, chainID, storetype.StateProofSuccess, remoteProofBytes); err != nil {
-					tm.logger.Errorf(DBCrossChainStateErrorFormat, chainID, crossID, storetype.StateProofSuccess)
-				}
-				// 证明完成后需要将该证据写入到链上，此时是写到当前链（即链1）
-				if err := tm.saveProof(firstEventTx.GetChainID(), crossID, firstEventTx.ProofKey, remoteProof, ok); err != nil {
-					tm.logger.Errorf(CrossChainProofSaveErrorFormat, chainID, crossID)
-				}
-				// 提交两笔交易
-				firstProofResponse := event.NewProofResponseByProof(crossID, chainID, crossChainStateSuccess, event.SuccessResp, event.ExecuteOpFunc, proof)
-				tm.commitAll(crossID, []*eventproto.CrossTx{firstEventTx, secondEventTx}, []*event.ProofResponse{firstProofResponse, proofResponse})
-				return
-			}
-		} else {
-			// 应答失败，则回滚本地结果
-			tm.logger.Errorf("cross[%v]->chain[%v]'s execute failed, %s", crossID, chainID, proofResponse.Msg)
-			if err := tm.db.WriteChainCrossState(crossID, chainID, storetype.StateFailed, nil); err != nil {
-				tm.logger.Errorf(DBCrossChainStateErrorFormat, chainID, crossID, storetype.StateFailed)
-			}
-			// 失败的情况下需要回滚之前已完成的提交
-			tm.rollbackCrossTx(crossID, firstEventTx)
-			// 记录整体状态，结束该事务
-			tm.recordInterruptedState(crossID, []byte(fmt.Sprintf("chain[%v]'s execute failed for %s", chainID, proofResponse.Msg)))
-			return
-		}
+		tm.logger.Errorf("cross[%v]->chain[%v]'s proof check error", crossID, chainID)
+		tm.recordChainProof(crossID, chainID, storetype.StateProofFailed, proof)
+	}
+	// 证明完成后需要将该证据写入到上一条链上
+	if err := tm.saveProof(prevChainID, crossID, proofKey, proof, ok); err != nil {
+		tm.logger.Errorf(CrossChainProofSaveErrorFormat, chainID, crossID)
 	}
+	return ok
 }
 
-// commitTwoTxs
-func (tm *Manager) commitTwoTxs(crossID string, firstEventTx, secondEventTx *eventproto.CrossTx) {
-	crossEventTxs := []*eventproto.CrossTx{firstEventTx, secondEventTx}
+// commitUnfinishedTxs commit all the txs which have not been committed successfully
+func (tm *Manager) commitUnfinishedTxs(crossID string, chainStates []*chainCrossState) {
+	if tm.interrupted(crossID) {
+		return
+	}
+	crossEventTxs := make([]*eventproto.CrossTx, 0, len(chainStates))
+	for _, chainState := range chainStates {
+		if !chainState.exist || chainState.state != storetype.StateCommitSuccess {
+			crossEventTxs = append(crossEventTxs, chainState.crossTx)
+		}
+	}
 	var (
 		wg           sync.WaitGroup
 		successCount int32 = 0
 	)
 	wg.Add(len(crossEventTxs))
-	for _, eventTx := range crossEventTxs {
-		commitSuccess := tm.commitCrossTx(crossID, eventTx)
-		wg.Done()
-		chainID := eventTx.GetChainID()
-		if commitSuccess {
-			tm.logger.Infof("cross[%v]->chain[%v] commit success", crossID, chainID)
-			tm.recordChainState(crossID, chainID, storetype.StateCommitSuccess)
-			atomic.AddInt32(&successCount, 1)
-		} else {
-			tm.logger.Infof("cross[%v]->chain[%v] commit failed", crossID, chainID)
-			tm.recordChainState(crossID, chainID, storetype.StateCommitFailed)
-		}
+	for _, crossTx := range crossEventTxs {
+		go func(crossTx *eventproto.CrossTx) {
+			defer wg.Done()
+			commitSuccess := tm.commitCrossTx(crossID, crossTx)
+			chainID := crossTx.GetChainID()
+			if commitSuccess {
+				tm.logger.Infof("cross[%v]->chain[%v] commit success", crossID, chainID)
+				tm.recordChainState(crossID, chainID, storetype.StateCommitSuccess)
+				atomic.AddInt32(&successCount, 1)
+			} else {
+				tm.logger.Infof("cross[%v]->chain[%v] commit failed", crossID, chainID)
+				tm.recordChainState(crossID, chainID, storetype.StateCommitFailed)
+			}
+		}(crossTx)
 	}
 	wg.Wait()
 	if int(successCount) >= len(crossEventTxs) {
+		// 所有链均已提交，由各链记录的执行证明生成结果并结束该事务
+		tm.recordSuccessFinishedState(crossID, tm.recoverProofResponses(crossID, chainStates))
 		tm.finishCrossEvent(crossID)
 	}
 }
 
-// commitDesignativeTx
-func (tm *Manager) commitDesignativeTx(crossID string, eventTx *eventproto.CrossTx) {
-	commitSuccess := tm.commitCrossTx(crossID, eventTx)
-	chainID := eventTx.GetChainID()
-	if commitSuccess {
-		tm.logger.Infof("cross[%v]->chain[%v] commit success", crossID, chainID)
-		tm.recordChainState(crossID, chainID, storetype.StateCommitSuccess)
-		tm.finishCrossEvent(crossID)
-	} else {
-		tm.logger.Infof("cross[%v]->chain[%v] commit failed", crossID, chainID)
-		tm.recordChainState(crossID, chainID, storetype.StateCommitFailed)
+// recoverProofResponses generate the responses of execution by the proofs which are recorded for each chain
+func (tm *Manager) recoverProofResponses(crossID string, chainStates []*chainCrossState) []*event.ProofResponse {
+	allResponse := make([]*event.ProofResponse, 0, len(chainStates))
+	for _, chainState := range chainStates {
+		chainID := chainState.crossTx.GetChainID()
+		proof, err := tm.unmarshalProof(chainState.result)
+		if err != nil {
+			tm.logger.Warnf("cross[%v]->chain[%v]'s proof can not be convert, it is omitted from result, %v", crossID, chainID, err)
+			continue
+		}
+		allResponse = append(allResponse, event.NewProofResponseByProof(crossID, chainID, crossChainStateSuccess, event.SuccessResp, event.ExecuteOpFunc, proof))
 	}
+	return allResponse
 }
 
-// rollbackTwoTxs
-func (tm *Manager) rollbackTwoTxs(crossID string, firstEventTx, secondEventTx *eventproto.CrossTx) {
-	crossEventTxs := []*eventproto.CrossTx{firstEventTx, secondEventTx}
+// rollbackUnfinishedTxs rollback all the txs which have not been rolled back successfully
+func (tm *Manager) rollbackUnfinishedTxs(crossID string, chainStates []*chainCrossState) {
+	if tm.interrupted(crossID) {
+		return
+	}
+	crossEventTxs := make([]*eventproto.CrossTx, 0, len(chainStates))
+	for _, chainState := range chainStates {
+		if !chainState.exist || chainState.state != storetype.StateRollbackSuccess {
+			crossEventTxs = append(crossEventTxs, chainState.crossTx)
+		}
+	}
 	var (
 		wg           sync.WaitGroup
 		successCount int32 = 0
@@ -663,8 +775,8 @@ func (tm *Manager) rollbackTwoTxs(crossID string, firstEventTx, secondEventTx *e
 	wg.Add(len(crossEventTxs))
 	for _, crossTx := range crossEventTxs {
 		go func(crossTx *eventproto.CrossTx) {
+			defer wg.Done()
 			rollbackSuccess := tm.rollbackCrossTx(crossID, crossTx)
-			wg.Done()
 			chainID := crossTx.GetChainID()
 			if rollbackSuccess {
 				tm.logger.Infof("cross[%v]->chain[%v] rollback success", crossID, chainID)
@@ -678,40 +790,40 @@ func (tm *Manager) rollbackTwoTxs(crossID string, firstEventTx, secondEventTx *e
 	}
 	wg.Wait()
 	if int(successCount) >= len(crossEventTxs) {
+		// 所有链均已回滚，记录整体失败状态并结束该事务
+		tm.recordInterruptedState(crossID, []byte("cross is rolled back after recovered"))
 		tm.finishCrossEvent(crossID)
 	}
 }
 
-func (tm *Manager) rollbackDesignativeTx(crossID string, eventTx *eventproto.CrossTx) {
-	rollbackSuccess := tm.rollbackCrossTx(crossID, eventTx)
-	chainID := eventTx.GetChainID()
-	if rollbackSuccess {
-		tm.logger.Infof("cross[%v]->chain[%v] rollback success", crossID, chainID)
-		tm.recordChainState(crossID, chainID, storetype.StateRollbackSuccess)
-		tm.finishCrossEvent(crossID)
-	} else {
-		tm.logger.Infof("cross[%v]->chain[%v] rollback failed", crossID, chainID)
-		tm.recordChainState(crossID, chainID, storetype.StateRollbackFailed)
-	}
-}
-
 func (tm *Manager) execute(crossID string, crossTx *eventproto.CrossTx, proof *eventproto.Proof) (*event.ProofResponse, error) {
 	chainID := crossTx.GetChainID()
+	defer monitor.ObservePhase(monitor.PhaseExecute, chainID, time.Now())
 	tm.logger.Infof("cross[%v]->chain[%v]'s execute start", crossID, chainID)
 	// 创建交易
 	eve := event.NewExecuteTransactionEvent(crossID, chainID, crossTx.GetExecutePayload(), crossTx.ProofKey, proof)
-	return tm.routerDispatcher.Invoke(eve, conf.TxMsgResultMaxWaitTimeout)
+	resp, err := tm.routerDispatcher.Invoke(eve, tm.getExecuteTimeout(crossID))
+	if err != nil {
+		return nil, err
+	}
+	// 交易已执行，结果尚未记录
+	if err = chaos.Inject(chaos.AfterExecute, crossID, chainID, ""); err != nil {
+		return nil, err
+	}
+	return resp, nil
 }
 
 // commit
 // 提交，暂时不需要进行证明
 func (tm *Manager) commit(crossID string, pkgTxEvent *eventproto.CrossTx) (*event.ProofResponse, error) {
+	defer monitor.ObservePhase(monitor.PhaseCommit, pkgTxEvent.GetChainID(), time.Now())
 	return tm.secondPhaseHandle(crossID, pkgTxEvent, event.CommitOpFunc)
 }
 
 // rollback
 // 回滚，暂时不需要进行结果证明
 func (tm *Manager) rollback(crossID string, pkgTxEvent *eventproto.CrossTx) (*event.ProofResponse, error) {
+	defer monitor.ObservePhase(monitor.PhaseRollback, pkgTxEvent.GetChainID(), time.Now())
 	return tm.secondPhaseHandle(crossID, pkgTxEvent, event.RollbackOpFunc)
 }
 
@@ -729,13 +841,27 @@ func (tm *Manager) secondPhaseHandle(crossID string, crossTx *eventproto.CrossTx
 	return tm.routerDispatcher.Invoke(eve, conf.TxMsgResultMaxWaitTimeout)
 }
 
-// saveProof save proof to chain
+// saveProof save proof to chain, the chain can be connected by other proxy
 func (tm *Manager) saveProof(chainID, crossID, proofTxKey string, proof *eventproto.Proof, verifiedResult bool) error {
-	_, err := tm.adapterDispatcher.SaveProof(chainID, crossID, proofTxKey, proof, verifiedResult)
-	return err
+	defer monitor.ObservePhase(monitor.PhaseSaveProof, chainID, time.Now())
+	if err := chaos.Inject(chaos.BeforeSaveProof, crossID, chainID, ""); err != nil {
+		return err
+	}
+	eve := event.NewSaveProofTransactionEvent(crossID, chainID, proofTxKey, proof, verifiedResult)
+	resp, err := tm.routerDispatcher.Invoke(eve, conf.TxMsgResultMaxWaitTimeout)
+	if err != nil {
+		return err
+	}
+	if !resp.IsSuccess() {
+		return fmt.Errorf("save proof to chain[%v] failed for %s", chainID, resp.Msg)
+	}
+	return nil
 }
 
 func (tm *Manager) rollbackHandledEvents(crossID string, handledPkgTxEvents []*eventproto.CrossTx, err error) {
+	if tm.interrupted(crossID) {
+		return
+	}
 	handledEventSize := len(handledPkgTxEvents)
 	if handledEventSize > 0 {
 		tm.logger.Infof("cross[%v] there are %v event need rollback", crossID, handledEventSize)
@@ -745,10 +871,11 @@ func (tm *Manager) rollbackHandledEvents(crossID string, handledPkgTxEvents []*e
 		// 并发回滚即可
 		for _, handledPkgTxEvent := range handledPkgTxEvents {
 			go func(txEve *eventproto.CrossTx) {
+				// 状态记录完成后才结束，停止时等待其退出
+				defer wg.Done()
 				chainID := txEve.GetChainID()
 				tm.logger.Infof("cross[%v]->chain[%v] will rollback", crossID, chainID)
 				rollbackSuccess := tm.rollbackCrossTx(crossID, txEve)
-				wg.Done()
 				// 进行状态记录
 				if rollbackSuccess {
 					tm.logger.Infof("cross[%v]->chain[%v] rollback success", crossID, chainID)
@@ -762,8 +889,9 @@ func (tm *Manager) rollbackHandledEvents(crossID string, handledPkgTxEvents []*e
 		}
 		wg.Wait()
 		// 判断是否全部回滚成功
-		if int(rollbackSuccessSize) >= handledEventSize {
+		if int(atomic.LoadInt32(&rollbackSuccessSize)) >= handledEventSize {
 			tm.logger.Infof("cross[%v] rollback completed", crossID)
+			tm.observeCrossResult(crossID, monitor.CrossRolledBack)
 			// 记录失败
 			tm.recordFailedFinishedState(crossID, err)
 		}
@@ -780,32 +908,37 @@ func (tm *Manager) rollbackCrossTx(crossID string, txEve *eventproto.CrossTx) bo
 	if err != nil || !re.IsSuccess() {
 		if err != nil {
 			tm.logger.Warnf("cross[%v]->chain[%v] rollback failed, ", crossID, chainID, err)
-		}
-		if !re.IsSuccess() {
+		} else {
 			tm.logger.Warnf("cross[%v]->chain[%v] rollback failed -> %s", crossID, chainID, re.Msg)
 		}
-		// 进行重试操作
-		for i := 0; i < RetryCount; i++ {
-			time.Sleep(RetryPeriod) // 先进行休眠
-			re, err = tm.rollback(crossID, txEve)
-			if err == nil && re.IsSuccess() {
-				// 操作成功，状态记录
-				rollbackSuccess = true
-				break
-			} else if err != nil {
-				tm.logger.Warnf("cross[%v]->chain[%v]->[%v] rollback failed, ", crossID, chainID, i+1, err)
-			} else if !re.IsSuccess() {
-				tm.logger.Warnf("cross[%v]->chain[%v]->[%v] rollback failed -> %s", crossID, chainID, i+1, re.Msg)
-			}
+		// 按重试策略进行重试，重试耗尽后移入死信集合
+		err = tm.retryPhase(tm.crossContext(crossID), crossID, chainID, monitor.PhaseRollback, tm.getRetryPolicy(crossID).GetRollbackTimeout(),
+			func() (*event.ProofResponse, error) {
+				return tm.rollback(crossID, txEve)
+			})
+		if err != nil {
+			tm.moveToDeadLetter(crossID, chainID, monitor.PhaseRollback, storetype.StateRollbackFailed, err)
+		} else {
+			rollbackSuccess = true
 		}
 	} else {
 		// 操作成功，状态更新
 		rollbackSuccess = true
 	}
+	if rollbackSuccess {
+		// 回滚已完成，链状态尚未记录
+		if err = chaos.Inject(chaos.MidRollback, crossID, chainID, ""); err != nil {
+			tm.logger.Warnf("cross[%v]->chain[%v] rollback interrupted, %v", crossID, chainID, err)
+			return false
+		}
+	}
 	return rollbackSuccess
 }
 
 func (tm *Manager) commitAll(crossID string, handledPkgTxEvents []*eventproto.CrossTx, allResponse []*event.ProofResponse) {
+	if tm.interrupted(crossID) {
+		return
+	}
 	handledEventSize := len(handledPkgTxEvents)
 	if handledEventSize > 0 {
 		tm.logger.Infof("cross[%v] there are %v event need commit", crossID, handledEventSize)
@@ -815,10 +948,10 @@ func (tm *Manager) commitAll(crossID string, handledPkgTxEvents []*eventproto.Cr
 		// 并发回滚即可
 		for _, handledPkgTxEvent := range handledPkgTxEvents {
 			go func(txEve *eventproto.CrossTx) {
+				defer wg.Done()
 				chainID := txEve.GetChainID()
 				tm.logger.Infof("cross[%v]->chain[%v] will commit", crossID, chainID)
 				commitSuccess := tm.commitCrossTx(crossID, txEve)
-				wg.Done()
 				if commitSuccess {
 					tm.logger.Infof("cross[%v]->chain[%v] commit success", crossID, chainID)
 					tm.recordChainState(crossID, chainID, storetype.StateCommitSuccess)
@@ -840,20 +973,32 @@ func (tm *Manager) commitCrossTx(crossID string, txEve *eventproto.CrossTx) bool
 	var commitSuccess = false
 	// 异常或操作失败均需要重试
 	if err != nil || !re.IsSuccess() {
-		// 进行重试操作
-		for i := 0; i < RetryCount; i++ {
-			time.Sleep(RetryPeriod) // 先进行休眠
-			re, err = tm.commit(crossID, txEve)
-			if err == nil && re.IsSuccess() {
-				// 操作成功，状态记录
-				commitSuccess = true
-				break
-			}
+		// 按重试策略进行重试，重试耗尽或超过截止时间后移入死信集合，超过截止时间的提交会被事务合约拒绝
+		chainID := txEve.GetChainID()
+		if tm.isExpired(crossID) {
+			err = deadlineExceededError(crossID)
+		} else {
+			err = tm.retryPhase(tm.crossContext(crossID), crossID, chainID, monitor.PhaseCommit, tm.getCommitTimeout(crossID),
+				func() (*event.ProofResponse, error) {
+					return tm.commit(crossID, txEve)
+				})
+		}
+		if err != nil {
+			tm.moveToDeadLetter(crossID, chainID, monitor.PhaseCommit, storetype.StateCommitFailed, err)
+		} else {
+			commitSuccess = true
 		}
 	} else {
 		// 操作成功，状态更新
 		commitSuccess = true
 	}
+	if commitSuccess {
+		// 提交已完成，链状态尚未记录
+		if err = chaos.Inject(chaos.MidCommit, crossID, txEve.GetChainID(), ""); err != nil {
+			tm.logger.Warnf("cross[%v]->chain[%v] commit interrupted, %v", crossID, txEve.GetChainID(), err)
+			return false
+		}
+	}
 	return commitSuccess
 }
 
@@ -863,9 +1008,8 @@ func (tm *Manager) toProof(chainID string, response *event.ProofResponse) (*even
 }
 
 func (tm *Manager) recordInterruptedState(crossID string, result []byte) {
-	if err := tm.db.FinishCross(crossID, result, storetype.StateFailed); err != nil {
-		tm.logger.Errorf(DBCrossStateErrorFormat, crossID, storetype.StateFailed)
-	}
+	tm.observeCrossResult(crossID, monitor.CrossFailed)
+	tm.finishCross(crossID, storetype.StateInit, storetype.StateFailed, result)
 }
 
 func (tm *Manager) recordSuccessFinishedState(crossID string, allResponse []*event.ProofResponse) {
@@ -873,6 +1017,7 @@ func (tm *Manager) recordSuccessFinishedState(crossID string, allResponse []*eve
 		code  int32 = event.SuccessResp
 		state       = storetype.StateSuccess
 	)
+	tm.observeCrossResult(crossID, monitor.CrossSucceeded)
 	// 生成CrossResponse
 	crossResponse := event.NewCrossResponse(crossID, code, crossChainStateSuccess)
 	for _, resp := range allResponse {
@@ -885,9 +1030,7 @@ func (tm *Manager) recordSuccessFinishedState(crossID string, allResponse []*eve
 	if err != nil {
 		tm.logger.Info("marshal cross response failed,", err)
 	} else {
-		if err := tm.db.FinishCross(crossID, binary, state); err != nil {
-			tm.logger.Errorf(DBCrossStateErrorFormat, crossID, state)
-		}
+		tm.finishCross(crossID, storetype.StateInit, state, binary)
 	}
 }
 
@@ -903,19 +1046,88 @@ func (tm *Manager) recordFailedFinishedState(crossID string, err error) {
 	if err != nil {
 		tm.logger.Info("marshal cross response failed,", err)
 	} else {
-		if err := tm.db.FinishCross(crossID, binary, state); err != nil {
-			tm.logger.Errorf(DBCrossStateErrorFormat, crossID, state)
-		}
+		tm.finishCross(crossID, storetype.StateInit, state, binary)
+	}
+}
+
+// finishCross change the total state of cross to the final state, the cross which has been finished is kept unchanged
+func (tm *Manager) finishCross(crossID string, from, state storetype.State, result []byte) {
+	if !tm.holdsLease(crossID) {
+		return
+	}
+	err := tm.db.ApplyTransition(&storetype.Transition{
+		CrossID: crossID,
+		From:    from,
+		To:      state,
+		Payload: result,
+	})
+	if storetype.IsIllegalTransition(err) {
+		tm.logger.Warnf("cross[%s] will not be finished as %v, %v", crossID, state, err)
+	} else if err != nil {
+		tm.logger.Errorf(DBCrossStateErrorFormat, crossID, state)
+	}
+}
+
+func (tm *Manager) recordChainProof(crossID, chainID string, state storetype.State, proof *eventproto.Proof) {
+	proofBytes, err := tm.txProofCoder.MarshalToBinary(proof)
+	if err != nil {
+		tm.logger.Errorf("cross[%v]->chain[%v]'s tx-proof marshal error", crossID, chainID, err)
+	}
+	tm.transitChainState(crossID, chainID, state, proofBytes)
+}
+
+func (tm *Manager) unmarshalProof(proofBytes []byte) (*eventproto.Proof, error) {
+	proofEvent, err := tm.txProofCoder.UnmarshalFromBinary(proofBytes)
+	if err != nil {
+		return nil, err
+	}
+	if proof, ok := proofEvent.(*eventproto.Proof); ok {
+		return proof, nil
 	}
+	return nil, proofConvertError
+}
+
+// observeCrossResult increase the metric of cross result, chain ids are loaded from db
+func (tm *Manager) observeCrossResult(crossID, result string) {
+	chainIDs, _ := tm.db.ReadChainIDs(crossID)
+	monitor.ObserveCrossEvent(chainIDs, result)
 }
 
 func (tm *Manager) recordChainState(crossID, chainID string, state storetype.State) {
-	if err := tm.db.WriteChainCrossState(crossID, chainID, state, nil); err != nil {
-		tm.logger.Errorf(DBCrossChainStateErrorFormat, crossID, state)
+	tm.transitChainState(crossID, chainID, state, nil)
+}
+
+// transitChainState change the state of chain from the stored state, the transition is retried with the latest state
+// when the state is changed concurrently, such as by the transaction handler of the same proxy
+func (tm *Manager) transitChainState(crossID, chainID string, state storetype.State, payload []byte) {
+	if !tm.holdsLease(crossID) {
+		return
+	}
+	var err error
+	for i := 0; i < ChainTransitionRetries; i++ {
+		current, _, _ := tm.db.ReadChainCrossState(crossID, chainID)
+		err = tm.db.ApplyTransition(&storetype.Transition{
+			CrossID: crossID,
+			ChainID: chainID,
+			From:    current,
+			To:      state,
+			Payload: payload,
+		})
+		if illegalErr, ok := err.(*storetype.IllegalTransitionError); !ok || illegalErr.Current == current {
+			break
+		}
+	}
+	if storetype.IsIllegalTransition(err) {
+		tm.logger.Warnf("cross[%v]->chain[%v]'s state will not be changed to %v, %v", crossID, chainID, state, err)
+	} else if err != nil {
+		tm.logger.Errorf(DBCrossChainStateErrorFormat, chainID, crossID, state)
 	}
 }
 
 func (tm *Manager) finishCrossEvent(crossID string) {
+	if !tm.holdsLease(crossID) {
+		return
+	}
 	// 只是从数据库中删除该值
 	if err := tm.db.DeleteCrossIDFromUnfinished(crossID); err != nil {
 		tm.logger.Errorf("delete crossID[%s] from db failed, ", crossID, err)
@@ -952,3 +1164,33 @@ func (tm *Manager) recoverUnfinishedCrossEvents() ([][]byte, error) {
 	}
 	return contents, nil
 }
+
+// checkChainIDs check the chain ids of cross event
+func checkChainIDs(chainIDs []string) error {
+	if len(chainIDs) < MinSupportedChainCount {
+		return fmt.Errorf("at least %v chains are required, but get %v", MinSupportedChainCount, len(chainIDs))
+	}
+	chainIDSet := make(map[string]struct{}, len(chainIDs))
+	for _, chainID := range chainIDs {
+		if _, exist := chainIDSet[chainID]; exist {
+			return fmt.Errorf("chain[%v] appears more than once", chainID)
+		}
+		chainIDSet[chainID] = struct{}{}
+	}
+	return nil
+}
+
+// hasAnyState return whether any chain is in one of the states
+func hasAnyState(chainStates []*chainCrossState, states ...storetype.State) bool {
+	for _, chainState := range chainStates {
+		if !chainState.exist {
+			continue
+		}
+		for _, state := range states {
+			if chainState.state == state {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -6,6 +6,7 @@
 package transaction
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -22,6 +23,7 @@ import (
 	"chainmaker.org/chainmaker-cross/prover/impl"
 	"chainmaker.org/chainmaker-cross/router"
 	"chainmaker.org/chainmaker-cross/store"
+	storetype "chainmaker.org/chainmaker-cross/store/types"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
@@ -62,7 +64,8 @@ func TestTransactionManager(t *testing.T) {
 	err = routerDispatcher()
 	require.Nil(t, err)
 	pd := prover.GetProverDispatcher()
-	pd.Register(NewProverMock([]string{chain1, chain2}))
+	// mock证明器需要实际验证，才能覆盖证明失败的场景
+	pd.RegisterWithPolicy(NewProverMock([]string{chain1, chain2}), conf.ProofPolicyRequired)
 	adapterDispatcher := adapter.GetChainAdapterDispatcher()
 	adapterDispatcher.SetLog(getLogger())
 	adapterDispatcher.Register(NewChainAdapterMock(chain1, chainInvoke, chain1QueryByTxKey, nil))
@@ -108,8 +111,26 @@ func TestTransactionManager(t *testing.T) {
 	fmt.Println(result)
 }
 
+func TestCheckChainIDs(t *testing.T) {
+	require.NotNil(t, checkChainIDs(nil))
+	require.NotNil(t, checkChainIDs([]string{chain1}))
+	require.NotNil(t, checkChainIDs([]string{chain1, chain2, chain1}))
+	require.Nil(t, checkChainIDs([]string{chain1, chain2}))
+	require.Nil(t, checkChainIDs([]string{chain1, chain2, "chain3", "chain4"}))
+}
+
+func TestHasAnyState(t *testing.T) {
+	chainStates := []*chainCrossState{
+		{crossTx: initCrossTxs(chain1, 0), state: storetype.StateProofSuccess, exist: true},
+		{crossTx: initCrossTxs(chain2, 1), state: storetype.StateCommitSuccess, exist: true},
+		{crossTx: initCrossTxs("chain3", 2), state: storetype.StateRollbackFailed, exist: false},
+	}
+	require.True(t, hasAnyState(chainStates, storetype.StateCommitSuccess, storetype.StateCommitFailed))
+	require.False(t, hasAnyState(chainStates, storetype.StateRollbackSuccess, storetype.StateRollbackFailed))
+}
+
 func handleCrossEvent(manager *Manager, crossEvent *eventproto.CrossEvent) (interface{}, error) {
-	manager.handle(crossEvent, true)
+	manager.handle(context.Background(), crossEvent, true)
 	time.Sleep(time.Second * 3) // 确保处理完成
 	// 查询对应结果
 	eveHandler, _ := handler.GetEventHandlerTools().GetHandler(handler.CrossSearch)
@@ -206,6 +227,14 @@ func (c *ChainAdapterMock) QueryTx(payload []byte) (*event.CommonTxResponse, err
 	return nil, nil
 }
 
+func (c *ChainAdapterMock) SaveProof(crossID, proofKey string, txProof *eventproto.Proof, verifyResult bool) (*eventproto.TxResponse, error) {
+	return event.NewTxResponse(c.chainID, "", 1024, 0, nil, nil), nil
+}
+
+func (c *ChainAdapterMock) Prove(crossID string, txProof *eventproto.Proof) bool {
+	return true
+}
+
 type ProverMock struct {
 	chainIDs []string
 }
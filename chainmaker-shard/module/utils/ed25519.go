@@ -0,0 +1,56 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// ParsePublicKey parse the hex encoded ed25519 public key
+func ParsePublicKey(key string) (ed25519.PublicKey, error) {
+	publicKey, err := hex.DecodeString(strings.TrimSpace(key))
+	if err != nil || len(publicKey) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid ed25519 public key [%s]", key)
+	}
+	return publicKey, nil
+}
+
+// ParsePublicKeys parse the hex encoded ed25519 public keys
+func ParsePublicKeys(keys []string) ([]ed25519.PublicKey, error) {
+	publicKeys := make([]ed25519.PublicKey, 0, len(keys))
+	for _, key := range keys {
+		publicKey, err := ParsePublicKey(key)
+		if err != nil {
+			return nil, err
+		}
+		publicKeys = append(publicKeys, publicKey)
+	}
+	return publicKeys, nil
+}
+
+// LoadPrivateKey load the ed25519 private key from the file, which contains the hex encoded seed or private key
+func LoadPrivateKey(keyFile string) (ed25519.PrivateKey, error) {
+	content, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	key, err := hex.DecodeString(strings.TrimSpace(string(content)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key in file[%s], %v", keyFile, err)
+	}
+	switch len(key) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(key), nil
+	case ed25519.PrivateKeySize:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("invalid length %d of private key in file[%s]", len(key), keyFile)
+	}
+}
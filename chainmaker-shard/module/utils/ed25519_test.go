@@ -0,0 +1,55 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePublicKeys(t *testing.T) {
+	key := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	publicKey := key.Public().(ed25519.PublicKey)
+	parsed, err := ParsePublicKey(" " + hex.EncodeToString(publicKey) + "\n")
+	require.NoError(t, err)
+	require.Equal(t, publicKey, parsed)
+	publicKeys, err := ParsePublicKeys([]string{hex.EncodeToString(publicKey)})
+	require.NoError(t, err)
+	require.Equal(t, []ed25519.PublicKey{publicKey}, publicKeys)
+	_, err = ParsePublicKey("abcd")
+	require.Error(t, err)
+	_, err = ParsePublicKeys([]string{hex.EncodeToString(publicKey), "not-hex"})
+	require.Error(t, err)
+}
+
+func TestLoadPrivateKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ed25519")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	key := ed25519.NewKeyFromSeed([]byte("0123456789abcdef0123456789abcdef"))
+	for name, content := range map[string]string{
+		"seed.key":    hex.EncodeToString(key.Seed()) + "\n",
+		"private.key": hex.EncodeToString(key),
+	} {
+		keyFile := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(keyFile, []byte(content), 0600))
+		loaded, err := LoadPrivateKey(keyFile)
+		require.NoError(t, err)
+		require.Equal(t, key, loaded)
+	}
+	invalidFile := filepath.Join(dir, "invalid.key")
+	require.NoError(t, ioutil.WriteFile(invalidFile, []byte("abcd"), 0600))
+	_, err = LoadPrivateKey(invalidFile)
+	require.Error(t, err)
+	_, err = LoadPrivateKey(filepath.Join(dir, "not_exist.key"))
+	require.Error(t, err)
+}
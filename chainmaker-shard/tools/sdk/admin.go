@@ -0,0 +1,151 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+ SPDX-License-Identifier: Apache-2.0
+*/
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	urlAdminCross      = "/admin/cross"
+	urlAdminDeadLetter = "/admin/deadletter"
+
+	AdminUnfinishedState = "unfinished"  // 未完成的跨链事务
+	AdminFailedState     = "failed"      // 失败的跨链事务
+	AdminDeadLetterState = "dead_letter" // 死信集合中的跨链事务
+	AdminAllState        = "all"         // 以上所有跨链事务
+
+	AdminCommitOp   = "commit"   // 重试提交
+	AdminRollbackOp = "rollback" // 重试回滚
+)
+
+//AdminResp response of admin api, Data is the raw json of result
+type AdminResp struct {
+	Code    int32           `json:"code"`
+	Message string          `json:"message,omitempty"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+//AdminClient client of the admin api of cross chain proxy, which is used to handle stuck cross transactions
+type AdminClient struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+//NewAdminClient create admin client, url is the address of web listener and token is the admin_token of proxy
+func NewAdminClient(url, token string) *AdminClient {
+	return &AdminClient{
+		url:    strings.TrimSuffix(url, "/"),
+		token:  token,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+//List list the cross ids which state is unfinished, failed, dead_letter or all
+func (a *AdminClient) List(state string) (*AdminResp, error) {
+	path := urlAdminCross
+	if state != "" {
+		path += "?state=" + url.QueryEscape(state)
+	}
+	return a.do(http.MethodGet, path, nil)
+}
+
+//Show show the state of cross transaction with the state of each chain
+func (a *AdminClient) Show(crossID string) (*AdminResp, error) {
+	return a.do(http.MethodGet, a.crossPath(crossID, ""), nil)
+}
+
+//Retry force a retry of commit or rollback for one chain
+func (a *AdminClient) Retry(crossID, chainID, op string) (*AdminResp, error) {
+	return a.do(http.MethodPost, a.crossPath(crossID, "retry"), map[string]string{
+		"chain_id": chainID,
+		"op":       op,
+	})
+}
+
+//Resolve mark the cross transaction as resolved with the note of operator
+func (a *AdminClient) Resolve(crossID, note string) (*AdminResp, error) {
+	return a.do(http.MethodPost, a.crossPath(crossID, "resolve"), map[string]string{
+		"note": note,
+	})
+}
+
+//History export the full state history of cross transaction
+func (a *AdminClient) History(crossID string) (*AdminResp, error) {
+	return a.do(http.MethodGet, a.crossPath(crossID, "history"), nil)
+}
+
+//Restore restore the archived cross transaction, then it can be searched and inspected again
+func (a *AdminClient) Restore(crossID string) (*AdminResp, error) {
+	return a.do(http.MethodPost, a.crossPath(crossID, "restore"), nil)
+}
+
+//DeadLetters list the cross ids in the dead-letter set
+func (a *AdminClient) DeadLetters() (*AdminResp, error) {
+	return a.do(http.MethodGet, urlAdminDeadLetter, nil)
+}
+
+//InspectDeadLetter show the dead-letter record of cross transaction
+func (a *AdminClient) InspectDeadLetter(crossID string) (*AdminResp, error) {
+	return a.do(http.MethodGet, urlAdminDeadLetter+"/"+url.PathEscape(crossID), nil)
+}
+
+//RedriveDeadLetter move the cross transaction out of the dead-letter set and handle it again
+func (a *AdminClient) RedriveDeadLetter(crossID string) (*AdminResp, error) {
+	return a.do(http.MethodPost, urlAdminDeadLetter+"/"+url.PathEscape(crossID)+"/redrive", nil)
+}
+
+func (a *AdminClient) crossPath(crossID, action string) string {
+	path := urlAdminCross + "/" + url.PathEscape(crossID)
+	if action != "" {
+		path += "/" + action
+	}
+	return path
+}
+
+func (a *AdminClient) do(method, path string, body interface{}) (*AdminResp, error) {
+	var reader io.Reader
+	if body != nil {
+		content, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(content)
+	}
+	req, err := http.NewRequest(method, a.url+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+a.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	adminResp := &AdminResp{}
+	if err = json.Unmarshal(content, adminResp); err != nil {
+		return nil, fmt.Errorf("unexpected response of admin api, status: %d, body: %s", resp.StatusCode, content)
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		return adminResp, fmt.Errorf("unauthorized, %s", adminResp.Message)
+	}
+	return adminResp, nil
+}
@@ -5,6 +5,8 @@
 package chainmaker
 
 import (
+	"strconv"
+
 	"chainmaker.org/chainmaker-cross/sdk/builder"
 	conf "chainmaker.org/chainmaker-cross/sdk/config"
 	"chainmaker.org/chainmaker/common/serialize"
@@ -34,6 +36,10 @@ func (pb *txContractParamBuilder) BuildExecuteParam(in *builder.CrossTxBuildPara
 		pb.Config.TransactionExecuteDataKey:  string(serialize.EasyMarshal(serialize.ParamsMapToEasyCodecItem(stringMap2BytesMap(eParams)))),
 		pb.Config.TransactionRollbackDataKey: string(serialize.EasyMarshal(serialize.ParamsMapToEasyCodecItem(stringMap2BytesMap(rParams)))),
 	}
+	// 仅由源码重新编译的事务合约检查截止时间，预编译的transaction.wasm会忽略该参数
+	if in.Deadline > 0 {
+		m[pb.Config.TransactionDeadlineKey] = strconv.FormatInt(in.Deadline, 10)
+	}
 	return builder.NewParamsWithMap(m), nil
 }
 
@@ -55,10 +55,15 @@ func (cb *CrossTxBuilder) Build(param *CrossTxBuildParam, opts ...CrossBuildOpti
 	if err != nil {
 		return nil, err
 	}
+	executeMethod := cb.Config.TransactionExecuteMethod
+	if param.Deadline > 0 && cb.Config.TransactionDeadlineMethod != "" {
+		// 带截止时间的交易调用单独的执行方法，未设置截止时间时保持原有调用方式
+		executeMethod = cb.Config.TransactionDeadlineMethod
+	}
 	executePayload, err := cb.SdkTxBuilder.Build(&TxRequestBuildParam{
 		Contract: &Contract{
 			Name:   cb.Config.TransactionContractName,
-			Method: cb.Config.TransactionExecuteMethod,
+			Method: executeMethod,
 			Params: params.ExecuteParam,
 		},
 	})
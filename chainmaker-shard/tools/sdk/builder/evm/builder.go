@@ -0,0 +1,39 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+ SPDX-License-Identifier: Apache-2.0
+*/
+package evm
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"chainmaker.org/chainmaker-cross/adapter/evm"
+	"chainmaker.org/chainmaker-cross/sdk/builder"
+	conf "chainmaker.org/chainmaker-cross/sdk/config"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// txRequestBuilder build the call of transaction contract, the transaction is signed and sent by the evm adapter
+type txRequestBuilder struct {
+	config *conf.CrossChainConf
+}
+
+func (cb *txRequestBuilder) Build(in *builder.TxRequestBuildParam) ([]byte, error) {
+	// evm链的事务合约名为合约地址
+	if !common.IsHexAddress(in.Contract.Name) {
+		return nil, fmt.Errorf("transaction contract [%s] of chain[%s] is not an address", in.Contract.Name, cb.config.ChainID)
+	}
+	data, err := evm.PackTransactionCall(in.Contract.Method, in.Contract.Params.Values()...)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(evm.NewTxRequest(common.HexToAddress(in.Contract.Name).Hex(), hexutil.Encode(data)))
+}
+
+func NewTxRequestBuilder(conf *conf.CrossChainConf) (*txRequestBuilder, error) {
+	return &txRequestBuilder{
+		config: conf,
+	}, nil
+}
@@ -0,0 +1,84 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+ SPDX-License-Identifier: Apache-2.0
+*/
+package evm
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"chainmaker.org/chainmaker-cross/adapter/evm"
+	"chainmaker.org/chainmaker-cross/sdk/builder"
+	conf "chainmaker.org/chainmaker-cross/sdk/config"
+)
+
+const (
+	transactionContract = "0x5B38Da6a701c568545dCfcB03FcB875f56beddC4"
+	businessContract    = "0xAb8483F64d9C6d1EcF9b849Ae677dD3315835cb2"
+)
+
+func TestEvmParamBuilder(t *testing.T) {
+	pbr := NewTxContractParamBuilder(&conf.CrossChainConf{ChainID: "evm1", ChainType: "evm"})
+	crossID := uuid.New().String()
+	buildParam := &builder.CrossTxBuildParam{
+		CrossID:                  crossID,
+		ExecuteBusinessContract:  builder.NewContract(businessContract, "transfer(address,uint256)", builder.NewParamsNoKeys(businessContract, "100")),
+		RollbackBusinessContract: builder.NewContract(businessContract, "refund(address,uint256)", builder.NewParamsNoKeys(businessContract, "100")),
+		Deadline:                 1024,
+	}
+	params, err := pbr.BuildExecuteParam(buildParam)
+	require.Nil(t, err)
+	values := params.Values()
+	require.Len(t, values, 6)
+	require.Equal(t, crossID, values[0])
+	require.Equal(t, businessContract, values[1])
+	require.Equal(t, "0xa9059cbb", values[2][:10])
+	require.Equal(t, "1024", values[5])
+
+	// proofKey is the first argument of business method
+	options := builder.NewCrossBuildOptions()
+	options.ProofKey = "123456"
+	builder.WithUseProofKey(true)(options)
+	buildParam.ExecuteBusinessContract.Method = "transfer(string,address,uint256)"
+	params, err = pbr.BuildExecuteParam(buildParam, options.ParamOptions...)
+	require.Nil(t, err)
+	require.Len(t, buildParam.ExecuteBusinessContract.Params.Values(), 2)
+
+	buildParam.ExecuteBusinessContract.Name = "ContractName"
+	_, err = pbr.BuildExecuteParam(buildParam)
+	require.NotNil(t, err)
+
+	params, err = pbr.BuildCommitParam(buildParam)
+	require.Nil(t, err)
+	require.Equal(t, []string{crossID}, params.Values())
+}
+
+func TestEvmBuilder(t *testing.T) {
+	txBuilder, err := NewTxRequestBuilder(&conf.CrossChainConf{ChainID: "evm1", ChainType: "evm"})
+	require.Nil(t, err)
+	reqBytes, err := txBuilder.Build(&builder.TxRequestBuildParam{
+		CrossID: uuid.New().String(),
+		Contract: &builder.Contract{
+			Name:   transactionContract,
+			Method: evm.CommitMethod,
+			Params: builder.NewParamsNoKeys("crossID"),
+		},
+	})
+	require.Nil(t, err)
+	request := &evm.TxRequest{}
+	require.Nil(t, json.Unmarshal(reqBytes, request))
+	require.Equal(t, transactionContract, request.To)
+
+	_, err = txBuilder.Build(&builder.TxRequestBuildParam{
+		Contract: &builder.Contract{
+			Name:   "TransactionStable",
+			Method: evm.CommitMethod,
+			Params: builder.NewParamsNoKeys("crossID"),
+		},
+	})
+	require.NotNil(t, err)
+}
@@ -0,0 +1,80 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+ SPDX-License-Identifier: Apache-2.0
+*/
+package evm
+
+import (
+	"fmt"
+	"strconv"
+
+	"chainmaker.org/chainmaker-cross/adapter/evm"
+	"chainmaker.org/chainmaker-cross/sdk/builder"
+	conf "chainmaker.org/chainmaker-cross/sdk/config"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+type txContractParamBuilder struct {
+	Config *conf.CrossChainConf
+}
+
+// BuildExecuteParam build the params of execute method, the name of business contract is the contract address,
+// the method is the solidity signature such as transfer(address,uint256), and the params are in the order of signature
+func (pb *txContractParamBuilder) BuildExecuteParam(in *builder.CrossTxBuildParam, opts ...builder.ParamsBuildOption) (*builder.Params, error) {
+	in = pb.refactorParam(in, opts...)
+	eData, err := ContractToCallData(in.ExecuteBusinessContract)
+	if err != nil {
+		return nil, err
+	}
+	rData, err := ContractToCallData(in.RollbackBusinessContract)
+	if err != nil {
+		return nil, err
+	}
+	// 参数顺序与事务合约execute方法一致
+	return builder.NewParamsNoKeys(in.CrossID, in.ExecuteBusinessContract.Name, eData,
+		in.RollbackBusinessContract.Name, rData, strconv.FormatInt(in.Deadline, 10)), nil
+}
+
+func (pb *txContractParamBuilder) BuildCommitParam(in *builder.CrossTxBuildParam) (*builder.Params, error) {
+	return builder.NewParamsNoKeys(in.CrossID), nil
+}
+
+func (pb *txContractParamBuilder) BuildRollbackParam(in *builder.CrossTxBuildParam) (*builder.Params, error) {
+	return builder.NewParamsNoKeys(in.CrossID), nil
+}
+
+func (pb *txContractParamBuilder) refactorParam(in *builder.CrossTxBuildParam, opts ...builder.ParamsBuildOption) *builder.CrossTxBuildParam {
+	if len(opts) == 0 {
+		return in
+	}
+	options := builder.NewParamsBuildOptions(opts...)
+	out := *in
+	if options.UseProofKey { //与fabric一致，proofkey作为业务合约方法的第一个参数，方法签名中需包含该参数
+		m := make([]string, in.ExecuteBusinessContract.Params.Len()+1)
+		m[0] = options.ProofKey
+		copy(m[1:], in.ExecuteBusinessContract.Params.Values())
+		execute := *in.ExecuteBusinessContract
+		execute.Params = builder.NewParamsNoKeys(m...)
+		out.ExecuteBusinessContract = &execute
+	}
+	return &out
+}
+
+func NewTxContractParamBuilder(conf *conf.CrossChainConf) *txContractParamBuilder {
+	return &txContractParamBuilder{
+		Config: conf,
+	}
+}
+
+// ContractToCallData encode the call of business contract to hex string
+func ContractToCallData(c *builder.Contract) (string, error) {
+	if !common.IsHexAddress(c.Name) {
+		return "", fmt.Errorf("business contract [%s] is not an address", c.Name)
+	}
+	data, err := evm.PackCall(c.Method, c.Params.Values()...)
+	if err != nil {
+		return "", err
+	}
+	return hexutil.Encode(data), nil
+}
@@ -6,6 +6,7 @@ package fabric
 
 import (
 	"encoding/json"
+	"strconv"
 
 	"chainmaker.org/chainmaker-cross/sdk/builder"
 	conf "chainmaker.org/chainmaker-cross/sdk/config"
@@ -42,6 +43,10 @@ func (pb *txContractParamBuilder) BuildExecuteParam(in *builder.CrossTxBuildPara
 		return nil, err
 	}
 
+	if in.Deadline > 0 {
+		// 设置了截止时间时调用ExecuteWithDeadline，第四个参数为截止时间
+		return builder.NewParamsNoKeys(in.CrossID, string(eParamsBz), string(rParamsBz), strconv.FormatInt(in.Deadline, 10)), nil
+	}
 	return builder.NewParamsNoKeys(in.CrossID, string(eParamsBz), string(rParamsBz)), nil
 }
 
@@ -18,6 +18,8 @@ type CrossTxBuildParam struct {
 	ExecuteBusinessContract *Contract
 	//business contract information to be rolled back
 	RollbackBusinessContract *Contract
+	//deadline of the cross-chain transaction, unix timestamp in seconds, 0 means no deadline
+	Deadline int64
 }
 
 func (cbp *CrossTxBuildParam) SetCrossID(crossID string) {
@@ -26,6 +28,14 @@ func (cbp *CrossTxBuildParam) SetCrossID(crossID string) {
 	}
 }
 
+//SetDeadline set the deadline which is passed to the transaction contract, the late commits will be rejected
+//by the contract which checks the deadline, the prebuilt chainmaker transaction.wasm ignores it
+func (cbp *CrossTxBuildParam) SetDeadline(deadline int64) {
+	if cbp != nil {
+		cbp.Deadline = deadline
+	}
+}
+
 func (c *CrossTxBuildParam) Fix() {
 	if c.ExecuteBusinessContract.Name == "" && c.RollbackBusinessContract.Name != "" {
 		c.ExecuteBusinessContract.Name = c.RollbackBusinessContract.Name
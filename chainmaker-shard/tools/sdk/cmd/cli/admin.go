@@ -0,0 +1,207 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+   SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"fmt"
+
+	"chainmaker.org/chainmaker-cross/sdk"
+	"github.com/spf13/cobra"
+)
+
+// AdminCMD admin command which handle the stuck cross transactions
+func AdminCMD() *cobra.Command {
+	adminCmd := &cobra.Command{
+		Use:   "admin",
+		Short: "Admin Stuck CrossEvent",
+		Long:  "List, Retry, Resolve, Export History, Restore Archived And Redrive Dead-Letter CrossEvent By Proxy Admin API",
+	}
+	adminCmd.AddCommand(adminListCMD())
+	adminCmd.AddCommand(adminShowCMD())
+	adminCmd.AddCommand(adminRetryCMD())
+	adminCmd.AddCommand(adminResolveCMD())
+	adminCmd.AddCommand(adminHistoryCMD())
+	adminCmd.AddCommand(adminRestoreCMD())
+	adminCmd.AddCommand(adminDeadLetterCMD())
+	return adminCmd
+}
+
+func adminListCMD() *cobra.Command {
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List cross ids which are unfinished, failed or in dead-letter set",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			state, _ := cmd.Flags().GetString(flagNameOfState)
+			return printAdminResp(newAdminClient().List(state))
+		},
+	}
+	attachFlags(listCmd, []string{flagNameOfUrl, flagNameOfToken})
+	listCmd.Flags().String(flagNameOfState, sdk.AdminUnfinishedState, "the state of cross, unfinished, failed, dead_letter or all")
+	return listCmd
+}
+
+func adminShowCMD() *cobra.Command {
+	showCmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the state of cross and each chain",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			crossID, err := getRequiredFlag(cmd, flagNameOfCrossID)
+			if err != nil {
+				return err
+			}
+			return printAdminResp(newAdminClient().Show(crossID))
+		},
+	}
+	attachFlags(showCmd, []string{flagNameOfUrl, flagNameOfToken})
+	showCmd.Flags().String(flagNameOfCrossID, "", "the cross id for event")
+	return showCmd
+}
+
+func adminRetryCMD() *cobra.Command {
+	retryCmd := &cobra.Command{
+		Use:   "retry",
+		Short: "Force a retry of commit or rollback for one chain",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			crossID, err := getRequiredFlag(cmd, flagNameOfCrossID)
+			if err != nil {
+				return err
+			}
+			chainID, err := getRequiredFlag(cmd, flagNameOfChainID)
+			if err != nil {
+				return err
+			}
+			op, _ := cmd.Flags().GetString(flagNameOfOp)
+			return printAdminResp(newAdminClient().Retry(crossID, chainID, op))
+		},
+	}
+	attachFlags(retryCmd, []string{flagNameOfUrl, flagNameOfToken})
+	retryCmd.Flags().String(flagNameOfCrossID, "", "the cross id for event")
+	retryCmd.Flags().String(flagNameOfChainID, "", "the chain id which will be retried")
+	retryCmd.Flags().String(flagNameOfOp, sdk.AdminCommitOp, "the operation which will be retried, commit or rollback")
+	return retryCmd
+}
+
+func adminResolveCMD() *cobra.Command {
+	resolveCmd := &cobra.Command{
+		Use:   "resolve",
+		Short: "Mark the cross as resolved with an operator note",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			crossID, err := getRequiredFlag(cmd, flagNameOfCrossID)
+			if err != nil {
+				return err
+			}
+			note, err := getRequiredFlag(cmd, flagNameOfNote)
+			if err != nil {
+				return err
+			}
+			return printAdminResp(newAdminClient().Resolve(crossID, note))
+		},
+	}
+	attachFlags(resolveCmd, []string{flagNameOfUrl, flagNameOfToken})
+	resolveCmd.Flags().String(flagNameOfCrossID, "", "the cross id for event")
+	resolveCmd.Flags().String(flagNameOfNote, "", "the note of operator")
+	return resolveCmd
+}
+
+func adminHistoryCMD() *cobra.Command {
+	historyCmd := &cobra.Command{
+		Use:   "history",
+		Short: "Export the full state history of cross",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			crossID, err := getRequiredFlag(cmd, flagNameOfCrossID)
+			if err != nil {
+				return err
+			}
+			return printAdminResp(newAdminClient().History(crossID))
+		},
+	}
+	attachFlags(historyCmd, []string{flagNameOfUrl, flagNameOfToken})
+	historyCmd.Flags().String(flagNameOfCrossID, "", "the cross id for event")
+	return historyCmd
+}
+
+func adminRestoreCMD() *cobra.Command {
+	restoreCmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore the archived cross for search",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			crossID, err := getRequiredFlag(cmd, flagNameOfCrossID)
+			if err != nil {
+				return err
+			}
+			return printAdminResp(newAdminClient().Restore(crossID))
+		},
+	}
+	attachFlags(restoreCmd, []string{flagNameOfUrl, flagNameOfToken})
+	restoreCmd.Flags().String(flagNameOfCrossID, "", "the cross id for event")
+	return restoreCmd
+}
+
+func adminDeadLetterCMD() *cobra.Command {
+	deadLetterCmd := &cobra.Command{
+		Use:   "deadletter",
+		Short: "List, inspect and re-drive the cross whose retries are exhausted",
+	}
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List cross ids in the dead-letter set",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return printAdminResp(newAdminClient().DeadLetters())
+		},
+	}
+	attachFlags(listCmd, []string{flagNameOfUrl, flagNameOfToken})
+	inspectCmd := &cobra.Command{
+		Use:   "inspect",
+		Short: "Show the dead-letter record of cross",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			crossID, err := getRequiredFlag(cmd, flagNameOfCrossID)
+			if err != nil {
+				return err
+			}
+			return printAdminResp(newAdminClient().InspectDeadLetter(crossID))
+		},
+	}
+	attachFlags(inspectCmd, []string{flagNameOfUrl, flagNameOfToken})
+	inspectCmd.Flags().String(flagNameOfCrossID, "", "the cross id for event")
+	redriveCmd := &cobra.Command{
+		Use:   "redrive",
+		Short: "Move the cross out of the dead-letter set and handle it again",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			crossID, err := getRequiredFlag(cmd, flagNameOfCrossID)
+			if err != nil {
+				return err
+			}
+			return printAdminResp(newAdminClient().RedriveDeadLetter(crossID))
+		},
+	}
+	attachFlags(redriveCmd, []string{flagNameOfUrl, flagNameOfToken})
+	redriveCmd.Flags().String(flagNameOfCrossID, "", "the cross id for event")
+	deadLetterCmd.AddCommand(listCmd, inspectCmd, redriveCmd)
+	return deadLetterCmd
+}
+
+func newAdminClient() *sdk.AdminClient {
+	return sdk.NewAdminClient(DefaultURL, AdminToken)
+}
+
+func getRequiredFlag(cmd *cobra.Command, flagName string) (string, error) {
+	value, err := cmd.Flags().GetString(flagName)
+	if err != nil || value == "" {
+		return "", fmt.Errorf("missing flag --%s", flagName)
+	}
+	return value, nil
+}
+
+func printAdminResp(resp *sdk.AdminResp, err error) error {
+	if err != nil {
+		return fmt.Errorf("admin request error: [%v]", err)
+	}
+	if resp.Code != 0 {
+		return fmt.Errorf("admin request failed: [%s]", resp.Message)
+	}
+	fmt.Printf("%s\n", resp.Data)
+	return nil
+}
@@ -22,6 +22,7 @@ func main() {
 	mainCmd := &cobra.Command{Use: "cross-chain-cli"}
 	mainCmd.AddCommand(DeliverEventCMD())
 	mainCmd.AddCommand(ShowCrossResultCMD())
+	mainCmd.AddCommand(AdminCMD())
 
 	err := mainCmd.Execute()
 	if err != nil {
@@ -116,6 +117,7 @@ func initFlagSet() *pflag.FlagSet {
 	flags := &pflag.FlagSet{}
 	flags.StringVarP(&ConfigFilepath, flagNameOfConfigFilepath, flagNameShortHandOfConfigFilepath, ConfigFilepath, "specify config file path, if not set, default use ./cross_chain_sdk.yml")
 	flags.StringVarP(&DefaultURL, flagNameOfUrl, flagNameShortHandOfUrl, DefaultURL, "specify default url, if not set, default use http://localhost:8080")
+	flags.StringVarP(&AdminToken, flagNameOfToken, flagNameShortHandOfToken, AdminToken, "specify the admin token of proxy, which is configured by listener.web.admin_token")
 	return flags
 }
 
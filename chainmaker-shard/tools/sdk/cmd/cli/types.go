@@ -18,11 +18,18 @@ var (
 	flagNameShortHandOfUrl            = "u"
 	flagNameOfCrossID                 = "crossID"
 	flagNameOfParams                  = "params"
+	flagNameOfToken                   = "token"
+	flagNameShortHandOfToken          = "t"
+	flagNameOfState                   = "state"
+	flagNameOfChainID                 = "chainID"
+	flagNameOfOp                      = "op"
+	flagNameOfNote                    = "note"
 )
 
 var (
 	ConfigFilepath = "./cross_chain_sdk.yml"
 	DefaultURL     = "http://localhost:8080"
+	AdminToken     = ""
 )
 
 type crossTxParam struct {
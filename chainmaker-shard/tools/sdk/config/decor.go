@@ -26,6 +26,7 @@ func chainParamNameSet(fn CfgHandle) CfgHandle {
 
 				v.TransactionExecuteDataKey = "execData"
 				v.TransactionRollbackDataKey = "rollbackData"
+				v.TransactionDeadlineKey = "deadline"
 
 				v.BusinessCrossIDKey = "crossID"
 				v.BusinessProofKey = "proofKey"
@@ -35,17 +36,27 @@ func chainParamNameSet(fn CfgHandle) CfgHandle {
 			case "fabric":
 				v.TransactionContractName = "Transaction"
 				v.TransactionExecuteMethod = "Execute"
+				v.TransactionDeadlineMethod = "ExecuteWithDeadline"
 				v.TransactionCommitMethod = "Commit"
 				v.TransactionRollbackMethod = "Rollback"
 
 				v.TransactionExecuteDataKey = "executeData"
 				v.TransactionRollbackDataKey = "rollbackData"
+				v.TransactionDeadlineKey = "deadline"
 
 				v.BusinessCrossIDKey = "crossID"
 				v.BusinessProofKey = "proofKey"
 				v.BusinessContractNameKey = "contract"
 				v.BusinessMethodKey = "method"
 				v.BusinessParamsKey = "params"
+			case "evm":
+				// evm链的事务合约名为部署后的合约地址，由配置指定
+				v.TransactionExecuteMethod = "execute"
+				v.TransactionCommitMethod = "commit"
+				v.TransactionRollbackMethod = "rollback"
+
+				v.BusinessCrossIDKey = "crossID"
+				v.BusinessProofKey = "proofKey"
 			}
 		}
 		if fn == nil {
@@ -73,6 +84,8 @@ func configTemFileSet(fn CfgHandle) CfgHandle {
 					return cfg, errors.WithMessage(err, "create fabric sdk tmp file error:")
 				}
 				v.ChainConfigTemplatePath = path
+			case "evm":
+				// evm交易由跨链代理签名发送，sdk无需链配置文件
 			default:
 				return cfg, fmt.Errorf("unrecognized chain type: %s", v.ChainType)
 			}
@@ -62,6 +62,14 @@ func (ccl *ConfigLists) ConvertToMap() *ConfigMap {
 				panic(fmt.Sprintf("create fabric sdk tmp file error: %s", err))
 			}
 			v.ChainConfigTemplatePath = path
+		case "evm":
+			// evm链的事务合约名为合约地址，保留配置中的值，方法名与事务合约一致
+			v.TransactionExecuteMethod = "execute"
+			v.TransactionCommitMethod = "commit"
+			v.TransactionRollbackMethod = "rollback"
+			v.BusinessCrossIDKey = "crossID"
+			ccM[v.ChainID] = v
+			continue
 		default:
 			panic(fmt.Sprintf("unrecognized chain type: %s", v.ChainType))
 		}
@@ -70,11 +78,13 @@ func (ccl *ConfigLists) ConvertToMap() *ConfigMap {
 		// 事物合约参数
 		v.TransactionContractName = "TransactionStable"
 		v.TransactionExecuteMethod = "Execute"
+		v.TransactionDeadlineMethod = "ExecuteWithDeadline"
 		v.TransactionCommitMethod = "Commit"
 		v.TransactionRollbackMethod = "Rollback"
 
 		v.TransactionExecuteDataKey = "executeData"
 		v.TransactionRollbackDataKey = "rollbackData"
+		v.TransactionDeadlineKey = "deadline"
 
 		v.BusinessCrossIDKey = "crossID"
 		v.BusinessContractNameKey = "contractName"
@@ -113,10 +123,12 @@ type CrossChainConf struct {
 	//ChainClientConfigPath      string `mapstructure:"chain_client_config_path"`      // 配置文件路径
 	TransactionContractName    string                 `mapstructure:"transaction_contract_name"`     // 事物合约名，一般一条链复用一个事务合约，合约提供通用的 执行、确认、回滚 方法
 	TransactionExecuteMethod   string                 `mapstructure:"transaction_execute_method"`    // 事物合约 执行方法 名
+	TransactionDeadlineMethod  string                 `mapstructure:"transaction_deadline_method"`   // 事物合约 带截止时间的执行方法 名，为空时截止时间通过执行方法传入
 	TransactionCommitMethod    string                 `mapstructure:"transaction_commit_method"`     // 事物合约 确认方法 名
 	TransactionRollbackMethod  string                 `mapstructure:"transaction_rollback_method"`   // 事物合约 回滚方法 名
 	TransactionExecuteDataKey  string                 `mapstructure:"transaction_execute_data_key"`  // 调用事物合约执行方法，执行数据入参的键
 	TransactionRollbackDataKey string                 `mapstructure:"transaction_rollback_data_key"` // 调用事物合约执行方法，回滚数据入参的键
+	TransactionDeadlineKey     string                 `mapstructure:"transaction_deadline_key"`      // 调用事物合约执行方法，截止时间入参的键
 	BusinessCrossIDKey         string                 `mapstructure:"business_cross_id_key"`         // 跨链交易ID的键
 	BusinessProofKey           string                 `mapstructure:"business_proof_key"`            // 跨链交易proofKey的键
 	BusinessContractNameKey    string                 `mapstructure:"business_contract_name_key"`    // 事务合约执行跨合约调用时，解析业务合约 合约名 的键
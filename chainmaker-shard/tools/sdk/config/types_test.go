@@ -19,4 +19,19 @@ func TestCrossChainConf_GetExtraParamsByKey(t *testing.T) {
 	sli, err := conf.GetExtraParamsByKey("slice")
 	require.NoError(t, err)
 	require.NotNil(t, sli)
-}
\ No newline at end of file
+}
+func TestConfigLists_ConvertToMapEvm(t *testing.T) {
+	lists := ConfigLists{
+		{
+			ChainID:                 "evm1",
+			ChainType:               "evm",
+			TransactionContractName: "0x5B38Da6a701c568545dCfcB03FcB875f56beddC4",
+		},
+	}
+	conf := lists.ConvertToMap().GetCrossChainConfByChainID("evm1")
+	require.NotNil(t, conf)
+	require.Equal(t, "0x5B38Da6a701c568545dCfcB03FcB875f56beddC4", conf.TransactionContractName)
+	require.Equal(t, "execute", conf.TransactionExecuteMethod)
+	require.Equal(t, "commit", conf.TransactionCommitMethod)
+	require.Equal(t, "rollback", conf.TransactionRollbackMethod)
+}
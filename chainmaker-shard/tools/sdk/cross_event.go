@@ -21,7 +21,8 @@ import (
 )
 
 const (
-	CrossTxsLimit = 2
+	// CrossTxsMinLimit a cross event needs at least two cross transactions
+	CrossTxsMinLimit = 2
 )
 
 //CrossEventContext represents a context of CrossEvent
@@ -61,17 +62,49 @@ func (cc *CrossEventContext) GetCrossID() string {
 }
 
 //BuildEvent construct the CrossEvent through parameters txs
-//txs is a variable parameter, note: the current limit for cross-chain transactions is two chains
+//txs is a variable parameter, note: the cross transactions will be executed in the order of their index,
+//and each chain can only appear once
 func (cc *CrossEventContext) BuildEvent(txs ...*eventproto.CrossTx) error {
-	if cc.event.TxEvents.Len()+len(txs) > CrossTxsLimit {
-		return ErrCrossTxOverrun
+	for _, tx := range txs {
+		for _, exist := range cc.event.TxEvents.GetCrossTxs() {
+			if exist.GetChainID() == tx.GetChainID() {
+				return ErrCrossTxChainRepeated
+			}
+		}
 	}
 	cc.event.TxEvents.Append(txs...)
 	return nil
 }
 
+//SetExecuteMode set the execute mode of CrossEvent
+//cross transactions without data dependency can be executed concurrently by ParallelExecuteMode
+func (cc *CrossEventContext) SetExecuteMode(mode eventproto.ExecuteMode) {
+	cc.event.SetExecuteMode(mode)
+}
+
+//SetCallbackUrls set the webhook urls of CrossEvent, the state changes of cross transaction will be posted to them
+func (cc *CrossEventContext) SetCallbackUrls(urls ...string) {
+	cc.event.CallbackUrls = urls
+}
+
+//SetRetryPolicy set the retry policy of CrossEvent, which overrides the retry policy configured in cross-chain proxy
+//zero fields of policy mean using the proxy's config
+func (cc *CrossEventContext) SetRetryPolicy(policy *eventproto.RetryPolicy) {
+	cc.event.RetryPolicy = policy
+}
+
+//SetDeadline set the deadline of CrossEvent, the proxy will rollback the cross transaction once the deadline passes
+func (cc *CrossEventContext) SetDeadline(deadline time.Time) {
+	cc.event.SetDeadline(deadline.Unix())
+}
+
+//SetInitiator set the initiator of CrossEvent, the history of cross transactions can be searched by initiator
+func (cc *CrossEventContext) SetInitiator(initiator string) {
+	cc.event.Initiator = initiator
+}
+
 func (cc *CrossEventContext) sendCheck() error {
-	if cc.event.TxEvents.Len() != CrossTxsLimit {
+	if cc.event.TxEvents.Len() < CrossTxsMinLimit {
 		return ErrCrossTxMismatch
 	}
 	return nil
@@ -18,8 +18,8 @@ const (
 )
 
 var (
-	ErrCrossTxOverrun            = fmt.Errorf("number of cross transaction gather than %d", CrossTxsLimit)
-	ErrCrossTxMismatch           = fmt.Errorf("number of cross transaction is not %d", CrossTxsLimit)
+	ErrCrossTxChainRepeated      = fmt.Errorf("chain of cross transaction is repeated")
+	ErrCrossTxMismatch           = fmt.Errorf("number of cross transaction is less than %d", CrossTxsMinLimit)
 	ErrCrossEventCrossIDNotMatch = fmt.Errorf("this cross event sent CrossID does not match the received CrossID ")
 
 	defaultEventSendOptions = eventSendOptions{
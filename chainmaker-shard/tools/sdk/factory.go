@@ -9,6 +9,7 @@ import (
 	"fmt"
 
 	"chainmaker.org/chainmaker-cross/sdk/builder/chainmaker"
+	"chainmaker.org/chainmaker-cross/sdk/builder/evm"
 	"chainmaker.org/chainmaker-cross/sdk/builder/fabric"
 	conf "chainmaker.org/chainmaker-cross/sdk/config"
 
@@ -21,6 +22,7 @@ type ChainType string
 const (
 	ChainTypeChainMaker ChainType = "chainmaker"
 	ChainTypeFabric 	ChainType = "fabric"
+	ChainTypeEvm    	ChainType = "evm"
 )
 
 var (
@@ -33,6 +35,8 @@ func TxRequestBuilderFactory(typ ChainType, conf *conf.CrossChainConf) (builder.
 		return chainmaker.NewTxRequestBuilder(conf)
 	case ChainTypeFabric:
 		return fabric.NewTxRequestBuilder(conf)
+	case ChainTypeEvm:
+		return evm.NewTxRequestBuilder(conf)
 	}
 	return nil, fmt.Errorf("TxRequestBuilder of the ChainType [%v] Unsupported", typ)
 }
@@ -43,6 +47,8 @@ func TxParamBuilderFactory(typ ChainType, conf *conf.CrossChainConf) (builder.Tx
 		return chainmaker.NewTxContractParamBuilder(conf), nil
 	case ChainTypeFabric:
 		return fabric.NewTxContractParamBuilder(conf), nil
+	case ChainTypeEvm:
+		return evm.NewTxContractParamBuilder(conf), nil
 	}
 	return nil, errors.New(fmt.Sprintf("TxParamBuilder of the ChainType [%v] Unsupported", typ))
 }
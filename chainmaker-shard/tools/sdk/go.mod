@@ -13,6 +13,7 @@ require (
 	chainmaker.org/chainmaker/pb-go v0.0.0-20210719032153-653bd8436ef6
 	chainmaker.org/chainmaker/pb-go/v2 v2.0.0
 	chainmaker.org/chainmaker/sdk-go/v2 v2.0.0
+	github.com/ethereum/go-ethereum v1.10.4
 	github.com/gogo/protobuf v1.3.2
 	github.com/golang/mock v1.6.0
 	github.com/golang/protobuf v1.5.2
@@ -23,6 +24,7 @@ require (
 	github.com/spf13/pflag v1.0.5
 	github.com/spf13/viper v1.7.1
 	github.com/stretchr/testify v1.7.0
+	google.golang.org/grpc v1.36.0
 )
 
 replace (
@@ -30,6 +32,7 @@ replace (
 	chainmaker.org/chainmaker-cross/conf => ../../module/conf
 	chainmaker.org/chainmaker-cross/event => ../../module/event
 	chainmaker.org/chainmaker-cross/logger => ../../module/logger
+	chainmaker.org/chainmaker-cross/monitor => ../../module/monitor
 	chainmaker.org/chainmaker-cross/mock => ../../mock
 	chainmaker.org/chainmaker-cross/net => ../../module/net
 	chainmaker.org/chainmaker-cross/pb/protogo => ../../module/pb/protogo
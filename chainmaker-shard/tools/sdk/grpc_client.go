@@ -0,0 +1,90 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+ SPDX-License-Identifier: Apache-2.0
+*/
+package sdk
+
+import (
+	"context"
+	"io"
+
+	"chainmaker.org/chainmaker-cross/conf"
+	"chainmaker.org/chainmaker-cross/net/net_http"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	eventproto "chainmaker.org/chainmaker-cross/pb/protogo/event"
+)
+
+//CrossGrpcClient the client of cross chain grpc service
+type CrossGrpcClient struct {
+	conn   *grpc.ClientConn
+	client eventproto.CrossChainServiceClient
+}
+
+//NewCrossGrpcClient create grpc client which connect to the cross chain proxy, tls will be used if security is not nil
+func NewCrossGrpcClient(address string, security *conf.TransportSecurity) (*CrossGrpcClient, error) {
+	opts := make([]grpc.DialOption, 0)
+	if security != nil {
+		tlsConfig, err := net_http.GetClientTlsConfig(security)
+		if err != nil {
+			return nil, errors.WithMessage(err, "grpc client tls config fail:")
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+	conn, err := grpc.Dial(address, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &CrossGrpcClient{
+		conn:   conn,
+		client: eventproto.NewCrossChainServiceClient(conn),
+	}, nil
+}
+
+//InvokeCrossEvent send cross event to the cross chain proxy
+func (c *CrossGrpcClient) InvokeCrossEvent(ctx context.Context, event *CrossEventContext) (*eventproto.CrossResponse, error) {
+	if event == nil || event.event == nil {
+		return nil, errors.New("crossEvent to be sent is invalid")
+	}
+	if err := event.sendCheck(); err != nil {
+		return nil, err
+	}
+	return c.client.InvokeCrossEvent(ctx, event.event)
+}
+
+//QueryCrossResult query the result of cross event
+func (c *CrossGrpcClient) QueryCrossResult(ctx context.Context, crossID string) (*eventproto.CrossResponse, error) {
+	return c.client.GetCrossEvent(ctx, &eventproto.CrossSearchEvent{CrossId: crossID})
+}
+
+//WatchCrossResult watch the result of cross event, every changed result will be passed to the callback,
+//it returns the final result when the cross event is finished
+func (c *CrossGrpcClient) WatchCrossResult(ctx context.Context, crossID string, callback func(*eventproto.CrossResponse)) (*eventproto.CrossResponse, error) {
+	stream, err := c.client.WatchCrossEvent(ctx, &eventproto.CrossSearchEvent{CrossId: crossID})
+	if err != nil {
+		return nil, err
+	}
+	var last *eventproto.CrossResponse
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return last, nil
+		}
+		if err != nil {
+			return last, err
+		}
+		if callback != nil {
+			callback(resp)
+		}
+		last = resp
+	}
+}
+
+//Close close the grpc connection
+func (c *CrossGrpcClient) Close() error {
+	return c.conn.Close()
+}
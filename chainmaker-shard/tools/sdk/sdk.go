@@ -7,6 +7,7 @@ package sdk
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"chainmaker.org/chainmaker-cross/net/net_http"
 	"github.com/pkg/errors"
@@ -145,7 +146,20 @@ func (s *CrossSDK) getCrossTxBuilder(chainID string) (b *builder.CrossTxBuilder,
 }
 
 func (s *CrossSDK) GenCrossEvent(params ...*CrossTxBuildCtx) (*CrossEventContext, error) {
+	return s.GenCrossEventWithDeadline(time.Time{}, params...)
+}
+
+//GenCrossEventWithDeadline generate the CrossEvent which should be finished before the deadline,
+//the proxy will rollback the cross once the deadline passes, zero deadline means no deadline.
+//The late commits are rejected on chain only by the transaction contracts which check the deadline,
+//the prebuilt chainmaker transaction.wasm ignores it until rebuilt from source
+func (s *CrossSDK) GenCrossEventWithDeadline(deadline time.Time, params ...*CrossTxBuildCtx) (*CrossEventContext, error) {
 	crossEvent := NewCrossEventCtx()
+	var deadlineUnix int64
+	if !deadline.IsZero() {
+		deadlineUnix = deadline.Unix()
+		crossEvent.SetDeadline(deadline)
+	}
 	crossTxs := make([]*eventproto.CrossTx, 0, len(params))
 	for _, param := range params {
 		b, ok := s.getCrossTxBuilder(param.chainID)
@@ -154,6 +168,7 @@ func (s *CrossSDK) GenCrossEvent(params ...*CrossTxBuildCtx) (*CrossEventContext
 				return nil, errors.New("CrossTxParam is invalid")
 			}
 			param.buildParam.SetCrossID(crossEvent.GetCrossID())
+			param.buildParam.SetDeadline(deadlineUnix)
 			crossTx, err := b.Build(param.buildParam, param.buildOpts...)
 			if err != nil {
 				return nil, err
@@ -0,0 +1,29 @@
+/*
+ Copyright (C) THL A29 Limited, a Tencent company. All rights reserved.
+ SPDX-License-Identifier: Apache-2.0
+*/
+package sdk
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+const (
+	WebhookSignatureHeader = "X-Cross-Signature" // 回调签名头
+	WebhookTimestampHeader = "X-Cross-Timestamp" // 回调签名时间戳头
+)
+
+//VerifyWebhookSignature verify the signature of webhook callback which is signed by cross chain proxy
+func VerifyWebhookSignature(secret, timestamp string, body []byte, signature string) bool {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(mac.Sum(nil), expected)
+}